@@ -17,6 +17,8 @@ var (
 	FileExpired                  = defineAction("FILE_EXPIRED")
 	FileTrashed                  = defineAction("FILE_TRASHED")
 	FileRestored                 = defineAction("FILE_RESTORED")
+	FileApproved                 = defineAction("FILE_APPROVED")
+	FileRejected                 = defineAction("FILE_REJECTED")
 	FolderCreated                = defineAction("FOLDER_CREATED")
 	FolderUpdated                = defineAction("FOLDER_UPDATED")
 	FolderTrashed                = defineAction("FOLDER_TRASHED")
@@ -28,11 +30,17 @@ var (
 	UserCreated                  = defineAction("USER_CREATED")
 	UserLoggedIn                 = defineAction("USER_LOGGED_IN")
 	UserDeleted                  = defineAction("USER_DELETED")
+	UserLocked                   = defineAction("USER_LOCKED")
+	UserUnlocked                 = defineAction("USER_UNLOCKED")
 	PasswordResetCodeVerified    = defineAction("PASSWORD_RESET_CODE_VERIFIED")
 	PasswordResetCompleted       = defineAction("PASSWORD_RESET_COMPLETED")
+	PasswordChanged              = defineAction("PASSWORD_CHANGED")
 	InviteAccepted               = defineAction("INVITE_ACCEPTED")
 	InviteChallengeAttemptFailed = defineAction("INVITE_CHALLENGE_ATTEMPT_FAILED")
 	InviteChallengeLocked        = defineAction("INVITE_CHALLENGE_LOCKED")
+	InviteResent                 = defineAction("INVITE_RESENT")
+	InviteRevoked                = defineAction("INVITE_REVOKED")
+	InviteExpired                = defineAction("INVITE_EXPIRED")
 	MFADeviceEnrolled            = defineAction("MFA_DEVICE_ENROLLED")
 	MFADeviceVerified            = defineAction("MFA_DEVICE_VERIFIED")
 	MFADeviceUpdated             = defineAction("MFA_DEVICE_UPDATED")
@@ -40,9 +48,24 @@ var (
 	SessionRevoked               = defineAction("SESSION_REVOKED")
 	OtherSessionsRevoked         = defineAction("OTHER_SESSIONS_REVOKED")
 	ShareCreated                 = defineAction("SHARE_CREATED")
+	ShareUpdated                 = defineAction("SHARE_UPDATED")
 	ShareDeleted                 = defineAction("SHARE_DELETED")
 	ShareExpired                 = defineAction("SHARE_EXPIRED")
 	ShareMaxViewsReached         = defineAction("SHARE_MAX_VIEWS_REACHED")
 	ShareFileDownloaded          = defineAction("SHARE_FILE_DOWNLOADED")
 	ShareFileUploaded            = defineAction("SHARE_FILE_UPLOADED")
+	ShareAccessDenied            = defineAction("SHARE_ACCESS_DENIED")
+	ShareSent                    = defineAction("SHARE_SENT")
+	ChannelCreated               = defineAction("CHANNEL_CREATED")
+	ChannelDeleted               = defineAction("CHANNEL_DELETED")
+	RetentionPolicyCreated       = defineAction("RETENTION_POLICY_CREATED")
+	RetentionPolicyUpdated       = defineAction("RETENTION_POLICY_UPDATED")
+	RetentionPolicyDeleted       = defineAction("RETENTION_POLICY_DELETED")
+	BucketRetentionUpdated       = defineAction("BUCKET_RETENTION_UPDATED")
+	LegalHoldPlaced              = defineAction("LEGAL_HOLD_PLACED")
+	LegalHoldReleased            = defineAction("LEGAL_HOLD_RELEASED")
+	BucketLifecycleUpdated       = defineAction("BUCKET_LIFECYCLE_UPDATED")
+	FileInactiveTrashed          = defineAction("FILE_INACTIVE_TRASHED")
+	UserKeyUpdated               = defineAction("USER_KEY_UPDATED")
+	BucketKeysShared             = defineAction("BUCKET_KEYS_SHARED")
 )
@@ -152,6 +152,8 @@ func (s *LokiClient) Search(
 				"folder_id":           result.Stream["folder_id"],
 				"share_id":            result.Stream["share_id"],
 				"bucket_member_email": result.Stream["bucket_member_email"],
+				"recipient_email":     result.Stream["recipient_email"],
+				"client_ip":           result.Stream["client_ip"],
 				"timestamp":           log[0],
 			}
 
@@ -54,6 +54,11 @@ func (r *RueidisCache) Get(key string) (string, error) {
 	return val, nil
 }
 
+func (r *RueidisCache) Set(key string, value string, ttl time.Duration) error {
+	ctx := context.Background()
+	return r.client.Do(ctx, r.client.B().Set().Key(key).Value(value).Ex(ttl).Build()).Error()
+}
+
 func (r *RueidisCache) SetNX(key string, value string, ttl time.Duration) (bool, error) {
 	ctx := context.Background()
 	err := r.client.Do(ctx,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/safebucket/safebucket/internal/configuration"
@@ -24,6 +25,14 @@ func setJSON[T any](c ICache, key string, value T, ttl time.Duration) error {
 	return err
 }
 
+func putJSON[T any](c ICache, key string, value T, ttl time.Duration) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.Set(key, string(payload), ttl)
+}
+
 func getJSON[T any](c ICache, key string) (T, bool, error) {
 	var value T
 	val, err := c.Get(key)
@@ -53,6 +62,51 @@ func DeleteMultipartState(c ICache, fileID string) error {
 	return c.Del(fmt.Sprintf(configuration.CacheMultipartStateKey, fileID))
 }
 
+// SetSAMLRequest remembers the ID of an SP-initiated authentication request under its relay state.
+func SetSAMLRequest(c ICache, relayState string, requestID string) error {
+	key := fmt.Sprintf(configuration.CacheSAMLRequestKey, relayState)
+	ok, err := c.SetNX(key, requestID, configuration.SAMLRequestTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("saml relay state %q already in use", relayState)
+	}
+	return nil
+}
+
+// ConsumeSAMLRequest returns the request ID stored for a relay state. A relay state can be
+// consumed once, so a replayed IdP response finds nothing.
+func ConsumeSAMLRequest(c ICache, relayState string) (string, bool, error) {
+	key := fmt.Sprintf(configuration.CacheSAMLRequestKey, relayState)
+	requestID, err := c.Get(key)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	first, err := c.SetNX(key+":consumed", "1", configuration.SAMLRequestTTL)
+	if err != nil {
+		return "", false, err
+	}
+	if !first {
+		return "", false, nil
+	}
+	if delErr := c.Del(key); delErr != nil {
+		return "", false, delErr
+	}
+	return requestID, true, nil
+}
+
+// MarkSAMLAssertionUsed records an assertion ID until the assertion expires. It returns false
+// when the assertion was already consumed, i.e. the IdP response is being replayed.
+func MarkSAMLAssertionUsed(c ICache, providerKey string, assertionID string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf(configuration.CacheSAMLAssertionKey, providerKey, assertionID)
+	return c.SetNX(key, "1", ttl)
+}
+
 func GetMFAAttempts(c ICache, userID string) (int, error) {
 	key := fmt.Sprintf(configuration.CacheMFAAttemptsKey, userID)
 	val, err := c.Get(key)
@@ -83,34 +137,171 @@ func ResetMFAAttempts(c ICache, userID string) error {
 	return c.Del(key)
 }
 
-func MarkTOTPCodeUsed(c ICache, deviceID string, code string) (bool, error) {
-	key := fmt.Sprintf(configuration.CacheTOTPUsedKey, deviceID, code)
-	return c.SetNX(key, "1", time.Duration(configuration.TOTPCodeTTL)*time.Second)
+// LoginAccountSubject identifies an account for the login lockout counters. It is derived from
+// the submitted email rather than the user ID so unknown accounts lock out exactly like real
+// ones and the lockout cannot be used to enumerate users.
+func LoginAccountSubject(providerKey string, email string) string {
+	return "account:" + providerKey + ":" + strings.ToLower(strings.TrimSpace(email))
 }
 
-func GetRateLimit(c ICache, userIdentifier string, requestsPerMinute int) (int, error) {
-	key := fmt.Sprintf(configuration.CacheAppRateLimitKey, userIdentifier)
+func LoginIPSubject(ip string) string {
+	return "ip:" + ip
+}
 
-	count, err := c.Incr(key)
+// LoginLockRemaining returns how long the subject stays locked out, or zero when it is not.
+func LoginLockRemaining(c ICache, subject string) (time.Duration, error) {
+	ttl, err := c.TTL(fmt.Sprintf(configuration.CacheLoginLockKey, subject))
 	if err != nil {
 		return 0, err
 	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
 
+// RecordLoginFailure counts a failed login for the subject. When the failure exhausts the
+// budget, the subject is locked out and the returned duration is the length of the new lockout.
+func RecordLoginFailure(c ICache, subject string, maxFailures int) (time.Duration, error) {
+	key := fmt.Sprintf(configuration.CacheLoginFailuresKey, subject)
+	count, err := c.Incr(key)
+	if err != nil {
+		return 0, err
+	}
 	if count == 1 {
-		if expErr := c.Expire(key, 1*time.Minute); expErr != nil {
+		if expErr := c.Expire(key, configuration.LoginFailureWindow); expErr != nil {
 			return 0, expErr
 		}
 	}
+	if int(count) < maxFailures {
+		return 0, nil
+	}
+
+	levelKey := fmt.Sprintf(configuration.CacheLoginLockLevelKey, subject)
+	level, err := c.Incr(levelKey)
+	if err != nil {
+		return 0, err
+	}
+	if expErr := c.Expire(levelKey, configuration.LoginLockoutLevelTTL); expErr != nil {
+		return 0, expErr
+	}
+
+	lockout := configuration.LoginLockoutMax
+	if level <= 16 {
+		lockout = min(configuration.LoginLockoutBase<<(level-1), configuration.LoginLockoutMax)
+	}
+
+	if delErr := c.Del(key); delErr != nil {
+		return 0, delErr
+	}
+	lockKey := fmt.Sprintf(configuration.CacheLoginLockKey, subject)
+	if delErr := c.Del(lockKey); delErr != nil {
+		return 0, delErr
+	}
+	if _, setErr := c.SetNX(lockKey, strconv.FormatInt(level, 10), lockout); setErr != nil {
+		return 0, setErr
+	}
+	return lockout, nil
+}
+
+// ClearLoginFailures forgets the pending failures of a subject after a successful login. The
+// lockout level is kept so repeated lockouts keep escalating.
+func ClearLoginFailures(c ICache, subject string) error {
+	return c.Del(fmt.Sprintf(configuration.CacheLoginFailuresKey, subject))
+}
+
+// UnlockLogin lifts a lockout and resets its escalation.
+func UnlockLogin(c ICache, subject string) error {
+	for _, pattern := range []string{
+		configuration.CacheLoginFailuresKey,
+		configuration.CacheLoginLockKey,
+		configuration.CacheLoginLockLevelKey,
+	} {
+		if err := c.Del(fmt.Sprintf(pattern, subject)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func MarkTOTPCodeUsed(c ICache, deviceID string, code string) (bool, error) {
+	key := fmt.Sprintf(configuration.CacheTOTPUsedKey, deviceID, code)
+	return c.SetNX(key, "1", time.Duration(configuration.TOTPCodeTTL)*time.Second)
+}
+
+// RateLimitStatus describes a rate limit budget after a request was counted against it.
+// RetryAfter is zero when the request is allowed.
+type RateLimitStatus struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
 
+func (s RateLimitStatus) Allowed() bool {
+	return s.RetryAfter == 0
+}
+
+// ConsumeRateLimit counts a request against a fixed one-minute window and, when burst is
+// positive, against a one-second window capped at burst requests. Both windows live in the
+// cache so the budget is shared by every replica.
+func ConsumeRateLimit(c ICache, identifier string, requestsPerMinute int, burst int) (RateLimitStatus, error) {
+	count, reset, err := countInWindow(c, fmt.Sprintf(configuration.CacheAppRateLimitKey, identifier), time.Minute)
+	if err != nil {
+		return RateLimitStatus{}, err
+	}
+
+	status := RateLimitStatus{
+		Limit:     requestsPerMinute,
+		Remaining: max(requestsPerMinute-int(count), 0),
+		Reset:     reset,
+	}
 	if int(count) > requestsPerMinute {
-		ttl, ttlErr := c.TTL(key)
-		if ttlErr != nil {
-			return 0, ttlErr
+		status.RetryAfter = max(reset, time.Second)
+		return status, nil
+	}
+
+	if burst > 0 {
+		burstCount, burstReset, burstErr := countInWindow(
+			c, fmt.Sprintf(configuration.CacheAppRateLimitBurstKey, identifier), time.Second,
+		)
+		if burstErr != nil {
+			return RateLimitStatus{}, burstErr
+		}
+		if int(burstCount) > burst {
+			status.RetryAfter = max(burstReset, time.Second)
+		}
+	}
+
+	return status, nil
+}
+
+// countInWindow increments the counter of a fixed window and returns the time left before it
+// resets. The expiry is re-applied if it was lost, so a counter can never outlive its window.
+func countInWindow(c ICache, key string, window time.Duration) (int64, time.Duration, error) {
+	count, err := c.Incr(key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if count == 1 {
+		if expErr := c.Expire(key, window); expErr != nil {
+			return 0, 0, expErr
 		}
-		return int(ttl.Seconds()), nil
+		return count, window, nil
 	}
 
-	return 0, nil
+	ttl, err := c.TTL(key)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ttl < 0 {
+		if expErr := c.Expire(key, window); expErr != nil {
+			return 0, 0, expErr
+		}
+		ttl = window
+	}
+	return count, ttl, nil
 }
 
 func RecordChallengeIssuance(c ICache, key string, limit int, window time.Duration) (bool, error) {
@@ -148,10 +339,22 @@ type ActiveSession struct {
 	CreatedAt time.Time
 }
 
+// SessionMetadata describes the client a session was issued to.
+type SessionMetadata struct {
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	AuthProvider string    `json:"auth_provider"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
 func sessionKey(userID string) string {
 	return fmt.Sprintf(configuration.CacheUserSessionsKey, userID)
 }
 
+func sessionMetadataKey(userID string, sid string) string {
+	return fmt.Sprintf(configuration.CacheUserSessionMetaKey, userID, sid)
+}
+
 func scoreCutoff(maxAge time.Duration) string {
 	return strconv.FormatFloat(float64(time.Now().Add(-maxAge).Unix()), 'f', 0, 64)
 }
@@ -225,7 +428,34 @@ func ListActiveSessions(c ICache, userID string, maxAge time.Duration) ([]Active
 }
 
 func RevokeSession(c ICache, userID string, sid string) error {
-	return c.ZAdd(sessionKey(userID), 0, sid)
+	if err := c.ZAdd(sessionKey(userID), 0, sid); err != nil {
+		return err
+	}
+	return c.Del(sessionMetadataKey(userID, sid))
+}
+
+// SetSessionMetadata stores the client details of a session, replacing any previous value in a
+// single write; ttl should match the session lifetime so the metadata does not outlive it.
+func SetSessionMetadata(c ICache, userID string, sid string, meta SessionMetadata, ttl time.Duration) error {
+	return putJSON(c, sessionMetadataKey(userID, sid), meta, ttl)
+}
+
+func GetSessionMetadata(c ICache, userID string, sid string) (SessionMetadata, bool, error) {
+	return getJSON[SessionMetadata](c, sessionMetadataKey(userID, sid))
+}
+
+// TouchSession bumps the last-seen time of a session. Sessions issued before metadata was
+// recorded get the fallback details instead.
+func TouchSession(c ICache, userID string, sid string, fallback SessionMetadata, ttl time.Duration) error {
+	meta, found, err := GetSessionMetadata(c, userID, sid)
+	if err != nil {
+		return err
+	}
+	if !found {
+		meta = fallback
+	}
+	meta.LastSeenAt = time.Now().UTC()
+	return SetSessionMetadata(c, userID, sid, meta, ttl)
 }
 
 func RevokeOtherSessions(c ICache, userID string, currentSID string, maxAge time.Duration) error {
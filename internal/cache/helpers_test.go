@@ -1,9 +1,12 @@
 package cache
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/safebucket/safebucket/internal/configuration"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -309,3 +312,217 @@ func TestRecordChallengeIssuance_ReassertsMissingTTL(t *testing.T) {
 	assert.Greater(t, ttl, time.Duration(0), "a missing TTL must be re-asserted so the window can reset")
 	assert.LessOrEqual(t, ttl, time.Hour)
 }
+
+func TestConsumeSAMLRequest_SingleUse(t *testing.T) {
+	mc := newTestCache(t)
+
+	require.NoError(t, SetSAMLRequest(mc, "relay-1", "id-123"))
+	require.Error(t, SetSAMLRequest(mc, "relay-1", "id-456"))
+
+	requestID, found, err := ConsumeSAMLRequest(mc, "relay-1")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "id-123", requestID)
+
+	_, found, err = ConsumeSAMLRequest(mc, "relay-1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestConsumeSAMLRequest_Unknown(t *testing.T) {
+	mc := newTestCache(t)
+
+	_, found, err := ConsumeSAMLRequest(mc, "missing")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestTouchSession_KeepsIssuedMetadata(t *testing.T) {
+	mc := newTestCache(t)
+
+	issued := SessionMetadata{
+		IP:           "203.0.113.7",
+		UserAgent:    "Firefox",
+		AuthProvider: "local",
+		LastSeenAt:   time.Now().Add(-time.Hour).UTC(),
+	}
+	require.NoError(t, SetSessionMetadata(mc, "user1", "sid-1", issued, testMaxAge))
+
+	fallback := SessionMetadata{IP: "198.51.100.1", UserAgent: "curl"}
+	require.NoError(t, TouchSession(mc, "user1", "sid-1", fallback, testMaxAge))
+
+	meta, found, err := GetSessionMetadata(mc, "user1", "sid-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "203.0.113.7", meta.IP)
+	assert.Equal(t, "Firefox", meta.UserAgent)
+	assert.WithinDuration(t, time.Now(), meta.LastSeenAt, 2*time.Second)
+}
+
+func TestTouchSession_UsesFallbackForLegacySessions(t *testing.T) {
+	mc := newTestCache(t)
+
+	fallback := SessionMetadata{IP: "198.51.100.1", UserAgent: "curl", AuthProvider: "local"}
+	require.NoError(t, TouchSession(mc, "user1", "sid-legacy", fallback, testMaxAge))
+
+	meta, found, err := GetSessionMetadata(mc, "user1", "sid-legacy")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "198.51.100.1", meta.IP)
+	assert.False(t, meta.LastSeenAt.IsZero())
+}
+
+func TestTouchSession_RenewsTTL(t *testing.T) {
+	mc := newTestCache(t)
+
+	require.NoError(t, SetSessionMetadata(mc, "user1", "sid-1", SessionMetadata{IP: "203.0.113.7"}, time.Minute))
+	require.NoError(t, TouchSession(mc, "user1", "sid-1", SessionMetadata{}, testMaxAge))
+
+	ttl, err := mc.TTL(sessionMetadataKey("user1", "sid-1"))
+	require.NoError(t, err)
+	assert.Greater(t, ttl, testMaxAge-time.Minute, "a touch extends the metadata to the new lifetime")
+}
+
+func TestRevokeSession_DeletesMetadata(t *testing.T) {
+	mc := newTestCache(t)
+
+	require.NoError(t, CreateSession(mc, "user1", "sid-1"))
+	require.NoError(t, SetSessionMetadata(mc, "user1", "sid-1", SessionMetadata{IP: "203.0.113.7"}, testMaxAge))
+	require.NoError(t, RevokeSession(mc, "user1", "sid-1"))
+
+	_, found, err := GetSessionMetadata(mc, "user1", "sid-1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestRecordLoginFailure_LocksAtThreshold(t *testing.T) {
+	mc := newTestCache(t)
+	subject := LoginAccountSubject("local", "Jane@Example.com")
+
+	for i := 1; i < 3; i++ {
+		lockout, err := RecordLoginFailure(mc, subject, 3)
+		require.NoError(t, err)
+		assert.Zerof(t, lockout, "failure %d should not lock", i)
+	}
+
+	lockout, err := RecordLoginFailure(mc, subject, 3)
+	require.NoError(t, err)
+	assert.Equal(t, configuration.LoginLockoutBase, lockout)
+
+	remaining, err := LoginLockRemaining(mc, LoginAccountSubject("local", "jane@example.com"))
+	require.NoError(t, err)
+	assert.Greater(t, remaining, configuration.LoginLockoutBase-time.Minute)
+}
+
+func TestRecordLoginFailure_EscalatesLockout(t *testing.T) {
+	mc := newTestCache(t)
+	subject := LoginIPSubject("203.0.113.7")
+
+	first, err := RecordLoginFailure(mc, subject, 1)
+	require.NoError(t, err)
+	second, err := RecordLoginFailure(mc, subject, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, configuration.LoginLockoutBase, first)
+	assert.Equal(t, 2*configuration.LoginLockoutBase, second)
+}
+
+func TestRecordLoginFailure_CapsLockout(t *testing.T) {
+	mc := newTestCache(t)
+	subject := LoginIPSubject("203.0.113.7")
+
+	var lockout time.Duration
+	for range 40 {
+		var err error
+		lockout, err = RecordLoginFailure(mc, subject, 1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, configuration.LoginLockoutMax, lockout)
+}
+
+func TestClearLoginFailures_KeepsLockoutLevel(t *testing.T) {
+	mc := newTestCache(t)
+	subject := LoginIPSubject("203.0.113.7")
+
+	_, err := RecordLoginFailure(mc, subject, 2)
+	require.NoError(t, err)
+	require.NoError(t, ClearLoginFailures(mc, subject))
+
+	lockout, err := RecordLoginFailure(mc, subject, 2)
+	require.NoError(t, err)
+	assert.Zero(t, lockout, "cleared failures must not count towards the budget")
+}
+
+func TestUnlockLogin_LiftsLockAndResetsLevel(t *testing.T) {
+	mc := newTestCache(t)
+	subject := LoginAccountSubject("local", "jane@example.com")
+
+	_, err := RecordLoginFailure(mc, subject, 1)
+	require.NoError(t, err)
+	require.NoError(t, UnlockLogin(mc, subject))
+
+	remaining, err := LoginLockRemaining(mc, subject)
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	lockout, err := RecordLoginFailure(mc, subject, 1)
+	require.NoError(t, err)
+	assert.Equal(t, configuration.LoginLockoutBase, lockout)
+}
+
+func TestConsumeRateLimit_TracksRemaining(t *testing.T) {
+	mc := newTestCache(t)
+
+	status, err := ConsumeRateLimit(mc, "user-1", 3, 0)
+	require.NoError(t, err)
+	assert.True(t, status.Allowed())
+	assert.Equal(t, 3, status.Limit)
+	assert.Equal(t, 2, status.Remaining)
+	assert.Equal(t, time.Minute, status.Reset)
+
+	for range 2 {
+		status, err = ConsumeRateLimit(mc, "user-1", 3, 0)
+		require.NoError(t, err)
+		require.True(t, status.Allowed())
+	}
+	assert.Equal(t, 0, status.Remaining)
+
+	status, err = ConsumeRateLimit(mc, "user-1", 3, 0)
+	require.NoError(t, err)
+	assert.False(t, status.Allowed())
+	assert.Equal(t, 0, status.Remaining)
+	assert.Positive(t, status.RetryAfter)
+	assert.LessOrEqual(t, status.RetryAfter, time.Minute)
+}
+
+func TestConsumeRateLimit_EnforcesBurst(t *testing.T) {
+	mc := newTestCache(t)
+
+	for i := 1; i <= 2; i++ {
+		status, err := ConsumeRateLimit(mc, "user-1", 100, 2)
+		require.NoError(t, err)
+		require.Truef(t, status.Allowed(), "call %d should be within the burst", i)
+	}
+
+	status, err := ConsumeRateLimit(mc, "user-1", 100, 2)
+	require.NoError(t, err)
+	assert.False(t, status.Allowed(), "call beyond the burst must be denied")
+	assert.Equal(t, 97, status.Remaining)
+	assert.LessOrEqual(t, status.RetryAfter, time.Second)
+}
+
+func TestConsumeRateLimit_ReassertsMissingTTL(t *testing.T) {
+	mc := newTestCache(t)
+	key := fmt.Sprintf(configuration.CacheAppRateLimitKey, "user-1")
+
+	_, err := mc.Incr(key)
+	require.NoError(t, err)
+
+	status, err := ConsumeRateLimit(mc, "user-1", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, status.Reset)
+
+	ttl, err := mc.TTL(key)
+	require.NoError(t, err)
+	assert.Positive(t, ttl, "a missing TTL must be re-asserted so the window can reset")
+}
@@ -15,6 +15,7 @@ type ZScoreEntry struct {
 
 type ICache interface {
 	Get(key string) (string, error)
+	Set(key string, value string, ttl time.Duration) error
 	SetNX(key string, value string, ttl time.Duration) (bool, error)
 	Del(key string) error
 	Incr(key string) (int64, error)
@@ -71,6 +71,17 @@ func (m *MemoryCache) Get(key string) (string, error) {
 	return e.value, nil
 }
 
+func (m *MemoryCache) Set(key string, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data[key] = entry{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	}
+	return nil
+}
+
 func (m *MemoryCache) SetNX(key string, value string, ttl time.Duration) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -29,13 +29,13 @@ var AuthExcludedPatterns = []AuthPatternRule{
 	},
 	{
 		Pattern: regexp.MustCompile(
-			`^/api/v1/auth/providers/[a-zA-Z0-9_-]+/(begin|callback)$`,
+			`^/api/v1/auth/providers/[a-zA-Z0-9_-]+/(begin|callback|metadata)$`,
 		),
 		Method: http.MethodGet,
 	},
 	{
 		Pattern: regexp.MustCompile(
-			`^/api/v1/auth/providers/[a-zA-Z0-9_-]+/login$`,
+			`^/api/v1/auth/providers/[a-zA-Z0-9_-]+/(login|acs)$`,
 		),
 		Method: http.MethodPost,
 	},
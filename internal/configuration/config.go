@@ -116,22 +116,36 @@ func loadBytes(k *koanf.Koanf, raw []byte) error {
 
 func loadDefaults(k *koanf.Koanf) {
 	defaults := map[string]interface{}{
-		"app.profile":                             "default",
-		"app.access_token_expiry":                 60,
-		"app.refresh_token_expiry":                600,
-		"app.mfa_token_expiry":                    5,
-		"app.log_level":                           "info",
-		"app.port":                                8080,
-		"app.trash_retention_days":                7,
-		"app.max_upload_size":                     int64(53687091200),
-		"app.allow_redirect_download":             true,
-		"app.request_timeout_seconds":             5,
-		"app.authenticated_requests_per_minute":   200,
-		"app.unauthenticated_requests_per_minute": 20,
-		"app.static_files.enabled":                true,
-		"tracing.enabled":                         false,
-		"profiling.enabled":                       false,
-		"database.type":                           ProviderPostgres,
+		"app.profile":                                      "default",
+		"app.access_token_expiry":                          60,
+		"app.refresh_token_expiry":                         600,
+		"app.mfa_token_expiry":                             5,
+		"app.log_level":                                    "info",
+		"app.port":                                         8080,
+		"app.trash_retention_days":                         7,
+		"app.invite_expiry_days":                           7,
+		"app.file_expiry_warning_days":                     3,
+		"app.password_policy.min_length":                   8,
+		"app.max_upload_size":                              int64(53687091200),
+		"app.allow_redirect_download":                      true,
+		"app.request_timeout_seconds":                      5,
+		"app.authenticated_requests_per_minute":            200,
+		"app.unauthenticated_requests_per_minute":          20,
+		"app.rate_limits.auth.requests_per_minute":         20,
+		"app.rate_limits.auth.burst":                       5,
+		"app.rate_limits.upload_init.requests_per_minute":  60,
+		"app.rate_limits.upload_init.burst":                10,
+		"app.rate_limits.download_url.requests_per_minute": 120,
+		"app.rate_limits.download_url.burst":               20,
+		"app.rate_limits.share_public.requests_per_minute": 60,
+		"app.rate_limits.share_public.burst":               10,
+		"app.rate_limits.admin.requests_per_minute":        120,
+		"app.rate_limits.per_share.requests_per_minute":    600,
+		"app.static_files.enabled":                         true,
+		"tracing.enabled":                                  false,
+		"profiling.enabled":                                false,
+		"database.type":                                    ProviderPostgres,
+		"events.max_attempts":                              5,
 	}
 
 	if err := k.Load(confmap.Provider(defaults, "."), nil); err != nil {
@@ -148,6 +162,11 @@ func setIfMissing(k *koanf.Koanf, key string, value interface{}) {
 func loadConditionalDefaults(k *koanf.Koanf) {
 	if k.String("database.type") == ProviderPostgres {
 		setIfMissing(k, "database.postgres.port", int32(5432))
+		setIfMissing(k, "database.postgres.replica_max_lag_seconds", PostgresReplicaMaxLagSeconds)
+		setIfMissing(k, "database.postgres.replica_health_check_interval", PostgresReplicaHealthCheckInterval)
+	}
+	if k.String("database.type") == ProviderMySQL {
+		setIfMissing(k, "database.mysql.port", int32(3306))
 	}
 	if k.String("storage.type") == "minio" {
 		setIfMissing(k, "storage.minio.region", "us-east-1")
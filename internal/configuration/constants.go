@@ -32,6 +32,7 @@ const (
 	CacheMaxAppIdentityLifetime  = 60
 	CacheAppIdentityKey          = "app:identity"
 	CacheAppRateLimitKey         = "app:ratelimit:%s"
+	CacheAppRateLimitBurstKey    = "app:ratelimit:burst:%s"
 	CacheAppWorkerLockKey        = "app:worker:lock:%s"
 	CacheAppWorkerLockTTL        = 60
 	CacheAppWorkerLockRefresh    = 55
@@ -41,15 +42,24 @@ const (
 	CacheMFAAttemptsKey          = "mfa:attempts:%s"
 	CacheTOTPUsedKey             = "totp:used:%s:%s"
 	CacheUserSessionsKey         = "user:sessions:%s"
+	CacheUserSessionMetaKey      = "user:session:meta:%s:%s"
 	CacheMultipartStateKey       = "multipart:state:%s"
+	CacheSAMLRequestKey          = "saml:request:%s"
+	CacheSAMLAssertionKey        = "saml:assertion:%s:%s"
+	CacheLoginFailuresKey        = "login:failures:%s"
+	CacheLoginLockKey            = "login:lock:%s"
+	CacheLoginLockLevelKey       = "login:lock:level:%s"
 )
 
 const (
-	WorkerObjectDeletion   = "object_deletion"
-	WorkerBucketEvents     = "bucket_events"
-	WorkerTrashCleanup     = "trash_cleanup"
-	WorkerGarbageCollector = "garbage_collector"
-	CoverageHTTPServer     = "http_server"
+	WorkerObjectDeletion     = "object_deletion"
+	WorkerBucketEvents       = "bucket_events"
+	WorkerTrashCleanup       = "trash_cleanup"
+	WorkerGarbageCollector   = "garbage_collector"
+	WorkerOutboxRelay        = "outbox_relay"
+	WorkerNotificationDigest = "notification_digest"
+	WorkerNotificationRetry  = "notification_retry"
+	CoverageHTTPServer       = "http_server"
 )
 
 const CacheMultipartStateExpiry = 2 * time.Hour
@@ -78,6 +88,7 @@ const (
 
 	SecurityPasswordResetMaxPerEmailPerHour = 3
 	SecurityInviteMaxPerEmailPerHour        = 5
+	SecurityShareAccessMaxPerEmailPerHour   = 5
 )
 
 const SecurityChallengeIssuanceWindow = time.Hour
@@ -91,15 +102,40 @@ const (
 	MFALockoutSeconds    = 900
 )
 
+// Progressive login lockout: once a subject reaches its failure budget inside the window it is
+// locked for LoginLockoutBase, doubling with every further lockout until LoginLockoutMax. The
+// lockout level is forgotten after LoginLockoutLevelTTL without a new lockout.
+const (
+	LoginAccountMaxFailures = 5
+	LoginIPMaxFailures      = 20
+	LoginFailureWindow      = 15 * time.Minute
+	LoginLockoutBase        = 5 * time.Minute
+	LoginLockoutMax         = 24 * time.Hour
+	LoginLockoutLevelTTL    = 24 * time.Hour
+)
+
+// SAMLRequestTTL bounds how long an SP-initiated authentication request can wait for the IdP response.
+const SAMLRequestTTL = 10 * time.Minute
+
 const (
 	ProviderPostgres = "postgres"
 	ProviderSQLite   = "sqlite"
+	ProviderMySQL    = "mysql"
 )
 
 const (
 	PostgresMaxOpenConns    = 25
 	PostgresMaxIdleConns    = 10
 	PostgresConnMaxLifetime = 30 // in minutes
+
+	PostgresReplicaMaxLagSeconds       = 10
+	PostgresReplicaHealthCheckInterval = 10 // in seconds
+)
+
+const (
+	MySQLMaxOpenConns    = 25
+	MySQLMaxIdleConns    = 10
+	MySQLConnMaxLifetime = 30 // in minutes
 )
 
 const (
@@ -111,10 +147,15 @@ const (
 	ProviderS3        = "s3"
 	ProviderMemory    = "memory"
 	ProviderAzure     = "azure"
+	ProviderRedis     = "redis"
+	ProviderValkey    = "valkey"
 )
 
 func RequiresUploadConfirmation(storageProvider, eventsProvider string) bool {
-	return storageProvider == ProviderS3 || eventsProvider == ProviderMemory
+	return storageProvider == ProviderS3 ||
+		eventsProvider == ProviderMemory ||
+		eventsProvider == ProviderRedis ||
+		eventsProvider == ProviderValkey
 }
 
 const (
@@ -123,15 +164,28 @@ const (
 	CacheNotifyBatchesKey    = "notify:batches"
 	CacheNotifyFlush         = 30
 	CacheNotifyBatchTTL      = CacheNotifyFlush + 5
+	CacheNotifyDigestKey     = "notify:digest:%s:%s"
+	CacheNotifyDigestsKey    = "notify:digests:%s"
+	CacheNotifyDigestTTL     = 48 * time.Hour
 )
 
 const BulkActionsLimit = 1000
 
+// ShareAnalyticsAccessLimit caps the number of individual accesses returned by the share
+// analytics endpoint; per-file aggregates cover every access still retained.
+const ShareAnalyticsAccessLimit = 500
+
+// ShareAccessRetention is how long individual share accesses are kept for the analytics.
+const ShareAccessRetention = 90 * 24 * time.Hour
+
 var ArrayConfigFields = []string{
 	"app.trusted_proxies",
 	"cors.allowed_origins",
 	"cache.redis.hosts",
 	"cache.valkey.hosts",
+	"events.redis.hosts",
+	"events.valkey.hosts",
+	"notifier.channels.mattermost_hosts",
 }
 
 var ConfigFileSearchPaths = []string{
@@ -151,4 +205,10 @@ var AuthProviderKeys = []string{
 	"bind_password",
 	"base_dn",
 	"user_filter",
+	// SAML keys
+	"idp_metadata_url",
+	"idp_metadata",
+	"entity_id",
+	"certificate",
+	"private_key",
 }
@@ -17,30 +17,39 @@ var Profiles = map[string]models.Profile{
 		Name:       ProfileDefault,
 		HTTPServer: true,
 		Workers: models.WorkerConfig{
-			ObjectDeletion:   models.WorkerModeAll,
-			BucketEvents:     models.WorkerModeAll,
-			TrashCleanup:     models.WorkerModeSingleton,
-			GarbageCollector: models.WorkerModeSingleton,
+			ObjectDeletion:     models.WorkerModeAll,
+			BucketEvents:       models.WorkerModeAll,
+			TrashCleanup:       models.WorkerModeSingleton,
+			GarbageCollector:   models.WorkerModeSingleton,
+			OutboxRelay:        models.WorkerModeSingleton,
+			NotificationDigest: models.WorkerModeSingleton,
+			NotificationRetry:  models.WorkerModeSingleton,
 		},
 	},
 	ProfileAPI: {
 		Name:       ProfileAPI,
 		HTTPServer: true,
 		Workers: models.WorkerConfig{
-			ObjectDeletion:   models.WorkerModeDisabled,
-			BucketEvents:     models.WorkerModeDisabled,
-			TrashCleanup:     models.WorkerModeDisabled,
-			GarbageCollector: models.WorkerModeDisabled,
+			ObjectDeletion:     models.WorkerModeDisabled,
+			BucketEvents:       models.WorkerModeDisabled,
+			TrashCleanup:       models.WorkerModeDisabled,
+			GarbageCollector:   models.WorkerModeDisabled,
+			OutboxRelay:        models.WorkerModeDisabled,
+			NotificationDigest: models.WorkerModeDisabled,
+			NotificationRetry:  models.WorkerModeDisabled,
 		},
 	},
 	ProfileWorker: {
 		Name:       ProfileWorker,
 		HTTPServer: false,
 		Workers: models.WorkerConfig{
-			ObjectDeletion:   models.WorkerModeSingleton,
-			BucketEvents:     models.WorkerModeSingleton,
-			TrashCleanup:     models.WorkerModeSingleton,
-			GarbageCollector: models.WorkerModeSingleton,
+			ObjectDeletion:     models.WorkerModeSingleton,
+			BucketEvents:       models.WorkerModeSingleton,
+			TrashCleanup:       models.WorkerModeSingleton,
+			GarbageCollector:   models.WorkerModeSingleton,
+			OutboxRelay:        models.WorkerModeSingleton,
+			NotificationDigest: models.WorkerModeSingleton,
+			NotificationRetry:  models.WorkerModeSingleton,
 		},
 	},
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/safebucket/safebucket/internal/models"
 
 	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/crewjam/saml"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 )
@@ -20,6 +21,8 @@ type Provider struct {
 	Verifier       *oidc.IDTokenVerifier
 	OauthConfig    oauth2.Config
 	LDAPConfig     *ldapclient.Config
+	SAML           *saml.ServiceProvider
+	SAMLConfig     *models.SAMLConfiguration
 	Order          int
 	MFARequired    bool
 	SharingOptions models.SharingConfiguration
@@ -138,6 +141,41 @@ func LoadProviders(
 				zap.String("url", providerCfg.LDAP.URL),
 				zap.Any("domains", providerCfg.Domains),
 			)
+
+		case models.SAMLProviderType:
+			sp, err := newSAMLServiceProvider(ctx, apiURL, name, providerCfg.SAML)
+			if err != nil {
+				zap.L().Fatal(
+					"Failed to load SAML provider",
+					zap.String("name", name),
+					zap.Error(err),
+				)
+			}
+
+			displayName := providerCfg.Name
+			if displayName == "" {
+				displayName = name
+			}
+
+			providers[name] = Provider{
+				Name:           displayName,
+				Type:           providerCfg.Type,
+				Domains:        providerCfg.Domains,
+				SAML:           sp,
+				SAMLConfig:     providerCfg.SAML,
+				Order:          idx,
+				MFARequired:    providerCfg.MFARequired,
+				SharingOptions: providerCfg.SharingConfiguration,
+			}
+			idx++
+
+			zap.L().Info(
+				"Loaded SAML auth provider",
+				zap.String("name", name),
+				zap.String("idp_entity_id", sp.IDPMetadata.EntityID),
+				zap.Bool("allow_idp_initiated", providerCfg.SAML.AllowIDPInitiated),
+				zap.Any("domains", providerCfg.Domains),
+			)
 		}
 	}
 	return providers
@@ -0,0 +1,91 @@
+package configuration
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// Rate limit classes give sensitive or expensive routes their own budget, so guessing
+// credentials or minting presigned URLs does not share a bucket with ordinary reads.
+const (
+	RateLimitClassAuth        = "auth"
+	RateLimitClassUploadInit  = "upload_init"
+	RateLimitClassDownloadURL = "download_url"
+	RateLimitClassSharePublic = "share_public"
+	RateLimitClassAdmin       = "admin"
+)
+
+type RateLimitRule struct {
+	Pattern *regexp.Regexp
+	Method  string
+	Class   string
+}
+
+// RateLimitRules maps routes to their rate limit class. The first matching rule wins, so the
+// share upload and download routes must come before the catch-all public share rule. Routes not
+// listed here use the general authenticated or unauthenticated budget.
+var RateLimitRules = []RateLimitRule{
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/auth/(login|verify|mfa/verify|reset-password)$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/auth/reset-password/` + UUIDv4Pattern + `/(validate|complete)$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/auth/providers/[a-zA-Z0-9_-]+/(login|acs)$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/account/password$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(
+			`^/api/v1/invites/` + UUIDv4Pattern + `/challenges(/` + UUIDv4Pattern + `/validate)?$`,
+		),
+		Method: http.MethodPost,
+		Class:  RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/shares/` + UUIDv4Pattern + `/(auth|otp|otp/verify)$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassAuth,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/(buckets|shares)/` + UUIDv4Pattern + `/files$`),
+		Method:  http.MethodPost,
+		Class:   RateLimitClassUploadInit,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/buckets/` + UUIDv4Pattern + `/files/` + UUIDv4Pattern + `/url$`),
+		Method:  http.MethodGet,
+		Class:   RateLimitClassDownloadURL,
+	},
+	{
+		Pattern: regexp.MustCompile(
+			`^/api/v1/shares/` + UUIDv4Pattern + `/(download|files/` + UUIDv4Pattern + `/(url|download))$`,
+		),
+		Method: http.MethodGet,
+		Class:  RateLimitClassDownloadURL,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/shares/` + UUIDv4Pattern + `(/.*)?$`),
+		Method:  "*",
+		Class:   RateLimitClassSharePublic,
+	},
+	{
+		Pattern: regexp.MustCompile(`^/api/v1/admin(/.*)?$`),
+		Method:  "*",
+		Class:   RateLimitClassAdmin,
+	},
+}
+
+// PublicSharePathPattern captures the share ID of public share routes, which are also counted
+// against the per-share budget.
+var PublicSharePathPattern = regexp.MustCompile(`^/api/v1/shares/(` + UUIDv4Pattern + `)(/|$)`)
@@ -0,0 +1,92 @@
+package configuration
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const samlMetadataFetchTimeout = 10 * time.Second
+
+// newSAMLServiceProvider builds the service provider for a SAML auth provider. The metadata and
+// assertion consumer endpoints live under the provider routes of the API.
+func newSAMLServiceProvider(
+	ctx context.Context,
+	apiURL string,
+	name string,
+	cfg *models.SAMLConfiguration,
+) (*saml.ServiceProvider, error) {
+	idpMetadata, err := loadIDPMetadata(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("load IdP metadata: %w", err)
+	}
+
+	baseURL := fmt.Sprintf("%s/api/v1/auth/providers/%s", apiURL, name)
+	metadataURL, err := url.Parse(baseURL + "/metadata")
+	if err != nil {
+		return nil, fmt.Errorf("parse metadata URL: %w", err)
+	}
+	acsURL, err := url.Parse(baseURL + "/acs")
+	if err != nil {
+		return nil, fmt.Errorf("parse ACS URL: %w", err)
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          cfg.EntityID,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	if sp.GetSSOBindingLocation(saml.HTTPRedirectBinding) == "" {
+		return nil, errors.New("IdP metadata has no HTTP-Redirect single sign-on endpoint")
+	}
+
+	if cfg.Certificate != "" {
+		keyPair, pairErr := tls.X509KeyPair([]byte(cfg.Certificate), []byte(cfg.PrivateKey))
+		if pairErr != nil {
+			return nil, fmt.Errorf("load SP key pair: %w", pairErr)
+		}
+		key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("SP private key must be an RSA key")
+		}
+		cert, certErr := x509.ParseCertificate(keyPair.Certificate[0])
+		if certErr != nil {
+			return nil, fmt.Errorf("parse SP certificate: %w", certErr)
+		}
+		sp.Key = key
+		sp.Certificate = cert
+		sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+	}
+
+	return sp, nil
+}
+
+func loadIDPMetadata(ctx context.Context, cfg *models.SAMLConfiguration) (*saml.EntityDescriptor, error) {
+	if cfg.IDPMetadata != "" {
+		return samlsp.ParseMetadata([]byte(cfg.IDPMetadata))
+	}
+
+	metadataURL, err := url.Parse(cfg.IDPMetadataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, samlMetadataFetchTimeout)
+	defer cancel()
+
+	return samlsp.FetchMetadata(ctx, &http.Client{Timeout: samlMetadataFetchTimeout}, *metadataURL)
+}
@@ -27,6 +27,7 @@ type BootedApp struct {
 	Cache          c.ICache
 	Storage        storage.IStorage
 	Notifier       notifier.INotifier
+	Channels       notifier.IChannelNotifier
 	ActivityLogger activity.IActivityLogger
 	EventsManager  *EventsManager
 	EventRouter    *EventRouter
@@ -46,7 +47,9 @@ func Boot(ctx context.Context, cfg models.Configuration, opts BootOptions) *Boot
 	cache := NewCache(cfg.Cache)
 	store := NewStorage(cfg.Storage, cfg.App.TrashRetentionDays)
 	notify := NewNotifier(cfg.Notifier)
+	channels := notifier.NewWebhookNotifier(cfg.Notifier.Channels.MattermostHosts)
 	activityLogger := NewActivityLogger(cfg.Activity)
+	passwordPolicy := NewPasswordPolicy(cfg.App.PasswordPolicy)
 
 	var eventsManager *EventsManager
 	var eventRouter *EventRouter
@@ -56,7 +59,7 @@ func Boot(ctx context.Context, cfg models.Configuration, opts BootOptions) *Boot
 	}
 
 	if profile.HTTPServer {
-		CreateAdminUser(db, cfg)
+		CreateAdminUser(db, cfg, passwordPolicy)
 	}
 
 	workers := NewWorkersHandle()
@@ -77,6 +80,7 @@ func Boot(ctx context.Context, cfg models.Configuration, opts BootOptions) *Boot
 			store,
 			activityLogger,
 			notify,
+			channels,
 			eventRouter,
 			cfg,
 			cache,
@@ -85,7 +89,9 @@ func Boot(ctx context.Context, cfg models.Configuration, opts BootOptions) *Boot
 	}
 
 	providers := configuration.LoadProviders(ctx, cfg.App.APIURL, cfg.Auth.Providers)
-	router := BuildAPIRouter(cfg, db, cache, store, activityLogger, notify, eventRouter, providers)
+	router := BuildAPIRouter(
+		cfg, db, cache, store, activityLogger, notify, channels, eventRouter, providers, passwordPolicy,
+	)
 
 	return &BootedApp{
 		Config:         cfg,
@@ -94,6 +100,7 @@ func Boot(ctx context.Context, cfg models.Configuration, opts BootOptions) *Boot
 		Cache:          cache,
 		Storage:        store,
 		Notifier:       notify,
+		Channels:       channels,
 		ActivityLogger: activityLogger,
 		EventsManager:  eventsManager,
 		EventRouter:    eventRouter,
@@ -21,6 +21,7 @@ import (
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/notifier"
+	"github.com/safebucket/safebucket/internal/password"
 	"github.com/safebucket/safebucket/internal/services"
 	"github.com/safebucket/safebucket/internal/storage"
 	"github.com/safebucket/safebucket/internal/workers"
@@ -59,7 +60,12 @@ func StartTracer(config models.Configuration) func() {
 	}
 }
 
-func CreateAdminUser(db *gorm.DB, config models.Configuration) {
+func CreateAdminUser(db *gorm.DB, config models.Configuration, policy password.Policy) {
+	// The admin password never goes through an API endpoint, so apply the policy at startup.
+	if err := policy.Validate(config.App.AdminPassword, config.App.AdminEmail); err != nil {
+		zap.L().Fatal("Admin password does not satisfy the password policy", zap.Error(err))
+	}
+
 	adminUser := models.User{
 		FirstName:    "admin",
 		LastName:     "admin",
@@ -123,23 +129,29 @@ func StartWorkers(
 	store storage.IStorage,
 	activityLogger activity.IActivityLogger,
 	notify notifier.INotifier,
+	channels notifier.IChannelNotifier,
 	eventRouter *EventRouter,
 	config models.Configuration,
 	cache c.ICache,
 	appIdentity string,
 ) {
+	// Queued payloads carry one-time codes, so they are sealed with the same key as the MFA secrets.
+	queue := notifier.NewQueuedNotifier(db, notify, config.App.MFAEncryptionKey)
+
 	eventParams := &events.EventParams{
 		WebURL:             config.App.WebURL,
 		Notifier:           notify,
+		QueuedNotifier:     queue,
 		Publisher:          eventRouter,
 		DB:                 db,
 		Storage:            store,
 		ActivityLogger:     activityLogger,
 		TrashRetentionDays: config.App.TrashRetentionDays,
 		Cache:              cache,
+		MaxAttempts:        config.Events.MaxAttempts,
 	}
 
-	events.StartFileNotificationBuffer(ctx, handle.wg, cache, notify)
+	events.StartFileNotificationBuffer(ctx, handle.wg, cache, notify, channels, db)
 	zap.L().Info("Started file notification buffer")
 
 	if notificationsSub := eventsManager.GetSubscriber(configuration.EventsNotifications); notificationsSub != nil {
@@ -178,6 +190,51 @@ func StartWorkers(
 				ActivityLogger:     activityLogger,
 				RunInterval:        15 * time.Minute,
 				RefreshTokenExpiry: config.App.RefreshTokenExpiry,
+				ExpiryWarningDays:  config.App.FileExpiryWarningDays,
+			}
+			worker.Start(workerCtx)
+		},
+	)
+
+	startWorker(ctx, handle.wg, profile.Workers.OutboxRelay, configuration.WorkerOutboxRelay, cache, appIdentity,
+		func(workerCtx context.Context) {
+			worker := &workers.OutboxRelayWorker{
+				DB:          db,
+				Publisher:   eventRouter,
+				RunInterval: workers.OutboxRelayInterval,
+			}
+			worker.Start(workerCtx)
+		})
+
+	startWorker(
+		ctx,
+		handle.wg,
+		profile.Workers.NotificationDigest,
+		configuration.WorkerNotificationDigest,
+		cache,
+		appIdentity,
+		func(workerCtx context.Context) {
+			worker := &workers.NotificationDigestWorker{
+				Cache:       cache,
+				Notifier:    notify,
+				RunInterval: workers.NotificationDigestInterval,
+			}
+			worker.Start(workerCtx)
+		},
+	)
+
+	startWorker(
+		ctx,
+		handle.wg,
+		profile.Workers.NotificationRetry,
+		configuration.WorkerNotificationRetry,
+		cache,
+		appIdentity,
+		func(workerCtx context.Context) {
+			worker := &workers.NotificationRetryWorker{
+				DB:          db,
+				Queue:       queue,
+				RunInterval: workers.NotificationRetryInterval,
 			}
 			worker.Start(workerCtx)
 		},
@@ -399,8 +456,10 @@ func BuildAPIRouter(
 	store storage.IStorage,
 	activityLogger activity.IActivityLogger,
 	notify notifier.INotifier,
+	channels notifier.IChannelNotifier,
 	publisher messaging.IPublisher,
 	providers configuration.Providers,
+	passwordPolicy password.Policy,
 ) chi.Router {
 	r := chi.NewRouter()
 
@@ -424,7 +483,7 @@ func BuildAPIRouter(
 		AllowedOrigins:   config.App.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PATCH", "PUT", "DELETE"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Retry-After"},
+		ExposedHeaders:   []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -441,6 +500,7 @@ func BuildAPIRouter(
 			cache,
 			config.App.AuthenticatedRequestsPerMinute,
 			config.App.UnauthenticatedRequestsPerMinute,
+			config.App.RateLimits,
 		))
 
 		userService := services.UserService{
@@ -451,10 +511,22 @@ func BuildAPIRouter(
 			Notifier:           notify,
 			ActivityLogger:     activityLogger,
 			RefreshTokenExpiry: configuration.RefreshTokenExpiry,
+			PasswordPolicy:     passwordPolicy,
 		}
 
 		apiRouter.Mount("/v1/users", userService.Routes())
 
+		apiRouter.Mount("/v1/account", services.AccountService{
+			DB:                 db,
+			Cache:              cache,
+			AuthConfig:         authConfig,
+			Providers:          providers,
+			Publisher:          publisher,
+			ActivityLogger:     activityLogger,
+			PasswordPolicy:     passwordPolicy,
+			RefreshTokenExpiry: configuration.RefreshTokenExpiry,
+		}.Routes())
+
 		apiRouter.Mount("/v1/mfa", services.MFAService{
 			DB:             db,
 			Cache:          cache,
@@ -466,14 +538,17 @@ func BuildAPIRouter(
 		}.Routes())
 
 		apiRouter.Mount("/v1/buckets", services.BucketService{
-			DB:                 db,
-			Cache:              cache,
-			Storage:            store,
-			Publisher:          publisher,
-			ActivityLogger:     activityLogger,
-			Providers:          providers,
-			WebURL:             config.App.WebURL,
-			TrashRetentionDays: config.App.TrashRetentionDays,
+			DB:                   db,
+			Cache:                cache,
+			Storage:              store,
+			Publisher:            publisher,
+			ActivityLogger:       activityLogger,
+			Providers:            providers,
+			WebURL:               config.App.WebURL,
+			TrashRetentionDays:   config.App.TrashRetentionDays,
+			InviteExpiryDays:     config.App.InviteExpiryDays,
+			Channels:             channels,
+			StorageEncryptionKey: config.App.StorageEncryptionKey,
 		}.Routes())
 
 		apiRouter.Mount("/v1/auth", services.AuthService{
@@ -483,6 +558,7 @@ func BuildAPIRouter(
 			Providers:      providers,
 			Publisher:      publisher,
 			ActivityLogger: activityLogger,
+			PasswordPolicy: passwordPolicy,
 		}.Routes())
 
 		apiRouter.Mount("/v1/invites", services.InviteService{
@@ -493,12 +569,14 @@ func BuildAPIRouter(
 			Publisher:      publisher,
 			ActivityLogger: activityLogger,
 			Providers:      providers,
+			PasswordPolicy: passwordPolicy,
 		}.Routes())
 
 		apiRouter.Mount("/v1/admin", services.AdminService{
 			DB:             db,
 			Cache:          cache,
 			ActivityLogger: activityLogger,
+			Publisher:      publisher,
 			Config:         config,
 		}.Routes())
 
@@ -509,8 +587,10 @@ func BuildAPIRouter(
 			ActivityLogger:        activityLogger,
 			Publisher:             publisher,
 			TokenSecret:           authConfig.TokenSecret,
+			WebURL:                config.App.WebURL,
 			CookieSecureForce:     authConfig.CookieSecureForce,
 			AllowRedirectDownload: config.App.AllowRedirectDownload,
+			StorageEncryptionKey:  config.App.StorageEncryptionKey,
 		}.Routes())
 	})
 
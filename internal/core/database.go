@@ -15,6 +15,8 @@ func NewDatabase(config models.DatabaseConfiguration) *gorm.DB {
 		return database.InitPostgres(config.Postgres)
 	case configuration.ProviderSQLite:
 		return database.InitSQLite(config.SQLite)
+	case configuration.ProviderMySQL:
+		return database.InitMySQL(config.MySQL)
 	default:
 		zap.L().Fatal("Unsupported database type", zap.String("type", config.Type))
 		return nil
@@ -53,15 +53,23 @@ func (er *EventRouter) getTopicKeyForEvent(eventType string) string {
 		events.ChallengeUserInviteName,
 		events.PasswordResetChallengeName,
 		events.PasswordResetSuccessName,
+		events.ShareAccessChallengeName,
+		events.ShareSentName,
+		events.SharePasswordSentName,
 		events.UserWelcomeName,
 		events.MFAResetChallengeName,
-		events.FileActivityNotificationName:
+		events.FileActivityNotificationName,
+		events.NewLoginDetectedName,
+		events.AccountLockedName,
+		events.PasswordChangedName,
+		events.FilesExpiringName:
 		return configuration.EventsNotifications
 	case events.BucketPurgeName,
 		events.FolderTrashName,
 		events.FolderPurgeName,
 		events.FolderRestoreName,
-		events.TrashExpirationName:
+		events.TrashExpirationName,
+		events.ObjectLockSyncName:
 		return configuration.EventsObjectDeletion
 	default:
 		return ""
@@ -52,6 +52,12 @@ func (em *EventsManager) initializePublishers() {
 			publisher = messaging.NewAWSPublisher(topicConfig.Name)
 		case configuration.ProviderAzure:
 			publisher = messaging.NewAzurePublisher(em.config.Azure, topicConfig.Name)
+		case configuration.ProviderRedis:
+			publisher = messaging.NewRedisStreamsPublisher(
+				messaging.RedisStreamsConnectionFromRedis(em.config.Redis), topicConfig.Name)
+		case configuration.ProviderValkey:
+			publisher = messaging.NewRedisStreamsPublisher(
+				messaging.RedisStreamsConnectionFromValkey(em.config.Valkey), topicConfig.Name)
 		case configuration.ProviderMemory:
 			ch := messaging.NewMemoryChannel()
 			publisher = messaging.NewMemoryPublisher(ch, topicConfig.Name)
@@ -86,6 +92,12 @@ func (em *EventsManager) initializeSubscribers() {
 			subscriber = messaging.NewAWSSubscriber(topicConfig.Name)
 		case configuration.ProviderAzure:
 			subscriber = messaging.NewAzureSubscriber(em.config.Azure, topicConfig.Name)
+		case configuration.ProviderRedis:
+			subscriber = messaging.NewRedisStreamsSubscriber(
+				messaging.RedisStreamsConnectionFromRedis(em.config.Redis), topicConfig.Name)
+		case configuration.ProviderValkey:
+			subscriber = messaging.NewRedisStreamsSubscriber(
+				messaging.RedisStreamsConnectionFromValkey(em.config.Valkey), topicConfig.Name)
 		case configuration.ProviderMemory:
 			// Memory subscribers are already created in initializePublishers() (shared GoChannel).
 			continue
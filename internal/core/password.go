@@ -0,0 +1,19 @@
+package core
+
+import (
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
+
+	"go.uber.org/zap"
+)
+
+func NewPasswordPolicy(config models.PasswordPolicy) password.Policy {
+	policy, err := password.NewPolicy(config)
+	if err != nil {
+		zap.L().Fatal("Failed to load password policy", zap.Error(err))
+	}
+	if policy.Breached != nil {
+		zap.L().Info("Loaded breached password list", zap.Int("hashes", policy.Breached.Size()))
+	}
+	return policy
+}
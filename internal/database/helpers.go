@@ -12,10 +12,14 @@ import (
 // FormatHourStr returns the SQL expression that formats a timestamp column as an
 // RFC3339 UTC hour bucket (e.g. 2026-06-16T14:00:00Z) for the underlying dialect.
 func FormatHourStr(db *gorm.DB, column string) string {
-	if db.Dialector.Name() == DialectSQLite {
+	switch db.Dialector.Name() {
+	case DialectSQLite:
 		return fmt.Sprintf("strftime('%%Y-%%m-%%dT%%H:00:00Z', %s)", column)
+	case DialectMySQL:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%dT%%H:00:00Z')", column)
+	default:
+		return fmt.Sprintf("TO_CHAR(%s, 'YYYY-MM-DD\"T\"HH24\":00:00Z\"')", column)
 	}
-	return fmt.Sprintf("TO_CHAR(%s, 'YYYY-MM-DD\"T\"HH24\":00:00Z\"')", column)
 }
 
 func UpsertAdminUser(db *gorm.DB, adminUser *models.User) {
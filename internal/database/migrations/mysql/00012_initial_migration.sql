@@ -0,0 +1,275 @@
+-- +goose Up
+
+-- MySQL and MariaDB have no partial indexes: the soft-delete aware unique indexes rely on
+-- virtual columns that are NULL for deleted rows, since NULLs never collide in a unique index.
+-- Tables use a binary collation so that names and emails compare case-sensitively as on PostgreSQL.
+
+CREATE TABLE users
+    (
+        id CHAR(36) PRIMARY KEY,
+        first_name VARCHAR(255),
+        last_name VARCHAR(255),
+        email VARCHAR(255) NOT NULL,
+        hashed_password VARCHAR(255),
+        provider_type VARCHAR(32) NOT NULL,
+        provider_key VARCHAR(255) NOT NULL,
+        role VARCHAR(32) NOT NULL DEFAULT 'user',
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+        active_key TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+        INDEX idx_users_email (email),
+        UNIQUE INDEX idx_users_email_provider_key (email, provider_key, active_key)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE buckets
+    (
+        id CHAR(36) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        created_by CHAR(36) NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+
+        INDEX idx_buckets_created_by (created_by),
+
+        CONSTRAINT fk_buckets_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE memberships
+    (
+        id CHAR(36) PRIMARY KEY,
+        user_id CHAR(36) NOT NULL,
+        bucket_id CHAR(36) NOT NULL,
+        `group` VARCHAR(32) NOT NULL,
+        upload_notifications BOOLEAN NOT NULL DEFAULT TRUE,
+        download_notifications BOOLEAN NOT NULL DEFAULT FALSE,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+        active_key TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+        INDEX idx_memberships_user_id (user_id),
+        INDEX idx_memberships_bucket_id (bucket_id),
+        UNIQUE INDEX idx_memberships_user_bucket (user_id, bucket_id, active_key),
+
+        CONSTRAINT fk_memberships_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_memberships_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE folders
+    (
+        id CHAR(36) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        status VARCHAR(32) NOT NULL DEFAULT 'created',
+        folder_id CHAR(36),
+        bucket_id CHAR(36) NOT NULL,
+        deleted_by CHAR(36),
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+        parent_key CHAR(36) GENERATED ALWAYS AS (COALESCE(folder_id, '00000000-0000-0000-0000-000000000000')) VIRTUAL,
+        active_key TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+        INDEX idx_folders_bucket_parent (bucket_id, folder_id),
+        INDEX idx_folders_deleted_by (deleted_by),
+        UNIQUE INDEX idx_folders_unique_name (bucket_id, parent_key, name, active_key),
+
+        CONSTRAINT fk_folders_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE SET NULL,
+        CONSTRAINT fk_folders_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_folders_deleted_by
+            FOREIGN KEY (deleted_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE files
+    (
+        id CHAR(36) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        extension VARCHAR(255),
+        status VARCHAR(32),
+        bucket_id CHAR(36) NOT NULL,
+        folder_id CHAR(36),
+        size BIGINT NOT NULL DEFAULT 0,
+        deleted_by CHAR(36),
+        expires_at DATETIME(6),
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+        parent_key CHAR(36) GENERATED ALWAYS AS (COALESCE(folder_id, '00000000-0000-0000-0000-000000000000')) VIRTUAL,
+        active_key TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+        INDEX idx_files_bucket_folder (bucket_id, folder_id),
+        INDEX idx_files_deleted_by (deleted_by),
+        INDEX idx_files_expires_at (expires_at),
+        UNIQUE INDEX idx_files_unique_name (bucket_id, parent_key, name, active_key),
+
+        CONSTRAINT fk_files_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_files_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE SET NULL,
+        CONSTRAINT fk_files_deleted_by
+            FOREIGN KEY (deleted_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+
+        CONSTRAINT chk_files_size_positive
+            CHECK (size >= 0)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE invites
+    (
+        id CHAR(36) PRIMARY KEY,
+        email VARCHAR(255) NOT NULL,
+        `group` VARCHAR(32) NOT NULL,
+        bucket_id CHAR(36) NOT NULL,
+        created_by CHAR(36) NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_invites_bucket_id (bucket_id),
+        INDEX idx_invites_email (email),
+        UNIQUE INDEX idx_invite_unique (email, `group`, bucket_id),
+
+        CONSTRAINT fk_invites_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_invites_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE challenges
+    (
+        id CHAR(36) PRIMARY KEY,
+        type VARCHAR(32) NOT NULL,
+        hashed_secret VARCHAR(255) NOT NULL,
+        attempts_left INT NOT NULL DEFAULT 3,
+        expires_at DATETIME(6),
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+        invite_id CHAR(36),
+        user_id CHAR(36),
+        active_invite_id CHAR(36) GENERATED ALWAYS AS (IF(deleted_at IS NULL, invite_id, NULL)) VIRTUAL,
+        active_user_id CHAR(36) GENERATED ALWAYS AS (IF(deleted_at IS NULL, user_id, NULL)) VIRTUAL,
+
+        INDEX idx_challenges_expires_at (expires_at),
+        UNIQUE INDEX idx_challenge_invite (active_invite_id),
+        UNIQUE INDEX idx_challenge_user (active_user_id),
+
+        CONSTRAINT fk_challenges_invite_id
+            FOREIGN KEY (invite_id) REFERENCES invites (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_challenges_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+
+        -- MySQL rejects CHECK constraints on columns with cascading foreign keys, so the
+        -- invite_id/user_id exclusivity enforced on the other dialects is left to the services.
+        CONSTRAINT chk_challenges_attempts_left
+            CHECK (attempts_left >= 0)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE mfa_devices
+    (
+        id CHAR(36) PRIMARY KEY,
+        user_id CHAR(36) NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        type VARCHAR(32) NOT NULL DEFAULT 'totp',
+        encrypted_secret TEXT NOT NULL,
+        is_default BOOLEAN NOT NULL DEFAULT FALSE,
+        is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        verified_at DATETIME(6),
+        last_used_at DATETIME(6),
+        default_user_id CHAR(36) GENERATED ALWAYS AS (IF(is_default, user_id, NULL)) VIRTUAL,
+
+        INDEX idx_mfa_devices_user_id (user_id),
+        INDEX idx_mfa_devices_verified (user_id, is_verified),
+        UNIQUE INDEX idx_mfa_devices_one_default_per_user (default_user_id),
+        UNIQUE INDEX unique_user_device_name (user_id, name),
+
+        CONSTRAINT fk_mfa_devices_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE shares
+    (
+        id CHAR(36) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        bucket_id CHAR(36) NOT NULL,
+        folder_id CHAR(36),
+        expires_at DATETIME(6),
+        max_views INT,
+        current_views INT NOT NULL DEFAULT 0,
+        hashed_password VARCHAR(255),
+        type VARCHAR(32) NOT NULL,
+        allow_upload BOOLEAN NOT NULL DEFAULT FALSE,
+        max_uploads INT,
+        current_uploads INT NOT NULL DEFAULT 0,
+        max_upload_size BIGINT,
+        created_by CHAR(36) NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        deleted_at DATETIME(6),
+
+        INDEX idx_shares_bucket_id (bucket_id),
+
+        CONSTRAINT fk_shares_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_shares_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_shares_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+
+        CONSTRAINT chk_shares_upload_type
+            CHECK (NOT (allow_upload = TRUE AND type = 'files')),
+        CONSTRAINT chk_shares_current_views
+            CHECK (current_views >= 0),
+        CONSTRAINT chk_shares_current_uploads
+            CHECK (current_uploads >= 0)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE share_files
+    (
+        id CHAR(36) PRIMARY KEY,
+        share_id CHAR(36) NOT NULL,
+        file_id CHAR(36) NOT NULL,
+
+        INDEX idx_share_files_file_id (file_id),
+        UNIQUE INDEX idx_share_files_unique (share_id, file_id),
+
+        CONSTRAINT fk_share_files_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_share_files_file_id
+            FOREIGN KEY (file_id) REFERENCES files (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE dead_letter_events
+    (
+        id CHAR(36) PRIMARY KEY,
+        message_id VARCHAR(255) NOT NULL,
+        event_type VARCHAR(255) NOT NULL,
+        worker VARCHAR(255) NOT NULL,
+        payload LONGTEXT NOT NULL,
+        metadata TEXT NOT NULL,
+        attempts INT NOT NULL DEFAULT 0,
+        last_error TEXT NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_dead_letter_events_event_type (event_type),
+        INDEX idx_dead_letter_events_created_at (created_at)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS dead_letter_events;
+DROP TABLE IF EXISTS share_files;
+DROP TABLE IF EXISTS shares;
+DROP TABLE IF EXISTS mfa_devices;
+DROP TABLE IF EXISTS challenges;
+DROP TABLE IF EXISTS invites;
+DROP TABLE IF EXISTS files;
+DROP TABLE IF EXISTS folders;
+DROP TABLE IF EXISTS memberships;
+DROP TABLE IF EXISTS buckets;
+DROP TABLE IF EXISTS users;
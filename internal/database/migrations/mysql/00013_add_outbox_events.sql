@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE outbox_events
+    (
+        id CHAR(36) PRIMARY KEY,
+        message_id VARCHAR(255) NOT NULL,
+        event_type VARCHAR(255) NOT NULL,
+        payload LONGTEXT NOT NULL,
+        status VARCHAR(32) NOT NULL DEFAULT 'pending',
+        attempts INT NOT NULL DEFAULT 0,
+        last_error TEXT,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        sent_at DATETIME(6),
+
+        INDEX idx_outbox_events_pending (status, created_at),
+        INDEX idx_outbox_events_sent_at (status, sent_at)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
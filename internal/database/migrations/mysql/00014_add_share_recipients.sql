@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE shares ADD COLUMN recipient_restricted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE share_recipients
+    (
+        id CHAR(36) PRIMARY KEY,
+        share_id CHAR(36) NOT NULL,
+        value VARCHAR(255) NOT NULL,
+
+        UNIQUE INDEX idx_share_recipients_unique (share_id, value),
+
+        CONSTRAINT fk_share_recipients_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+ALTER TABLE challenges
+    ADD COLUMN share_id CHAR(36),
+    ADD COLUMN email VARCHAR(255),
+    ADD INDEX idx_challenge_share (share_id, email),
+    ADD CONSTRAINT fk_challenges_share_id
+        FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- +goose Down
+DELETE FROM challenges WHERE share_id IS NOT NULL;
+
+ALTER TABLE challenges
+    DROP FOREIGN KEY fk_challenges_share_id,
+    DROP INDEX idx_challenge_share,
+    DROP COLUMN email,
+    DROP COLUMN share_id;
+
+DROP TABLE IF EXISTS share_recipients;
+ALTER TABLE shares DROP COLUMN recipient_restricted;
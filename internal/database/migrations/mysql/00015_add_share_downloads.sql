@@ -0,0 +1,40 @@
+-- +goose Up
+ALTER TABLE shares
+    ADD COLUMN max_downloads INT,
+    ADD COLUMN current_downloads INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_shares_current_downloads CHECK (current_downloads >= 0);
+
+ALTER TABLE share_files
+    ADD COLUMN max_downloads INT,
+    ADD COLUMN current_downloads INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_share_files_current_downloads CHECK (current_downloads >= 0);
+
+CREATE TABLE share_accesses
+    (
+        id CHAR(36) PRIMARY KEY,
+        share_id CHAR(36) NOT NULL,
+        file_id CHAR(36),
+        type VARCHAR(32) NOT NULL,
+        ip_address VARCHAR(64) NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL,
+        recipient_email VARCHAR(255) NOT NULL DEFAULT '',
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_share_accesses_share_id (share_id, created_at),
+
+        CONSTRAINT fk_share_accesses_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS share_accesses;
+
+ALTER TABLE share_files
+    DROP CHECK chk_share_files_current_downloads,
+    DROP COLUMN current_downloads,
+    DROP COLUMN max_downloads;
+
+ALTER TABLE shares
+    DROP CHECK chk_shares_current_downloads,
+    DROP COLUMN current_downloads,
+    DROP COLUMN max_downloads;
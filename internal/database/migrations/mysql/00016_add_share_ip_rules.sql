@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shares
+    ADD COLUMN ip_allowlist TEXT,
+    ADD COLUMN ip_denylist TEXT;
+
+-- +goose Down
+ALTER TABLE shares
+    DROP COLUMN ip_denylist,
+    DROP COLUMN ip_allowlist;
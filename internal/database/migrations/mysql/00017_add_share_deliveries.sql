@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE share_deliveries
+    (
+        id CHAR(36) PRIMARY KEY,
+        share_id CHAR(36) NOT NULL,
+        email VARCHAR(255) NOT NULL,
+        sent_by CHAR(36),
+        password_sent BOOLEAN NOT NULL DEFAULT FALSE,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_share_deliveries_share_id (share_id, created_at),
+
+        CONSTRAINT fk_share_deliveries_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_share_deliveries_sent_by
+            FOREIGN KEY (sent_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS share_deliveries;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE shares ADD COLUMN require_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE files
+    ADD COLUMN share_id CHAR(36),
+    ADD CONSTRAINT fk_files_share_id
+        FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+-- +goose Down
+UPDATE files SET status = 'uploaded' WHERE status = 'pending';
+
+ALTER TABLE files
+    DROP FOREIGN KEY fk_files_share_id,
+    DROP COLUMN share_id;
+
+ALTER TABLE shares DROP COLUMN require_approval;
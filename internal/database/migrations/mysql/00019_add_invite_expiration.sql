@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE invites
+    ADD COLUMN expires_at DATETIME(6),
+    ADD INDEX idx_invites_expires_at (expires_at);
+
+-- +goose Down
+ALTER TABLE invites
+    DROP INDEX idx_invites_expires_at,
+    DROP COLUMN expires_at;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE memberships
+    ADD COLUMN delete_notifications BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN restore_notifications BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN share_notifications BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN member_notifications BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN notification_delivery VARCHAR(16) NOT NULL DEFAULT 'immediate';
+
+-- +goose Down
+ALTER TABLE memberships
+    DROP COLUMN notification_delivery,
+    DROP COLUMN member_notifications,
+    DROP COLUMN share_notifications,
+    DROP COLUMN restore_notifications,
+    DROP COLUMN delete_notifications;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE bucket_channels
+    (
+        id CHAR(36) PRIMARY KEY,
+        bucket_id CHAR(36) NOT NULL,
+        type VARCHAR(32) NOT NULL,
+        name VARCHAR(255) NOT NULL,
+        webhook_url TEXT NOT NULL,
+        notify_uploads BOOLEAN NOT NULL DEFAULT TRUE,
+        notify_downloads BOOLEAN NOT NULL DEFAULT FALSE,
+        notify_shares BOOLEAN NOT NULL DEFAULT TRUE,
+        created_by CHAR(36),
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_bucket_channels_bucket_id (bucket_id),
+
+        CONSTRAINT fk_bucket_channels_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_channels_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS bucket_channels;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE queued_notifications
+    (
+        id CHAR(36) PRIMARY KEY,
+        recipient VARCHAR(255) NOT NULL,
+        subject VARCHAR(255) NOT NULL,
+        template VARCHAR(64) NOT NULL,
+        payload LONGTEXT NOT NULL,
+        status VARCHAR(16) NOT NULL DEFAULT 'pending',
+        attempts INT NOT NULL DEFAULT 0,
+        next_attempt_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        last_error TEXT,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        sent_at DATETIME(6),
+
+        INDEX idx_queued_notifications_pending (status, next_attempt_at),
+        INDEX idx_queued_notifications_status (status, created_at)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+ALTER TABLE users
+    ADD COLUMN email_bounced BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN email_bounced;
+
+DROP TABLE IF EXISTS queued_notifications;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE user_login_devices
+    (
+        id CHAR(36) PRIMARY KEY,
+        user_id CHAR(36) NOT NULL,
+        fingerprint VARCHAR(64) NOT NULL,
+        ip VARCHAR(45) NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL,
+        first_seen_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        last_seen_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        UNIQUE INDEX idx_user_login_devices_fingerprint (user_id, fingerprint),
+
+        CONSTRAINT fk_user_login_devices_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_login_devices;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE user_password_hashes
+    (
+        id CHAR(36) PRIMARY KEY,
+        user_id CHAR(36) NOT NULL,
+        hashed_password TEXT NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        INDEX idx_user_password_hashes_user_id (user_id, created_at),
+
+        CONSTRAINT fk_user_password_hashes_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_password_hashes;
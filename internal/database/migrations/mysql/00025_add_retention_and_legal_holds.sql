@@ -0,0 +1,50 @@
+-- +goose Up
+CREATE TABLE retention_policies
+    (
+        id CHAR(36) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        retention_days INT NOT NULL,
+        locked BOOLEAN NOT NULL DEFAULT FALSE,
+        created_by CHAR(36) NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        CONSTRAINT chk_retention_policies_days_positive
+            CHECK (retention_days > 0)
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+ALTER TABLE buckets
+    ADD COLUMN retention_policy_id CHAR(36),
+    ADD CONSTRAINT fk_buckets_retention_policy_id
+        FOREIGN KEY (retention_policy_id) REFERENCES retention_policies (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE TABLE legal_holds
+    (
+        id CHAR(36) PRIMARY KEY,
+        bucket_id CHAR(36) NOT NULL,
+        folder_id CHAR(36),
+        file_id CHAR(36),
+        reason TEXT NOT NULL,
+        created_by CHAR(36) NOT NULL,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        released_by CHAR(36),
+        released_at DATETIME(6),
+
+        INDEX idx_legal_holds_active (bucket_id, released_at),
+
+        CONSTRAINT fk_legal_holds_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_file_id
+            FOREIGN KEY (file_id) REFERENCES files (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS legal_holds;
+
+ALTER TABLE buckets
+    DROP FOREIGN KEY fk_buckets_retention_policy_id,
+    DROP COLUMN retention_policy_id;
+
+DROP TABLE IF EXISTS retention_policies;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE bucket_lifecycle_rules
+    (
+        id CHAR(36) PRIMARY KEY,
+        bucket_id CHAR(36) NOT NULL,
+        extension VARCHAR(255) NOT NULL DEFAULT '',
+        default_expiry_days INT,
+        max_expiry_days INT,
+        inactive_days INT,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+        updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        UNIQUE INDEX idx_bucket_lifecycle_rules_extension (bucket_id, extension),
+
+        CONSTRAINT fk_bucket_lifecycle_rules_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+ALTER TABLE files
+    ADD COLUMN last_downloaded_at DATETIME(6),
+    ADD COLUMN expiry_warned_at DATETIME(6);
+
+-- +goose Down
+ALTER TABLE files
+    DROP COLUMN expiry_warned_at,
+    DROP COLUMN last_downloaded_at;
+
+DROP TABLE IF EXISTS bucket_lifecycle_rules;
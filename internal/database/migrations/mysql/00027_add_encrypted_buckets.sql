@@ -0,0 +1,56 @@
+-- +goose Up
+CREATE TABLE user_keys
+    (
+        id CHAR(36) PRIMARY KEY,
+        user_id CHAR(36) NOT NULL,
+        algorithm VARCHAR(64) NOT NULL,
+        public_key TEXT NOT NULL,
+        encrypted_private_key TEXT,
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        UNIQUE INDEX idx_user_keys_user_id (user_id),
+
+        CONSTRAINT fk_user_keys_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE TABLE bucket_keys
+    (
+        id CHAR(36) PRIMARY KEY,
+        bucket_id CHAR(36) NOT NULL,
+        user_id CHAR(36) NOT NULL,
+        user_key_id CHAR(36) NOT NULL,
+        key_version INT NOT NULL,
+        wrapped_key TEXT NOT NULL,
+        created_by CHAR(36),
+        created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+        UNIQUE INDEX idx_bucket_keys_version (bucket_id, user_id, key_version),
+
+        CONSTRAINT fk_bucket_keys_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_user_key_id
+            FOREIGN KEY (user_key_id) REFERENCES user_keys (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    ) DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+ALTER TABLE buckets
+    ADD COLUMN encrypted BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN key_version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE files
+    ADD COLUMN encryption TEXT;
+
+-- +goose Down
+ALTER TABLE files
+    DROP COLUMN encryption;
+
+ALTER TABLE buckets
+    DROP COLUMN key_version,
+    DROP COLUMN encrypted;
+
+DROP TABLE IF EXISTS bucket_keys;
+DROP TABLE IF EXISTS user_keys;
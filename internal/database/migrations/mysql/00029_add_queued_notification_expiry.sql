@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE queued_notifications
+    ADD COLUMN expires_at DATETIME(6);
+
+-- Payloads are stored encrypted from now on: unsent entries kept in plain text cannot be delivered.
+UPDATE queued_notifications
+SET payload = '', status = 'failed', last_error = 'payload discarded on upgrade'
+WHERE status <> 'sent';
+
+-- +goose Down
+ALTER TABLE queued_notifications
+    DROP COLUMN expires_at;
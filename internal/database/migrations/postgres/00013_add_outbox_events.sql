@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE outbox_status AS ENUM ('pending', 'sent');
+
+CREATE TABLE outbox_events
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        message_id TEXT NOT NULL,
+        event_type TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        status outbox_status NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        last_error TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        sent_at TIMESTAMP
+    );
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events (created_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_events_sent_at ON outbox_events (sent_at) WHERE status = 'sent';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS outbox_events;
+DROP TYPE IF EXISTS outbox_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TYPE challenge_type ADD VALUE 'share_access';
+
+ALTER TABLE shares ADD COLUMN recipient_restricted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE share_recipients
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        share_id UUID NOT NULL,
+        value TEXT NOT NULL,
+
+        CONSTRAINT fk_share_recipients_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+
+        CONSTRAINT idx_share_recipients_unique
+            UNIQUE (share_id, value)
+    );
+
+ALTER TABLE challenges
+    ADD COLUMN share_id UUID,
+    ADD COLUMN email TEXT,
+    ADD CONSTRAINT fk_challenges_share_id
+        FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE challenges DROP CONSTRAINT chk_challenges_mutual_exclusive;
+ALTER TABLE challenges ADD CONSTRAINT chk_challenges_mutual_exclusive
+    CHECK (num_nonnulls(invite_id, user_id, share_id) = 1);
+
+CREATE INDEX idx_challenge_share ON challenges (share_id, email) WHERE share_id IS NOT NULL AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM challenges WHERE share_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_challenge_share;
+ALTER TABLE challenges DROP CONSTRAINT chk_challenges_mutual_exclusive;
+ALTER TABLE challenges ADD CONSTRAINT chk_challenges_mutual_exclusive
+    CHECK ( (invite_id IS NOT NULL AND user_id IS NULL) OR (invite_id IS NULL AND user_id IS NOT NULL) );
+ALTER TABLE challenges DROP CONSTRAINT fk_challenges_share_id;
+ALTER TABLE challenges DROP COLUMN email;
+ALTER TABLE challenges DROP COLUMN share_id;
+
+DROP TABLE IF EXISTS share_recipients;
+ALTER TABLE shares DROP COLUMN recipient_restricted;
+
+-- +goose StatementEnd
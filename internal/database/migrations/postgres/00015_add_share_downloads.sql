@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE share_access_type AS ENUM ('view', 'download');
+
+ALTER TABLE shares
+    ADD COLUMN max_downloads INTEGER,
+    ADD COLUMN current_downloads INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_shares_current_downloads CHECK (current_downloads >= 0);
+
+ALTER TABLE share_files
+    ADD COLUMN max_downloads INTEGER,
+    ADD COLUMN current_downloads INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_share_files_current_downloads CHECK (current_downloads >= 0);
+
+CREATE TABLE share_accesses
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        share_id UUID NOT NULL,
+        file_id UUID,
+        type share_access_type NOT NULL,
+        ip_address TEXT NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL DEFAULT '',
+        recipient_email TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_share_accesses_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_share_accesses_share_id ON share_accesses (share_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS share_accesses;
+
+ALTER TABLE share_files
+    DROP CONSTRAINT chk_share_files_current_downloads,
+    DROP COLUMN current_downloads,
+    DROP COLUMN max_downloads;
+
+ALTER TABLE shares
+    DROP CONSTRAINT chk_shares_current_downloads,
+    DROP COLUMN current_downloads,
+    DROP COLUMN max_downloads;
+
+DROP TYPE IF EXISTS share_access_type;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE shares
+    ADD COLUMN ip_allowlist TEXT,
+    ADD COLUMN ip_denylist TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE shares
+    DROP COLUMN ip_denylist,
+    DROP COLUMN ip_allowlist;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE share_deliveries
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        share_id UUID NOT NULL,
+        email TEXT NOT NULL,
+        sent_by UUID,
+        password_sent BOOLEAN NOT NULL DEFAULT FALSE,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_share_deliveries_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_share_deliveries_sent_by
+            FOREIGN KEY (sent_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_share_deliveries_share_id ON share_deliveries (share_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS share_deliveries;
+
+-- +goose StatementEnd
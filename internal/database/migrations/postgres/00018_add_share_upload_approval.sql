@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TYPE file_status ADD VALUE 'pending';
+
+ALTER TABLE shares ADD COLUMN require_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE files
+    ADD COLUMN share_id UUID,
+    ADD CONSTRAINT fk_files_share_id
+        FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE files SET status = 'uploaded' WHERE status = 'pending';
+
+ALTER TABLE files
+    DROP CONSTRAINT fk_files_share_id,
+    DROP COLUMN share_id;
+
+ALTER TABLE shares DROP COLUMN require_approval;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE invites
+    ADD COLUMN expires_at TIMESTAMP;
+
+CREATE INDEX idx_invites_expires_at ON invites (expires_at) WHERE expires_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_invites_expires_at;
+
+ALTER TABLE invites
+    DROP COLUMN expires_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE bucket_channels
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        bucket_id UUID NOT NULL,
+        type VARCHAR(32) NOT NULL,
+        name TEXT NOT NULL,
+        webhook_url TEXT NOT NULL,
+        notify_uploads BOOLEAN NOT NULL DEFAULT TRUE,
+        notify_downloads BOOLEAN NOT NULL DEFAULT FALSE,
+        notify_shares BOOLEAN NOT NULL DEFAULT TRUE,
+        created_by UUID,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_bucket_channels_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_channels_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_bucket_channels_bucket_id ON bucket_channels (bucket_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bucket_channels;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE queued_notifications
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        recipient TEXT NOT NULL,
+        subject TEXT NOT NULL,
+        template VARCHAR(64) NOT NULL,
+        payload TEXT NOT NULL,
+        status VARCHAR(16) NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_error TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        sent_at TIMESTAMP
+    );
+
+CREATE INDEX idx_queued_notifications_pending ON queued_notifications (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_queued_notifications_status ON queued_notifications (status, created_at);
+
+ALTER TABLE users
+    ADD COLUMN email_bounced BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP COLUMN email_bounced;
+
+DROP TABLE IF EXISTS queued_notifications;
+
+-- +goose StatementEnd
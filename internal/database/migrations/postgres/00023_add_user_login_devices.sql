@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE user_login_devices
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL,
+        fingerprint VARCHAR(64) NOT NULL,
+        ip VARCHAR(45) NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL DEFAULT '',
+        first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_user_login_devices_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_user_login_devices_fingerprint ON user_login_devices (user_id, fingerprint);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_login_devices;
+
+-- +goose StatementEnd
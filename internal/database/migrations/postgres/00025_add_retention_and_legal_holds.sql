@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE retention_policies
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        name TEXT NOT NULL,
+        retention_days INTEGER NOT NULL,
+        locked BOOLEAN NOT NULL DEFAULT FALSE,
+        created_by UUID NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT chk_retention_policies_days_positive
+            CHECK (retention_days > 0)
+    );
+
+ALTER TABLE buckets
+    ADD COLUMN retention_policy_id UUID,
+    ADD CONSTRAINT fk_buckets_retention_policy_id
+        FOREIGN KEY (retention_policy_id) REFERENCES retention_policies (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE TABLE legal_holds
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        bucket_id UUID NOT NULL,
+        folder_id UUID,
+        file_id UUID,
+        reason TEXT NOT NULL,
+        created_by UUID NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        released_by UUID,
+        released_at TIMESTAMP,
+
+        CONSTRAINT fk_legal_holds_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_file_id
+            FOREIGN KEY (file_id) REFERENCES files (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT chk_legal_holds_single_target
+            CHECK (folder_id IS NULL OR file_id IS NULL)
+    );
+
+CREATE INDEX idx_legal_holds_active ON legal_holds (bucket_id) WHERE released_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS legal_holds;
+
+ALTER TABLE buckets
+    DROP CONSTRAINT fk_buckets_retention_policy_id,
+    DROP COLUMN retention_policy_id;
+
+DROP TABLE IF EXISTS retention_policies;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE bucket_lifecycle_rules
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        bucket_id UUID NOT NULL,
+        extension TEXT NOT NULL DEFAULT '',
+        default_expiry_days INTEGER,
+        max_expiry_days INTEGER,
+        inactive_days INTEGER,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_bucket_lifecycle_rules_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_bucket_lifecycle_rules_extension ON bucket_lifecycle_rules (bucket_id, extension);
+
+ALTER TABLE files
+    ADD COLUMN last_downloaded_at TIMESTAMP,
+    ADD COLUMN expiry_warned_at TIMESTAMP;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE files
+    DROP COLUMN expiry_warned_at,
+    DROP COLUMN last_downloaded_at;
+
+DROP TABLE IF EXISTS bucket_lifecycle_rules;
+
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE user_keys
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL,
+        algorithm TEXT NOT NULL,
+        public_key TEXT NOT NULL,
+        encrypted_private_key TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_user_keys_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_user_keys_user_id ON user_keys (user_id);
+
+CREATE TABLE bucket_keys
+    (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        bucket_id UUID NOT NULL,
+        user_id UUID NOT NULL,
+        user_key_id UUID NOT NULL,
+        key_version INTEGER NOT NULL,
+        wrapped_key TEXT NOT NULL,
+        created_by UUID,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_bucket_keys_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_user_key_id
+            FOREIGN KEY (user_key_id) REFERENCES user_keys (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_keys_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    );
+
+CREATE UNIQUE INDEX idx_bucket_keys_version ON bucket_keys (bucket_id, user_id, key_version);
+
+ALTER TABLE buckets
+    ADD COLUMN encrypted BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN key_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE files
+    ADD COLUMN encryption TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE files
+    DROP COLUMN encryption;
+
+ALTER TABLE buckets
+    DROP COLUMN key_version,
+    DROP COLUMN encrypted;
+
+DROP TABLE IF EXISTS bucket_keys;
+DROP TABLE IF EXISTS user_keys;
+
+-- +goose StatementEnd
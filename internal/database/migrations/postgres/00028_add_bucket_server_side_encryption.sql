@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE buckets
+    ADD COLUMN sse_mode VARCHAR(16),
+    ADD COLUMN sse_key_id TEXT,
+    ADD COLUMN sse_customer_key TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE buckets
+    DROP COLUMN sse_customer_key,
+    DROP COLUMN sse_key_id,
+    DROP COLUMN sse_mode;
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE queued_notifications
+    ADD COLUMN expires_at TIMESTAMP;
+
+-- Payloads are stored encrypted from now on: unsent entries kept in plain text cannot be delivered.
+UPDATE queued_notifications
+SET payload = '', status = 'failed', last_error = 'payload discarded on upgrade'
+WHERE status <> 'sent';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE queued_notifications
+    DROP COLUMN expires_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE dead_letter_events
+    (
+        id TEXT PRIMARY KEY,
+        message_id TEXT NOT NULL,
+        event_type TEXT NOT NULL,
+        worker TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        metadata TEXT NOT NULL DEFAULT '{}',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        last_error TEXT NOT NULL,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_dead_letter_events_event_type ON dead_letter_events (event_type);
+CREATE INDEX idx_dead_letter_events_created_at ON dead_letter_events (created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS dead_letter_events;
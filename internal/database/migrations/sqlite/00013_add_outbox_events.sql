@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE outbox_events
+    (
+        id TEXT PRIMARY KEY,
+        message_id TEXT NOT NULL,
+        event_type TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        status TEXT NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        last_error TEXT,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        sent_at DATETIME
+    );
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events (created_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_events_sent_at ON outbox_events (sent_at) WHERE status = 'sent';
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
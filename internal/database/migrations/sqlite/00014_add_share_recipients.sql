@@ -0,0 +1,97 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+ALTER TABLE shares ADD COLUMN recipient_restricted INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE share_recipients
+    (
+        id TEXT PRIMARY KEY,
+        share_id TEXT NOT NULL,
+        value TEXT NOT NULL,
+
+        CONSTRAINT fk_share_recipients_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+
+        CONSTRAINT idx_share_recipients_unique
+            UNIQUE (share_id, value)
+    );
+
+CREATE TABLE challenges_new
+    (
+        id TEXT PRIMARY KEY,
+        type TEXT NOT NULL,
+        hashed_secret TEXT NOT NULL,
+        attempts_left INTEGER NOT NULL DEFAULT 3,
+        expires_at DATETIME,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        deleted_at DATETIME,
+        invite_id TEXT,
+        user_id TEXT,
+        share_id TEXT,
+        email TEXT,
+        CONSTRAINT fk_challenges_invite_id
+            FOREIGN KEY (invite_id) REFERENCES invites (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_challenges_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_challenges_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT chk_challenges_attempts_left
+            CHECK (attempts_left >= 0),
+        CONSTRAINT chk_challenges_mutual_exclusive
+            CHECK ( (invite_id IS NOT NULL) + (user_id IS NOT NULL) + (share_id IS NOT NULL) = 1 )
+    );
+INSERT INTO challenges_new SELECT id, type, hashed_secret, attempts_left, expires_at,
+    created_at, deleted_at, invite_id, user_id, NULL, NULL FROM challenges;
+DROP TABLE challenges;
+ALTER TABLE challenges_new RENAME TO challenges;
+CREATE INDEX idx_challenges_expires_at ON challenges (expires_at);
+CREATE UNIQUE INDEX idx_challenge_invite ON challenges (invite_id) WHERE invite_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_challenge_user ON challenges (user_id) WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX idx_challenge_share ON challenges (share_id, email) WHERE share_id IS NOT NULL AND deleted_at IS NULL;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+CREATE TABLE challenges_old
+    (
+        id TEXT PRIMARY KEY,
+        type TEXT NOT NULL,
+        hashed_secret TEXT NOT NULL,
+        attempts_left INTEGER NOT NULL DEFAULT 3,
+        expires_at DATETIME,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        deleted_at DATETIME,
+        invite_id TEXT,
+        user_id TEXT,
+        CONSTRAINT fk_challenges_invite_id
+            FOREIGN KEY (invite_id) REFERENCES invites (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_challenges_user_id
+            FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT chk_challenges_attempts_left
+            CHECK (attempts_left >= 0),
+        CONSTRAINT chk_challenges_mutual_exclusive
+            CHECK ( (invite_id IS NOT NULL AND user_id IS NULL) OR (invite_id IS NULL AND user_id IS NOT NULL) )
+    );
+INSERT INTO challenges_old SELECT id, type, hashed_secret, attempts_left, expires_at,
+    created_at, deleted_at, invite_id, user_id FROM challenges WHERE share_id IS NULL;
+DROP TABLE challenges;
+ALTER TABLE challenges_old RENAME TO challenges;
+CREATE INDEX idx_challenges_expires_at ON challenges (expires_at);
+CREATE UNIQUE INDEX idx_challenge_invite ON challenges (invite_id) WHERE invite_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_challenge_user ON challenges (user_id) WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+
+DROP TABLE IF EXISTS share_recipients;
+ALTER TABLE shares DROP COLUMN recipient_restricted;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE shares ADD COLUMN max_downloads INTEGER;
+ALTER TABLE shares ADD COLUMN current_downloads INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE share_files ADD COLUMN max_downloads INTEGER;
+ALTER TABLE share_files ADD COLUMN current_downloads INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE share_accesses
+    (
+        id TEXT PRIMARY KEY,
+        share_id TEXT NOT NULL,
+        file_id TEXT,
+        type TEXT NOT NULL CHECK (type IN ('view', 'download')),
+        ip_address TEXT NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL DEFAULT '',
+        recipient_email TEXT NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_share_accesses_share_id
+            FOREIGN KEY (share_id) REFERENCES shares (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_share_accesses_share_id ON share_accesses (share_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS share_accesses;
+
+ALTER TABLE share_files DROP COLUMN current_downloads;
+ALTER TABLE share_files DROP COLUMN max_downloads;
+
+ALTER TABLE shares DROP COLUMN current_downloads;
+ALTER TABLE shares DROP COLUMN max_downloads;
+
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE shares ADD COLUMN ip_allowlist TEXT;
+ALTER TABLE shares ADD COLUMN ip_denylist TEXT;
+
+-- +goose Down
+ALTER TABLE shares DROP COLUMN ip_denylist;
+ALTER TABLE shares DROP COLUMN ip_allowlist;
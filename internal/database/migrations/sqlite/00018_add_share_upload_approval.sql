@@ -0,0 +1,58 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+ALTER TABLE shares ADD COLUMN require_approval INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE files ADD COLUMN share_id TEXT
+    CONSTRAINT fk_files_share_id REFERENCES shares (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+CREATE TABLE files_old
+    (
+        id TEXT PRIMARY KEY,
+        name TEXT NOT NULL,
+        extension TEXT,
+        status TEXT,
+        bucket_id TEXT NOT NULL,
+        folder_id TEXT,
+        size INTEGER NOT NULL DEFAULT 0,
+        deleted_by TEXT,
+        expires_at DATETIME,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        deleted_at DATETIME,
+        CONSTRAINT fk_files_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_files_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE SET NULL,
+        CONSTRAINT fk_files_deleted_by
+            FOREIGN KEY (deleted_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+        CONSTRAINT chk_files_size_positive
+            CHECK (size >= 0)
+    );
+INSERT INTO files_old SELECT id, name, extension,
+    CASE WHEN status = 'pending' THEN 'uploaded' ELSE status END,
+    bucket_id, folder_id, size, deleted_by, expires_at, created_at, updated_at, deleted_at FROM files;
+DROP TABLE files;
+ALTER TABLE files_old RENAME TO files;
+CREATE INDEX idx_files_bucket_folder ON files (bucket_id, folder_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_files_deleted_by ON files (deleted_by) WHERE deleted_by IS NOT NULL;
+CREATE UNIQUE INDEX idx_files_unique_name ON files (bucket_id, COALESCE(folder_id, '00000000-0000-0000-0000-000000000000'), name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_files_expires_at ON files (expires_at) WHERE expires_at IS NOT NULL;
+
+ALTER TABLE shares DROP COLUMN require_approval;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
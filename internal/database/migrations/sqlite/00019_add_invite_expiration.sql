@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE invites ADD COLUMN expires_at DATETIME;
+CREATE INDEX idx_invites_expires_at ON invites (expires_at) WHERE expires_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_invites_expires_at;
+ALTER TABLE invites DROP COLUMN expires_at;
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE memberships ADD COLUMN delete_notifications INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memberships ADD COLUMN restore_notifications INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memberships ADD COLUMN share_notifications INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memberships ADD COLUMN member_notifications INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memberships ADD COLUMN notification_delivery TEXT NOT NULL DEFAULT 'immediate';
+
+-- +goose Down
+ALTER TABLE memberships DROP COLUMN notification_delivery;
+ALTER TABLE memberships DROP COLUMN member_notifications;
+ALTER TABLE memberships DROP COLUMN share_notifications;
+ALTER TABLE memberships DROP COLUMN restore_notifications;
+ALTER TABLE memberships DROP COLUMN delete_notifications;
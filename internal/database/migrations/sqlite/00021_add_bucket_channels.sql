@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE bucket_channels
+    (
+        id TEXT PRIMARY KEY,
+        bucket_id TEXT NOT NULL,
+        type TEXT NOT NULL,
+        name TEXT NOT NULL,
+        webhook_url TEXT NOT NULL,
+        notify_uploads INTEGER NOT NULL DEFAULT 1,
+        notify_downloads INTEGER NOT NULL DEFAULT 0,
+        notify_shares INTEGER NOT NULL DEFAULT 1,
+        created_by TEXT,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_bucket_channels_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_bucket_channels_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_bucket_channels_bucket_id ON bucket_channels (bucket_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bucket_channels;
+
+-- +goose StatementEnd
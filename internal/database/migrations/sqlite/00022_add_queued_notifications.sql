@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE queued_notifications
+    (
+        id TEXT PRIMARY KEY,
+        recipient TEXT NOT NULL,
+        subject TEXT NOT NULL,
+        template TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        status TEXT NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_error TEXT,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        sent_at DATETIME
+    );
+
+CREATE INDEX idx_queued_notifications_pending ON queued_notifications (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_queued_notifications_status ON queued_notifications (status, created_at);
+
+ALTER TABLE users ADD COLUMN email_bounced INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN email_bounced;
+
+DROP TABLE IF EXISTS queued_notifications;
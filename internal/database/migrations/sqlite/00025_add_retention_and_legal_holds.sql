@@ -0,0 +1,81 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+CREATE TABLE retention_policies
+    (
+        id TEXT PRIMARY KEY,
+        name TEXT NOT NULL,
+        retention_days INTEGER NOT NULL,
+        locked INTEGER NOT NULL DEFAULT 0,
+        created_by TEXT NOT NULL,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT chk_retention_policies_days_positive
+            CHECK (retention_days > 0)
+    );
+
+ALTER TABLE buckets ADD COLUMN retention_policy_id TEXT
+    CONSTRAINT fk_buckets_retention_policy_id
+        REFERENCES retention_policies (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE TABLE legal_holds
+    (
+        id TEXT PRIMARY KEY,
+        bucket_id TEXT NOT NULL,
+        folder_id TEXT,
+        file_id TEXT,
+        reason TEXT NOT NULL,
+        created_by TEXT NOT NULL,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        released_by TEXT,
+        released_at DATETIME,
+
+        CONSTRAINT fk_legal_holds_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_folder_id
+            FOREIGN KEY (folder_id) REFERENCES folders (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT fk_legal_holds_file_id
+            FOREIGN KEY (file_id) REFERENCES files (id) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT chk_legal_holds_single_target
+            CHECK (folder_id IS NULL OR file_id IS NULL)
+    );
+
+CREATE INDEX idx_legal_holds_active ON legal_holds (bucket_id) WHERE released_at IS NULL;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS legal_holds;
+
+CREATE TABLE buckets_old
+    (
+        id TEXT PRIMARY KEY,
+        name TEXT NOT NULL,
+        created_by TEXT NOT NULL,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        deleted_at DATETIME,
+
+        CONSTRAINT fk_buckets_created_by
+            FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+INSERT INTO buckets_old SELECT id, name, created_by, created_at, updated_at, deleted_at FROM buckets;
+DROP TABLE buckets;
+ALTER TABLE buckets_old RENAME TO buckets;
+CREATE INDEX idx_buckets_created_by ON buckets (created_by);
+
+DROP TABLE IF EXISTS retention_policies;
+
+COMMIT;
+PRAGMA foreign_keys=ON;
+-- +goose StatementEnd
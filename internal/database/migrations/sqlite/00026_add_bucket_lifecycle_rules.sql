@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE bucket_lifecycle_rules
+    (
+        id TEXT PRIMARY KEY,
+        bucket_id TEXT NOT NULL,
+        extension TEXT NOT NULL DEFAULT '',
+        default_expiry_days INTEGER,
+        max_expiry_days INTEGER,
+        inactive_days INTEGER,
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+        CONSTRAINT fk_bucket_lifecycle_rules_bucket_id
+            FOREIGN KEY (bucket_id) REFERENCES buckets (id) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_bucket_lifecycle_rules_extension ON bucket_lifecycle_rules (bucket_id, extension);
+
+ALTER TABLE files ADD COLUMN last_downloaded_at DATETIME;
+ALTER TABLE files ADD COLUMN expiry_warned_at DATETIME;
+
+-- +goose Down
+ALTER TABLE files DROP COLUMN expiry_warned_at;
+ALTER TABLE files DROP COLUMN last_downloaded_at;
+
+DROP TABLE IF EXISTS bucket_lifecycle_rules;
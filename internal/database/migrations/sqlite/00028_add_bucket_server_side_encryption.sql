@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE buckets ADD COLUMN sse_mode TEXT;
+ALTER TABLE buckets ADD COLUMN sse_key_id TEXT;
+ALTER TABLE buckets ADD COLUMN sse_customer_key TEXT;
+
+-- +goose Down
+ALTER TABLE buckets DROP COLUMN sse_customer_key;
+ALTER TABLE buckets DROP COLUMN sse_key_id;
+ALTER TABLE buckets DROP COLUMN sse_mode;
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/models"
+
+	driver "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// MySQLDSN builds the driver DSN for a MySQL or MariaDB server. Timestamps are parsed
+// into time.Time and evaluated in a UTC session so that they compare like the other dialects.
+func MySQLDSN(config *models.MySQLDatabaseConfig) string {
+	dsn := driver.NewConfig()
+	dsn.User = config.User
+	dsn.Passwd = config.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port)))
+	dsn.DBName = config.Name
+	dsn.ParseTime = true
+	dsn.Loc = time.UTC
+	dsn.Params = map[string]string{
+		"charset":   "utf8mb4",
+		"time_zone": "'+00:00'",
+	}
+	if config.TLS != "" {
+		dsn.TLSConfig = config.TLS
+	}
+	return dsn.FormatDSN()
+}
+
+func InitMySQL(config *models.MySQLDatabaseConfig) *gorm.DB {
+	db, err := gorm.Open(mysql.Open(MySQLDSN(config)), &gorm.Config{})
+	if err != nil {
+		zap.L().Fatal("Failed to connect to MySQL", zap.Error(err))
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		zap.L().Fatal("Failed to retrieve raw SQL database", zap.Error(err))
+	}
+
+	sqlDB.SetMaxOpenConns(configuration.MySQLMaxOpenConns)
+	sqlDB.SetMaxIdleConns(configuration.MySQLMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(configuration.MySQLConnMaxLifetime) * time.Minute)
+
+	RunMigrations(sqlDB, DialectMySQL)
+	RegisterCallbacks(db)
+
+	return db
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	dsn := MySQLDSN(&models.MySQLDatabaseConfig{
+		Host:     "db.internal",
+		Port:     3307,
+		User:     "safebucket",
+		Password: "p@ss:word/",
+		Name:     "safebucket",
+		TLS:      "skip-verify",
+	})
+
+	parsed, err := driver.ParseDSN(dsn)
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal:3307", parsed.Addr)
+	assert.Equal(t, "safebucket", parsed.User)
+	assert.Equal(t, "p@ss:word/", parsed.Passwd)
+	assert.Equal(t, "safebucket", parsed.DBName)
+	assert.True(t, parsed.ParseTime)
+	assert.Equal(t, "UTC", parsed.Loc.String())
+	assert.Equal(t, "skip-verify", parsed.TLSConfig)
+	assert.Equal(t, "'+00:00'", parsed.Params["time_zone"])
+}
+
+func TestFormatHourStr(t *testing.T) {
+	mysqlDB, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:pass@tcp(localhost:3306)/db",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"DATE_FORMAT(files.created_at, '%Y-%m-%dT%H:00:00Z')",
+		FormatHourStr(mysqlDB, "files.created_at"))
+
+	sqliteDB := setupCallbackTestDB(t)
+	assert.Equal(t,
+		"strftime('%Y-%m-%dT%H:00:00Z', files.created_at)",
+		FormatHourStr(sqliteDB, "files.created_at"))
+}
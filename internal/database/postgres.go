@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -10,12 +11,17 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
-func InitPostgres(config *models.PostgresDatabaseConfig) *gorm.DB {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
-		config.Host, config.User, config.Password, config.Name, config.Port, config.SSLMode,
+func postgresDSN(config *models.PostgresDatabaseConfig, host string, port int32) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		host, config.User, config.Password, config.Name, port, config.SSLMode,
 	)
+}
+
+func InitPostgres(config *models.PostgresDatabaseConfig) *gorm.DB {
+	dsn := postgresDSN(config, config.Host, config.Port)
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		zap.L().Fatal("Failed to connect to PostgreSQL", zap.Error(err))
@@ -32,5 +38,65 @@ func InitPostgres(config *models.PostgresDatabaseConfig) *gorm.DB {
 
 	RunMigrations(sqlDB, DialectPostgres)
 
+	if len(config.Replicas) > 0 {
+		registerPostgresReplicas(db, config)
+	}
+
 	return db
 }
+
+// registerPostgresReplicas opens the configured read replicas and registers them under
+// ReplicaResolver. The primary is appended as the last replica so that the policy is
+// always consulted and can fall back to it when every replica is unhealthy or lagging.
+func registerPostgresReplicas(db *gorm.DB, config *models.PostgresDatabaseConfig) {
+	var replicas []gorm.ConnPool
+	var dialectors []gorm.Dialector
+
+	for _, replica := range config.Replicas {
+		port := replica.Port
+		if port == 0 {
+			port = config.Port
+		}
+
+		replicaDB, err := gorm.Open(postgres.Open(postgresDSN(config, replica.Host, port)), &gorm.Config{})
+		if err != nil {
+			zap.L().Fatal("Failed to connect to PostgreSQL read replica",
+				zap.String("host", replica.Host), zap.Error(err))
+		}
+
+		sqlDB, err := replicaDB.DB()
+		if err != nil {
+			zap.L().Fatal("Failed to retrieve raw SQL database", zap.Error(err))
+		}
+
+		sqlDB.SetMaxOpenConns(configuration.PostgresMaxOpenConns)
+		sqlDB.SetMaxIdleConns(configuration.PostgresMaxIdleConns)
+		sqlDB.SetConnMaxLifetime(time.Duration(configuration.PostgresConnMaxLifetime) * time.Minute)
+
+		replicas = append(replicas, sqlDB)
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: sqlDB}))
+	}
+
+	primary, err := db.DB()
+	if err != nil {
+		zap.L().Fatal("Failed to retrieve raw SQL database", zap.Error(err))
+	}
+	dialectors = append(dialectors, postgres.New(postgres.Config{Conn: primary}))
+
+	policy := NewReplicaPolicy(primary, time.Duration(config.ReplicaMaxLagSeconds)*time.Second)
+
+	if err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   policy,
+	}, ReplicaResolver)); err != nil {
+		zap.L().Fatal("Failed to register PostgreSQL read replicas", zap.Error(err))
+	}
+
+	interval := time.Duration(config.ReplicaHealthCheckInterval) * time.Second
+	if interval <= 0 {
+		interval = configuration.PostgresReplicaHealthCheckInterval * time.Second
+	}
+	policy.StartHealthCheck(context.Background(), replicas, interval)
+
+	zap.L().Info("Registered PostgreSQL read replicas", zap.Int("count", len(config.Replicas)))
+}
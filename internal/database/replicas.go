@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaResolver is the dbresolver configuration name used by read-only queries. Queries
+// that do not opt in through ReadReplica always run on the primary.
+const (
+	ReplicaResolver     = "replicas"
+	replicaProbeTimeout = 2 * time.Second
+)
+
+// replicaLagQuery returns the replication lag in seconds. A replica that has replayed
+// everything it received reports no lag, even if the primary has been idle for a while.
+const replicaLagQuery = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() THEN 0
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// ReadReplica routes the queries of the returned session to a healthy read replica when
+// replicas are configured. Writes and SELECT ... FOR UPDATE still go to the primary.
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(ReplicaResolver)).Session(&gorm.Session{})
+}
+
+// ReplicaPolicy is a dbresolver policy that round-robins over the replicas that passed
+// their last health check and falls back to the primary when none did.
+type ReplicaPolicy struct {
+	primary gorm.ConnPool
+	maxLag  time.Duration
+	next    atomic.Uint64
+
+	mu      sync.RWMutex
+	healthy map[gorm.ConnPool]bool
+}
+
+func NewReplicaPolicy(primary gorm.ConnPool, maxLag time.Duration) *ReplicaPolicy {
+	return &ReplicaPolicy{
+		primary: primary,
+		maxLag:  maxLag,
+		healthy: map[gorm.ConnPool]bool{},
+	}
+}
+
+func (p *ReplicaPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	p.mu.RLock()
+	candidates := make([]gorm.ConnPool, 0, len(connPools))
+	for _, pool := range connPools {
+		if pool != p.primary && p.healthy[pool] {
+			candidates = append(candidates, pool)
+		}
+	}
+	p.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return p.primary
+	}
+
+	return candidates[p.next.Add(1)%uint64(len(candidates))]
+}
+
+// Check probes every replica and records whether it is reachable and within the
+// configured replication lag.
+func (p *ReplicaPolicy) Check(ctx context.Context, replicas []gorm.ConnPool) {
+	for _, pool := range replicas {
+		if pool == p.primary {
+			continue
+		}
+
+		healthy := p.probe(ctx, pool)
+
+		p.mu.Lock()
+		previous, known := p.healthy[pool]
+		p.healthy[pool] = healthy
+		p.mu.Unlock()
+
+		if known && previous != healthy {
+			if healthy {
+				zap.L().Info("Read replica is healthy again, resuming reads")
+			} else {
+				zap.L().Warn("Read replica is unhealthy, falling back to primary")
+			}
+		}
+	}
+}
+
+func (p *ReplicaPolicy) probe(ctx context.Context, pool gorm.ConnPool) bool {
+	ctx, cancel := context.WithTimeout(ctx, replicaProbeTimeout)
+	defer cancel()
+
+	var lag float64
+	if err := pool.QueryRowContext(ctx, replicaLagQuery).Scan(&lag); err != nil {
+		zap.L().Debug("Read replica health check failed", zap.Error(err))
+		return false
+	}
+
+	if time.Duration(lag*float64(time.Second)) > p.maxLag {
+		zap.L().Debug("Read replica is lagging", zap.Float64("lag_seconds", lag))
+		return false
+	}
+
+	return true
+}
+
+// StartHealthCheck runs Check on the given interval until the context is cancelled.
+func (p *ReplicaPolicy) StartHealthCheck(ctx context.Context, replicas []gorm.ConnPool, interval time.Duration) {
+	p.Check(ctx, replicas)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Check(ctx, replicas)
+			}
+		}
+	}()
+}
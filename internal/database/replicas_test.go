@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func openTestPool(t *testing.T) gorm.ConnPool {
+	t.Helper()
+
+	sqlDB, err := setupCallbackTestDB(t).DB()
+	require.NoError(t, err)
+	return sqlDB
+}
+
+func TestReplicaPolicy_FallsBackToPrimary(t *testing.T) {
+	primary, replica := openTestPool(t), openTestPool(t)
+	policy := NewReplicaPolicy(primary, time.Second)
+
+	assert.Equal(t, primary, policy.Resolve([]gorm.ConnPool{replica, primary}),
+		"an unchecked replica must not receive reads")
+
+	policy.Check(context.Background(), []gorm.ConnPool{replica, primary})
+	assert.Equal(t, primary, policy.Resolve([]gorm.ConnPool{replica, primary}),
+		"a replica failing its health check must not receive reads")
+}
+
+func TestReplicaPolicy_RoundRobinsHealthyReplicas(t *testing.T) {
+	primary, first, second, unhealthy := openTestPool(t), openTestPool(t), openTestPool(t), openTestPool(t)
+	policy := NewReplicaPolicy(primary, time.Second)
+	policy.healthy[first] = true
+	policy.healthy[second] = true
+	policy.healthy[unhealthy] = false
+
+	pools := []gorm.ConnPool{first, unhealthy, second, primary}
+	seen := map[gorm.ConnPool]int{}
+	for range 10 {
+		seen[policy.Resolve(pools)]++
+	}
+
+	assert.Equal(t, 5, seen[first])
+	assert.Equal(t, 5, seen[second])
+	assert.Zero(t, seen[unhealthy])
+	assert.Zero(t, seen[primary])
+}
+
+func TestReadReplica_SessionIsReusable(t *testing.T) {
+	db := setupSQLiteDB(t)
+
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		require.NoError(t, db.Create(&models.User{
+			Email:        email,
+			ProviderType: models.LocalProviderType,
+			ProviderKey:  string(models.LocalProviderType),
+			Role:         models.RoleUser,
+		}).Error)
+	}
+
+	replica := ReadReplica(db)
+
+	var first, second models.User
+	require.NoError(t, replica.Where("email = ?", "first@example.com").First(&first).Error)
+	require.NoError(t, replica.Where("email = ?", "second@example.com").First(&second).Error,
+		"conditions from a previous query must not leak into the next one")
+	assert.Equal(t, "second@example.com", second.Email)
+}
@@ -26,14 +26,19 @@ var postgresMigrations embed.FS
 //go:embed migrations/sqlite/*.sql
 var sqliteMigrations embed.FS
 
+//go:embed migrations/mysql/*.sql
+var mysqlMigrations embed.FS
+
 const (
 	DialectPostgres = "postgres"
 	DialectSQLite   = "sqlite"
+	DialectMySQL    = "mysql"
 )
 
 var migrationSources = map[string]embed.FS{
 	DialectPostgres: postgresMigrations,
 	DialectSQLite:   sqliteMigrations,
+	DialectMySQL:    mysqlMigrations,
 }
 
 func RunMigrations(db *sql.DB, dialect string) {
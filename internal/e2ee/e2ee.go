@@ -0,0 +1,109 @@
+// Package e2ee keeps track of the data keys of client-side encrypted buckets. Clients generate
+// the data key of a bucket, encrypt files with it and wrap it with the public key of each member;
+// the server only stores the wrapped copies and works out who is still waiting for one.
+package e2ee
+
+import (
+	"slices"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RevokeMember drops the wrapped keys of a user leaving a bucket. When the bucket is encrypted its
+// key version moves forward, so files uploaded afterwards use a data key the user never received
+// and the remaining members show up as pending until one of them wraps the new key.
+func RevokeMember(tx *gorm.DB, bucketID uuid.UUID, userID uuid.UUID) error {
+	err := tx.Where("bucket_id = ? AND user_id = ?", bucketID, userID).Delete(&models.BucketKey{}).Error
+	if err != nil {
+		return err
+	}
+	return rotate(tx.Where("id = ?", bucketID))
+}
+
+// RevokeUser applies RevokeMember to every bucket of a user being deleted. It must run before the
+// memberships of the user are removed.
+func RevokeUser(tx *gorm.DB, userID uuid.UUID) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.BucketKey{}).Error; err != nil {
+		return err
+	}
+	return rotate(tx.Where(
+		"id IN (?)", tx.Model(&models.Membership{}).Select("bucket_id").Where("user_id = ?", userID),
+	))
+}
+
+func rotate(query *gorm.DB) error {
+	return query.Model(&models.Bucket{}).
+		Where("encrypted = ?", true).
+		UpdateColumn("key_version", gorm.Expr("key_version + 1")).Error
+}
+
+// Holders returns the users holding the data key of a bucket at the given version.
+func Holders(db *gorm.DB, bucketID uuid.UUID, version int) (map[uuid.UUID]bool, error) {
+	var userIDs []uuid.UUID
+	err := db.Model(&models.BucketKey{}).
+		Where("bucket_id = ? AND key_version = ?", bucketID, version).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	holders := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		holders[userID] = true
+	}
+	return holders, nil
+}
+
+// Pending lists, for every version of the data key of a bucket, the members with a registered
+// public key who do not hold it yet. Members need the older versions as well to read the files
+// uploaded before they joined. Members without a public key are left out until they register one.
+func Pending(db *gorm.DB, bucket models.Bucket) ([]models.PendingBucketKey, error) {
+	var held []models.BucketKey
+	if err := db.Select("user_id", "key_version").
+		Where("bucket_id = ?", bucket.ID).
+		Find(&held).Error; err != nil {
+		return nil, err
+	}
+
+	versions := []int{bucket.KeyVersion}
+	holds := map[uuid.UUID]map[int]bool{}
+	for _, key := range held {
+		if !slices.Contains(versions, key.KeyVersion) {
+			versions = append(versions, key.KeyVersion)
+		}
+		if holds[key.UserID] == nil {
+			holds[key.UserID] = map[int]bool{}
+		}
+		holds[key.UserID][key.KeyVersion] = true
+	}
+	slices.Sort(versions)
+
+	var members []models.PendingBucketKey
+	if err := db.Table("memberships").
+		Select(
+			"memberships.user_id, users.email, user_keys.id AS user_key_id, "+
+				"user_keys.algorithm, user_keys.public_key",
+		).
+		Joins("JOIN users ON users.id = memberships.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN user_keys ON user_keys.user_id = memberships.user_id").
+		Where("memberships.bucket_id = ? AND memberships.deleted_at IS NULL", bucket.ID).
+		Order("users.email").
+		Scan(&members).Error; err != nil {
+		return nil, err
+	}
+
+	var pending []models.PendingBucketKey
+	for _, version := range versions {
+		for _, member := range members {
+			if holds[member.UserID][version] {
+				continue
+			}
+			member.KeyVersion = version
+			pending = append(pending, member)
+		}
+	}
+	return pending, nil
+}
@@ -25,6 +25,10 @@ const (
 	CodeOIDCStateMismatch   = "OIDC_STATE_MISMATCH"
 	CodeOIDCNonceNotFound   = "OIDC_NONCE_NOT_FOUND"
 	CodeOIDCNonceMismatch   = "OIDC_NONCE_MISMATCH"
+	CodeSAMLResponseMissing = "SAML_RESPONSE_MISSING"
+	CodeSAMLResponseInvalid = "SAML_RESPONSE_INVALID"
+	CodeSAMLRequestNotFound = "SAML_REQUEST_NOT_FOUND"
+	CodeSAMLEmailMissing    = "SAML_EMAIL_MISSING"
 )
 
 const (
@@ -34,11 +38,24 @@ const (
 	CodeWrongCode         = "WRONG_CODE"
 )
 
+const CodeAccountLocked = "ACCOUNT_LOCKED"
+
 const (
 	CodeUserNotFound      = "USER_NOT_FOUND"
 	CodeUserAlreadyExists = "USER_ALREADY_EXISTS"
 )
 
+const (
+	CodePasswordTooShort         = "PASSWORD_TOO_SHORT"
+	CodePasswordMissingUppercase = "PASSWORD_MISSING_UPPERCASE"
+	CodePasswordMissingLowercase = "PASSWORD_MISSING_LOWERCASE"
+	CodePasswordMissingDigit     = "PASSWORD_MISSING_DIGIT"
+	CodePasswordMissingSymbol    = "PASSWORD_MISSING_SYMBOL"
+	CodePasswordContainsEmail    = "PASSWORD_CONTAINS_EMAIL"
+	CodePasswordReused           = "PASSWORD_REUSED"
+	CodePasswordBreached         = "PASSWORD_BREACHED"
+)
+
 const (
 	CodeAuthProviderUnavailable  = "AUTH_PROVIDER_UNAVAILABLE"
 	CodePasswordChangeNotAllowed = "PASSWORD_CHANGE_NOT_ALLOWED"
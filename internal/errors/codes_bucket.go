@@ -8,6 +8,12 @@ const (
 	CodeSharingDisabledForProvider = "SHARING_DISABLED_FOR_PROVIDER"
 )
 
+const (
+	CodeChannelNotFound          = "CHANNEL_NOT_FOUND"
+	CodeChannelDeliveryFailed    = "CHANNEL_DELIVERY_FAILED"
+	CodeChannelWebhookNotAllowed = "CHANNEL_WEBHOOK_NOT_ALLOWED"
+)
+
 const (
 	CodeFileNotFound                = "FILE_NOT_FOUND"
 	CodeFileAlreadyExists           = "FILE_ALREADY_EXISTS"
@@ -19,6 +25,7 @@ const (
 	CodeFileExpired                 = "FILE_EXPIRED"
 	CodeCannotDownloadTrashed       = "CANNOT_DOWNLOAD_TRASHED_FILE"
 	CodeInvalidFileStatusTransition = "INVALID_FILE_STATUS_TRANSITION"
+	CodeFileNotPending              = "FILE_NOT_PENDING"
 	CodeInvalidStatus               = "INVALID_STATUS"
 	CodeMaxUploadsReached           = "MAX_UPLOADS_REACHED"
 	CodeMultipartSizeMismatch       = "MULTIPART_SIZE_MISMATCH"
@@ -0,0 +1,19 @@
+package apierrors
+
+const (
+	CodeUserKeyNotFound           = "USER_KEY_NOT_FOUND"
+	CodeUserKeyOutdated           = "USER_KEY_OUTDATED"
+	CodeBucketKeyVersionInvalid   = "BUCKET_KEY_VERSION_INVALID"
+	CodeBucketKeyNotHeld          = "BUCKET_KEY_NOT_HELD"
+	CodeBucketKeyIncomplete       = "BUCKET_KEY_INCOMPLETE"
+	CodeBucketKeyExists           = "BUCKET_KEY_EXISTS"
+	CodeBucketKeyNotMember        = "BUCKET_KEY_NOT_MEMBER"
+	CodeBucketNotEncrypted        = "BUCKET_NOT_ENCRYPTED"
+	CodeFileEncryptionRequired    = "FILE_ENCRYPTION_REQUIRED"
+	CodeFileEncryptionNotAllowed  = "FILE_ENCRYPTION_NOT_ALLOWED"
+	CodeFileEncryptionKeyOutdated = "FILE_ENCRYPTION_KEY_OUTDATED"
+	CodeShareSendEncryptedBucket  = "SHARE_SEND_ENCRYPTED_BUCKET"
+	CodeBucketSSEUnsupported      = "BUCKET_SSE_UNSUPPORTED"
+	CodeBucketSSEKeyInvalid       = "BUCKET_SSE_KEY_INVALID"
+	CodeShareCustomerKeyBucket    = "SHARE_CUSTOMER_KEY_BUCKET"
+)
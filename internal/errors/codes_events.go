@@ -0,0 +1,10 @@
+package apierrors
+
+const (
+	CodeDeadLetterNotFound     = "DEAD_LETTER_NOT_FOUND"
+	CodeDeadLetterReplayFailed = "DEAD_LETTER_REPLAY_FAILED"
+	CodeEventsUnavailable      = "EVENTS_UNAVAILABLE"
+	CodeNotificationNotFound   = "NOTIFICATION_NOT_FOUND"
+	CodeNotificationNotFailed  = "NOTIFICATION_NOT_FAILED"
+	CodeNotificationExpired    = "NOTIFICATION_EXPIRED"
+)
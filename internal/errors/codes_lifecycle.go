@@ -0,0 +1,7 @@
+package apierrors
+
+const (
+	CodeLifecycleRuleInvalid   = "LIFECYCLE_RULE_INVALID"
+	CodeLifecycleRuleDuplicate = "LIFECYCLE_RULE_DUPLICATE"
+	CodeFileExpiryExceedsLimit = "FILE_EXPIRY_EXCEEDS_LIMIT"
+)
@@ -0,0 +1,11 @@
+package apierrors
+
+const (
+	CodeRetentionPolicyNotFound  = "RETENTION_POLICY_NOT_FOUND"
+	CodeRetentionPolicyLocked    = "RETENTION_POLICY_LOCKED"
+	CodeRetentionPolicyInUse     = "RETENTION_POLICY_IN_USE"
+	CodeRetentionPeriodActive    = "RETENTION_PERIOD_ACTIVE"
+	CodeLegalHoldNotFound        = "LEGAL_HOLD_NOT_FOUND"
+	CodeLegalHoldActive          = "LEGAL_HOLD_ACTIVE"
+	CodeLegalHoldAlreadyReleased = "LEGAL_HOLD_ALREADY_RELEASED"
+)
@@ -1,21 +1,31 @@
 package apierrors
 
 const (
-	CodeShareNotFound             = "SHARE_NOT_FOUND"
-	CodeShareExpired              = "SHARE_EXPIRED"
-	CodeShareMaxViewsReached      = "SHARE_MAX_VIEWS_REACHED"
-	CodeShareTokenRequired        = "SHARE_TOKEN_REQUIRED"
-	CodeShareTokenInvalid         = "SHARE_TOKEN_INVALID"
-	CodeSharePasswordInvalid      = "SHARE_PASSWORD_INVALID"
-	CodeShareNotPasswordProtected = "SHARE_NOT_PASSWORD_PROTECTED"
-	CodeShareFileNotInShare       = "SHARE_FILE_NOT_IN_SHARE"
-	CodeShareUploadNotAllowed     = "SHARE_UPLOAD_NOT_ALLOWED"
-	CodeShareMaxUploadsReached    = "SHARE_MAX_UPLOADS_REACHED"
-	CodeShareUploadSizeExceeded   = "SHARE_UPLOAD_SIZE_EXCEEDED"
-	CodeShareNotSingleFile        = "SHARE_NOT_SINGLE_FILE"
-	CodeRedirectDownloadDisabled  = "REDIRECT_DOWNLOAD_DISABLED"
+	CodeShareNotFound                = "SHARE_NOT_FOUND"
+	CodeShareExpired                 = "SHARE_EXPIRED"
+	CodeShareMaxViewsReached         = "SHARE_MAX_VIEWS_REACHED"
+	CodeShareTokenRequired           = "SHARE_TOKEN_REQUIRED"
+	CodeShareTokenInvalid            = "SHARE_TOKEN_INVALID"
+	CodeSharePasswordInvalid         = "SHARE_PASSWORD_INVALID"
+	CodeShareNotPasswordProtected    = "SHARE_NOT_PASSWORD_PROTECTED"
+	CodeShareFileNotInShare          = "SHARE_FILE_NOT_IN_SHARE"
+	CodeShareUploadNotAllowed        = "SHARE_UPLOAD_NOT_ALLOWED"
+	CodeShareMaxUploadsReached       = "SHARE_MAX_UPLOADS_REACHED"
+	CodeShareUploadSizeExceeded      = "SHARE_UPLOAD_SIZE_EXCEEDED"
+	CodeShareNotSingleFile           = "SHARE_NOT_SINGLE_FILE"
+	CodeShareFilesNotEditable        = "SHARE_FILES_NOT_EDITABLE"
+	CodeShareFilesEmpty              = "SHARE_FILES_EMPTY"
+	CodeShareRecipientRequired       = "SHARE_RECIPIENT_REQUIRED"
+	CodeShareNotRecipientRestricted  = "SHARE_NOT_RECIPIENT_RESTRICTED"
+	CodeShareRecipientsWithPassword  = "SHARE_RECIPIENTS_WITH_PASSWORD"
+	CodeShareMaxDownloadsReached     = "SHARE_MAX_DOWNLOADS_REACHED"
+	CodeShareFileMaxDownloadsReached = "SHARE_FILE_MAX_DOWNLOADS_REACHED"
+	CodeShareAccessDenied            = "SHARE_ACCESS_DENIED"
+	CodeShareEmailDomainNotAllowed   = "SHARE_EMAIL_DOMAIN_NOT_ALLOWED"
+	CodeRedirectDownloadDisabled     = "REDIRECT_DOWNLOAD_DISABLED"
 )
 
 const (
 	CodeInviteNotFound = "INVITE_NOT_FOUND"
+	CodeInviteExpired  = "INVITE_EXPIRED"
 )
@@ -55,7 +55,7 @@ func (p *AWSEventParser) ParseBucketUploadEvents(msg *message.Message) []BucketU
 
 	var uploadEvents []BucketUploadEvent
 	for _, record := range event.Records {
-		metadata, err := p.Storage.StatObject(record.S3.Object.Key)
+		metadata, err := p.Storage.StatObject(record.S3.Object.Key, storage.ObjectEncryption{})
 		if err != nil {
 			zap.L().Error("failed to stat object",
 				zap.String("object_key", record.S3.Object.Key),
@@ -68,7 +68,7 @@ func (p *AzureEventParser) ParseBucketUploadEvents(msg *message.Message) []Bucke
 			continue
 		}
 
-		metadata, statErr := p.Storage.StatObject(objectKey)
+		metadata, statErr := p.Storage.StatObject(objectKey, storage.ObjectEncryption{})
 		if statErr != nil {
 			zap.L().Error("failed to stat object", zap.String("object_key", objectKey), zap.Error(statErr))
 			continue
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	AccountLockedName        = "AccountLocked"
+	AccountLockedPayloadName = "AccountLockedPayload"
+)
+
+type AccountLockedPayload struct {
+	Type        string
+	To          string
+	IP          string
+	LockedUntil string
+	WebURL      string
+}
+
+// AccountLocked tells a user that repeated failed sign-ins temporarily locked their account.
+type AccountLocked struct {
+	Publisher messaging.IPublisher
+	Payload   AccountLockedPayload
+}
+
+func NewAccountLocked(
+	publisher messaging.IPublisher,
+	to string,
+	ip string,
+	lockedUntil string,
+	webURL string,
+) AccountLocked {
+	return AccountLocked{
+		Publisher: publisher,
+		Payload: AccountLockedPayload{
+			Type:        AccountLockedName,
+			To:          to,
+			IP:          ip,
+			LockedUntil: lockedUntil,
+			WebURL:      webURL,
+		},
+	}
+}
+
+func (e *AccountLocked) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger event", zap.Error(err))
+	}
+}
+
+func (e *AccountLocked) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := "Your Safebucket account has been locked"
+	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "account_locked", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.String("to", e.Payload.To), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -2,7 +2,7 @@ package events
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"path"
 	"time"
 
@@ -69,15 +69,15 @@ func (e *BucketPurge) callback(params *EventParams) error {
 	)
 
 	if !e.deleteRootFiles(params) {
-		return errors.New("remaining files to delete")
+		return fmt.Errorf("%w: remaining files to delete", ErrBatchInProgress)
 	}
 
 	if !e.deleteRootFolders(params) {
-		return errors.New("remaining folders to delete")
+		return fmt.Errorf("%w: remaining folders to delete", ErrBatchInProgress)
 	}
 
 	if !e.cleanupOrphanedStorage(params) {
-		return errors.New("remaining storage objects")
+		return fmt.Errorf("%w: remaining storage objects", ErrBatchInProgress)
 	}
 
 	zap.L().Info("Bucket purge complete",
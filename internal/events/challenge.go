@@ -3,6 +3,7 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/safebucket/safebucket/internal/messaging"
 
@@ -90,6 +91,12 @@ type PasswordResetChallengePayload struct {
 	To           string
 	WebURL       string
 	ChallengeURL string
+	ExpiresAt    time.Time
+}
+
+// NotificationExpiresAt stops the queued email from being delivered after the challenge expired.
+func (p PasswordResetChallengePayload) NotificationExpiresAt() time.Time {
+	return p.ExpiresAt
 }
 
 type PasswordResetChallengeEvent struct {
@@ -102,6 +109,7 @@ func NewPasswordResetChallenge(
 	secret string,
 	to string,
 	challengeID string,
+	expiresAt time.Time,
 	webURL string,
 ) PasswordResetChallengeEvent {
 	challengeURL := fmt.Sprintf("%s/auth/reset-password/%s", webURL, challengeID)
@@ -113,6 +121,7 @@ func NewPasswordResetChallenge(
 			To:           to,
 			WebURL:       webURL,
 			ChallengeURL: challengeURL,
+			ExpiresAt:    expiresAt,
 		},
 	}
 }
@@ -135,9 +144,9 @@ func (e *PasswordResetChallengeEvent) Trigger() {
 func (e *PasswordResetChallengeEvent) callback(params *EventParams) error {
 	e.Payload.WebURL = params.WebURL
 	subject := "Password Reset Request"
-	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "password_reset", e.Payload)
+	err := params.QueuedNotifier.NotifyFromTemplate(e.Payload.To, subject, "password_reset", e.Payload)
 	if err != nil {
-		zap.L().Error("failed to notify", zap.Any("event", e), zap.Error(err))
+		zap.L().Error("failed to queue notification", zap.String("to", e.Payload.To), zap.Error(err))
 		return err
 	}
 	return nil
@@ -208,6 +217,73 @@ func (e *PasswordResetSuccessEvent) callback(params *EventParams) error {
 	return nil
 }
 
+const (
+	ShareAccessChallengeName        = "ShareAccessChallenge"
+	ShareAccessChallengePayloadName = "ShareAccessChallengePayload"
+)
+
+type ShareAccessChallengePayload struct {
+	Type      string
+	Secret    string
+	To        string
+	ShareName string
+	WebURL    string
+	ShareURL  string
+}
+
+type ShareAccessChallengeEvent struct {
+	Publisher messaging.IPublisher
+	Payload   ShareAccessChallengePayload
+}
+
+func NewShareAccessChallenge(
+	publisher messaging.IPublisher,
+	secret string,
+	to string,
+	shareID string,
+	shareName string,
+	webURL string,
+) ShareAccessChallengeEvent {
+	shareURL := fmt.Sprintf("%s/shares/%s", webURL, shareID)
+	return ShareAccessChallengeEvent{
+		Publisher: publisher,
+		Payload: ShareAccessChallengePayload{
+			Type:      ShareAccessChallengeName,
+			Secret:    secret,
+			To:        to,
+			ShareName: shareName,
+			WebURL:    webURL,
+			ShareURL:  shareURL,
+		},
+	}
+}
+
+func (e *ShareAccessChallengeEvent) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger event", zap.Error(err))
+	}
+}
+
+func (e *ShareAccessChallengeEvent) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := "Your share access code"
+	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "share_access", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.Any("event", e), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 const (
 	MFAResetChallengeName        = "MFAResetChallenge"
 	MFAResetChallengePayloadName = "MFAResetChallengePayload"
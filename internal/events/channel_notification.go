@@ -0,0 +1,85 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// channelWantsNotification reports whether a chat channel subscribed to the activity type.
+// Chat channels only carry uploads, downloads and share creation.
+func channelWantsNotification(ch models.BucketChannel, activityType FileActivityType) bool {
+	switch activityType {
+	case FileActivityUpload:
+		return ch.NotifyUploads
+	case FileActivityDownload:
+		return ch.NotifyDownloads
+	case FileActivityShare:
+		return ch.NotifyShares
+	default:
+		return false
+	}
+}
+
+// bufferChannelNotifications batches the activity for every chat channel of the bucket that
+// subscribed to it, using the same short window as email notifications.
+func bufferChannelNotifications(
+	db *gorm.DB,
+	c cache.ICache,
+	payload FileActivityNotificationPayload,
+	webURL string,
+) error {
+	var channels []models.BucketChannel
+	if err := db.Where("bucket_id = ?", payload.BucketID).Find(&channels).Error; err != nil {
+		return fmt.Errorf("failed to get bucket channels: %w", err)
+	}
+
+	for _, ch := range channels {
+		if !channelWantsNotification(ch, payload.NotificationType) {
+			continue
+		}
+
+		meta := batchMeta{
+			ActorEmail:       payload.ActorEmail,
+			BucketID:         payload.BucketID,
+			BucketName:       payload.BucketName,
+			NotificationType: string(payload.NotificationType),
+			Source:           payload.Source,
+			WebURL:           webURL,
+			ChannelID:        &ch.ID,
+		}
+
+		groupKey := batchGroupKey(
+			"channel:"+ch.ID.String(), payload.BucketID, payload.ActorEmail, payload.NotificationType,
+		)
+		if _, err := addToBuffer(c, groupKey, payload.FileName, meta); err != nil {
+			return fmt.Errorf("failed to add to notification buffer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func notifyChannel(channels notifier.IChannelNotifier, db *gorm.DB, channelID uuid.UUID, meta batchMeta) error {
+	if channels == nil || db == nil {
+		return nil
+	}
+
+	var ch models.BucketChannel
+	if err := db.Where("id = ?", channelID).First(&ch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zap.L().Debug("skipping notification for deleted channel", zap.String("channel_id", channelID.String()))
+			return nil
+		}
+		return fmt.Errorf("failed to get bucket channel: %w", err)
+	}
+
+	return channels.NotifyChannel(ch.Type, ch.WebhookURL, "file_activity", meta)
+}
@@ -0,0 +1,204 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	AttemptsMetadataKey  = "attempts"
+	NotBeforeMetadataKey = "not_before"
+	defaultMaxAttempts   = 5
+)
+
+// Failed events are retried after an exponential backoff, so a short outage of the notifier or
+// the storage does not exhaust the attempts before it is over. The delay stays below the idle
+// time after which Redis Streams hands a pending entry to another consumer.
+const (
+	retryBaseDelay = 2 * time.Second
+	retryMaxDelay  = 10 * time.Second
+)
+
+// ErrBatchInProgress is returned by callbacks that processed a batch and need to be
+// redelivered to continue. It is not counted as a failed attempt.
+var ErrBatchInProgress = errors.New("batch in progress")
+
+// ErrDeliveryLimitReached is recorded on messages the subscriber gave up redelivering, typically
+// because processing them keeps crashing the consumer.
+var ErrDeliveryLimitReached = errors.New("delivery limit reached")
+
+// GetAttempts returns the number of failed attempts recorded on the message.
+func GetAttempts(msg *message.Message) int {
+	attempts, err := strconv.Atoi(msg.Metadata.Get(AttemptsMetadataKey))
+	if err != nil || attempts < 0 {
+		return 0
+	}
+	return attempts
+}
+
+// retryDelay returns how long to wait before the given attempt.
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
+
+// waitForRetry holds a retried message until its not-before time. It returns false if the
+// context is cancelled first.
+func waitForRetry(ctx context.Context, msg *message.Message) bool {
+	notBefore, err := strconv.ParseInt(msg.Metadata.Get(NotBeforeMetadataKey), 10, 64)
+	if err != nil {
+		return true
+	}
+
+	wait := time.Until(time.UnixMilli(notBefore))
+	if wait <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// NewRetryMessage copies a message with the given attempts count so it can be republished.
+// The copy is deliverable immediately.
+func NewRetryMessage(msg *message.Message, attempts int) *message.Message {
+	retry := message.NewMessage(msg.UUID, msg.Payload)
+	retry.Metadata = maps.Clone(msg.Metadata)
+	if retry.Metadata == nil {
+		retry.Metadata = make(message.Metadata)
+	}
+	retry.Metadata.Set(AttemptsMetadataKey, strconv.Itoa(attempts))
+	delete(retry.Metadata, messaging.DeliveryLimitReachedMetadataKey)
+	delete(retry.Metadata, NotBeforeMetadataKey)
+	return retry
+}
+
+// secretPayloadFields lists the payload fields of each event type that must not be stored in
+// the dead-letter table, where admins can read them.
+var secretPayloadFields = map[string][]string{
+	SharePasswordSentName: {"Password"},
+}
+
+// redactPayload blanks the secret fields of a payload before it is dead-lettered. Payloads
+// that cannot be decoded are dropped entirely rather than stored with their secrets.
+func redactPayload(eventType string, payload []byte) string {
+	fields, ok := secretPayloadFields[eventType]
+	if !ok {
+		return string(payload)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return "{}"
+	}
+	for _, field := range fields {
+		if _, present := decoded[field]; present {
+			decoded[field] = ""
+		}
+	}
+
+	redacted, err := json.Marshal(decoded)
+	if err != nil {
+		return "{}"
+	}
+	return string(redacted)
+}
+
+func maxAttempts(params *EventParams) int {
+	if params.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return params.MaxAttempts
+}
+
+// handleFailure republishes the message with an incremented attempts count and a backoff, or
+// moves it to the dead-letter table once the maximum number of attempts has been reached.
+func handleFailure(workerName string, params *EventParams, msg *message.Message, eventType string, cause error) {
+	attempts := GetAttempts(msg) + 1
+
+	if attempts < maxAttempts(params) && params.Publisher != nil {
+		delay := retryDelay(attempts)
+		zap.L().Warn("event failed, scheduling retry",
+			zap.Error(cause),
+			zap.String("eventType", eventType),
+			zap.String("worker", workerName),
+			zap.Int("attempts", attempts),
+			zap.Duration("delay", delay),
+		)
+
+		retry := NewRetryMessage(msg, attempts)
+		retry.Metadata.Set(NotBeforeMetadataKey, strconv.FormatInt(time.Now().Add(delay).UnixMilli(), 10))
+		if err := params.Publisher.Publish(retry); err != nil {
+			zap.L().Error("failed to republish event", zap.Error(err), zap.String("eventType", eventType))
+			msg.Nack()
+			return
+		}
+
+		msg.Ack()
+		return
+	}
+
+	deadLetter(workerName, params, msg, eventType, attempts, cause)
+}
+
+// deadLetter stores the message in the dead-letter table and acks it. The message is
+// nacked if it cannot be stored so that it is not lost.
+func deadLetter(
+	workerName string,
+	params *EventParams,
+	msg *message.Message,
+	eventType string,
+	attempts int,
+	cause error,
+) {
+	entry := models.DeadLetterEvent{
+		MessageID: msg.UUID,
+		EventType: eventType,
+		Worker:    workerName,
+		Payload:   redactPayload(eventType, msg.Payload),
+		Metadata:  maps.Clone(map[string]string(msg.Metadata)),
+		Attempts:  attempts,
+		LastError: cause.Error(),
+	}
+	if entry.Metadata == nil {
+		entry.Metadata = map[string]string{}
+	}
+
+	if err := params.DB.Create(&entry).Error; err != nil {
+		zap.L().Error("failed to store dead-letter event",
+			zap.Error(err),
+			zap.String("eventType", eventType),
+			zap.String("worker", workerName),
+		)
+		msg.Nack()
+		return
+	}
+
+	zap.L().Error("event moved to dead-letter queue",
+		zap.Error(cause),
+		zap.String("eventType", eventType),
+		zap.String("worker", workerName),
+		zap.Int("attempts", attempts),
+		zap.String("dead_letter_id", entry.ID.String()),
+	)
+	msg.Ack()
+}
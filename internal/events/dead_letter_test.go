@@ -0,0 +1,232 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/database"
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type capturePublisher struct {
+	messages []*message.Message
+	err      error
+}
+
+func (p *capturePublisher) Publish(messages ...*message.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *capturePublisher) Close() error { return nil }
+
+func setupDeadLetterTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	database.RunMigrations(sqlDB, database.DialectSQLite)
+	database.RegisterCallbacks(db)
+
+	return db
+}
+
+func newTestEventMessage(eventType string) *message.Message {
+	msg := message.NewMessage(watermill.NewUUID(), []byte(`{"Type":"`+eventType+`"}`))
+	msg.Metadata.Set("type", eventType)
+	return msg
+}
+
+func TestGetAttempts(t *testing.T) {
+	msg := newTestEventMessage(BucketPurgeName)
+	assert.Equal(t, 0, GetAttempts(msg))
+
+	msg.Metadata.Set(AttemptsMetadataKey, "3")
+	assert.Equal(t, 3, GetAttempts(msg))
+
+	msg.Metadata.Set(AttemptsMetadataKey, "garbage")
+	assert.Equal(t, 0, GetAttempts(msg))
+}
+
+func TestNewRetryMessage_KeepsPayloadAndMetadata(t *testing.T) {
+	msg := newTestEventMessage(FolderRestoreName)
+
+	retry := NewRetryMessage(msg, 2)
+
+	assert.Equal(t, msg.UUID, retry.UUID)
+	assert.Equal(t, string(msg.Payload), string(retry.Payload))
+	assert.Equal(t, FolderRestoreName, retry.Metadata.Get("type"))
+	assert.Equal(t, "2", retry.Metadata.Get(AttemptsMetadataKey))
+	assert.Empty(t, msg.Metadata.Get(AttemptsMetadataKey), "original metadata must not be mutated")
+}
+
+func TestHandleFailure_RepublishesBelowMaxAttempts(t *testing.T) {
+	db := setupDeadLetterTestDB(t)
+	publisher := &capturePublisher{}
+	params := &EventParams{DB: db, Publisher: publisher, MaxAttempts: 3}
+
+	msg := newTestEventMessage(BucketPurgeName)
+	handleFailure("object_deletion", params, msg, BucketPurgeName, errors.New("boom"))
+
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, "1", publisher.messages[0].Metadata.Get(AttemptsMetadataKey))
+	notBefore, err := strconv.ParseInt(publisher.messages[0].Metadata.Get(NotBeforeMetadataKey), 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(retryBaseDelay), time.UnixMilli(notBefore), time.Second)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Fatal("expected original message to be acked")
+	}
+
+	var count int64
+	db.Model(&models.DeadLetterEvent{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, retryBaseDelay, retryDelay(1))
+	assert.Equal(t, 2*retryBaseDelay, retryDelay(2))
+	assert.Equal(t, 4*retryBaseDelay, retryDelay(3))
+	assert.Equal(t, retryMaxDelay, retryDelay(20))
+}
+
+func TestWaitForRetry(t *testing.T) {
+	msg := newTestEventMessage(BucketPurgeName)
+	msg.Metadata.Set(NotBeforeMetadataKey, strconv.FormatInt(time.Now().Add(150*time.Millisecond).UnixMilli(), 10))
+
+	start := time.Now()
+	assert.True(t, waitForRetry(context.Background(), msg))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "a retry waits for its backoff")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	msg.Metadata.Set(NotBeforeMetadataKey, strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10))
+	assert.False(t, waitForRetry(ctx, msg), "shutdown interrupts the wait")
+
+	assert.Empty(t, NewRetryMessage(msg, 0).Metadata.Get(NotBeforeMetadataKey), "replays are delivered immediately")
+}
+
+func TestHandleFailure_DeadLettersAtMaxAttempts(t *testing.T) {
+	db := setupDeadLetterTestDB(t)
+	publisher := &capturePublisher{}
+	params := &EventParams{DB: db, Publisher: publisher, MaxAttempts: 3}
+
+	msg := newTestEventMessage(BucketPurgeName)
+	msg.Metadata.Set(AttemptsMetadataKey, "2")
+	handleFailure("object_deletion", params, msg, BucketPurgeName, errors.New("storage unavailable"))
+
+	assert.Empty(t, publisher.messages)
+
+	var entries []models.DeadLetterEvent
+	require.NoError(t, db.Find(&entries).Error)
+	require.Len(t, entries, 1)
+	assert.Equal(t, msg.UUID, entries[0].MessageID)
+	assert.Equal(t, BucketPurgeName, entries[0].EventType)
+	assert.Equal(t, "object_deletion", entries[0].Worker)
+	assert.Equal(t, 3, entries[0].Attempts)
+	assert.Equal(t, "storage unavailable", entries[0].LastError)
+	assert.Equal(t, string(msg.Payload), entries[0].Payload)
+	assert.Equal(t, BucketPurgeName, entries[0].Metadata["type"])
+}
+
+func TestHandleFailure_NacksWhenRepublishFails(t *testing.T) {
+	db := setupDeadLetterTestDB(t)
+	publisher := &capturePublisher{err: errors.New("broker down")}
+	params := &EventParams{DB: db, Publisher: publisher, MaxAttempts: 3}
+
+	msg := newTestEventMessage(FolderTrashName)
+	handleFailure("object_deletion", params, msg, FolderTrashName, errors.New("boom"))
+
+	select {
+	case <-msg.Nacked():
+	default:
+		t.Fatal("expected message to be nacked")
+	}
+}
+
+func TestHandleEvents_DeadLettersWhenDeliveryLimitReached(t *testing.T) {
+	db := setupDeadLetterTestDB(t)
+	params := &EventParams{DB: db, Publisher: &capturePublisher{}}
+
+	msg := newTestEventMessage(BucketPurgeName)
+	msg.Metadata.Set(messaging.DeliveryLimitReachedMetadataKey, "true")
+
+	messages := make(chan *message.Message, 1)
+	messages <- msg
+	close(messages)
+	HandleEvents(t.Context(), "object_deletion", params, messages)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Fatal("expected message to be acked once dead-lettered")
+	}
+
+	var entries []models.DeadLetterEvent
+	require.NoError(t, db.Find(&entries).Error)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ErrDeliveryLimitReached.Error(), entries[0].LastError)
+
+	retry := NewRetryMessage(msg, 0)
+	assert.Empty(t, retry.Metadata.Get(messaging.DeliveryLimitReachedMetadataKey))
+}
+
+type countingNotifier struct {
+	calls int
+}
+
+func (n *countingNotifier) NotifyFromTemplate(string, string, string, interface{}) error {
+	n.calls++
+	return nil
+}
+
+func TestDeadLetter_RedactsSharePasswords(t *testing.T) {
+	db := setupDeadLetterTestDB(t)
+
+	event := NewSharePasswordSent(nil, "bob@example.com", "alice@example.com", "Q3 report", "hunter2")
+	payload, err := json.Marshal(event.Payload)
+	require.NoError(t, err)
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", SharePasswordSentName)
+
+	deadLetter("notifications", &EventParams{DB: db}, msg, SharePasswordSentName, 5, errors.New("smtp down"))
+
+	var entry models.DeadLetterEvent
+	require.NoError(t, db.First(&entry).Error)
+	assert.NotContains(t, entry.Payload, "hunter2")
+	assert.Contains(t, entry.Payload, "bob@example.com")
+
+	queue := &countingNotifier{}
+	params := &EventParams{QueuedNotifier: queue}
+
+	replayed, err := getEventFromMessage(SharePasswordSentName, message.NewMessage(msg.UUID, []byte(entry.Payload)))
+	require.NoError(t, err)
+	require.NoError(t, replayed.callback(params))
+	assert.Zero(t, queue.calls, "a redacted password is never mailed")
+
+	original, err := getEventFromMessage(SharePasswordSentName, msg)
+	require.NoError(t, err)
+	require.NoError(t, original.callback(params))
+	assert.Equal(t, 1, queue.calls, "passwords are sent through the sealed notification queue")
+}
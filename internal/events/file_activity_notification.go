@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -18,6 +19,13 @@ type FileActivityType string
 const (
 	FileActivityUpload   FileActivityType = "upload"
 	FileActivityDownload FileActivityType = "download"
+	FileActivityDelete   FileActivityType = "delete"
+	FileActivityRestore  FileActivityType = "restore"
+	FileActivityShare    FileActivityType = "share"
+	// FileActivityMember reports a membership change; FileName carries the affected member's email.
+	FileActivityMember FileActivityType = "member"
+	// FileActivityPendingApproval asks reviewers to approve a file uploaded through a share.
+	FileActivityPendingApproval FileActivityType = "pending_approval"
 )
 
 type FileActivitySource string
@@ -96,17 +104,22 @@ func (e *FileActivityNotification) callback(params *EventParams) error {
 	}
 
 	for _, m := range memberships {
-		if m.UserID == e.Payload.ActorID {
+		// Approval requests are actionable, so they reach every reviewer, including the share
+		// creator, regardless of their upload notification preference.
+		if e.Payload.NotificationType == FileActivityPendingApproval {
+			if !rbac.HasGroup(m.Group, models.GroupContributor) {
+				continue
+			}
+		} else if m.UserID == e.Payload.ActorID {
 			continue
 		}
-		if e.Payload.NotificationType == FileActivityUpload && !m.UploadNotifications {
+		if !wantsNotification(m, e.Payload.NotificationType) {
 			continue
 		}
-		if e.Payload.NotificationType == FileActivityDownload && !m.DownloadNotifications {
+		if e.Payload.NotificationType == FileActivityMember && m.User.Email == e.Payload.FileName {
 			continue
 		}
 
-		groupKey := batchGroupKey(m.User.Email, e.Payload.BucketID, e.Payload.ActorEmail, e.Payload.NotificationType)
 		meta := batchMeta{
 			RecipientEmail:   m.User.Email,
 			ActorEmail:       e.Payload.ActorEmail,
@@ -117,6 +130,15 @@ func (e *FileActivityNotification) callback(params *EventParams) error {
 			WebURL:           params.WebURL,
 		}
 
+		// Approval requests stay on the short buffer so reviewers are not kept waiting on a digest.
+		if isDigestDelivery(m.NotificationDelivery) && e.Payload.NotificationType != FileActivityPendingApproval {
+			if err = addToDigest(params.Cache, m.NotificationDelivery, e.Payload.FileName, meta); err != nil {
+				return fmt.Errorf("failed to add to notification digest: %w", err)
+			}
+			continue
+		}
+
+		groupKey := batchGroupKey(m.User.Email, e.Payload.BucketID, e.Payload.ActorEmail, e.Payload.NotificationType)
 		count, batchErr := addToBuffer(params.Cache, groupKey, e.Payload.FileName, meta)
 		if batchErr != nil {
 			return fmt.Errorf("failed to add to notification buffer: %w", batchErr)
@@ -127,5 +149,27 @@ func (e *FileActivityNotification) callback(params *EventParams) error {
 			zap.Int64("count", count))
 	}
 
-	return nil
+	return bufferChannelNotifications(params.DB, params.Cache, e.Payload, params.WebURL)
+}
+
+// wantsNotification reports whether the member opted in to the given activity type.
+func wantsNotification(m models.Membership, activityType FileActivityType) bool {
+	switch activityType {
+	case FileActivityUpload:
+		return m.UploadNotifications
+	case FileActivityDownload:
+		return m.DownloadNotifications
+	case FileActivityDelete:
+		return m.DeleteNotifications
+	case FileActivityRestore:
+		return m.RestoreNotifications
+	case FileActivityShare:
+		return m.ShareNotifications
+	case FileActivityMember:
+		return m.MemberNotifications
+	case FileActivityPendingApproval:
+		return true
+	default:
+		return false
+	}
 }
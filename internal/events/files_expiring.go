@@ -0,0 +1,62 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	FilesExpiringName        = "FilesExpiring"
+	FilesExpiringPayloadName = "FilesExpiringPayload"
+)
+
+type ExpiringFile struct {
+	Name      string
+	ExpiresAt time.Time
+}
+
+type FilesExpiringPayload struct {
+	Type       string
+	To         string
+	BucketID   uuid.UUID
+	BucketName string
+	Files      []ExpiringFile
+	WebURL     string
+}
+
+// FilesExpiring warns a bucket owner that files of the bucket are about to be deleted by their
+// expiration date. It is only sent through the outbox, together with the warned flag of the files.
+type FilesExpiring struct {
+	Payload FilesExpiringPayload
+}
+
+func NewFilesExpiring(
+	to string,
+	bucketID uuid.UUID,
+	bucketName string,
+	files []ExpiringFile,
+) FilesExpiring {
+	return FilesExpiring{
+		Payload: FilesExpiringPayload{
+			Type:       FilesExpiringName,
+			To:         to,
+			BucketID:   bucketID,
+			BucketName: bucketName,
+			Files:      files,
+		},
+	}
+}
+
+func (e *FilesExpiring) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := fmt.Sprintf("Files in \"%s\" expire soon", e.Payload.BucketName)
+	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "files_expiring", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.String("to", e.Payload.To), zap.Error(err))
+		return err
+	}
+	return nil
+}
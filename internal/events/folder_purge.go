@@ -3,13 +3,16 @@ package events
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"path"
 
 	"github.com/safebucket/safebucket/internal/activity"
 	c "github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/messaging"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -87,6 +90,17 @@ func (e *FolderPurge) callback(params *EventParams) error {
 		return errors.New("folder not in trash")
 	}
 
+	if err := retention.CheckFolder(params.DB, folder, retention.ActionPurge); err != nil {
+		var apiErr *apierrors.APIError
+		if !errors.As(err, &apiErr) {
+			return err
+		}
+		zap.L().Info("Folder is protected by a legal hold or retention period, skipping purge",
+			zap.String("folder_id", folder.ID.String()),
+			zap.Error(err))
+		return nil
+	}
+
 	err := params.DB.Transaction(func(tx *gorm.DB) error {
 		var childFolders []models.Folder
 		if err := tx.Unscoped().Where(
@@ -174,7 +188,7 @@ func (e *FolderPurge) callback(params *EventParams) error {
 		zap.L().Info("More items to purge, requeuing event",
 			zap.Int64("remaining_folders", remainingFolders),
 			zap.Int64("remaining_files", remainingFiles))
-		return errors.New("remaining items to purge")
+		return fmt.Errorf("%w: remaining items to purge", ErrBatchInProgress)
 	}
 
 	objectPath := path.Join("buckets", e.Payload.BucketID.String(), e.Payload.FolderID.String())
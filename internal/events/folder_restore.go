@@ -3,6 +3,7 @@ package events
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"path"
 
 	"github.com/safebucket/safebucket/internal/activity"
@@ -328,7 +329,7 @@ func (e *FolderRestore) checkRemainingItemsToRestore(params *EventParams) error
 		zap.L().Info("More items to restore, requeuing event",
 			zap.Int64("remaining_folders", remainingFolders),
 			zap.Int64("remaining_files", remainingFiles))
-		return errors.New("remaining items to restore")
+		return fmt.Errorf("%w: remaining items to restore", ErrBatchInProgress)
 	}
 
 	return nil
@@ -346,7 +347,7 @@ func (e *FolderRestore) checkChildFoldersStillRestoring(params *EventParams) err
 	if restoringFolders > 0 {
 		zap.L().Info("Child folders still restoring, requeuing event",
 			zap.Int64("restoring_folders", restoringFolders))
-		return errors.New("child folders still restoring")
+		return fmt.Errorf("%w: child folders still restoring", ErrBatchInProgress)
 	}
 
 	return nil
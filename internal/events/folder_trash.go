@@ -3,6 +3,7 @@ package events
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"path"
 
 	"github.com/safebucket/safebucket/internal/activity"
@@ -241,7 +242,7 @@ func (e *FolderTrash) callback(params *EventParams) error {
 		zap.L().Info("More items to trash, requeuing event",
 			zap.Int64("remaining_folders", remainingFolders),
 			zap.Int64("remaining_files", remainingFiles))
-		return errors.New("remaining items to trash")
+		return fmt.Errorf("%w: remaining items to trash", ErrBatchInProgress)
 	}
 
 	action := models.Activity{
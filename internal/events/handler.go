@@ -3,6 +3,7 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -25,12 +26,14 @@ import (
 type EventParams struct {
 	WebURL             string
 	Notifier           notifier.INotifier
+	QueuedNotifier     notifier.INotifier
 	Publisher          messaging.IPublisher
 	DB                 *gorm.DB
 	Storage            storage.IStorage
 	ActivityLogger     activity.IActivityLogger
 	TrashRetentionDays int
 	Cache              cache.ICache
+	MaxAttempts        int
 }
 
 type Event interface {
@@ -85,7 +88,16 @@ func HandleEvents(ctx context.Context, workerName string, params *EventParams, m
 			zap.L().
 				Debug("message received", zap.Any("raw_payload", string(msg.Payload)), zap.Any("metadata", msg.Metadata))
 
+			if !waitForRetry(ctx, msg) {
+				return
+			}
+
 			eventType := msg.Metadata.Get("type")
+			if msg.Metadata.Get(messaging.DeliveryLimitReachedMetadataKey) != "" {
+				deadLetter(workerName, params, msg, eventType, GetAttempts(msg), ErrDeliveryLimitReached)
+				continue
+			}
+
 			event, err := getEventFromMessage(eventType, msg)
 			if err != nil {
 				zap.L().Error("event is misconfigured",
@@ -93,14 +105,18 @@ func HandleEvents(ctx context.Context, workerName string, params *EventParams, m
 					zap.String("eventType", eventType),
 					zap.String("worker", workerName),
 				)
-				msg.Ack()
+				deadLetter(workerName, params, msg, eventType, GetAttempts(msg), err)
 				continue
 			}
 
-			if err = event.callback(params); err != nil {
-				msg.Nack()
-			} else {
+			err = event.callback(params)
+			switch {
+			case err == nil:
 				msg.Ack()
+			case errors.Is(err, ErrBatchInProgress):
+				msg.Nack()
+			default:
+				handleFailure(workerName, params, msg, eventType, err)
 			}
 		}
 	}
@@ -111,6 +127,7 @@ func handleUploadEvents(
 	msg *message.Message,
 	db *gorm.DB,
 	activityLogger activity.IActivityLogger,
+	store storage.IStorage,
 	publisher messaging.IPublisher,
 ) {
 	uploadEvents := parser.ParseBucketUploadEvents(msg)
@@ -141,7 +158,36 @@ func handleUploadEvents(
 			continue
 		}
 
-		db.Model(&file).Update("status", models.FileStatusUploaded)
+		var share *models.Share
+		if event.ShareID != "" {
+			share = &models.Share{}
+			if err = db.Where("id = ?", event.ShareID).First(share).Error; err != nil {
+				zap.L().Error("share of the upload not found", zap.String("shareID", event.ShareID), zap.Error(err))
+				continue
+			}
+		}
+
+		// Uploads through a share that requires approval stay hidden until a reviewer approves them.
+		status := models.FileStatusUploaded
+		if share != nil && share.RequireApproval {
+			status = models.FileStatusPending
+		}
+
+		// Stored files get their legal holds and locked retention mirrored like the other upload paths.
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if txErr := tx.Model(&file).Update("status", status).Error; txErr != nil {
+				return txErr
+			}
+			if status != models.FileStatusUploaded || !store.SupportsObjectLock() {
+				return nil
+			}
+			lock := NewObjectLockSync(publisher, file.BucketID, nil, &file.ID)
+			return lock.Enqueue(tx)
+		})
+		if err != nil {
+			zap.L().Error("failed to confirm upload", zap.String("file_id", event.FileID), zap.Error(err))
+			continue
+		}
 
 		action := models.Activity{
 			Message: activity.FileUploaded,
@@ -165,12 +211,7 @@ func handleUploadEvents(
 			continue
 		}
 
-		if event.ShareID != "" {
-			shareUUID, parseErr := uuid.Parse(event.ShareID)
-			if parseErr != nil {
-				zap.L().Error("share id should be a valid UUID", zap.String("shareID", event.ShareID))
-				continue
-			}
+		if share != nil {
 			if err = activityLogger.Send(models.Activity{
 				Message: activity.ShareFileUploaded,
 				Object:  file.ToActivity(),
@@ -179,24 +220,23 @@ func handleUploadEvents(
 					ObjectType: rbac.ResourceFile.String(),
 					FileID:     event.FileID,
 					BucketID:   event.BucketID,
-					ShareID:    shareUUID.String(),
+					ShareID:    share.ID.String(),
 				}),
 			}); err != nil {
 				zap.L().Error("failed to send activity", zap.Error(err))
 			}
 
-			var share models.Share
-			if err = db.Where("id = ?", shareUUID).First(&share).Error; err != nil {
-				continue
-			}
-
 			var user models.User
 			if err = db.Where("id = ?", share.CreatedBy).First(&user).Error; err != nil {
 				continue
 			}
 
+			notificationType := FileActivityUpload
+			if share.RequireApproval {
+				notificationType = FileActivityPendingApproval
+			}
 			evt := NewFileActivityNotification(
-				publisher, FileActivityUpload, FileActivitySourceShare,
+				publisher, notificationType, FileActivitySourceShare,
 				bucketUUID, bucket.Name, file.Name, share.CreatedBy, user.Email,
 			)
 			evt.Trigger()
@@ -292,7 +332,7 @@ func HandleBucketEvents(
 
 			switch eventType {
 			case eventparser.BucketEventTypeUpload:
-				handleUploadEvents(parser, msg, db, activityLogger, publisher)
+				handleUploadEvents(parser, msg, db, activityLogger, storage, publisher)
 
 			case eventparser.BucketEventTypeDeletion:
 				handleDeletionEvents(parser, msg, db, storage, activityLogger, trashRetentionDays)
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/eventparser"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/storage"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type stubUploadParser struct {
+	eventparser.IBucketEventParser
+	events []eventparser.BucketUploadEvent
+}
+
+func (p stubUploadParser) ParseBucketUploadEvents(*message.Message) []eventparser.BucketUploadEvent {
+	return p.events
+}
+
+type stubActivityLogger struct{}
+
+func (stubActivityLogger) Search(map[string][]string, time.Time, time.Time, int) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (stubActivityLogger) Send(models.Activity) error { return nil }
+
+func (stubActivityLogger) CountByHour(map[string][]string, int) ([]models.TimeSeriesPoint, error) {
+	return nil, nil
+}
+
+func (stubActivityLogger) Close() error { return nil }
+
+type objectLockStorage struct {
+	storage.IStorage
+	supported bool
+}
+
+func (s objectLockStorage) SupportsObjectLock() bool { return s.supported }
+
+type uploadFixture struct {
+	db     *gorm.DB
+	owner  models.User
+	bucket models.Bucket
+	file   models.File
+}
+
+func newUploadFixture(t *testing.T) uploadFixture {
+	t.Helper()
+
+	db := setupDeadLetterTestDB(t)
+	owner := models.User{
+		Email:        "owner@example.com",
+		ProviderType: models.LocalProviderType,
+		ProviderKey:  string(models.LocalProviderType),
+		Role:         models.RoleUser,
+	}
+	require.NoError(t, db.Create(&owner).Error)
+	bucket := models.Bucket{Name: "inbox", CreatedBy: owner.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+	file := models.File{Name: "scan.pdf", BucketID: bucket.ID, Status: models.FileStatusUploading}
+	require.NoError(t, db.Create(&file).Error)
+
+	return uploadFixture{db: db, owner: owner, bucket: bucket, file: file}
+}
+
+func (f uploadFixture) objectLockSyncs(t *testing.T) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, f.db.Model(&models.OutboxEvent{}).Where("event_type = ?", ObjectLockSyncName).Count(&count).Error)
+	return count
+}
+
+func TestHandleUploadEvents_ShareApproval(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		requireApproval  bool
+		status           models.FileStatus
+		notificationType FileActivityType
+		objectLockSyncs  int64
+	}{
+		{"share without approval", false, models.FileStatusUploaded, FileActivityUpload, 1},
+		{"share requiring approval", true, models.FileStatusPending, FileActivityPendingApproval, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUploadFixture(t)
+			share := models.Share{
+				Name:            "drop",
+				BucketID:        f.bucket.ID,
+				Type:            models.ShareTypeBucket,
+				AllowUpload:     true,
+				RequireApproval: tc.requireApproval,
+				CreatedBy:       f.owner.ID,
+			}
+			require.NoError(t, f.db.Create(&share).Error)
+
+			parser := stubUploadParser{events: []eventparser.BucketUploadEvent{{
+				BucketID: f.bucket.ID.String(),
+				FileID:   f.file.ID.String(),
+				ShareID:  share.ID.String(),
+			}}}
+			publisher := &capturePublisher{}
+
+			handleUploadEvents(
+				parser, message.NewMessage(watermill.NewUUID(), nil), f.db, stubActivityLogger{},
+				objectLockStorage{supported: true}, publisher,
+			)
+
+			var file models.File
+			require.NoError(t, f.db.First(&file, "id = ?", f.file.ID).Error)
+			assert.Equal(t, tc.status, file.Status)
+			assert.Equal(t, tc.objectLockSyncs, f.objectLockSyncs(t), "pending files are locked once approved")
+
+			require.Len(t, publisher.messages, 1)
+			var payload FileActivityNotificationPayload
+			require.NoError(t, json.Unmarshal(publisher.messages[0].Payload, &payload))
+			assert.Equal(t, tc.notificationType, payload.NotificationType)
+		})
+	}
+}
+
+func TestHandleUploadEvents_ObjectLock(t *testing.T) {
+	for _, supported := range []bool{true, false} {
+		f := newUploadFixture(t)
+		parser := stubUploadParser{events: []eventparser.BucketUploadEvent{{
+			BucketID: f.bucket.ID.String(),
+			FileID:   f.file.ID.String(),
+			UserID:   f.owner.ID.String(),
+		}}}
+
+		handleUploadEvents(
+			parser, message.NewMessage(watermill.NewUUID(), nil), f.db, stubActivityLogger{},
+			objectLockStorage{supported: supported}, &capturePublisher{},
+		)
+
+		want := int64(0)
+		if supported {
+			want = 1
+		}
+		assert.Equal(t, want, f.objectLockSyncs(t), "object lock supported: %t", supported)
+	}
+}
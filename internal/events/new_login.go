@@ -0,0 +1,82 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	NewLoginDetectedName        = "NewLoginDetected"
+	NewLoginDetectedPayloadName = "NewLoginDetectedPayload"
+)
+
+type NewLoginDetectedPayload struct {
+	Type         string
+	To           string
+	IP           string
+	UserAgent    string
+	AuthProvider string
+	LoginDate    string
+	WebURL       string
+}
+
+// NewLoginDetected warns a user that their account was signed in to from an IP and user agent
+// combination it had never been used from before.
+type NewLoginDetected struct {
+	Publisher messaging.IPublisher
+	Payload   NewLoginDetectedPayload
+}
+
+func NewNewLoginDetected(
+	publisher messaging.IPublisher,
+	to string,
+	ip string,
+	userAgent string,
+	authProvider string,
+	loginDate string,
+	webURL string,
+) NewLoginDetected {
+	return NewLoginDetected{
+		Publisher: publisher,
+		Payload: NewLoginDetectedPayload{
+			Type:         NewLoginDetectedName,
+			To:           to,
+			IP:           ip,
+			UserAgent:    userAgent,
+			AuthProvider: authProvider,
+			LoginDate:    loginDate,
+			WebURL:       webURL,
+		},
+	}
+}
+
+func (e *NewLoginDetected) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger event", zap.Error(err))
+	}
+}
+
+func (e *NewLoginDetected) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := "New sign-in to your Safebucket account"
+	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "new_login", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.String("to", e.Payload.To), zap.Error(err))
+		return err
+	}
+	return nil
+}
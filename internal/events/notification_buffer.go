@@ -15,6 +15,7 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type batchMeta struct {
@@ -26,6 +27,7 @@ type batchMeta struct {
 	Source           FileActivitySource `json:"source"`
 	WebURL           string             `json:"web_url"`
 	FirstFileName    string             `json:"first_file_name"`
+	ChannelID        *uuid.UUID         `json:"channel_id,omitempty"`
 	ActionText       string             `json:"-"`
 }
 
@@ -67,7 +69,13 @@ func addToBuffer(
 	return count, nil
 }
 
-func flushBuffer(c cache.ICache, n notifier.INotifier, groupKey string) error {
+func flushBuffer(
+	c cache.ICache,
+	n notifier.INotifier,
+	channels notifier.IChannelNotifier,
+	db *gorm.DB,
+	groupKey string,
+) error {
 	countKey := fmt.Sprintf(configuration.CacheNotifyBatchCountKey, groupKey)
 	metaKey := fmt.Sprintf(configuration.CacheNotifyBatchMetaKey, groupKey)
 
@@ -95,9 +103,13 @@ func flushBuffer(c cache.ICache, n notifier.INotifier, groupKey string) error {
 	var subject string
 	meta.ActionText, subject = composeBatchEmail(meta, count, meta.FirstFileName)
 
-	err = n.NotifyFromTemplate(meta.RecipientEmail, subject, "file_activity", meta)
+	if meta.ChannelID != nil {
+		err = notifyChannel(channels, db, *meta.ChannelID, meta)
+	} else {
+		err = n.NotifyFromTemplate(meta.RecipientEmail, subject, "file_activity", meta)
+	}
 	if err != nil {
-		zap.L().Error("failed to send batched file notification email",
+		zap.L().Error("failed to send batched file notification",
 			zap.String("to", meta.RecipientEmail),
 			zap.Int64("count", count),
 			zap.Error(err))
@@ -122,36 +134,64 @@ func composeBatchEmail(meta batchMeta, count int64, firstName string) (string, s
 	}
 }
 
+// activityPhrase describes how an activity type reads in notification emails, e.g.
+// `alice uploaded "report.pdf" to bucket "Finance".` or `alice uploaded 3 files to Finance`.
+type activityPhrase struct {
+	verb        string
+	preposition string
+	label       string // prefix placed before a single quoted name
+	singular    string
+	plural      string
+}
+
+var userActivityPhrases = map[FileActivityType]activityPhrase{
+	FileActivityUpload:   {verb: "uploaded", preposition: "to", singular: "a file", plural: "files"},
+	FileActivityDownload: {verb: "downloaded", preposition: "from", singular: "a file", plural: "files"},
+	FileActivityDelete: {
+		verb: "moved", preposition: "to the trash in", singular: "an item", plural: "items",
+	},
+	FileActivityRestore: {
+		verb: "restored", preposition: "from the trash in", singular: "an item", plural: "items",
+	},
+	FileActivityShare: {
+		verb: "created", preposition: "for", label: "sharing link ",
+		singular: "a sharing link", plural: "sharing links",
+	},
+	FileActivityMember: {
+		verb: "updated", preposition: "in", label: "member ", singular: "a member", plural: "members",
+	},
+}
+
 func composeUserBatchEmail(meta batchMeta, count int64, firstName string) (string, string) {
-	var verb, preposition string
-	if meta.NotificationType == string(FileActivityUpload) {
-		verb = "uploaded"
-		preposition = "to"
-	} else {
-		verb = "downloaded"
-		preposition = "from"
+	phrase, ok := userActivityPhrases[FileActivityType(meta.NotificationType)]
+	if !ok {
+		phrase = userActivityPhrases[FileActivityDownload]
 	}
 
 	if count == 1 {
 		actionText := fmt.Sprintf(
-			"%s %s \"%s\" %s bucket \"%s\".",
-			meta.ActorEmail, verb, firstName, preposition, meta.BucketName,
+			"%s %s %s\"%s\" %s bucket \"%s\".",
+			meta.ActorEmail, phrase.verb, phrase.label, firstName, phrase.preposition, meta.BucketName,
 		)
-		subject := fmt.Sprintf("%s %s a file %s %s",
-			meta.ActorEmail, verb, preposition, meta.BucketName)
+		subject := fmt.Sprintf("%s %s %s %s %s",
+			meta.ActorEmail, phrase.verb, phrase.singular, phrase.preposition, meta.BucketName)
 		return actionText, subject
 	}
 
 	actionText := fmt.Sprintf(
-		"%s %s %d files %s bucket \"%s\".",
-		meta.ActorEmail, verb, count, preposition, meta.BucketName,
+		"%s %s %d %s %s bucket \"%s\".",
+		meta.ActorEmail, phrase.verb, count, phrase.plural, phrase.preposition, meta.BucketName,
 	)
-	subject := fmt.Sprintf("%s %s %d files %s %s",
-		meta.ActorEmail, verb, count, preposition, meta.BucketName)
+	subject := fmt.Sprintf("%s %s %d %s %s %s",
+		meta.ActorEmail, phrase.verb, count, phrase.plural, phrase.preposition, meta.BucketName)
 	return actionText, subject
 }
 
 func composeShareBatchEmail(meta batchMeta, count int64) (string, string) {
+	if meta.NotificationType == string(FileActivityPendingApproval) {
+		return composeApprovalBatchEmail(meta, count)
+	}
+
 	var verb, preposition string
 	if meta.NotificationType == string(FileActivityUpload) {
 		verb = "uploaded"
@@ -184,9 +224,34 @@ func composeShareBatchEmail(meta batchMeta, count int64) (string, string) {
 	return actionText, subject
 }
 
+func composeApprovalBatchEmail(meta batchMeta, count int64) (string, string) {
+	if count == 1 {
+		actionText := fmt.Sprintf(
+			"A file was uploaded via sharing link to bucket \"%s\" and is awaiting your approval (link created by %s).",
+			meta.BucketName, meta.ActorEmail,
+		)
+		subject := fmt.Sprintf("A file is awaiting approval in %s", meta.BucketName)
+		return actionText, subject
+	}
+
+	actionText := fmt.Sprintf(
+		"%d files were uploaded via sharing link to bucket \"%s\" and are awaiting your approval (link created by %s).",
+		count, meta.BucketName, meta.ActorEmail,
+	)
+	subject := fmt.Sprintf("%d files are awaiting approval in %s", count, meta.BucketName)
+	return actionText, subject
+}
+
 // StartFileNotificationBuffer starts a background goroutine that flushes all notification batches.
 // The goroutine is registered on wg so callers can wait for it to drain on shutdown.
-func StartFileNotificationBuffer(ctx context.Context, wg *sync.WaitGroup, c cache.ICache, n notifier.INotifier) {
+func StartFileNotificationBuffer(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	c cache.ICache,
+	n notifier.INotifier,
+	channels notifier.IChannelNotifier,
+	db *gorm.DB,
+) {
 	ticker := time.NewTicker(configuration.CacheNotifyFlush * time.Second)
 
 	wg.Go(func() {
@@ -209,7 +274,7 @@ func StartFileNotificationBuffer(ctx context.Context, wg *sync.WaitGroup, c cach
 			}
 
 			for _, entry := range entries {
-				if err = flushBuffer(c, n, entry.Member); err != nil {
+				if err = flushBuffer(c, n, channels, db, entry.Member); err != nil {
 					zap.L().Error("batch flusher: failed to flush batch",
 						zap.String("groupKey", entry.Member), zap.Error(err))
 				}
@@ -0,0 +1,204 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// digestEntry is a single notification held back for a digest. Entries are stored as members
+// of a per-recipient sorted set scored by the time they were recorded.
+type digestEntry struct {
+	batchMeta
+
+	ID string `json:"id"`
+}
+
+type digestBucket struct {
+	BucketID   uuid.UUID
+	BucketName string
+	Lines      []string
+}
+
+type digestData struct {
+	WebURL  string
+	Period  string
+	Total   int
+	Buckets []*digestBucket
+}
+
+var digestWindows = map[models.NotificationDelivery]time.Duration{
+	models.NotificationDeliveryHourly: time.Hour,
+	models.NotificationDeliveryDaily:  24 * time.Hour,
+}
+
+func isDigestDelivery(delivery models.NotificationDelivery) bool {
+	_, ok := digestWindows[delivery]
+	return ok
+}
+
+func addToDigest(c cache.ICache, delivery models.NotificationDelivery, fileName string, meta batchMeta) error {
+	entryKey := fmt.Sprintf(configuration.CacheNotifyDigestKey, delivery, meta.RecipientEmail)
+	digestsKey := fmt.Sprintf(configuration.CacheNotifyDigestsKey, delivery)
+	now := float64(time.Now().UnixMilli())
+
+	meta.FirstFileName = fileName
+	entryJSON, err := json.Marshal(digestEntry{batchMeta: meta, ID: uuid.NewString()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest entry: %w", err)
+	}
+
+	if err = c.ZAdd(entryKey, now, string(entryJSON)); err != nil {
+		return fmt.Errorf("failed to add digest entry: %w", err)
+	}
+
+	if err = c.Expire(entryKey, configuration.CacheNotifyDigestTTL); err != nil {
+		return fmt.Errorf("failed to set digest TTL: %w", err)
+	}
+
+	// The recipient is scored by its oldest pending entry so the digest window starts with
+	// the first event rather than sliding forward with every new one.
+	if _, err = c.ZScore(digestsKey, meta.RecipientEmail); errors.Is(err, cache.ErrKeyNotFound) {
+		if err = c.ZAdd(digestsKey, now, meta.RecipientEmail); err != nil {
+			return fmt.Errorf("failed to register digest recipient: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to look up digest recipient: %w", err)
+	}
+
+	return nil
+}
+
+// FlushNotificationDigests sends a digest to every recipient whose oldest pending entry is
+// older than the window of their delivery mode, and returns the number of digests sent.
+func FlushNotificationDigests(c cache.ICache, n notifier.INotifier, now time.Time) int {
+	flushed := 0
+	for delivery, window := range digestWindows {
+		digestsKey := fmt.Sprintf(configuration.CacheNotifyDigestsKey, delivery)
+		cutoff := strconv.FormatInt(now.Add(-window).UnixMilli(), 10)
+
+		recipients, err := c.ZRangeByScoreWithScores(digestsKey, "1", cutoff)
+		if err != nil {
+			zap.L().Error("digest flusher: failed to query recipients",
+				zap.String("delivery", string(delivery)), zap.Error(err))
+			continue
+		}
+
+		for _, recipient := range recipients {
+			if err = flushDigest(c, n, delivery, recipient.Member, now); err != nil {
+				zap.L().Error("digest flusher: failed to flush digest",
+					zap.String("delivery", string(delivery)),
+					zap.String("to", recipient.Member),
+					zap.Error(err))
+				continue
+			}
+			flushed++
+		}
+
+		_ = c.ZRemRangeByScore(digestsKey, "-inf", "0")
+	}
+	return flushed
+}
+
+func flushDigest(
+	c cache.ICache,
+	n notifier.INotifier,
+	delivery models.NotificationDelivery,
+	recipientEmail string,
+	now time.Time,
+) error {
+	entryKey := fmt.Sprintf(configuration.CacheNotifyDigestKey, delivery, recipientEmail)
+	digestsKey := fmt.Sprintf(configuration.CacheNotifyDigestsKey, delivery)
+	upTo := strconv.FormatInt(now.UnixMilli(), 10)
+
+	raw, err := c.ZRangeByScoreWithScores(entryKey, "-inf", upTo)
+	if err != nil {
+		return fmt.Errorf("failed to get digest entries: %w", err)
+	}
+
+	entries := make([]digestEntry, 0, len(raw))
+	for _, r := range raw {
+		var entry digestEntry
+		if err = json.Unmarshal([]byte(r.Member), &entry); err != nil {
+			zap.L().Warn("digest flusher: skipping malformed entry", zap.Error(err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) > 0 {
+		data := composeDigest(delivery, entries)
+		subject := fmt.Sprintf("Your %s Safebucket digest: %d updates", delivery, data.Total)
+		if data.Total == 1 {
+			subject = fmt.Sprintf("Your %s Safebucket digest: 1 update", delivery)
+		}
+
+		if err = n.NotifyFromTemplate(recipientEmail, subject, "notification_digest", data); err != nil {
+			return err
+		}
+	}
+
+	_ = c.ZRemRangeByScore(entryKey, "-inf", upTo)
+
+	// Entries recorded while the digest was being sent start the next window.
+	remaining, err := c.ZRangeByScoreWithScores(entryKey, "-inf", "+inf")
+	if err == nil && len(remaining) > 0 {
+		return c.ZAdd(digestsKey, remaining[0].Score, recipientEmail)
+	}
+
+	return c.ZAdd(digestsKey, 0, recipientEmail)
+}
+
+// composeDigest groups entries per bucket and collapses repeated activity from the same actor
+// into a single line, the same way the immediate buffer batches them.
+func composeDigest(delivery models.NotificationDelivery, entries []digestEntry) digestData {
+	type group struct {
+		meta  batchMeta
+		count int64
+	}
+
+	data := digestData{Period: string(delivery), Total: len(entries)}
+	buckets := make(map[uuid.UUID]*digestBucket)
+	groups := make(map[string]*group)
+	var order []string
+
+	for _, entry := range entries {
+		if data.WebURL == "" {
+			data.WebURL = entry.WebURL
+		}
+
+		if _, ok := buckets[entry.BucketID]; !ok {
+			b := &digestBucket{BucketID: entry.BucketID, BucketName: entry.BucketName}
+			buckets[entry.BucketID] = b
+			data.Buckets = append(data.Buckets, b)
+		}
+
+		key := fmt.Sprintf("%s:%s:%s:%s",
+			entry.BucketID, entry.ActorEmail, entry.NotificationType, entry.Source)
+		if g, ok := groups[key]; ok {
+			g.count++
+			continue
+		}
+		groups[key] = &group{meta: entry.batchMeta, count: 1}
+		order = append(order, key)
+	}
+
+	for _, key := range order {
+		g := groups[key]
+		line, _ := composeBatchEmail(g.meta, g.count, g.meta.FirstFileName)
+		b := buckets[g.meta.BucketID]
+		b.Lines = append(b.Lines, line)
+	}
+
+	return data
+}
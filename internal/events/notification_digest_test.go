@@ -0,0 +1,96 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentDigest struct {
+	to      string
+	subject string
+	data    digestData
+}
+
+type captureNotifier struct {
+	sent []sentDigest
+}
+
+func (n *captureNotifier) NotifyFromTemplate(to string, subject string, templateName string, data interface{}) error {
+	if templateName == "notification_digest" {
+		n.sent = append(n.sent, sentDigest{to: to, subject: subject, data: data.(digestData)})
+	}
+	return nil
+}
+
+func TestWantsNotification(t *testing.T) {
+	m := models.Membership{UploadNotifications: true, ShareNotifications: true}
+
+	assert.True(t, wantsNotification(m, FileActivityUpload))
+	assert.False(t, wantsNotification(m, FileActivityDownload))
+	assert.False(t, wantsNotification(m, FileActivityDelete))
+	assert.False(t, wantsNotification(m, FileActivityRestore))
+	assert.True(t, wantsNotification(m, FileActivityShare))
+	assert.False(t, wantsNotification(m, FileActivityMember))
+	assert.True(t, wantsNotification(m, FileActivityPendingApproval))
+}
+
+func TestNotificationDigest(t *testing.T) {
+	c := cache.NewMemoryCache()
+	n := &captureNotifier{}
+
+	finance := batchMeta{
+		RecipientEmail:   "reader@example.com",
+		ActorEmail:       "alice@example.com",
+		BucketID:         uuid.New(),
+		BucketName:       "Finance",
+		NotificationType: string(FileActivityUpload),
+		Source:           FileActivitySourceUser,
+		WebURL:           "http://localhost:3000",
+	}
+	legal := finance
+	legal.BucketID = uuid.New()
+	legal.BucketName = "Legal"
+	legal.NotificationType = string(FileActivityDelete)
+
+	require.NoError(t, addToDigest(c, models.NotificationDeliveryHourly, "q1.pdf", finance))
+	require.NoError(t, addToDigest(c, models.NotificationDeliveryHourly, "q2.pdf", finance))
+	require.NoError(t, addToDigest(c, models.NotificationDeliveryHourly, "contract.docx", legal))
+
+	t.Run("holds entries until the window elapses", func(t *testing.T) {
+		FlushNotificationDigests(c, n, time.Now())
+		assert.Empty(t, n.sent)
+	})
+
+	t.Run("sends one email across buckets", func(t *testing.T) {
+		FlushNotificationDigests(c, n, time.Now().Add(time.Hour+time.Minute))
+		require.Len(t, n.sent, 1)
+
+		digest := n.sent[0]
+		assert.Equal(t, "reader@example.com", digest.to)
+		assert.Equal(t, "Your hourly Safebucket digest: 3 updates", digest.subject)
+		assert.Equal(t, 3, digest.data.Total)
+		require.Len(t, digest.data.Buckets, 2)
+
+		assert.Equal(t, "Finance", digest.data.Buckets[0].BucketName)
+		assert.Equal(t,
+			[]string{`alice@example.com uploaded 2 files to bucket "Finance".`},
+			digest.data.Buckets[0].Lines)
+
+		assert.Equal(t, "Legal", digest.data.Buckets[1].BucketName)
+		assert.Equal(t,
+			[]string{`alice@example.com moved "contract.docx" to the trash in bucket "Legal".`},
+			digest.data.Buckets[1].Lines)
+	})
+
+	t.Run("does not resend flushed entries", func(t *testing.T) {
+		FlushNotificationDigests(c, n, time.Now().Add(2*time.Hour))
+		assert.Len(t, n.sent, 1)
+	})
+}
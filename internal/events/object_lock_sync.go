@@ -0,0 +1,126 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/retention"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ObjectLockSyncName        = "ObjectLockSync"
+	ObjectLockSyncPayloadName = "ObjectLockSyncPayload"
+	objectLockSyncBatchSize   = 500
+)
+
+type ObjectLockSyncPayload struct {
+	Type     string
+	BucketID uuid.UUID
+	FolderID *uuid.UUID
+	FileID   *uuid.UUID
+}
+
+// ObjectLockSync mirrors the legal holds and locked retention periods of a bucket, a folder
+// subtree or a single file onto the storage provider's object lock.
+type ObjectLockSync struct {
+	Publisher messaging.IPublisher
+	Payload   ObjectLockSyncPayload
+}
+
+func NewObjectLockSync(
+	publisher messaging.IPublisher,
+	bucketID uuid.UUID,
+	folderID *uuid.UUID,
+	fileID *uuid.UUID,
+) ObjectLockSync {
+	return ObjectLockSync{
+		Publisher: publisher,
+		Payload: ObjectLockSyncPayload{
+			Type:     ObjectLockSyncName,
+			BucketID: bucketID,
+			FolderID: folderID,
+			FileID:   fileID,
+		},
+	}
+}
+
+func (e *ObjectLockSync) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling object lock sync event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger object lock sync event", zap.Error(err))
+	}
+}
+
+func (e *ObjectLockSync) callback(params *EventParams) error {
+	if !params.Storage.SupportsObjectLock() {
+		return nil
+	}
+
+	scope, err := retention.LoadScope(params.DB, &e.Payload.BucketID)
+	if err != nil {
+		zap.L().Error("Failed to load retention scope", zap.Error(err))
+		return err
+	}
+
+	query := params.DB.Unscoped().
+		Where("bucket_id = ? AND status IN ?", e.Payload.BucketID, retention.StoredStatuses)
+	switch {
+	case e.Payload.FileID != nil:
+		query = query.Where("id = ?", *e.Payload.FileID)
+	case e.Payload.FolderID != nil:
+		subtree, subtreeErr := retention.FolderSubtree(params.DB, []uuid.UUID{*e.Payload.FolderID})
+		if subtreeErr != nil {
+			zap.L().Error("Failed to load folder subtree", zap.Error(subtreeErr))
+			return subtreeErr
+		}
+		query = query.Where("folder_id IN ?", subtree)
+	}
+
+	var failed error
+	lastID := uuid.Nil
+	for {
+		var files []models.File
+		if err = query.Session(&gorm.Session{}).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(objectLockSyncBatchSize).
+			Find(&files).Error; err != nil {
+			zap.L().Error("Failed to list files for object lock sync", zap.Error(err))
+			return err
+		}
+
+		for _, file := range files {
+			objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
+			if lockErr := params.Storage.SetObjectLock(objectPath, scope.ObjectLock(file)); lockErr != nil {
+				zap.L().Warn("Failed to apply object lock",
+					zap.String("path", objectPath),
+					zap.Error(lockErr))
+				failed = errors.Join(failed, lockErr)
+			}
+		}
+
+		if len(files) < objectLockSyncBatchSize {
+			break
+		}
+		lastID = files[len(files)-1].ID
+	}
+
+	return failed
+}
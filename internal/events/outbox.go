@@ -0,0 +1,62 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"gorm.io/gorm"
+)
+
+// enqueueOutbox writes an event to the outbox table using the caller's transaction.
+// The outbox relay worker publishes it once the transaction has committed.
+func enqueueOutbox(tx *gorm.DB, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.OutboxEvent{
+		MessageID: watermill.NewUUID(),
+		EventType: eventType,
+		Payload:   string(data),
+		Status:    models.OutboxStatusPending,
+	}).Error
+}
+
+// NewOutboxMessage rebuilds the watermill message stored in an outbox row.
+func NewOutboxMessage(entry models.OutboxEvent) *message.Message {
+	msg := message.NewMessage(entry.MessageID, []byte(entry.Payload))
+	msg.Metadata.Set("type", entry.EventType)
+	return msg
+}
+
+func (e *BucketPurge) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *FilesExpiring) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *FolderTrash) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *FolderRestore) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *FolderPurge) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *ObjectLockSync) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
+
+func (e *ShareSent) Enqueue(tx *gorm.DB) error {
+	return enqueueOutbox(tx, e.Payload.Type, e.Payload)
+}
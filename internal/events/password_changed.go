@@ -0,0 +1,76 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	PasswordChangedName        = "PasswordChanged"
+	PasswordChangedPayloadName = "PasswordChangedPayload"
+)
+
+type PasswordChangedPayload struct {
+	Type       string
+	Email      string
+	IP         string
+	ChangeDate string
+	WebURL     string
+}
+
+// PasswordChanged confirms to a user that they changed their password from their account
+// settings and that their other sessions were signed out.
+type PasswordChanged struct {
+	Publisher messaging.IPublisher
+	Payload   PasswordChangedPayload
+}
+
+func NewPasswordChanged(
+	publisher messaging.IPublisher,
+	email string,
+	ip string,
+	changeDate string,
+	webURL string,
+) PasswordChanged {
+	return PasswordChanged{
+		Publisher: publisher,
+		Payload: PasswordChangedPayload{
+			Type:       PasswordChangedName,
+			Email:      email,
+			IP:         ip,
+			ChangeDate: changeDate,
+			WebURL:     webURL,
+		},
+	}
+}
+
+func (e *PasswordChanged) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger event", zap.Error(err))
+	}
+}
+
+func (e *PasswordChanged) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := "Your Safebucket password was changed"
+	err := params.Notifier.NotifyFromTemplate(e.Payload.Email, subject, "password_changed", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.String("to", e.Payload.Email), zap.Error(err))
+		return err
+	}
+	return nil
+}
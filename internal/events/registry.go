@@ -9,6 +9,12 @@ var eventRegistry = map[string]reflect.Type{
 	ChallengeUserInvitePayloadName:      reflect.TypeOf(ChallengeUserInvitePayload{}),
 	PasswordResetChallengeName:          reflect.TypeOf(PasswordResetChallengeEvent{}),
 	PasswordResetChallengePayloadName:   reflect.TypeOf(PasswordResetChallengePayload{}),
+	ShareAccessChallengeName:            reflect.TypeOf(ShareAccessChallengeEvent{}),
+	ShareAccessChallengePayloadName:     reflect.TypeOf(ShareAccessChallengePayload{}),
+	ShareSentName:                       reflect.TypeOf(ShareSent{}),
+	ShareSentPayloadName:                reflect.TypeOf(ShareSentPayload{}),
+	SharePasswordSentName:               reflect.TypeOf(SharePasswordSent{}),
+	SharePasswordSentPayloadName:        reflect.TypeOf(SharePasswordSentPayload{}),
 	PasswordResetSuccessName:            reflect.TypeOf(PasswordResetSuccessEvent{}),
 	PasswordResetSuccessPayloadName:     reflect.TypeOf(PasswordResetSuccessPayload{}),
 	UserWelcomeName:                     reflect.TypeOf(UserWelcomeEvent{}),
@@ -27,4 +33,22 @@ var eventRegistry = map[string]reflect.Type{
 	FolderPurgePayloadName:              reflect.TypeOf(FolderPurgePayload{}),
 	FileActivityNotificationName:        reflect.TypeOf(FileActivityNotification{}),
 	FileActivityNotificationPayloadName: reflect.TypeOf(FileActivityNotificationPayload{}),
+	NewLoginDetectedName:                reflect.TypeOf(NewLoginDetected{}),
+	NewLoginDetectedPayloadName:         reflect.TypeOf(NewLoginDetectedPayload{}),
+	AccountLockedName:                   reflect.TypeOf(AccountLocked{}),
+	AccountLockedPayloadName:            reflect.TypeOf(AccountLockedPayload{}),
+	PasswordChangedName:                 reflect.TypeOf(PasswordChanged{}),
+	PasswordChangedPayloadName:          reflect.TypeOf(PasswordChangedPayload{}),
+	ObjectLockSyncName:                  reflect.TypeOf(ObjectLockSync{}),
+	ObjectLockSyncPayloadName:           reflect.TypeOf(ObjectLockSyncPayload{}),
+	FilesExpiringName:                   reflect.TypeOf(FilesExpiring{}),
+	FilesExpiringPayloadName:            reflect.TypeOf(FilesExpiringPayload{}),
+}
+
+// IsRegistered reports whether events of the given type can be decoded and handled. Events
+// outside the registry cannot be routed either, so they must never be published.
+func IsRegistered(eventType string) bool {
+	_, event := eventRegistry[eventType]
+	_, payload := eventRegistry[eventType+"Payload"]
+	return event && payload
 }
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/messaging"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	ShareSentName                = "ShareSent"
+	ShareSentPayloadName         = "ShareSentPayload"
+	SharePasswordSentName        = "SharePasswordSent"
+	SharePasswordSentPayloadName = "SharePasswordSentPayload"
+)
+
+type ShareSentPayload struct {
+	Type              string
+	To                string
+	From              string
+	ShareName         string
+	ShareURL          string
+	Message           string
+	ExpiresAt         *time.Time
+	PasswordProtected bool
+	WebURL            string
+}
+
+type ShareSent struct {
+	Publisher messaging.IPublisher
+	Payload   ShareSentPayload
+}
+
+func NewShareSent(
+	publisher messaging.IPublisher,
+	to string,
+	from string,
+	shareID string,
+	shareName string,
+	message string,
+	expiresAt *time.Time,
+	passwordProtected bool,
+	webURL string,
+) ShareSent {
+	return ShareSent{
+		Publisher: publisher,
+		Payload: ShareSentPayload{
+			Type:              ShareSentName,
+			To:                to,
+			From:              from,
+			ShareName:         shareName,
+			ShareURL:          fmt.Sprintf("%s/shares/%s", webURL, shareID),
+			Message:           message,
+			ExpiresAt:         expiresAt,
+			PasswordProtected: passwordProtected,
+			WebURL:            webURL,
+		},
+	}
+}
+
+func (e *ShareSent) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	subject := fmt.Sprintf("%s has shared \"%s\" with you", e.Payload.From, e.Payload.ShareName)
+	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "share_sent", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to notify", zap.Any("event", e), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// SharePasswordSentPayload carries a share password in clear text, so it is published
+// directly instead of going through the outbox table, delivered through the queued notifier
+// which seals it at rest, and stripped from the dead-letter table.
+type SharePasswordSentPayload struct {
+	Type      string
+	To        string
+	From      string
+	ShareName string
+	Password  string
+	WebURL    string
+}
+
+type SharePasswordSent struct {
+	Publisher messaging.IPublisher
+	Payload   SharePasswordSentPayload
+}
+
+func NewSharePasswordSent(
+	publisher messaging.IPublisher,
+	to string,
+	from string,
+	shareName string,
+	password string,
+) SharePasswordSent {
+	return SharePasswordSent{
+		Publisher: publisher,
+		Payload: SharePasswordSentPayload{
+			Type:      SharePasswordSentName,
+			To:        to,
+			From:      from,
+			ShareName: shareName,
+			Password:  password,
+		},
+	}
+}
+
+func (e *SharePasswordSent) Trigger() {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		zap.L().Error("Error marshalling event payload", zap.Error(err))
+		return
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("type", e.Payload.Type)
+	err = e.Publisher.Publish(msg)
+	if err != nil {
+		zap.L().Error("failed to trigger event", zap.Error(err))
+	}
+}
+
+func (e *SharePasswordSent) callback(params *EventParams) error {
+	e.Payload.WebURL = params.WebURL
+	if e.Payload.Password == "" {
+		zap.L().Warn("share password was redacted, it cannot be sent again",
+			zap.String("to", e.Payload.To), zap.String("share_name", e.Payload.ShareName))
+		return nil
+	}
+
+	subject := fmt.Sprintf("Password for \"%s\"", e.Payload.ShareName)
+	err := params.QueuedNotifier.NotifyFromTemplate(e.Payload.To, subject, "share_password", e.Payload)
+	if err != nil {
+		zap.L().Error("failed to queue notification",
+			zap.String("to", e.Payload.To), zap.String("share_name", e.Payload.ShareName), zap.Error(err))
+		return err
+	}
+	return nil
+}
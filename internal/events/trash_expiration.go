@@ -2,12 +2,15 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"path"
 	"strings"
 
 	"github.com/safebucket/safebucket/internal/activity"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -207,6 +210,18 @@ func (e *TrashExpiration) callback(params *EventParams) error {
 		return nil
 	}
 
+	if err = retention.CheckFile(params.DB, currentFile, retention.ActionPurge); err != nil {
+		var apiErr *apierrors.APIError
+		if !errors.As(err, &apiErr) {
+			return err
+		}
+		zap.L().Info("File is protected by a legal hold or retention period, skipping expiration",
+			zap.String("file_id", file.ID.String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+
 	zap.L().Info("Processing file deletion from trash",
 		zap.String("file_id", file.ID.String()),
 		zap.String("file_name", file.Name),
@@ -92,9 +92,9 @@ func (e *UserInvitation) Trigger() {
 func (e *UserInvitation) callback(params *EventParams) error {
 	e.Payload.WebURL = params.WebURL
 	subject := fmt.Sprintf("%s has invited you to SafeBucket", e.Payload.From)
-	err := params.Notifier.NotifyFromTemplate(e.Payload.To, subject, "user_invitation", e.Payload)
+	err := params.QueuedNotifier.NotifyFromTemplate(e.Payload.To, subject, "user_invitation", e.Payload)
 	if err != nil {
-		zap.L().Error("failed to notify", zap.Any("event", e), zap.Error(err))
+		zap.L().Error("failed to queue notification", zap.String("to", e.Payload.To), zap.Error(err))
 		return err
 	}
 	return nil
@@ -18,6 +18,9 @@ import (
 type (
 	OpenIDBeginFunc    func(string, string, string) (string, error)
 	OpenIDCallbackFunc func(context.Context, *zap.Logger, string, string, string) (models.OIDCCallbackResult, error)
+	SAMLMetadataFunc   func(string) ([]byte, error)
+	SAMLBeginFunc      func(*zap.Logger, string) (string, error)
+	SAMLCallbackFunc   func(context.Context, *zap.Logger, string, string, string) (models.OIDCCallbackResult, error)
 )
 
 func providerKeyFromURL(r *http.Request) (string, error) {
@@ -98,14 +101,109 @@ func OpenIDCallbackHandler(webURL string, cookieSecureForce bool, openidCallback
 			return
 		}
 
-		if result.MFARequired {
-			SetMFACookie(w, r, result.MFAToken, cookieSecureForce)
-			http.Redirect(w, r, fmt.Sprintf("%s/auth/complete?mfa=required", webURL), http.StatusFound)
+		redirectToWeb(w, r, webURL, providerName, result, cookieSecureForce)
+	}
+}
+
+func SAMLMetadataHandler(samlMetadata SAMLMetadataFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.StartSpan(r.Context(), "handlers.SAMLMetadata")
+		defer span.End()
+
+		providerName, err := providerKeyFromURL(r)
+		if err != nil {
+			WriteError(span, w, err)
+			return
+		}
+
+		metadata, err := samlMetadata(providerName)
+		if err != nil {
+			WriteError(span, w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(metadata); err != nil {
+			m.GetLogger(r.WithContext(ctx)).Error("Failed to write SAML metadata", zap.Error(err))
+		}
+	}
+}
+
+func SAMLBeginHandler(samlBegin SAMLBeginFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.StartSpan(r.Context(), "handlers.SAMLBegin")
+		defer span.End()
+		r = r.WithContext(ctx)
+
+		providerName, err := providerKeyFromURL(r)
+		if err != nil {
+			WriteError(span, w, err)
+			return
+		}
+
+		url, err := samlBegin(m.GetLogger(r), providerName)
+		if err != nil {
+			WriteError(span, w, err)
 			return
 		}
 
-		SetAuthCookies(w, r, result.AccessToken, result.RefreshToken, providerName, cookieSecureForce)
+		http.Redirect(w, r, url, http.StatusFound)
+	}
+}
+
+// SAMLACSHandler is the assertion consumer service: the IdP posts the SAML response here with
+// the HTTP-POST binding, for both SP-initiated and IdP-initiated sign-ins.
+func SAMLACSHandler(webURL string, cookieSecureForce bool, samlCallback SAMLCallbackFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.StartSpan(r.Context(), "handlers.SAMLACS")
+		defer span.End()
+		r = r.WithContext(ctx)
+
+		providerName, err := providerKeyFromURL(r)
+		if err != nil {
+			WriteError(span, w, err)
+			return
+		}
 
-		http.Redirect(w, r, fmt.Sprintf("%s/auth/complete", webURL), http.StatusFound)
+		if err = r.ParseForm(); err != nil {
+			h.RespondWithError(w, http.StatusBadRequest, []string{apierrors.CodeSAMLResponseMissing})
+			return
+		}
+
+		result, err := samlCallback(
+			r.Context(),
+			m.GetLogger(r),
+			providerName,
+			r.PostForm.Get("SAMLResponse"),
+			r.PostForm.Get("RelayState"),
+		)
+		if err != nil {
+			WriteError(span, w, err)
+			return
+		}
+
+		redirectToWeb(w, r, webURL, providerName, result, cookieSecureForce)
 	}
 }
+
+// redirectToWeb ends a redirect-based sign-in by setting the session or MFA cookies and sending
+// the browser back to the web app.
+func redirectToWeb(
+	w http.ResponseWriter,
+	r *http.Request,
+	webURL string,
+	providerName string,
+	result models.OIDCCallbackResult,
+	cookieSecureForce bool,
+) {
+	if result.MFARequired {
+		SetMFACookie(w, r, result.MFAToken, cookieSecureForce)
+		http.Redirect(w, r, fmt.Sprintf("%s/auth/complete?mfa=required", webURL), http.StatusFound)
+		return
+	}
+
+	SetAuthCookies(w, r, result.AccessToken, result.RefreshToken, providerName, cookieSecureForce)
+
+	http.Redirect(w, r, fmt.Sprintf("%s/auth/complete", webURL), http.StatusFound)
+}
@@ -21,6 +21,7 @@ type AuthFlowResult struct {
 
 type AuthFlowTargetFunc[In any] func(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	claims models.UserClaims,
 	ids uuid.UUIDs,
@@ -48,7 +49,8 @@ func AuthFlowHandler[In any](forceSecure bool, target AuthFlowTargetFunc[In]) ht
 			return
 		}
 
-		result, err := target(isSecureRequest(r, forceSecure), logger, claims, ids, body)
+		client := models.ClientInfoFromContext(r.Context())
+		result, err := target(isSecureRequest(r, forceSecure), client, logger, claims, ids, body)
 		if err != nil {
 			WriteError(span, w, err)
 			return
@@ -61,6 +63,7 @@ func AuthFlowHandler[In any](forceSecure bool, target AuthFlowTargetFunc[In]) ht
 
 type AuthFlowProviderFunc[In any] func(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	providerKey string,
 	body In,
@@ -88,7 +91,8 @@ func AuthFlowProviderHandler[In any](forceSecure bool, target AuthFlowProviderFu
 			return
 		}
 
-		result, err := target(isSecureRequest(r, forceSecure), logger, providerKey, body)
+		client := models.ClientInfoFromContext(r.Context())
+		result, err := target(isSecureRequest(r, forceSecure), client, logger, providerKey, body)
 		if err != nil {
 			WriteError(span, w, err)
 			return
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -235,3 +237,65 @@ func TestOpenIDCallbackHandler_Errors(t *testing.T) {
 
 	mockOpenIDCallback.AssertExpectations(t)
 }
+
+func TestSAMLACSHandler(t *testing.T) {
+	providerName := "corp"
+	webURL := "https://safebucket.com"
+
+	newACSRequest := func(form url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/auth/providers/corp/acs", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("provider", providerName)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("passes the posted response and relay state to the service", func(t *testing.T) {
+		mockSAMLCallback := new(tests.MockSAMLCallbackFunc)
+		mockSAMLCallback.On("SAMLCallback", mock.Anything, mock.Anything, providerName, "PHNhbWw+", "relay").
+			Return(models.OIDCCallbackResult{AccessToken: "access", RefreshToken: "refresh"}, nil)
+
+		recorder := httptest.NewRecorder()
+		form := url.Values{"SAMLResponse": {"PHNhbWw+"}, "RelayState": {"relay"}}
+		SAMLACSHandler(webURL, false, mockSAMLCallback.SAMLCallback)(recorder, newACSRequest(form))
+
+		mockSAMLCallback.AssertExpectations(t)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+		assert.Equal(t, webURL+"/auth/complete", recorder.Header().Get("Location"))
+
+		var names []string
+		for _, cookie := range recorder.Result().Cookies() {
+			names = append(names, cookie.Name)
+		}
+		assert.Contains(t, names, "safebucket_access_token")
+		assert.Contains(t, names, "safebucket_refresh_token")
+	})
+
+	t.Run("redirects to the MFA step when required", func(t *testing.T) {
+		mockSAMLCallback := new(tests.MockSAMLCallbackFunc)
+		mockSAMLCallback.On("SAMLCallback", mock.Anything, mock.Anything, providerName, "PHNhbWw+", "").
+			Return(models.OIDCCallbackResult{MFAToken: "mfa", MFARequired: true}, nil)
+
+		recorder := httptest.NewRecorder()
+		form := url.Values{"SAMLResponse": {"PHNhbWw+"}}
+		SAMLACSHandler(webURL, false, mockSAMLCallback.SAMLCallback)(recorder, newACSRequest(form))
+
+		assert.Equal(t, http.StatusFound, recorder.Code)
+		assert.Equal(t, webURL+"/auth/complete?mfa=required", recorder.Header().Get("Location"))
+	})
+
+	t.Run("surfaces a rejected assertion", func(t *testing.T) {
+		mockSAMLCallback := new(tests.MockSAMLCallbackFunc)
+		mockSAMLCallback.On("SAMLCallback", mock.Anything, mock.Anything, providerName, "forged", "").
+			Return(models.OIDCCallbackResult{},
+				apierrors.New(http.StatusUnauthorized, apierrors.CodeSAMLResponseInvalid))
+
+		recorder := httptest.NewRecorder()
+		form := url.Values{"SAMLResponse": {"forged"}}
+		SAMLACSHandler(webURL, false, mockSAMLCallback.SAMLCallback)(recorder, newACSRequest(form))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "SAML_RESPONSE_INVALID")
+		assert.Empty(t, recorder.Result().Cookies())
+	})
+}
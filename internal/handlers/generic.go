@@ -29,6 +29,7 @@ type (
 	GetOneListTargetFunc[Out any]             func(*zap.Logger, models.UserClaims, uuid.UUIDs) []Out
 	BodyTargetFunc[In any]                    func(*zap.Logger, models.UserClaims, uuid.UUIDs, In) error
 	DeleteTargetFunc                          func(*zap.Logger, models.UserClaims, uuid.UUIDs) error
+	ActionTargetFunc                          func(*zap.Logger, models.UserClaims, uuid.UUIDs) error
 )
 
 func spanName(fn any) string {
@@ -228,7 +229,16 @@ func BodyHandler[In any](handler BodyTargetFunc[In]) http.HandlerFunc {
 }
 
 func DeleteHandler(del DeleteTargetFunc) http.HandlerFunc {
-	name := spanName(del)
+	return noContentHandler(del)
+}
+
+// ActionHandler serves body-less POST actions such as replays, answering 204 on success.
+func ActionHandler(action ActionTargetFunc) http.HandlerFunc {
+	return noContentHandler(action)
+}
+
+func noContentHandler(target func(*zap.Logger, models.UserClaims, uuid.UUIDs) error) http.HandlerFunc {
+	name := spanName(target)
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, span := tracing.StartSpan(r.Context(), name)
 		defer span.End()
@@ -241,7 +251,7 @@ func DeleteHandler(del DeleteTargetFunc) http.HandlerFunc {
 
 		claims, _ := h.GetUserClaims(r.Context())
 		logger := m.GetLogger(r)
-		if err := del(logger, claims, ids); err != nil {
+		if err := target(logger, claims, ids); err != nil {
 			WriteError(span, w, err)
 		} else {
 			h.RespondWithJSON(w, http.StatusNoContent, nil)
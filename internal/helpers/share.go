@@ -1,7 +1,11 @@
 package helpers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	apierrors "github.com/safebucket/safebucket/internal/errors"
@@ -83,3 +87,73 @@ func IsFolderDescendant(db *gorm.DB, folderID *uuid.UUID, targetFolderID uuid.UU
 	}
 	return false
 }
+
+// ShareAccessFingerprint identifies the password a share token was issued against. Password
+// hashes are salted, so setting a new password, even the same one, changes the fingerprint.
+func ShareAccessFingerprint(share models.Share) string {
+	if share.HashedPassword == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(share.HashedPassword))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NormalizeShareRecipient lowercases a recipient email or domain and strips a leading "@"
+// from domains, so "@Example.com" and "example.com" are stored the same way.
+func NormalizeShareRecipient(value string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "@")
+}
+
+// MatchShareRecipient reports whether the email is listed as is or belongs to a listed domain.
+func MatchShareRecipient(recipients []models.ShareRecipient, email string) bool {
+	email = NormalizeShareRecipient(email)
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return false
+	}
+	domain := email[at+1:]
+
+	for _, recipient := range recipients {
+		if recipient.Value == email || recipient.Value == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// IsShareIPAllowed reports whether a client IP may open a share. Entries are single IPs or
+// CIDR ranges; a match on the denylist always rejects, and a non-empty allowlist rejects
+// every address it does not contain. Unparsable client IPs only pass when no rule is set.
+func IsShareIPAllowed(ip string, allowlist, denylist []string) bool {
+	if len(allowlist) == 0 && len(denylist) == 0 {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	if matchIPRules(parsed, denylist) {
+		return false
+	}
+
+	return len(allowlist) == 0 || matchIPRules(parsed, allowlist)
+}
+
+func matchIPRules(ip net.IP, rules []string) bool {
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if _, network, err := net.ParseCIDR(rule); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if single := net.ParseIP(rule); single != nil && single.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
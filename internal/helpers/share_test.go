@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeShareRecipient(t *testing.T) {
+	assert.Equal(t, "alice@example.com", NormalizeShareRecipient(" Alice@Example.com "))
+	assert.Equal(t, "example.com", NormalizeShareRecipient("@Example.com"))
+	assert.Equal(t, "example.com", NormalizeShareRecipient("example.com"))
+}
+
+func TestMatchShareRecipient(t *testing.T) {
+	recipients := []models.ShareRecipient{
+		{Value: "alice@example.com"},
+		{Value: "partner.org"},
+	}
+
+	tests := []struct {
+		name  string
+		email string
+		want  bool
+	}{
+		{"exact email", "alice@example.com", true},
+		{"exact email is case insensitive", "ALICE@example.com", true},
+		{"other email on listed email domain", "bob@example.com", false},
+		{"email on listed domain", "carol@partner.org", true},
+		{"subdomain of listed domain", "dave@eu.partner.org", false},
+		{"suffix lookalike domain", "eve@evilpartner.org", false},
+		{"missing local part", "@partner.org", false},
+		{"not an email", "partner.org", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchShareRecipient(recipients, tt.email))
+		})
+	}
+}
+
+func TestIsShareIPAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip        string
+		allowlist []string
+		denylist  []string
+		want      bool
+	}{
+		{"no rules", "203.0.113.7", nil, nil, true},
+		{"no rules with unresolved ip", "", nil, nil, true},
+		{"inside allowed range", "10.1.2.3", []string{"10.0.0.0/8"}, nil, true},
+		{"outside allowed range", "192.168.1.1", []string{"10.0.0.0/8"}, nil, false},
+		{"allowed single ip", "198.51.100.4", []string{"198.51.100.4"}, nil, true},
+		{"denied range", "10.1.2.3", nil, []string{"10.1.0.0/16"}, false},
+		{"outside denied range", "10.2.0.1", nil, []string{"10.1.0.0/16"}, true},
+		{"deny wins over allow", "10.1.2.3", []string{"10.0.0.0/8"}, []string{"10.1.2.3"}, false},
+		{"ipv6 range", "2001:db8::1", []string{"2001:db8::/32"}, nil, true},
+		{"unresolved ip with rules", "", []string{"10.0.0.0/8"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsShareIPAllowed(tt.ip, tt.allowlist, tt.denylist))
+		})
+	}
+}
@@ -196,12 +196,18 @@ func ParseToken(
 	return *claims, nil
 }
 
+// NewShareAccessToken issues a share token. The email is empty for password-protected
+// shares and holds the verified recipient for recipient-restricted ones. The token is bound
+// to the current share password, so changing it revokes the tokens already issued.
 func NewShareAccessToken(
 	jwtSecret string,
-	shareID uuid.UUID,
+	share models.Share,
+	email string,
 ) (string, error) {
 	claims := models.ShareClaims{
-		ShareID: shareID,
+		ShareID:     share.ID,
+		Email:       email,
+		Fingerprint: ShareAccessFingerprint(share),
 		RegisteredClaims: newRegisteredClaims(
 			configuration.AudienceShareAccess,
 			configuration.ShareTokenExpiry,
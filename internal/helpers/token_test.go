@@ -583,7 +583,7 @@ func TestNewShareAccessToken(t *testing.T) {
 	shareID := uuid.New()
 
 	t.Run("should create valid share token", func(t *testing.T) {
-		token, err := NewShareAccessToken(jwtSecret, shareID)
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "")
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, token)
@@ -591,7 +591,7 @@ func TestNewShareAccessToken(t *testing.T) {
 	})
 
 	t.Run("should have correct claims", func(t *testing.T) {
-		token, err := NewShareAccessToken(jwtSecret, shareID)
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "")
 		require.NoError(t, err)
 
 		claims, err := ParseShareToken(jwtSecret, token)
@@ -600,10 +600,36 @@ func TestNewShareAccessToken(t *testing.T) {
 		assert.Equal(t, shareID, claims.ShareID)
 		assert.Equal(t, configuration.AppName, claims.Issuer)
 		assert.Equal(t, configuration.AudienceShareAccess, claims.Audience[0])
+		assert.Empty(t, claims.Email)
+	})
+
+	t.Run("should carry verified recipient email", func(t *testing.T) {
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "alice@example.com")
+		require.NoError(t, err)
+
+		claims, err := ParseShareToken(jwtSecret, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", claims.Email)
+	})
+
+	t.Run("should be bound to the share password", func(t *testing.T) {
+		share := models.Share{ID: shareID, HashedPassword: "$argon2id$v=19$m=65536,t=3,p=2$salt$hash"}
+		token, err := NewShareAccessToken(jwtSecret, share, "")
+		require.NoError(t, err)
+
+		claims, err := ParseShareToken(jwtSecret, token)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, claims.Fingerprint)
+		assert.Equal(t, ShareAccessFingerprint(share), claims.Fingerprint)
+
+		share.HashedPassword = "$argon2id$v=19$m=65536,t=3,p=2$other$hash"
+		assert.NotEqual(t, ShareAccessFingerprint(share), claims.Fingerprint)
 	})
 
 	t.Run("should expire in configured minutes", func(t *testing.T) {
-		token, err := NewShareAccessToken(jwtSecret, shareID)
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "")
 		require.NoError(t, err)
 
 		claims, err := ParseShareToken(jwtSecret, token)
@@ -622,7 +648,7 @@ func TestParseShareToken(t *testing.T) {
 	shareID := uuid.New()
 
 	t.Run("should parse valid share token", func(t *testing.T) {
-		token, err := NewShareAccessToken(jwtSecret, shareID)
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "")
 		require.NoError(t, err)
 
 		claims, err := ParseShareToken(jwtSecret, token)
@@ -645,7 +671,7 @@ func TestParseShareToken(t *testing.T) {
 	})
 
 	t.Run("should reject token with wrong secret", func(t *testing.T) {
-		token, err := NewShareAccessToken(jwtSecret, shareID)
+		token, err := NewShareAccessToken(jwtSecret, models.Share{ID: shareID}, "")
 		require.NoError(t, err)
 
 		_, err = ParseShareToken("wrong-secret", token)
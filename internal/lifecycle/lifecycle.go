@@ -0,0 +1,153 @@
+package lifecycle
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// lastActivity is when a file was last downloaded, or uploaded if it never was.
+const lastActivity = "COALESCE(last_downloaded_at, created_at)"
+
+// Policy is the outcome of the lifecycle rules of a bucket for one file extension.
+type Policy struct {
+	DefaultExpiryDays *int
+	MaxExpiryDays     *int
+	InactiveDays      *int
+}
+
+// ExpiresAt returns the expiration date of a file uploaded at now. Files without a requested
+// date get the default expiry, capped by the maximum one, and a requested date beyond the
+// maximum is refused rather than silently shortened.
+func (p Policy) ExpiresAt(requested *time.Time, now time.Time) (*time.Time, error) {
+	var limit *time.Time
+	if p.MaxExpiryDays != nil {
+		at := now.AddDate(0, 0, *p.MaxExpiryDays)
+		limit = &at
+	}
+
+	if requested != nil {
+		if limit != nil && requested.After(*limit) {
+			return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeFileExpiryExceedsLimit)
+		}
+		return requested, nil
+	}
+
+	if p.DefaultExpiryDays != nil {
+		at := now.AddDate(0, 0, *p.DefaultExpiryDays)
+		if limit == nil || at.Before(*limit) {
+			return &at, nil
+		}
+	}
+
+	return limit, nil
+}
+
+// Rules are the lifecycle rules of a single bucket.
+type Rules struct {
+	bucket     *models.BucketLifecycleRule
+	extensions map[string]models.BucketLifecycleRule
+}
+
+// Load returns the lifecycle rules of a bucket. A bucket without rules yields an empty policy.
+func Load(db *gorm.DB, bucketID uuid.UUID) (Rules, error) {
+	var rules []models.BucketLifecycleRule
+	if err := db.Where("bucket_id = ?", bucketID).Find(&rules).Error; err != nil {
+		return Rules{}, err
+	}
+
+	loaded := Rules{extensions: map[string]models.BucketLifecycleRule{}}
+	for i := range rules {
+		if rules[i].Extension == "" {
+			loaded.bucket = &rules[i]
+		} else {
+			loaded.extensions[rules[i].Extension] = rules[i]
+		}
+	}
+
+	return loaded, nil
+}
+
+// Resolve loads the rules of a bucket and returns the policy of the given extension.
+func Resolve(db *gorm.DB, bucketID uuid.UUID, extension string) (Policy, error) {
+	rules, err := Load(db, bucketID)
+	if err != nil {
+		return Policy{}, err
+	}
+	return rules.For(extension), nil
+}
+
+// For returns the policy of an extension: the fields set on its own rule, and the bucket rule
+// for the others.
+func (r Rules) For(extension string) Policy {
+	var policy Policy
+	if r.bucket != nil {
+		policy = Policy{
+			DefaultExpiryDays: r.bucket.DefaultExpiryDays,
+			MaxExpiryDays:     r.bucket.MaxExpiryDays,
+			InactiveDays:      r.bucket.InactiveDays,
+		}
+	}
+
+	rule, ok := r.extensions[NormalizeExtension(extension)]
+	if !ok {
+		return policy
+	}
+	if rule.DefaultExpiryDays != nil {
+		policy.DefaultExpiryDays = rule.DefaultExpiryDays
+	}
+	if rule.MaxExpiryDays != nil {
+		policy.MaxExpiryDays = rule.MaxExpiryDays
+	}
+	if rule.InactiveDays != nil {
+		policy.InactiveDays = rule.InactiveDays
+	}
+
+	return policy
+}
+
+// InactiveFiles restricts a files query to the files that were not downloaded within the
+// inactivity period of their extension. It reports false when no rule sets such a period.
+func (r Rules) InactiveFiles(query *gorm.DB, now time.Time) (*gorm.DB, bool) {
+	var conditions []string
+	var args []any
+	var overridden []string
+
+	for extension, rule := range r.extensions {
+		if rule.InactiveDays == nil {
+			continue
+		}
+		overridden = append(overridden, extension)
+		conditions = append(conditions, "(LOWER(extension) = ? AND "+lastActivity+" < ?)")
+		args = append(args, extension, now.AddDate(0, 0, -*rule.InactiveDays))
+	}
+
+	if r.bucket != nil && r.bucket.InactiveDays != nil {
+		cutoff := now.AddDate(0, 0, -*r.bucket.InactiveDays)
+		if len(overridden) == 0 {
+			conditions = append(conditions, "("+lastActivity+" < ?)")
+			args = append(args, cutoff)
+		} else {
+			conditions = append(conditions,
+				"(LOWER(COALESCE(extension, '')) NOT IN ? AND "+lastActivity+" < ?)")
+			args = append(args, overridden, cutoff)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return query, false
+	}
+
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...), true
+}
+
+// NormalizeExtension makes extension rules case insensitive.
+func NormalizeExtension(extension string) string {
+	return strings.ToLower(strings.TrimPrefix(extension, "."))
+}
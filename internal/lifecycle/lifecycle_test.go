@@ -0,0 +1,94 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func days(v int) *int { return &v }
+
+func TestPolicy_ExpiresAt(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	inDays := func(n int) *time.Time {
+		at := now.AddDate(0, 0, n)
+		return &at
+	}
+	requested := *inDays(5)
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		requested *time.Time
+		want      *time.Time
+		wantErr   bool
+	}{
+		{name: "no rules keeps the requested date", requested: &requested, want: &requested},
+		{name: "no rules and no date never expires"},
+		{
+			name:   "default applies without a requested date",
+			policy: Policy{DefaultExpiryDays: days(30)},
+			want:   inDays(30),
+		},
+		{
+			name:      "requested date wins over the default",
+			policy:    Policy{DefaultExpiryDays: days(30)},
+			requested: &requested,
+			want:      &requested,
+		},
+		{
+			name:   "maximum applies without a requested date",
+			policy: Policy{MaxExpiryDays: days(10)},
+			want:   inDays(10),
+		},
+		{
+			name:   "default is capped by the maximum",
+			policy: Policy{DefaultExpiryDays: days(30), MaxExpiryDays: days(10)},
+			want:   inDays(10),
+		},
+		{
+			name:      "requested date beyond the maximum is refused",
+			policy:    Policy{MaxExpiryDays: days(3)},
+			requested: &requested,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.ExpiresAt(tt.requested, now)
+			if tt.wantErr {
+				var apiErr *apierrors.APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, apierrors.CodeFileExpiryExceedsLimit, apiErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRules_For(t *testing.T) {
+	rules := Rules{
+		bucket: &models.BucketLifecycleRule{DefaultExpiryDays: days(30), InactiveDays: days(90)},
+		extensions: map[string]models.BucketLifecycleRule{
+			"tmp": {Extension: "tmp", DefaultExpiryDays: days(1)},
+		},
+	}
+
+	tmp := rules.For("TMP")
+	assert.Equal(t, days(1), tmp.DefaultExpiryDays)
+	assert.Equal(t, days(90), tmp.InactiveDays, "unset fields fall back to the bucket rule")
+
+	pdf := rules.For("pdf")
+	assert.Equal(t, days(30), pdf.DefaultExpiryDays)
+	assert.Nil(t, pdf.MaxExpiryDays)
+
+	assert.Equal(t, Policy{}, Rules{}.For("pdf"))
+}
@@ -2,5 +2,5 @@ package mails
 
 import "embed"
 
-//go:embed *.html
+//go:embed *.html *.txt
 var TemplatesFS embed.FS
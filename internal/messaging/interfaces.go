@@ -13,3 +13,7 @@ type ISubscriber interface {
 	Subscribe() <-chan *message.Message
 	Close() error
 }
+
+// DeliveryLimitReachedMetadataKey is set by subscribers on a message that was delivered too many
+// times without being acked or nacked, so that it is dead-lettered rather than processed again.
+const DeliveryLimitReachedMetadataKey = "delivery_limit_reached"
@@ -0,0 +1,368 @@
+package messaging
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/redis/rueidis"
+	"go.uber.org/zap"
+)
+
+const (
+	redisEnvelopeField       = "envelope"
+	redisReadBatchSize       = 1
+	redisBlockMilliseconds   = 2000
+	redisClaimMinIdle        = 15 * time.Second
+	redisClaimInterval       = 10 * time.Second
+	redisClaimBatchSize      = 10
+	redisMaxDeliveryCount    = 5
+	redisRetryInterval       = 2 * time.Second
+	redisConsumerGroupPrefix = "safebucket"
+)
+
+// RedisStreamsConnection holds the connection settings shared by the Redis and Valkey
+// flavours of the streams provider.
+type RedisStreamsConnection struct {
+	Hosts         []string
+	Username      string
+	Password      string
+	TLSEnabled    bool
+	TLSServerName string
+}
+
+func RedisStreamsConnectionFromRedis(config *models.RedisCacheConfiguration) RedisStreamsConnection {
+	return RedisStreamsConnection{
+		Hosts:         config.Hosts,
+		Username:      config.Username,
+		Password:      config.Password,
+		TLSEnabled:    config.TLSEnabled,
+		TLSServerName: config.TLSServerName,
+	}
+}
+
+func RedisStreamsConnectionFromValkey(config *models.ValkeyCacheConfiguration) RedisStreamsConnection {
+	return RedisStreamsConnection{
+		Hosts:         config.Hosts,
+		Username:      config.Username,
+		Password:      config.Password,
+		TLSEnabled:    config.TLSEnabled,
+		TLSServerName: config.TLSServerName,
+	}
+}
+
+type RedisStreamsPublisher struct {
+	streamName string
+	client     rueidis.Client
+}
+
+func NewRedisStreamsPublisher(conn RedisStreamsConnection, streamName string) IPublisher {
+	return &RedisStreamsPublisher{streamName: streamName, client: newRedisStreamsClient(conn)}
+}
+
+func (p *RedisStreamsPublisher) Publish(messages ...*message.Message) error {
+	for _, msg := range messages {
+		content, err := encodeRedisEnvelope(msg)
+		if err != nil {
+			return err
+		}
+
+		cmd := p.client.B().Xadd().Key(p.streamName).Id("*").
+			FieldValue().FieldValue(redisEnvelopeField, content).Build()
+		if pubErr := p.client.Do(context.Background(), cmd).Error(); pubErr != nil {
+			return pubErr
+		}
+	}
+
+	return nil
+}
+
+func (p *RedisStreamsPublisher) Close() error {
+	p.client.Close()
+	return nil
+}
+
+type RedisStreamsSubscriber struct {
+	streamName    string
+	group         string
+	consumer      string
+	client        rueidis.Client
+	claimMinIdle  time.Duration
+	claimInterval time.Duration
+	maxDeliveries int64
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+func NewRedisStreamsSubscriber(conn RedisStreamsConnection, streamName string) ISubscriber {
+	return newRedisStreamsSubscriber(newRedisStreamsClient(conn), streamName)
+}
+
+func newRedisStreamsSubscriber(client rueidis.Client, streamName string) *RedisStreamsSubscriber {
+	group := fmt.Sprintf("%s__%s", redisConsumerGroupPrefix, streamName)
+
+	err := client.Do(context.Background(),
+		client.B().XgroupCreate().Key(streamName).Group(group).Id("0").Mkstream().Build(),
+	).Error()
+	if err != nil && !rueidis.IsRedisBusyGroup(err) {
+		zap.L().Fatal("Failed to create stream consumer group",
+			zap.String("stream", streamName),
+			zap.String("group", group),
+			zap.Error(err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RedisStreamsSubscriber{
+		streamName:    streamName,
+		group:         group,
+		consumer:      redisConsumerName(),
+		client:        client,
+		claimMinIdle:  redisClaimMinIdle,
+		claimInterval: redisClaimInterval,
+		maxDeliveries: redisMaxDeliveryCount,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func (s *RedisStreamsSubscriber) Subscribe() <-chan *message.Message {
+	out := make(chan *message.Message)
+	go s.poll(out)
+	return out
+}
+
+func (s *RedisStreamsSubscriber) Close() error {
+	s.cancel()
+	s.client.Close()
+	return nil
+}
+
+func (s *RedisStreamsSubscriber) poll(out chan<- *message.Message) {
+	defer close(out)
+
+	lastClaim := time.Time{}
+
+	for {
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(lastClaim) >= s.claimInterval {
+			if !s.reclaim(out) {
+				return
+			}
+			lastClaim = time.Now()
+		}
+
+		cmd := s.client.B().Xreadgroup().Group(s.group, s.consumer).
+			Count(redisReadBatchSize).Block(redisBlockMilliseconds).
+			Streams().Key(s.streamName).Id(">").Build()
+
+		streams, err := s.client.Do(s.ctx, cmd).AsXRead()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			if rueidis.IsRedisNil(err) {
+				continue
+			}
+			zap.L().Error("Failed to read from stream", zap.String("stream", s.streamName), zap.Error(err))
+			s.wait(redisRetryInterval)
+			continue
+		}
+
+		for _, entry := range streams[s.streamName] {
+			if !s.dispatch(out, entry, false) {
+				return
+			}
+		}
+	}
+}
+
+// reclaim takes over entries left pending by a crashed consumer. Nacked messages never get
+// here since they are requeued right away, so an entry claimed once more than the delivery
+// limit keeps taking its consumer down and is handed over marked for the dead-letter queue.
+func (s *RedisStreamsSubscriber) reclaim(out chan<- *message.Message) bool {
+	pending, err := s.client.Do(s.ctx, s.client.B().Xpending().Key(s.streamName).Group(s.group).
+		Idle(s.claimMinIdle.Milliseconds()).Start("-").End("+").Count(redisClaimBatchSize).Build(),
+	).ToArray()
+	if err != nil {
+		if s.ctx.Err() == nil {
+			zap.L().Error("Failed to list pending stream entries", zap.String("stream", s.streamName), zap.Error(err))
+		}
+		return s.ctx.Err() == nil
+	}
+
+	deliveries := make(map[string]int64, len(pending))
+	for _, item := range pending {
+		if id, count, ok := parsePendingEntry(item); ok {
+			deliveries[id] = count
+		}
+	}
+
+	minIdle := strconv.FormatInt(s.claimMinIdle.Milliseconds(), 10)
+	result, err := s.client.Do(s.ctx, s.client.B().Xautoclaim().Key(s.streamName).Group(s.group).
+		Consumer(s.consumer).MinIdleTime(minIdle).Start("0-0").Count(redisClaimBatchSize).Build(),
+	).ToArray()
+	if err != nil || len(result) < 2 {
+		if err != nil && s.ctx.Err() == nil {
+			zap.L().Error("Failed to claim pending stream entries", zap.String("stream", s.streamName), zap.Error(err))
+		}
+		return s.ctx.Err() == nil
+	}
+
+	entries, err := result[1].AsXRange()
+	if err != nil {
+		zap.L().Error("Failed to parse claimed stream entries", zap.String("stream", s.streamName), zap.Error(err))
+		return true
+	}
+
+	for _, entry := range entries {
+		// The claim itself counts as one more delivery.
+		exhausted := deliveries[entry.ID] >= s.maxDeliveries
+		if exhausted {
+			zap.L().Warn("Stream entry reached the delivery limit",
+				zap.String("stream", s.streamName),
+				zap.String("id", entry.ID),
+				zap.Int64("delivery_count", deliveries[entry.ID]+1))
+		}
+		if !s.dispatch(out, entry, exhausted) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *RedisStreamsSubscriber) dispatch(out chan<- *message.Message, entry rueidis.XRangeEntry, exhausted bool) bool {
+	msg := buildWatermillMessage([]byte(entry.FieldValues[redisEnvelopeField]))
+	msg.SetContext(s.ctx)
+	if exhausted {
+		msg.Metadata.Set(DeliveryLimitReachedMetadataKey, "true")
+	}
+
+	select {
+	case out <- msg:
+	case <-s.ctx.Done():
+		return false
+	}
+
+	select {
+	case <-msg.Acked():
+		s.ack(entry.ID)
+	case <-msg.Nacked():
+		s.requeue(entry)
+	case <-s.ctx.Done():
+		return false
+	}
+
+	return true
+}
+
+// requeue appends a nacked entry back to the stream and acknowledges the original, so that
+// deliberate redeliveries are picked up right away and never count towards the delivery limit.
+// If the entry cannot be appended it stays pending and is reclaimed once idle.
+func (s *RedisStreamsSubscriber) requeue(entry rueidis.XRangeEntry) {
+	cmd := s.client.B().Xadd().Key(s.streamName).Id("*").
+		FieldValue().FieldValue(redisEnvelopeField, entry.FieldValues[redisEnvelopeField]).Build()
+	if err := s.client.Do(s.ctx, cmd).Error(); err != nil {
+		zap.L().Error("Failed to requeue stream entry", zap.String("stream", s.streamName), zap.Error(err))
+		return
+	}
+
+	s.ack(entry.ID)
+}
+
+func (s *RedisStreamsSubscriber) ack(id string) {
+	err := s.client.Do(s.ctx, s.client.B().Xack().Key(s.streamName).Group(s.group).Id(id).Build()).Error()
+	if err != nil {
+		zap.L().Error("Failed to acknowledge stream entry", zap.String("stream", s.streamName), zap.Error(err))
+		return
+	}
+
+	if delErr := s.client.Do(s.ctx, s.client.B().Xdel().Key(s.streamName).Id(id).Build()).Error(); delErr != nil {
+		zap.L().Warn("Failed to delete acknowledged stream entry", zap.String("stream", s.streamName), zap.Error(delErr))
+	}
+}
+
+func (s *RedisStreamsSubscriber) wait(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-s.ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func newRedisStreamsClient(conn RedisStreamsConnection) rueidis.Client {
+	clientOption := rueidis.ClientOption{
+		InitAddress:  conn.Hosts,
+		Username:     conn.Username,
+		Password:     conn.Password,
+		DisableCache: true,
+	}
+
+	if conn.TLSEnabled {
+		clientOption.TLSConfig = &tls.Config{
+			ServerName: conn.TLSServerName,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	client, err := rueidis.NewClient(clientOption)
+	if err != nil {
+		zap.L().Fatal("Failed to connect to stream server", zap.Error(err))
+	}
+
+	return client
+}
+
+func redisConsumerName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "consumer"
+	}
+	return fmt.Sprintf("%s-%s", hostname, watermill.NewShortUUID())
+}
+
+func encodeRedisEnvelope(msg *message.Message) (string, error) {
+	content, err := json.Marshal(queueEnvelope{
+		UUID:     msg.UUID,
+		Metadata: map[string]string(msg.Metadata),
+		Payload:  msg.Payload,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// parsePendingEntry reads one row of the extended XPENDING form:
+// [id, consumer, idle-ms, delivery-count].
+func parsePendingEntry(item rueidis.RedisMessage) (string, int64, bool) {
+	fields, err := item.ToArray()
+	if err != nil || len(fields) < 4 {
+		return "", 0, false
+	}
+
+	id, err := fields[0].ToString()
+	if err != nil {
+		return "", 0, false
+	}
+
+	deliveries, err := fields[3].AsInt64()
+	if err != nil {
+		return "", 0, false
+	}
+
+	return id, deliveries, true
+}
@@ -0,0 +1,201 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+)
+
+func TestRedisEnvelopeRoundTrip(t *testing.T) {
+	uuid := watermill.NewUUID()
+	original := message.NewMessage(uuid, []byte(`{"type":"BucketPurge"}`))
+	original.Metadata.Set("type", "BucketPurge")
+
+	content, err := encodeRedisEnvelope(original)
+	if err != nil {
+		t.Fatalf("encodeRedisEnvelope failed: %v", err)
+	}
+
+	decoded := buildWatermillMessage([]byte(content))
+	if decoded.UUID != uuid {
+		t.Errorf("expected UUID %s, got %s", uuid, decoded.UUID)
+	}
+	if string(decoded.Payload) != string(original.Payload) {
+		t.Errorf("expected payload %q, got %q", original.Payload, decoded.Payload)
+	}
+	if decoded.Metadata.Get("type") != "BucketPurge" {
+		t.Errorf("expected metadata type BucketPurge, got %q", decoded.Metadata.Get("type"))
+	}
+}
+
+func TestRedisConsumerNameIsUnique(t *testing.T) {
+	if redisConsumerName() == redisConsumerName() {
+		t.Error("expected distinct consumer names")
+	}
+}
+
+const testRedisStream = "safebucket-test"
+
+func newTestRedisStreams(t *testing.T) (*RedisStreamsPublisher, *RedisStreamsSubscriber) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	newClient := func() rueidis.Client {
+		client, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{server.Addr()},
+			DisableCache: true,
+		})
+		if err != nil {
+			t.Fatalf("failed to connect to miniredis: %v", err)
+		}
+		return client
+	}
+
+	pub := &RedisStreamsPublisher{streamName: testRedisStream, client: newClient()}
+	sub := newRedisStreamsSubscriber(newClient(), testRedisStream)
+	sub.claimMinIdle = 10 * time.Millisecond
+	sub.claimInterval = 0
+	t.Cleanup(func() {
+		_ = sub.Close()
+		_ = pub.Close()
+	})
+
+	return pub, sub
+}
+
+func publishTestMessage(t *testing.T, pub *RedisStreamsPublisher) string {
+	t.Helper()
+
+	uuid := watermill.NewUUID()
+	if err := pub.Publish(message.NewMessage(uuid, []byte(`{"type":"BucketPurge"}`))); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	return uuid
+}
+
+// pendingDeliveries returns the delivery count of every entry pending in the consumer group.
+func pendingDeliveries(t *testing.T, sub *RedisStreamsSubscriber) map[string]int64 {
+	t.Helper()
+
+	items, err := sub.client.Do(context.Background(), sub.client.B().Xpending().Key(sub.streamName).
+		Group(sub.group).Start("-").End("+").Count(100).Build()).ToArray()
+	if err != nil {
+		t.Fatalf("XPENDING failed: %v", err)
+	}
+
+	deliveries := make(map[string]int64, len(items))
+	for _, item := range items {
+		if id, count, ok := parsePendingEntry(item); ok {
+			deliveries[id] = count
+		}
+	}
+	return deliveries
+}
+
+// abandonEntry reads the next entry as a consumer that never acks it, as a crashed instance
+// would, then sets its delivery count and makes it look idle for a minute.
+func abandonEntry(t *testing.T, sub *RedisStreamsSubscriber, deliveries int64) {
+	t.Helper()
+
+	ctx := context.Background()
+	streams, err := sub.client.Do(ctx, sub.client.B().Xreadgroup().Group(sub.group, "crashed").
+		Count(1).Streams().Key(sub.streamName).Id(">").Build()).AsXRead()
+	if err != nil || len(streams[sub.streamName]) != 1 {
+		t.Fatalf("XREADGROUP failed: %v", err)
+	}
+
+	id := streams[sub.streamName][0].ID
+	err = sub.client.Do(ctx, sub.client.B().Xclaim().Key(sub.streamName).Group(sub.group).Consumer("crashed").
+		MinIdleTime("0").Id(id).Idle(time.Minute.Milliseconds()).Retrycount(deliveries).Build()).Error()
+	if err != nil {
+		t.Fatalf("XCLAIM failed: %v", err)
+	}
+}
+
+func waitForEmptyStream(t *testing.T, sub *RedisStreamsSubscriber) {
+	t.Helper()
+
+	deadline := time.Now().Add(testTimeout)
+	for time.Now().Before(deadline) {
+		length, err := sub.client.Do(context.Background(), sub.client.B().Xlen().Key(sub.streamName).Build()).AsInt64()
+		if err == nil && length == 0 && len(pendingDeliveries(t, sub)) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the stream to drain")
+}
+
+func TestRedisStreamsAckRemovesEntry(t *testing.T) {
+	pub, sub := newTestRedisStreams(t)
+	uuid := publishTestMessage(t, pub)
+
+	msg := receiveOne(t, sub.Subscribe())
+	if msg.UUID != uuid {
+		t.Errorf("expected UUID %s, got %s", uuid, msg.UUID)
+	}
+	msg.Ack()
+
+	waitForEmptyStream(t, sub)
+}
+
+func TestRedisStreamsNackRequeuesWithoutCountingDelivery(t *testing.T) {
+	pub, sub := newTestRedisStreams(t)
+	sub.maxDeliveries = 1
+	uuid := publishTestMessage(t, pub)
+	messages := sub.Subscribe()
+
+	for range 3 {
+		msg := receiveOne(t, messages)
+		if msg.UUID != uuid {
+			t.Fatalf("expected UUID %s, got %s", uuid, msg.UUID)
+		}
+		if msg.Metadata.Get(DeliveryLimitReachedMetadataKey) != "" {
+			t.Fatal("nacked message must not reach the delivery limit")
+		}
+		msg.Nack()
+	}
+
+	msg := receiveOne(t, messages)
+	msg.Ack()
+	waitForEmptyStream(t, sub)
+}
+
+func TestRedisStreamsReclaimsAbandonedEntries(t *testing.T) {
+	pub, sub := newTestRedisStreams(t)
+	uuid := publishTestMessage(t, pub)
+	abandonEntry(t, sub, 1)
+
+	msg := receiveOne(t, sub.Subscribe())
+	if msg.UUID != uuid {
+		t.Errorf("expected UUID %s, got %s", uuid, msg.UUID)
+	}
+	if msg.Metadata.Get(DeliveryLimitReachedMetadataKey) != "" {
+		t.Error("entry below the delivery limit must be processed normally")
+	}
+	msg.Ack()
+
+	waitForEmptyStream(t, sub)
+}
+
+func TestRedisStreamsFlagsEntriesAtDeliveryLimit(t *testing.T) {
+	pub, sub := newTestRedisStreams(t)
+	uuid := publishTestMessage(t, pub)
+	abandonEntry(t, sub, redisMaxDeliveryCount)
+
+	msg := receiveOne(t, sub.Subscribe())
+	if msg.UUID != uuid {
+		t.Errorf("expected UUID %s, got %s", uuid, msg.UUID)
+	}
+	if msg.Metadata.Get(DeliveryLimitReachedMetadataKey) == "" {
+		t.Error("expected entry to be flagged for the dead-letter queue")
+	}
+	msg.Ack()
+
+	waitForEmptyStream(t, sub)
+}
@@ -219,6 +219,22 @@ func TestAuthenticate_ExcludedPaths(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			description:    "Provider login (POST) should not require authentication",
 		},
+		{
+			name:           "Excluded path - /api/v1/auth/providers/*/acs without token (POST)",
+			path:           "/api/v1/auth/providers/corp-saml/acs",
+			method:         http.MethodPost,
+			authHeader:     "",
+			expectedStatus: http.StatusOK,
+			description:    "SAML assertion consumer service should not require authentication",
+		},
+		{
+			name:           "Excluded path - /api/v1/auth/providers/*/metadata without token",
+			path:           "/api/v1/auth/providers/corp-saml/metadata",
+			method:         http.MethodGet,
+			authHeader:     "",
+			expectedStatus: http.StatusOK,
+			description:    "SAML SP metadata should be public",
+		},
 		{
 			name:           "Excluded path - /api/v1/invites/*/challenges without token (POST)",
 			path:           "/api/v1/invites/550e8400-e29b-41d4-a716-446655440000/challenges",
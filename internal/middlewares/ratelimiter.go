@@ -1,12 +1,15 @@
 package middlewares
 
 import (
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/helpers"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/tracing"
@@ -14,24 +17,94 @@ import (
 	"go.uber.org/zap"
 )
 
+// rateLimitClass returns the class of the first rule matching the request, or "" when the
+// request uses the general budget.
+func rateLimitClass(path, method string) string {
+	for _, rule := range configuration.RateLimitRules {
+		if (rule.Method == "*" || rule.Method == method) && rule.Pattern.MatchString(path) {
+			return rule.Class
+		}
+	}
+	return ""
+}
+
+func classBudget(classes models.RateLimitClasses, class string) models.RateLimitBudget {
+	switch class {
+	case configuration.RateLimitClassAuth:
+		return classes.Auth
+	case configuration.RateLimitClassUploadInit:
+		return classes.UploadInit
+	case configuration.RateLimitClassDownloadURL:
+		return classes.DownloadURL
+	case configuration.RateLimitClassSharePublic:
+		return classes.SharePublic
+	case configuration.RateLimitClassAdmin:
+		return classes.Admin
+	default:
+		return models.RateLimitBudget{}
+	}
+}
+
+func ceilSeconds(seconds float64) string {
+	return strconv.Itoa(int(math.Ceil(seconds)))
+}
+
+func writeRateLimitHeaders(w http.ResponseWriter, status cache.RateLimitStatus) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	w.Header().Set("X-RateLimit-Reset", ceilSeconds(status.Reset.Seconds()))
+}
+
 func applyRateLimit(
 	next http.Handler,
 	w http.ResponseWriter,
 	r *http.Request,
 	c cache.ICache,
-	userIdentifier string,
-	requestsPerMinute int,
+	subject string,
+	general int,
+	classes models.RateLimitClasses,
 ) {
-	retryAfter, err := cache.GetRateLimit(c, userIdentifier, requestsPerMinute)
+	// The router accepts upper-case UUIDs, so the path is lowercased before matching the
+	// lower-case rule patterns; otherwise changing the case would skip the class and share budgets.
+	path := strings.ToLower(r.URL.Path)
+
+	identifier := subject
+	budget := models.RateLimitBudget{RequestsPerMinute: general}
+	if class := rateLimitClass(path, r.Method); class != "" {
+		if classLimit := classBudget(classes, class); classLimit.RequestsPerMinute > 0 {
+			identifier = class + ":" + subject
+			budget = classLimit
+		}
+	}
 
+	status, err := cache.ConsumeRateLimit(c, identifier, budget.RequestsPerMinute, budget.Burst)
 	if err != nil {
 		zap.L().Error("error", zap.Error(err))
 		helpers.RespondWithError(w, 500, []string{apierrors.CodeInternalServerError})
 		return
 	}
 
-	if retryAfter > 0 {
-		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	// Every client of a public share also draws from the share's own budget, so spreading
+	// requests over many addresses cannot hammer a single link. The headers report whichever
+	// budget is closer to running out.
+	match := configuration.PublicSharePathPattern.FindStringSubmatch(path)
+	if status.Allowed() && match != nil && classes.PerShare.RequestsPerMinute > 0 {
+		shareStatus, shareErr := cache.ConsumeRateLimit(
+			c, "share:"+match[1], classes.PerShare.RequestsPerMinute, classes.PerShare.Burst,
+		)
+		if shareErr != nil {
+			zap.L().Error("error", zap.Error(shareErr))
+			helpers.RespondWithError(w, 500, []string{apierrors.CodeInternalServerError})
+			return
+		}
+		if !shareStatus.Allowed() || shareStatus.Remaining < status.Remaining {
+			status = shareStatus
+		}
+	}
+
+	writeRateLimitHeaders(w, status)
+	if !status.Allowed() {
+		w.Header().Set("Retry-After", ceilSeconds(status.RetryAfter.Seconds()))
 		helpers.RespondWithError(w, http.StatusTooManyRequests, []string{apierrors.CodeRateLimitExceeded})
 		return
 	}
@@ -39,10 +112,13 @@ func applyRateLimit(
 	next.ServeHTTP(w, r)
 }
 
+// RateLimit counts requests per user, or per client IP for anonymous requests. Routes listed
+// in configuration.RateLimitRules use their class budget instead of the general one.
 func RateLimit(
 	cache cache.ICache,
 	authenticatedRequestsPerMinute int,
 	unauthenticatedRequestsPerMinute int,
+	classes models.RateLimitClasses,
 ) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
@@ -58,10 +134,10 @@ func RateLimit(
 					helpers.RespondWithErrorCtx(r.Context(), w, 500, []string{apierrors.CodeInternalServerError})
 					return
 				}
-				applyRateLimit(next, w, r, cache, info.IP, unauthenticatedRequestsPerMinute)
+				applyRateLimit(next, w, r, cache, info.IP, unauthenticatedRequestsPerMinute, classes)
 			} else {
 				userID := claims.UserID.String()
-				applyRateLimit(next, w, r, cache, userID, authenticatedRequestsPerMinute)
+				applyRateLimit(next, w, r, cache, userID, authenticatedRequestsPerMinute, classes)
 			}
 		}
 		return http.HandlerFunc(fn)
@@ -4,14 +4,44 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const (
+	testShareID  = "3f2b7c1e-9d4a-4b6e-8f1a-2c3d4e5f6a7b"
+	testShareURL = "/api/v1/shares/" + testShareID
+	testOtherID  = "8a1b2c3d-4e5f-4a6b-9c7d-0e1f2a3b4c5d"
+)
+
+func serveRateLimited(t *testing.T, handler http.Handler, method, path, ip string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(context.WithValue(req.Context(), models.ClientInfoKey{}, models.ClientInfo{IP: ip}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func newRateLimitedHandler(t *testing.T, classes models.RateLimitClasses) http.Handler {
+	t.Helper()
+
+	c := cache.NewMemoryCache()
+	t.Cleanup(func() { c.Close() })
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return RateLimit(c, 200, 20, classes)(next)
+}
+
 func TestRateLimitUnauthenticated(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -48,7 +78,7 @@ func TestRateLimitUnauthenticated(t *testing.T) {
 				w.WriteHeader(http.StatusOK)
 			})
 
-			handler := RateLimit(cache.NewMemoryCache(), 200, 20)(next)
+			handler := RateLimit(cache.NewMemoryCache(), 200, 20, models.RateLimitClasses{})(next)
 			handler.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expectedStatus, recorder.Code)
@@ -56,3 +86,112 @@ func TestRateLimitUnauthenticated(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitHeaders(t *testing.T) {
+	handler := newRateLimitedHandler(t, models.RateLimitClasses{})
+
+	recorder := serveRateLimited(t, handler, http.MethodGet, "/test", "203.0.113.7")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "20", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "19", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitClassHasOwnBudget(t *testing.T) {
+	handler := newRateLimitedHandler(t, models.RateLimitClasses{
+		Auth: models.RateLimitBudget{RequestsPerMinute: 2},
+	})
+
+	for range 2 {
+		recorder := serveRateLimited(t, handler, http.MethodPost, "/api/v1/auth/login", "203.0.113.7")
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	recorder := serveRateLimited(t, handler, http.MethodPost, "/api/v1/auth/login", "203.0.113.7")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, recorder.Header().Get("Retry-After"))
+
+	// The exhausted class does not eat into the general budget.
+	recorder = serveRateLimited(t, handler, http.MethodGet, "/api/v1/auth/providers", "203.0.113.7")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "19", recorder.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestRateLimitDisabledClassUsesGeneralBudget(t *testing.T) {
+	handler := newRateLimitedHandler(t, models.RateLimitClasses{})
+
+	recorder := serveRateLimited(t, handler, http.MethodPost, "/api/v1/auth/login", "203.0.113.7")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "20", recorder.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	handler := newRateLimitedHandler(t, models.RateLimitClasses{
+		Auth: models.RateLimitBudget{RequestsPerMinute: 20, Burst: 1},
+	})
+
+	recorder := serveRateLimited(t, handler, http.MethodPost, "/api/v1/auth/login", "203.0.113.7")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serveRateLimited(t, handler, http.MethodPost, "/api/v1/auth/login", "203.0.113.7")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitPerShare(t *testing.T) {
+	handler := newRateLimitedHandler(t, models.RateLimitClasses{
+		SharePublic: models.RateLimitBudget{RequestsPerMinute: 10},
+		PerShare:    models.RateLimitBudget{RequestsPerMinute: 2},
+	})
+
+	// Two clients share the budget of the link they both open.
+	recorder := serveRateLimited(t, handler, http.MethodGet, testShareURL, "203.0.113.7")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", recorder.Header().Get("X-RateLimit-Remaining"))
+
+	recorder = serveRateLimited(t, handler, http.MethodGet, testShareURL, "198.51.100.1")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serveRateLimited(t, handler, http.MethodGet, testShareURL, "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	recorder = serveRateLimited(t, handler, http.MethodGet, "/api/v1/shares/"+strings.ToUpper(testShareID), "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code, "an upper-case share ID draws from the same budget")
+
+	recorder = serveRateLimited(t, handler, http.MethodGet, "/api/v1/shares/"+testOtherID, "192.0.2.1")
+	assert.Equal(t, http.StatusOK, recorder.Code, "other shares keep their own budget")
+}
+
+func TestRateLimitClassRules(t *testing.T) {
+	bucketFile := "/api/v1/buckets/" + testShareID + "/files"
+	testCases := []struct {
+		method string
+		path   string
+		class  string
+	}{
+		{http.MethodPost, "/api/v1/auth/login", configuration.RateLimitClassAuth},
+		{http.MethodPost, "/api/v1/auth/mfa/verify", configuration.RateLimitClassAuth},
+		{http.MethodPost, "/api/v1/account/password", configuration.RateLimitClassAuth},
+		{http.MethodPost, testShareURL + "/otp/verify", configuration.RateLimitClassAuth},
+		{http.MethodPost, bucketFile, configuration.RateLimitClassUploadInit},
+		{http.MethodPost, testShareURL + "/files", configuration.RateLimitClassUploadInit},
+		{http.MethodGet, bucketFile + "/" + testOtherID + "/url", configuration.RateLimitClassDownloadURL},
+		{http.MethodGet, testShareURL + "/download", configuration.RateLimitClassDownloadURL},
+		{http.MethodGet, testShareURL + "/files/" + testOtherID + "/url", configuration.RateLimitClassDownloadURL},
+		{http.MethodGet, testShareURL, configuration.RateLimitClassSharePublic},
+		{http.MethodPatch, testShareURL + "/files/" + testOtherID, configuration.RateLimitClassSharePublic},
+		{http.MethodGet, "/api/v1/admin/settings", configuration.RateLimitClassAdmin},
+		{http.MethodGet, bucketFile, ""},
+		{http.MethodPost, "/api/v1/auth/refresh", ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.class, rateLimitClass(tt.path, tt.method))
+		})
+	}
+}
@@ -5,17 +5,20 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/configuration"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/helpers"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type ShareKey struct{}
 
-func ValidateShareAccess(db *gorm.DB) func(next http.Handler) http.Handler {
+func ValidateShareAccess(db *gorm.DB, activityLogger activity.IActivityLogger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ids, ok := helpers.ParseUUIDs(w, r)
@@ -31,6 +34,14 @@ func ValidateShareAccess(db *gorm.DB) func(next http.Handler) http.Handler {
 				return
 			}
 
+			share.Client, _ = r.Context().Value(models.ClientInfoKey{}).(models.ClientInfo)
+
+			if !helpers.IsShareIPAllowed(share.Client.IP, share.IPAllowlist, share.IPDenylist) {
+				logShareAccessDenied(r, activityLogger, share)
+				helpers.RespondWithError(w, http.StatusForbidden, []string{apierrors.CodeShareAccessDenied})
+				return
+			}
+
 			if share.ExpiresAt != nil && share.ExpiresAt.Before(time.Now()) {
 				helpers.RespondWithError(w, http.StatusGone, []string{apierrors.CodeShareExpired})
 				return
@@ -47,28 +58,82 @@ func ValidateShareAccess(db *gorm.DB) func(next http.Handler) http.Handler {
 	}
 }
 
-func ValidateShareToken(jwtSecret string) func(next http.Handler) http.Handler {
+func logShareAccessDenied(r *http.Request, activityLogger activity.IActivityLogger, share models.Share) {
+	if err := activityLogger.Send(models.Activity{
+		Message: activity.ShareAccessDenied,
+		Object:  share.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     activity.ShareAccessDenied,
+			ObjectType: rbac.ResourceShare.String(),
+			BucketID:   share.BucketID.String(),
+			ShareID:    share.ID.String(),
+			ClientIP:   share.Client.IP,
+		}),
+	}); err != nil {
+		GetLogger(r).Error("Failed to log share access denied activity", zap.Error(err))
+	}
+}
+
+// ValidateShareToken checks the share token of password-protected and recipient-restricted
+// shares against the current share settings, so tokens stop working as soon as the password
+// changes, the share switches mode or the recipient is removed from the list.
+func ValidateShareToken(db *gorm.DB, jwtSecret string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			share, _ := r.Context().Value(ShareKey{}).(models.Share)
 
-			if share.HashedPassword == "" {
+			if share.HashedPassword == "" && !share.RecipientRestricted {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			missingCode := apierrors.CodeShareTokenRequired
+			if share.RecipientRestricted {
+				missingCode = apierrors.CodeShareRecipientRequired
+			}
+
 			cookie, err := r.Cookie(configuration.CookieShareToken)
 			if err != nil {
-				helpers.RespondWithError(w, http.StatusUnauthorized, []string{apierrors.CodeShareTokenRequired})
+				helpers.RespondWithError(w, http.StatusUnauthorized, []string{missingCode})
 				return
 			}
 
 			claims, err := helpers.ParseShareToken(jwtSecret, cookie.Value)
-			if err != nil || claims.ShareID != share.ID {
+			if err != nil || claims.ShareID != share.ID ||
+				claims.Fingerprint != helpers.ShareAccessFingerprint(share) {
+				helpers.RespondWithError(w, http.StatusUnauthorized, []string{apierrors.CodeShareTokenInvalid})
+				return
+			}
+
+			if !share.RecipientRestricted {
+				if claims.Email != "" {
+					helpers.RespondWithError(w, http.StatusUnauthorized, []string{apierrors.CodeShareTokenInvalid})
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if claims.Email == "" {
+				helpers.RespondWithError(w, http.StatusUnauthorized, []string{missingCode})
+				return
+			}
+
+			var recipients []models.ShareRecipient
+			if err = db.Where("share_id = ?", share.ID).Find(&recipients).Error; err != nil {
+				GetLogger(r).Error("Failed to list share recipients", zap.Error(err))
+				helpers.RespondWithError(w, http.StatusInternalServerError, []string{apierrors.CodeInternalServerError})
+				return
+			}
+
+			if !helpers.MatchShareRecipient(recipients, claims.Email) {
 				helpers.RespondWithError(w, http.StatusUnauthorized, []string{apierrors.CodeShareTokenInvalid})
 				return
 			}
 
+			share.VerifiedRecipient = claims.Email
+			r = r.WithContext(context.WithValue(r.Context(), ShareKey{}, share))
+
 			next.ServeHTTP(w, r)
 		})
 	}
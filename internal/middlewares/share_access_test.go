@@ -0,0 +1,117 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/database"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const shareTestJWTSecret = "test-secret-key-for-share-testing"
+
+func setupShareTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	database.RunMigrations(sqlDB, database.DialectSQLite)
+	database.RegisterCallbacks(db)
+
+	return db
+}
+
+func serveShareToken(db *gorm.DB, share models.Share, token string) *httptest.ResponseRecorder {
+	handler := ValidateShareToken(db, shareTestJWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shared, _ := r.Context().Value(ShareKey{}).(models.Share)
+		w.Header().Set("X-Verified-Recipient", shared.VerifiedRecipient)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ShareKey{}, share))
+	if token != "" {
+		req.AddCookie(&http.Cookie{Name: configuration.CookieShareToken, Value: token})
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestValidateShareToken_PasswordChangeRevokesTokens(t *testing.T) {
+	share := models.Share{ID: uuid.New(), HashedPassword: "$argon2id$v=19$m=65536,t=3,p=2$first$hash"}
+	token, err := helpers.NewShareAccessToken(shareTestJWTSecret, share, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serveShareToken(nil, share, token).Code)
+
+	share.HashedPassword = "$argon2id$v=19$m=65536,t=3,p=2$second$hash"
+	rr := serveShareToken(nil, share, token)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), apierrors.CodeShareTokenInvalid)
+}
+
+func TestValidateShareToken_RecipientChanges(t *testing.T) {
+	db := setupShareTestDB(t)
+
+	owner := models.User{Email: "owner@example.com", Role: models.RoleUser, ProviderType: models.LocalProviderType}
+	require.NoError(t, db.Create(&owner).Error)
+	bucket := models.Bucket{Name: "shared", CreatedBy: owner.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+	share := models.Share{
+		Name:                "recipients",
+		BucketID:            bucket.ID,
+		Type:                models.ShareTypeBucket,
+		CreatedBy:           owner.ID,
+		RecipientRestricted: true,
+	}
+	require.NoError(t, db.Create(&share).Error)
+	require.NoError(t, db.Create(&[]models.ShareRecipient{
+		{ShareID: share.ID, Value: "alice@example.com"},
+		{ShareID: share.ID, Value: "partner.com"},
+	}).Error)
+
+	alice, err := helpers.NewShareAccessToken(shareTestJWTSecret, share, "alice@example.com")
+	require.NoError(t, err)
+	bob, err := helpers.NewShareAccessToken(shareTestJWTSecret, share, "bob@partner.com")
+	require.NoError(t, err)
+
+	rr := serveShareToken(db, share, alice)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "alice@example.com", rr.Header().Get("X-Verified-Recipient"))
+	assert.Equal(t, http.StatusOK, serveShareToken(db, share, bob).Code)
+
+	t.Run("removed recipients lose access", func(t *testing.T) {
+		require.NoError(t, db.Where("share_id = ? AND value = ?", share.ID, "alice@example.com").
+			Delete(&models.ShareRecipient{}).Error)
+
+		rr = serveShareToken(db, share, alice)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), apierrors.CodeShareTokenInvalid)
+		assert.Equal(t, http.StatusOK, serveShareToken(db, share, bob).Code)
+	})
+
+	t.Run("recipient tokens stop working once the share uses a password", func(t *testing.T) {
+		passwordShare := share
+		passwordShare.RecipientRestricted = false
+		passwordShare.HashedPassword = "$argon2id$v=19$m=65536,t=3,p=2$salt$hash"
+
+		rr = serveShareToken(db, passwordShare, bob)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), apierrors.CodeShareTokenInvalid)
+	})
+}
@@ -35,6 +35,8 @@ type ActivityFields struct {
 	InviteID          string `json:"invite_id"           bleve:"keyword"`
 	AttemptsLeft      string `json:"attempts_left"       bleve:"keyword"`
 	SessionID         string `json:"session_id"          bleve:"keyword"`
+	RecipientEmail    string `json:"recipient_email"     bleve:"keyword"`
+	ClientIP          string `json:"client_ip"           bleve:"keyword"`
 }
 
 // ToMap converts non-empty fields to a map keyed by their json tag.
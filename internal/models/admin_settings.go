@@ -24,6 +24,7 @@ type AppSettings struct {
 	StaticFilesEnabled    bool   `json:"static_files_enabled"`
 	MaxUploadSize         int64  `json:"max_upload_size"`
 	TrashRetentionDays    int    `json:"trash_retention_days"`
+	InviteExpiryDays      int    `json:"invite_expiry_days"`
 	AllowRedirectDownload bool   `json:"allow_redirect_download"`
 	TLSEnabled            bool   `json:"tls_enabled"`
 }
@@ -38,20 +39,24 @@ const (
 )
 
 type WorkerSettings struct {
-	HTTPServer       CoverageStatus `json:"http_server"`
-	ObjectDeletion   CoverageStatus `json:"object_deletion"`
-	BucketEvents     CoverageStatus `json:"bucket_events"`
-	TrashCleanup     CoverageStatus `json:"trash_cleanup"`
-	GarbageCollector CoverageStatus `json:"garbage_collector"`
+	HTTPServer         CoverageStatus `json:"http_server"`
+	ObjectDeletion     CoverageStatus `json:"object_deletion"`
+	BucketEvents       CoverageStatus `json:"bucket_events"`
+	TrashCleanup       CoverageStatus `json:"trash_cleanup"`
+	GarbageCollector   CoverageStatus `json:"garbage_collector"`
+	OutboxRelay        CoverageStatus `json:"outbox_relay"`
+	NotificationDigest CoverageStatus `json:"notification_digest"`
+	NotificationRetry  CoverageStatus `json:"notification_retry"`
 }
 
 type DatabaseSettings struct {
-	Type    string `json:"type"`
-	Host    string `json:"host,omitempty"`
-	Port    int32  `json:"port,omitempty"`
-	Name    string `json:"name,omitempty"`
-	SSLMode string `json:"sslmode,omitempty"`
-	Path    string `json:"path,omitempty"`
+	Type     string   `json:"type"`
+	Host     string   `json:"host,omitempty"`
+	Port     int32    `json:"port,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	SSLMode  string   `json:"sslmode,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Replicas []string `json:"replicas,omitempty"`
 }
 
 type CacheSettings struct {
@@ -79,6 +84,7 @@ type EventsSettings struct {
 	Port               string   `json:"port,omitempty"`
 	ProjectID          string   `json:"project_id,omitempty"`
 	SubscriptionSuffix string   `json:"subscription_suffix,omitempty"`
+	Hosts              []string `json:"hosts,omitempty"`
 }
 
 type NotifierSettings struct {
@@ -102,19 +108,21 @@ type AuthSettings struct {
 }
 
 type AuthProviderSettings struct {
-	Key             string   `json:"key"`
-	Name            string   `json:"name,omitempty"`
-	Type            string   `json:"type"`
-	Domains         []string `json:"domains,omitempty"`
-	MFARequired     bool     `json:"mfa_required"`
-	SharingAllowed  bool     `json:"sharing_allowed"`
-	Issuer          string   `json:"issuer,omitempty"`
-	URL             string   `json:"url,omitempty"`
-	BaseDN          string   `json:"base_dn,omitempty"`
-	UserFilter      string   `json:"user_filter,omitempty"`
-	StartTLS        *bool    `json:"start_tls,omitempty"`
-	TLSInsecureSkip *bool    `json:"tls_insecure_skip,omitempty"`
-	AttributeEmail  string   `json:"attribute_email,omitempty"`
+	Key              string   `json:"key"`
+	Name             string   `json:"name,omitempty"`
+	Type             string   `json:"type"`
+	Domains          []string `json:"domains,omitempty"`
+	MFARequired      bool     `json:"mfa_required"`
+	SharingAllowed   bool     `json:"sharing_allowed"`
+	ShareIPAllowlist []string `json:"share_ip_allowlist,omitempty"`
+	ShareIPDenylist  []string `json:"share_ip_denylist,omitempty"`
+	Issuer           string   `json:"issuer,omitempty"`
+	URL              string   `json:"url,omitempty"`
+	BaseDN           string   `json:"base_dn,omitempty"`
+	UserFilter       string   `json:"user_filter,omitempty"`
+	StartTLS         *bool    `json:"start_tls,omitempty"`
+	TLSInsecureSkip  *bool    `json:"tls_insecure_skip,omitempty"`
+	AttributeEmail   string   `json:"attribute_email,omitempty"`
 }
 
 type ObservabilitySettings struct {
@@ -138,13 +146,33 @@ type TracingSettings struct {
 	SamplingRate float64 `json:"sampling_rate,omitempty"`
 }
 
+type RateLimitBudgetSettings struct {
+	RequestsPerMinute int `json:"requests_per_minute"`
+	Burst             int `json:"burst"`
+}
+
+type RateLimitSettings struct {
+	Auth        RateLimitBudgetSettings `json:"auth"`
+	UploadInit  RateLimitBudgetSettings `json:"upload_init"`
+	DownloadURL RateLimitBudgetSettings `json:"download_url"`
+	SharePublic RateLimitBudgetSettings `json:"share_public"`
+	Admin       RateLimitBudgetSettings `json:"admin"`
+	PerShare    RateLimitBudgetSettings `json:"per_share"`
+}
+
 type SecuritySettings struct {
-	AuthenticatedRequestsPerMinute   int      `json:"authenticated_requests_per_minute"`
-	UnauthenticatedRequestsPerMinute int      `json:"unauthenticated_requests_per_minute"`
-	AccessTokenExpiry                int      `json:"access_token_expiry"`
-	RefreshTokenExpiry               int      `json:"refresh_token_expiry"`
-	MFATokenExpiry                   int      `json:"mfa_token_expiry"`
-	TrustedProxies                   []string `json:"trusted_proxies,omitempty"`
-	AllowedOrigins                   []string `json:"allowed_origins,omitempty"`
-	CookieSecureForce                bool     `json:"cookie_secure_force"`
+	AuthenticatedRequestsPerMinute   int               `json:"authenticated_requests_per_minute"`
+	UnauthenticatedRequestsPerMinute int               `json:"unauthenticated_requests_per_minute"`
+	RateLimits                       RateLimitSettings `json:"rate_limits"`
+	AccessTokenExpiry                int               `json:"access_token_expiry"`
+	RefreshTokenExpiry               int               `json:"refresh_token_expiry"`
+	MFATokenExpiry                   int               `json:"mfa_token_expiry"`
+	TrustedProxies                   []string          `json:"trusted_proxies,omitempty"`
+	AllowedOrigins                   []string          `json:"allowed_origins,omitempty"`
+	CookieSecureForce                bool              `json:"cookie_secure_force"`
+	PasswordMinLength                int               `json:"password_min_length"`
+	PasswordCharacterClasses         []string          `json:"password_character_classes,omitempty"`
+	PasswordDisallowEmail            bool              `json:"password_disallow_email"`
+	PasswordHistorySize              int               `json:"password_history_size"`
+	BreachedPasswordCheck            bool              `json:"breached_password_check"`
 }
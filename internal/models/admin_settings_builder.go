@@ -1,6 +1,10 @@
 package models
 
-import "sort"
+import (
+	"net"
+	"sort"
+	"strconv"
+)
 
 func NewAdminSettingsResponse(
 	cfg Configuration,
@@ -33,6 +37,7 @@ func buildAppSettings(app AppConfiguration) AppSettings {
 		StaticFilesEnabled:    app.StaticFiles.Enabled,
 		MaxUploadSize:         app.MaxUploadSize,
 		TrashRetentionDays:    app.TrashRetentionDays,
+		InviteExpiryDays:      app.InviteExpiryDays,
 		AllowRedirectDownload: app.AllowRedirectDownload,
 		TLSEnabled:            app.TLSCertFile != "" && app.TLSKeyFile != "",
 	}
@@ -48,11 +53,25 @@ func buildDatabaseSettings(db DatabaseConfiguration) DatabaseSettings {
 			settings.Port = db.Postgres.Port
 			settings.Name = db.Postgres.Name
 			settings.SSLMode = db.Postgres.SSLMode
+			for _, replica := range db.Postgres.Replicas {
+				port := replica.Port
+				if port == 0 {
+					port = db.Postgres.Port
+				}
+				settings.Replicas = append(settings.Replicas, net.JoinHostPort(replica.Host, strconv.Itoa(int(port))))
+			}
 		}
 	case "sqlite":
 		if db.SQLite != nil {
 			settings.Path = db.SQLite.Path
 		}
+	case "mysql":
+		if db.MySQL != nil {
+			settings.Host = db.MySQL.Host
+			settings.Port = db.MySQL.Port
+			settings.Name = db.MySQL.Name
+			settings.SSLMode = db.MySQL.TLS
+		}
 	}
 
 	return settings
@@ -145,6 +164,14 @@ func buildEventsSettings(events EventsConfiguration) EventsSettings {
 			settings.ProjectID = events.PubSub.ProjectID
 			settings.SubscriptionSuffix = events.PubSub.SubscriptionSuffix
 		}
+	case "redis":
+		if events.Redis != nil {
+			settings.Hosts = events.Redis.Hosts
+		}
+	case "valkey":
+		if events.Valkey != nil {
+			settings.Hosts = events.Valkey.Hosts
+		}
 	}
 
 	return settings
@@ -199,12 +226,14 @@ func buildAuthSettings(auth AuthConfiguration) AuthSettings {
 	for _, key := range keys {
 		provider := auth.Providers[key]
 		settings := AuthProviderSettings{
-			Key:            key,
-			Name:           provider.Name,
-			Type:           string(provider.Type),
-			Domains:        provider.Domains,
-			MFARequired:    provider.MFARequired,
-			SharingAllowed: provider.SharingConfiguration.Allowed,
+			Key:              key,
+			Name:             provider.Name,
+			Type:             string(provider.Type),
+			Domains:          provider.Domains,
+			MFARequired:      provider.MFARequired,
+			SharingAllowed:   provider.SharingConfiguration.Allowed,
+			ShareIPAllowlist: provider.SharingConfiguration.IPAllowlist,
+			ShareIPDenylist:  provider.SharingConfiguration.IPDenylist,
 		}
 
 		switch provider.Type {
@@ -219,6 +248,11 @@ func buildAuthSettings(auth AuthConfiguration) AuthSettings {
 				settings.TLSInsecureSkip = boolPtr(provider.LDAP.TLSInsecureSkip)
 				settings.AttributeEmail = provider.LDAP.AttributeMap.Email
 			}
+		case SAMLProviderType:
+			if provider.SAML != nil {
+				settings.URL = provider.SAML.IDPMetadataURL
+				settings.AttributeEmail = provider.SAML.AttributeMap.Email
+			}
 		case LocalProviderType:
 		}
 
@@ -256,13 +290,43 @@ func buildSecuritySettings(app AppConfiguration) SecuritySettings {
 	return SecuritySettings{
 		AuthenticatedRequestsPerMinute:   app.AuthenticatedRequestsPerMinute,
 		UnauthenticatedRequestsPerMinute: app.UnauthenticatedRequestsPerMinute,
-		AccessTokenExpiry:                app.AccessTokenExpiry,
-		RefreshTokenExpiry:               app.RefreshTokenExpiry,
-		MFATokenExpiry:                   app.MFATokenExpiry,
-		TrustedProxies:                   app.TrustedProxies,
-		AllowedOrigins:                   app.AllowedOrigins,
-		CookieSecureForce:                app.CookieSecureForce,
+		RateLimits: RateLimitSettings{
+			Auth:        RateLimitBudgetSettings(app.RateLimits.Auth),
+			UploadInit:  RateLimitBudgetSettings(app.RateLimits.UploadInit),
+			DownloadURL: RateLimitBudgetSettings(app.RateLimits.DownloadURL),
+			SharePublic: RateLimitBudgetSettings(app.RateLimits.SharePublic),
+			Admin:       RateLimitBudgetSettings(app.RateLimits.Admin),
+			PerShare:    RateLimitBudgetSettings(app.RateLimits.PerShare),
+		},
+		AccessTokenExpiry:        app.AccessTokenExpiry,
+		RefreshTokenExpiry:       app.RefreshTokenExpiry,
+		MFATokenExpiry:           app.MFATokenExpiry,
+		TrustedProxies:           app.TrustedProxies,
+		AllowedOrigins:           app.AllowedOrigins,
+		CookieSecureForce:        app.CookieSecureForce,
+		PasswordMinLength:        app.PasswordPolicy.MinLength,
+		PasswordCharacterClasses: passwordCharacterClasses(app.PasswordPolicy),
+		PasswordDisallowEmail:    app.PasswordPolicy.DisallowEmail,
+		PasswordHistorySize:      app.PasswordPolicy.HistorySize,
+		BreachedPasswordCheck:    app.PasswordPolicy.BreachedPasswordsFile != "",
+	}
+}
+
+func passwordCharacterClasses(policy PasswordPolicy) []string {
+	var classes []string
+	if policy.RequireUppercase {
+		classes = append(classes, "uppercase")
+	}
+	if policy.RequireLowercase {
+		classes = append(classes, "lowercase")
+	}
+	if policy.RequireDigit {
+		classes = append(classes, "digit")
+	}
+	if policy.RequireSymbol {
+		classes = append(classes, "symbol")
 	}
+	return classes
 }
 
 func boolPtr(b bool) *bool {
@@ -8,8 +8,15 @@ const (
 	LocalProviderType ProviderType = "local"
 	OIDCProviderType  ProviderType = "oidc"
 	LDAPProviderType  ProviderType = "ldap"
+	SAMLProviderType  ProviderType = "saml"
 )
 
+// IsRedirectFlow reports whether users of the provider type sign in at an external identity
+// provider, so their password never reaches Safebucket.
+func (t ProviderType) IsRedirectFlow() bool {
+	return t == OIDCProviderType || t == SAMLProviderType
+}
+
 type AuthLoginBody struct {
 	Email    string `json:"email"    validate:"required,email,max=254"`
 	Password string `json:"password" validate:"required,max=72"`
@@ -7,15 +7,34 @@ import (
 	"gorm.io/gorm"
 )
 
+// SSEMode selects how the storage provider encrypts the objects of a bucket at rest. Buckets
+// without a mode rely on the default encryption of the provider.
+type SSEMode string
+
+const (
+	// SSEModeKMS encrypts objects with a key held by the provider: an SSE-KMS key on AWS and
+	// S3-compatible stores, a Cloud KMS key on GCP or an encryption scope on Azure.
+	SSEModeKMS SSEMode = "kms"
+	// SSEModeCustomer encrypts objects with an SSE-C key the provider never stores. Safebucket
+	// keeps it sealed with the storage encryption key and sends it with every request.
+	SSEModeCustomer SSEMode = "customer"
+)
+
 type Bucket struct {
-	ID        uuid.UUID      `gorm:"default:(-)"           json:"id"`
-	Name      string         `gorm:"not null;default:null" json:"name"       validate:"required"`
-	Files     []File         `                             json:"files"`
-	Folders   []Folder       `                             json:"folders"`
-	CreatedAt time.Time      `                             json:"created_at"`
-	CreatedBy uuid.UUID      `gorm:"not null"              json:"-"`
-	UpdatedAt time.Time      `                             json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index"                 json:"deleted_at"`
+	ID                uuid.UUID      `gorm:"default:(-)"            json:"id"`
+	Name              string         `gorm:"not null;default:null"  json:"name"                          validate:"required"`
+	Files             []File         `                              json:"files"`
+	Folders           []Folder       `                              json:"folders"`
+	RetentionPolicyID *uuid.UUID     `gorm:"default:null"           json:"retention_policy_id,omitempty"`
+	Encrypted         bool           `gorm:"not null;default:false" json:"encrypted"`
+	KeyVersion        int            `gorm:"not null;default:1"     json:"key_version"`
+	SSEMode           SSEMode        `gorm:"default:null"           json:"sse_mode,omitempty"`
+	SSEKeyID          string         `gorm:"default:null"           json:"sse_key_id,omitempty"`
+	SSECustomerKey    string         `gorm:"default:null"           json:"-"`
+	CreatedAt         time.Time      `                              json:"created_at"`
+	CreatedBy         uuid.UUID      `gorm:"not null"               json:"-"`
+	UpdatedAt         time.Time      `                              json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index"                  json:"deleted_at"`
 }
 
 type BucketActivity struct {
@@ -34,6 +53,23 @@ type BucketCreateUpdateBody struct {
 	Name string `json:"name" validate:"required,max=100"`
 }
 
+// BucketCreateBody creates a bucket. Encryption settings are only accepted here: a bucket cannot
+// switch between plaintext and client-side encrypted storage, or change the key its objects are
+// stored with, once it holds files.
+type BucketCreateBody struct {
+	Name                 string                `json:"name"                   validate:"required,max=100"`
+	Encryption           *BucketEncryptionBody `json:"encryption"             validate:"omitempty"`
+	ServerSideEncryption *BucketSSEBody        `json:"server_side_encryption" validate:"omitempty"`
+}
+
+// BucketSSEBody selects the customer-managed key the storage provider encrypts the objects of a
+// new bucket with. CustomerKey is a base64-encoded 256-bit SSE-C key.
+type BucketSSEBody struct {
+	Mode        SSEMode `json:"mode"         validate:"required,oneof=kms customer"`
+	KeyID       string  `json:"key_id"       validate:"required_if=Mode kms,excluded_unless=Mode kms,max=2048"`
+	CustomerKey string  `json:"customer_key" validate:"required_if=Mode customer,excluded_unless=Mode customer,omitempty,base64"`
+}
+
 type AdminBucketListItem struct {
 	ID          uuid.UUID    `json:"id"`
 	Name        string       `json:"name"`
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChannelType string
+
+const (
+	ChannelTypeSlack      ChannelType = "slack"
+	ChannelTypeTeams      ChannelType = "teams"
+	ChannelTypeMattermost ChannelType = "mattermost"
+)
+
+// BucketChannel routes bucket notifications to a chat incoming webhook.
+type BucketChannel struct {
+	ID              uuid.UUID   `gorm:"default:(-)"    json:"id"`
+	BucketID        uuid.UUID   `gorm:"not null;index" json:"bucket_id"`
+	Type            ChannelType `gorm:"not null"       json:"type"`
+	Name            string      `gorm:"not null"       json:"name"`
+	WebhookURL      string      `gorm:"not null"       json:"-"`
+	WebhookHost     string      `gorm:"-"              json:"webhook_host"`
+	NotifyUploads   bool        `gorm:"not null"       json:"notify_uploads"`
+	NotifyDownloads bool        `gorm:"not null"       json:"notify_downloads"`
+	NotifyShares    bool        `gorm:"not null"       json:"notify_shares"`
+	CreatedBy       *uuid.UUID  `gorm:"default:null"   json:"created_by,omitempty"`
+	CreatedAt       time.Time   `                      json:"created_at"`
+	UpdatedAt       time.Time   `                      json:"updated_at"`
+}
+
+// Redacted hides the webhook URL, which embeds the credential, keeping only its host so
+// owners can still tell channels apart.
+func (c BucketChannel) Redacted() BucketChannel {
+	if u, err := url.Parse(c.WebhookURL); err == nil {
+		c.WebhookHost = u.Host
+	}
+	c.WebhookURL = ""
+	return c
+}
+
+type BucketChannelCreateBody struct {
+	Name            string      `json:"name"             validate:"required,max=100"`
+	Type            ChannelType `json:"type"             validate:"required,oneof=slack teams mattermost"`
+	WebhookURL      string      `json:"webhook_url"      validate:"required,http_url,max=2048"`
+	NotifyUploads   bool        `json:"notify_uploads"`
+	NotifyDownloads bool        `json:"notify_downloads"`
+	NotifyShares    bool        `json:"notify_shares"`
+}
+
+type BucketChannelUpdateBody struct {
+	Name            *string `json:"name"             validate:"omitempty,max=100"`
+	WebhookURL      *string `json:"webhook_url"      validate:"omitempty,http_url,max=2048"`
+	NotifyUploads   *bool   `json:"notify_uploads"   validate:"omitempty,boolean"`
+	NotifyDownloads *bool   `json:"notify_downloads" validate:"omitempty,boolean"`
+	NotifyShares    *bool   `json:"notify_shares"    validate:"omitempty,boolean"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type BucketMemberBody struct {
 	Email string `json:"email" validate:"required,email,max=254"`
@@ -12,14 +16,21 @@ type UpdateMembersBody struct {
 }
 
 type BucketMember struct {
-	UserID                uuid.UUID `json:"user_id,omitempty"`
-	Email                 string    `json:"email"                  validate:"required"`
-	FirstName             string    `json:"first_name"`
-	LastName              string    `json:"last_name"`
-	Group                 Group     `json:"group"                  validate:"required,oneof=owner contributor viewer"`
-	Status                string    `json:"status"                 validate:"required,oneof=active invited"`
-	UploadNotifications   bool      `json:"upload_notifications"`
-	DownloadNotifications bool      `json:"download_notifications"`
+	UserID                uuid.UUID            `json:"user_id,omitempty"`
+	InviteID              *uuid.UUID           `json:"invite_id,omitempty"`
+	Email                 string               `json:"email"                  validate:"required"`
+	FirstName             string               `json:"first_name"`
+	LastName              string               `json:"last_name"`
+	Group                 Group                `json:"group"                  validate:"required,oneof=owner contributor viewer"`
+	Status                string               `json:"status"                 validate:"required,oneof=active invited"`
+	UploadNotifications   bool                 `json:"upload_notifications"`
+	DownloadNotifications bool                 `json:"download_notifications"`
+	DeleteNotifications   bool                 `json:"delete_notifications"`
+	RestoreNotifications  bool                 `json:"restore_notifications"`
+	ShareNotifications    bool                 `json:"share_notifications"`
+	MemberNotifications   bool                 `json:"member_notifications"`
+	NotificationDelivery  NotificationDelivery `json:"notification_delivery,omitempty"`
+	ExpiresAt             *time.Time           `json:"expires_at,omitempty"`
 }
 
 type BucketMemberToUpdate struct {
@@ -11,11 +11,12 @@ type ChallengeType string
 const (
 	ChallengeTypeInvite        ChallengeType = "invite"
 	ChallengeTypePasswordReset ChallengeType = "password_reset"
+	ChallengeTypeShareAccess   ChallengeType = "share_access"
 )
 
 type Challenge struct {
 	ID           uuid.UUID     `gorm:"default:(-)"                                                      json:"id"`
-	Type         ChallengeType `gorm:"not null;index:idx_challenge_type"                                json:"type"                 validate:"required,oneof=invite password_reset"`
+	Type         ChallengeType `gorm:"not null;index:idx_challenge_type"                                json:"type"                 validate:"required,oneof=invite password_reset share_access"`
 	HashedSecret string        `gorm:"not null;default:null"                                            json:"hashed_secret"        validate:"required"`
 	AttemptsLeft int           `gorm:"not null;default:3"                                               json:"attempts_left"`
 	ExpiresAt    *time.Time    `gorm:"index"                                                            json:"expires_at,omitempty"`
@@ -25,4 +26,7 @@ type Challenge struct {
 	Invite       *Invite       `gorm:"foreignKey:InviteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"invite,omitempty"`
 	UserID       *uuid.UUID    `gorm:"index:idx_challenge_user,unique"                                  json:"user_id,omitempty"`
 	User         *User         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"   json:"user,omitempty"`
+	ShareID      *uuid.UUID    `gorm:"index:idx_challenge_share"                                        json:"share_id,omitempty"`
+	Share        *Share        `gorm:"foreignKey:ShareID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"  json:"share,omitempty"`
+	Email        *string       `gorm:"index:idx_challenge_share"                                        json:"email,omitempty"`
 }
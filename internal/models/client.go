@@ -1,8 +1,17 @@
 package models
 
+import "context"
+
 type ClientInfoKey struct{}
 
 type ClientInfo struct {
 	IP        string
 	UserAgent string
 }
+
+// ClientInfoFromContext returns the client details set by the ClientInfo middleware, or the zero
+// value when the request did not go through it.
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(ClientInfoKey{}).(ClientInfo)
+	return info
+}
@@ -28,10 +28,12 @@ type AppConfiguration struct {
 	Profile                          string                 `mapstructure:"profile"                             validate:"oneof=default api worker"`
 	AdminEmail                       string                 `mapstructure:"admin_email"                         validate:"required,email"`
 	AdminPassword                    string                 `mapstructure:"admin_password"                      validate:"required"`
+	PasswordPolicy                   PasswordPolicy         `mapstructure:"password_policy"`
 	APIURL                           string                 `mapstructure:"api_url"                             validate:"required"`
 	AllowedOrigins                   []string               `mapstructure:"allowed_origins"                     validate:"required"`
 	TokenSecret                      string                 `mapstructure:"token_secret"                        validate:"required"`
 	MFAEncryptionKey                 string                 `mapstructure:"mfa_encryption_key"                  validate:"len=32"`
+	StorageEncryptionKey             string                 `mapstructure:"storage_encryption_key"              validate:"omitempty,len=32"`
 	AccessTokenExpiry                int                    `mapstructure:"access_token_expiry"                 validate:"gte=1,lte=1440"`
 	RefreshTokenExpiry               int                    `mapstructure:"refresh_token_expiry"                validate:"gte=1,lte=720"`
 	MFATokenExpiry                   int                    `mapstructure:"mfa_token_expiry"                    validate:"gte=1,lte=30"`
@@ -41,9 +43,12 @@ type AppConfiguration struct {
 	TrustedProxies                   []string               `mapstructure:"trusted_proxies"                     validate:"omitempty,dive,cidr"`
 	WebURL                           string                 `mapstructure:"web_url"                             validate:"required"`
 	TrashRetentionDays               int                    `mapstructure:"trash_retention_days"                validate:"gte=1,lte=365"`
+	InviteExpiryDays                 int                    `mapstructure:"invite_expiry_days"                  validate:"gte=1,lte=365"`
+	FileExpiryWarningDays            int                    `mapstructure:"file_expiry_warning_days"            validate:"gte=0,lte=30"`
 	MaxUploadSize                    int64                  `mapstructure:"max_upload_size"                     validate:"gte=1"`
 	AuthenticatedRequestsPerMinute   int                    `mapstructure:"authenticated_requests_per_minute"   validate:"gte=1"`
 	UnauthenticatedRequestsPerMinute int                    `mapstructure:"unauthenticated_requests_per_minute" validate:"gte=1"`
+	RateLimits                       RateLimitClasses       `mapstructure:"rate_limits"`
 	TLSCertFile                      string                 `mapstructure:"tls_cert_file"                       validate:"required_with=TLSKeyFile"`
 	TLSKeyFile                       string                 `mapstructure:"tls_key_file"                        validate:"required_with=TLSCertFile"`
 	CookieSecureForce                bool                   `mapstructure:"cookie_secure_force"`
@@ -52,6 +57,39 @@ type AppConfiguration struct {
 	Profiling                        ProfilingConfiguration `mapstructure:"profiling"`
 }
 
+// PasswordPolicy holds the rules applied to passwords chosen by local users. HistorySize counts
+// the current password, so 1 only forbids keeping the same password. BreachedPasswordsFile
+// points to a HIBP-style list of SHA-1 hashes ("HASH" or "HASH:COUNT" per line).
+type PasswordPolicy struct {
+	MinLength             int    `mapstructure:"min_length"              validate:"gte=8,lte=72"`
+	RequireUppercase      bool   `mapstructure:"require_uppercase"`
+	RequireLowercase      bool   `mapstructure:"require_lowercase"`
+	RequireDigit          bool   `mapstructure:"require_digit"`
+	RequireSymbol         bool   `mapstructure:"require_symbol"`
+	DisallowEmail         bool   `mapstructure:"disallow_email"`
+	HistorySize           int    `mapstructure:"history_size"            validate:"gte=0,lte=24"`
+	BreachedPasswordsFile string `mapstructure:"breached_passwords_file"`
+}
+
+// RateLimitBudget is a per-minute request budget. Burst caps how many of those requests are
+// accepted within a single second, 0 leaving the minute budget unconstrained. A budget of 0
+// requests per minute disables the class and its routes count against the general budget.
+type RateLimitBudget struct {
+	RequestsPerMinute int `mapstructure:"requests_per_minute" validate:"gte=0"`
+	Burst             int `mapstructure:"burst"               validate:"gte=0"`
+}
+
+// RateLimitClasses holds the budgets of the routes listed in configuration.RateLimitRules.
+// PerShare is shared by every client of a public share, on top of their own budget.
+type RateLimitClasses struct {
+	Auth        RateLimitBudget `mapstructure:"auth"`
+	UploadInit  RateLimitBudget `mapstructure:"upload_init"`
+	DownloadURL RateLimitBudget `mapstructure:"download_url"`
+	SharePublic RateLimitBudget `mapstructure:"share_public"`
+	Admin       RateLimitBudget `mapstructure:"admin"`
+	PerShare    RateLimitBudget `mapstructure:"per_share"`
+}
+
 type ProfilingConfiguration struct {
 	Enabled   bool                    `mapstructure:"enabled"`
 	Type      string                  `mapstructure:"type"      validate:"required_if=Enabled true,omitempty,oneof=pyroscope"`
@@ -79,18 +117,38 @@ type TempoConfiguration struct {
 }
 
 type DatabaseConfiguration struct {
-	Type     string                  `mapstructure:"type"     validate:"required,oneof=postgres sqlite"`
+	Type     string                  `mapstructure:"type"     validate:"required,oneof=postgres sqlite mysql"`
 	Postgres *PostgresDatabaseConfig `mapstructure:"postgres" validate:"required_if=Type postgres"`
 	SQLite   *SQLiteDatabaseConfig   `mapstructure:"sqlite"   validate:"required_if=Type sqlite"`
+	MySQL    *MySQLDatabaseConfig    `mapstructure:"mysql"    validate:"required_if=Type mysql"`
 }
 
 type PostgresDatabaseConfig struct {
+	Host                       string                  `mapstructure:"host"                          validate:"required"`
+	Port                       int32                   `mapstructure:"port"                          validate:"gte=1,lte=65535"`
+	User                       string                  `mapstructure:"user"                          validate:"required"`
+	Password                   string                  `mapstructure:"password"                      validate:"required"`
+	Name                       string                  `mapstructure:"name"                          validate:"required"`
+	SSLMode                    string                  `mapstructure:"sslmode"`
+	Replicas                   []PostgresReplicaConfig `mapstructure:"replicas"                      validate:"omitempty,dive"`
+	ReplicaMaxLagSeconds       int                     `mapstructure:"replica_max_lag_seconds"       validate:"gte=0"`
+	ReplicaHealthCheckInterval int                     `mapstructure:"replica_health_check_interval" validate:"gte=0"`
+}
+
+// PostgresReplicaConfig describes a read replica. Credentials, database name and SSL mode
+// are inherited from the primary.
+type PostgresReplicaConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int32  `mapstructure:"port" validate:"omitempty,gte=1,lte=65535"`
+}
+
+type MySQLDatabaseConfig struct {
 	Host     string `mapstructure:"host"     validate:"required"`
 	Port     int32  `mapstructure:"port"     validate:"gte=1,lte=65535"`
 	User     string `mapstructure:"user"     validate:"required"`
 	Password string `mapstructure:"password" validate:"required"`
 	Name     string `mapstructure:"name"     validate:"required"`
-	SSLMode  string `mapstructure:"sslmode"`
+	TLS      string `mapstructure:"tls"      validate:"omitempty,oneof=true false skip-verify preferred"`
 }
 
 type SQLiteDatabaseConfig struct {
@@ -103,9 +161,10 @@ type AuthConfiguration struct {
 
 type ProviderConfiguration struct {
 	Name                 string               `mapstructure:"name"         validate:"required_if=Type oidc"`
-	Type                 ProviderType         `mapstructure:"type"         validate:"required,oneof=local oidc ldap"`
+	Type                 ProviderType         `mapstructure:"type"         validate:"required,oneof=local oidc ldap saml"`
 	OIDC                 OIDCConfiguration    `mapstructure:"oidc"         validate:"required_if=Type oidc"`
 	LDAP                 *LDAPConfiguration   `mapstructure:"ldap"         validate:"required_if=Type ldap"`
+	SAML                 *SAMLConfiguration   `mapstructure:"saml"         validate:"required_if=Type saml"`
 	Domains              []string             `mapstructure:"domains"`
 	MFARequired          bool                 `mapstructure:"mfa_required"`
 	SharingConfiguration SharingConfiguration `mapstructure:"sharing"`
@@ -127,15 +186,39 @@ type LDAPAttributeMap struct {
 	Email string `mapstructure:"email"`
 }
 
+// SAMLConfiguration describes a SAML 2.0 identity provider. The IdP metadata is either fetched
+// from IDPMetadataURL at startup or read inline from IDPMetadata. The SP certificate and key are
+// optional PEM blocks; when set, authentication requests are signed and encrypted assertions
+// can be decrypted.
+type SAMLConfiguration struct {
+	IDPMetadataURL    string           `mapstructure:"idp_metadata_url"    validate:"required_without=IDPMetadata,omitempty,url"`
+	IDPMetadata       string           `mapstructure:"idp_metadata"`
+	EntityID          string           `mapstructure:"entity_id"`
+	Certificate       string           `mapstructure:"certificate"         validate:"required_with=PrivateKey"`
+	PrivateKey        string           `mapstructure:"private_key"         validate:"required_with=Certificate"`
+	AllowIDPInitiated bool             `mapstructure:"allow_idp_initiated"`
+	AttributeMap      SAMLAttributeMap `mapstructure:"attribute_map"`
+}
+
+type SAMLAttributeMap struct {
+	Email     string `mapstructure:"email"`
+	FirstName string `mapstructure:"first_name"`
+	LastName  string `mapstructure:"last_name"`
+}
+
 type OIDCConfiguration struct {
 	ClientID     string `mapstructure:"client_id"     validate:"required_if=Type oidc"`
 	ClientSecret string `mapstructure:"client_secret" validate:"required_if=Type oidc"`
 	Issuer       string `mapstructure:"issuer"        validate:"required_if=Type oidc"`
 }
 
+// SharingConfiguration holds the sharing policy of an auth provider. The IP lists are the
+// defaults applied to share links created by the provider's users when they set none.
 type SharingConfiguration struct {
-	Allowed bool     `mapstructure:"allowed"`
-	Domains []string `mapstructure:"domains" validate:"dive"`
+	Allowed     bool     `mapstructure:"allowed"`
+	Domains     []string `mapstructure:"domains"      validate:"dive"`
+	IPAllowlist []string `mapstructure:"ip_allowlist" validate:"dive,cidr|ip"`
+	IPDenylist  []string `mapstructure:"ip_denylist"  validate:"dive,cidr|ip"`
 }
 
 type CacheConfiguration struct {
@@ -287,11 +370,14 @@ type QueueConfig struct {
 }
 
 type EventsConfiguration struct {
-	Type      string                    `mapstructure:"type"      validate:"required,oneof=jetstream gcp aws memory azure"`
-	Queues    map[string]QueueConfig    `mapstructure:"queues"    validate:"required,dive"`
-	Jetstream *JetStreamEventsConfig    `mapstructure:"jetstream" validate:"required_if=Type jetstream"`
-	PubSub    *PubSubConfiguration      `mapstructure:"gcp"       validate:"required_if=Type gcp"`
-	Azure     *AzureEventsConfiguration `mapstructure:"azure"     validate:"required_if=Type azure"`
+	Type        string                    `mapstructure:"type"         validate:"required,oneof=jetstream gcp aws memory azure redis valkey"`
+	Queues      map[string]QueueConfig    `mapstructure:"queues"       validate:"required,dive"`
+	MaxAttempts int                       `mapstructure:"max_attempts" validate:"gte=1"`
+	Jetstream   *JetStreamEventsConfig    `mapstructure:"jetstream"    validate:"required_if=Type jetstream"`
+	PubSub      *PubSubConfiguration      `mapstructure:"gcp"          validate:"required_if=Type gcp"`
+	Azure       *AzureEventsConfiguration `mapstructure:"azure"        validate:"required_if=Type azure"`
+	Redis       *RedisCacheConfiguration  `mapstructure:"redis"        validate:"required_if=Type redis"`
+	Valkey      *ValkeyCacheConfiguration `mapstructure:"valkey"       validate:"required_if=Type valkey"`
 }
 
 type AzureEventsConfiguration struct {
@@ -331,6 +417,11 @@ type NotifierConfiguration struct {
 	Type       string                           `mapstructure:"type"       validate:"required,oneof=smtp filesystem"`
 	SMTP       *MailerConfiguration             `mapstructure:"smtp"       validate:"required_if=Type smtp"`
 	Filesystem *FilesystemNotifierConfiguration `mapstructure:"filesystem" validate:"required_if=Type filesystem"`
+	Channels   ChannelsNotifierConfiguration    `mapstructure:"channels"`
+}
+
+type ChannelsNotifierConfiguration struct {
+	MattermostHosts []string `mapstructure:"mattermost_hosts" validate:"dive,hostname"`
 }
 
 type FilesystemNotifierConfiguration struct {
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeadLetterEvent struct {
+	ID        uuid.UUID         `gorm:"default:(-)"              json:"id"`
+	MessageID string            `gorm:"not null"                 json:"message_id"`
+	EventType string            `gorm:"not null;index"           json:"event_type"`
+	Worker    string            `gorm:"not null"                 json:"worker"`
+	Payload   string            `gorm:"not null"                 json:"payload"`
+	Metadata  map[string]string `gorm:"not null;serializer:json" json:"metadata"`
+	Attempts  int               `gorm:"not null;default:0"       json:"attempts"`
+	LastError string            `gorm:"not null"                 json:"last_error"`
+	CreatedAt time.Time         `gorm:"index"                    json:"created_at"`
+}
+
+type DeadLetterQueryParams struct {
+	Cursor string `json:"cursor" validate:"omitempty,uuid"`
+	Limit  int    `json:"limit"  validate:"omitempty,min=1,max=200"`
+}
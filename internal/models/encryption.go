@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserKey is the public key a user registers to receive the data keys of encrypted buckets.
+// EncryptedPrivateKey is an opaque backup of the private key, sealed client-side so the user
+// can unlock it on another device; the server never sees it in the clear.
+type UserKey struct {
+	ID                  uuid.UUID `gorm:"default:(-)"  json:"id"`
+	UserID              uuid.UUID `gorm:"not null"     json:"user_id"`
+	Algorithm           string    `gorm:"not null"     json:"algorithm"`
+	PublicKey           string    `gorm:"not null"     json:"public_key"`
+	EncryptedPrivateKey string    `gorm:"default:null" json:"encrypted_private_key,omitempty"`
+	CreatedAt           time.Time `                    json:"created_at"`
+}
+
+type UserKeyBody struct {
+	Algorithm           string `json:"algorithm"             validate:"required,oneof=RSA-OAEP-256 ECDH-ES+A256KW"`
+	PublicKey           string `json:"public_key"            validate:"required,base64,max=8192"`
+	EncryptedPrivateKey string `json:"encrypted_private_key" validate:"omitempty,base64,max=16384"`
+}
+
+// BucketKey is the data key of an encrypted bucket at a given version, wrapped with the public
+// key of one member. The version increases when a member leaves, so files uploaded afterwards
+// use a key the former member never received.
+type BucketKey struct {
+	ID         uuid.UUID  `gorm:"default:(-)" json:"id"`
+	BucketID   uuid.UUID  `gorm:"not null"    json:"bucket_id"`
+	UserID     uuid.UUID  `gorm:"not null"    json:"user_id"`
+	UserKeyID  uuid.UUID  `gorm:"not null"    json:"user_key_id"`
+	KeyVersion int        `gorm:"not null"    json:"key_version"`
+	WrappedKey string     `gorm:"not null"    json:"wrapped_key"`
+	CreatedBy  *uuid.UUID `                   json:"-"`
+	CreatedAt  time.Time  `                   json:"created_at"`
+}
+
+type BucketKeyBody struct {
+	UserID     uuid.UUID `json:"user_id"     validate:"required"`
+	UserKeyID  uuid.UUID `json:"user_key_id" validate:"required"`
+	WrappedKey string    `json:"wrapped_key" validate:"required,base64,max=4096"`
+}
+
+// BucketKeysBody submits the data key of a version wrapped for one or more members.
+type BucketKeysBody struct {
+	KeyVersion int             `json:"key_version" validate:"required,gte=1"`
+	Keys       []BucketKeyBody `json:"keys"        validate:"required,min=1,max=1000,dive"`
+}
+
+// BucketEncryptionBody turns on client-side encryption for a new bucket. WrappedKey is the first
+// data key of the bucket, wrapped with the current public key of its creator.
+type BucketEncryptionBody struct {
+	UserKeyID  uuid.UUID `json:"user_key_id" validate:"required"`
+	WrappedKey string    `json:"wrapped_key" validate:"required,base64,max=4096"`
+}
+
+// PendingBucketKey is a member still waiting for the data key of a version. Members holding that
+// version unwrap it locally and wrap it again with PublicKey.
+type PendingBucketKey struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Email      string    `json:"email"`
+	UserKeyID  uuid.UUID `json:"user_key_id"`
+	Algorithm  string    `json:"algorithm"`
+	PublicKey  string    `json:"public_key"`
+	KeyVersion int       `json:"key_version"`
+}
+
+// FileEncryption describes how the client encrypted a file of an encrypted bucket: the content is
+// split into chunks of ChunkSize bytes, each sealed with Cipher under the bucket data key of
+// KeyVersion. Nonce is the base nonce the per-chunk nonces derive from.
+type FileEncryption struct {
+	Cipher     string `json:"cipher"      validate:"required,oneof=AES-256-GCM XChaCha20-Poly1305"`
+	ChunkSize  int    `json:"chunk_size"  validate:"required,gte=4096,lte=67108864"`
+	KeyVersion int    `json:"key_version" validate:"required,gte=1"`
+	Nonce      string `json:"nonce"       validate:"required,base64,max=64"`
+}
@@ -14,24 +14,30 @@ const (
 	FileStatusUploaded  FileStatus = "uploaded"
 	FileStatusDeleted   FileStatus = "deleted"
 	FileStatusRestoring FileStatus = "restoring"
+	// FileStatusPending marks a file uploaded through a share that requires approval.
+	FileStatusPending FileStatus = "pending"
 )
 
 type File struct {
-	ID           uuid.UUID      `gorm:"default:(-)"           json:"id"`
-	Name         string         `gorm:"not null;default:null" json:"name"`
-	Extension    string         `gorm:"default:null"          json:"extension"`
-	Status       FileStatus     `gorm:"default:null"          json:"status"`
-	BucketID     uuid.UUID      `                             json:"bucket_id"`
-	Bucket       Bucket         `                             json:"-"`
-	FolderID     *uuid.UUID     `gorm:"default:null"          json:"folder_id,omitempty"`
-	ParentFolder *Folder        `gorm:"foreignKey:FolderID"   json:"parent_folder,omitempty"`
-	Size         int            `gorm:"not null;default:0"    json:"size"`
-	DeletedBy    *uuid.UUID     `gorm:"default:null"          json:"deleted_by,omitempty"`
-	ExpiresAt    *time.Time     `gorm:"default:null"          json:"expires_at"`
-	OriginalPath string         `gorm:"-"                     json:"original_path,omitempty"`
-	CreatedAt    time.Time      `                             json:"created_at"`
-	UpdatedAt    time.Time      `                             json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `                             json:"deleted_at"`
+	ID               uuid.UUID       `gorm:"default:(-)"           json:"id"`
+	Name             string          `gorm:"not null;default:null" json:"name"`
+	Extension        string          `gorm:"default:null"          json:"extension"`
+	Status           FileStatus      `gorm:"default:null"          json:"status"`
+	BucketID         uuid.UUID       `                             json:"bucket_id"`
+	Bucket           Bucket          `                             json:"-"`
+	FolderID         *uuid.UUID      `gorm:"default:null"          json:"folder_id,omitempty"`
+	ShareID          *uuid.UUID      `gorm:"default:null"          json:"share_id,omitempty"`
+	ParentFolder     *Folder         `gorm:"foreignKey:FolderID"   json:"parent_folder,omitempty"`
+	Size             int             `gorm:"not null;default:0"    json:"size"`
+	DeletedBy        *uuid.UUID      `gorm:"default:null"          json:"deleted_by,omitempty"`
+	ExpiresAt        *time.Time      `gorm:"default:null"          json:"expires_at"`
+	LastDownloadedAt *time.Time      `gorm:"default:null"          json:"last_downloaded_at,omitempty"`
+	ExpiryWarnedAt   *time.Time      `gorm:"default:null"          json:"-"`
+	Encryption       *FileEncryption `gorm:"serializer:json"       json:"encryption,omitempty"`
+	OriginalPath     string          `gorm:"-"                     json:"original_path,omitempty"`
+	CreatedAt        time.Time       `                             json:"created_at"`
+	UpdatedAt        time.Time       `                             json:"updated_at"`
+	DeletedAt        gorm.DeletedAt  `                             json:"deleted_at"`
 }
 
 type FileActivity struct {
@@ -47,10 +53,11 @@ func (f *File) ToActivity() FileActivity {
 }
 
 type FileUploadBody struct {
-	Name      string     `json:"name"                 validate:"required,filename,max=255"`
-	FolderID  *uuid.UUID `json:"folder_id"            validate:"omitempty,uuid"`
-	Size      int        `json:"size"                 validate:"required,gte=1,maxuploadsize"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty" validate:"omitempty,futuredate"`
+	Name       string          `json:"name"                 validate:"required,filename,max=255"`
+	FolderID   *uuid.UUID      `json:"folder_id"            validate:"omitempty,uuid"`
+	Size       int             `json:"size"                 validate:"required,gte=1,maxuploadsize"`
+	ExpiresAt  *time.Time      `json:"expires_at,omitempty" validate:"omitempty,futuredate"`
+	Encryption *FileEncryption `json:"encryption,omitempty" validate:"omitempty"`
 }
 
 type FileUploadResponse struct {
@@ -68,9 +75,12 @@ type FilePartURL struct {
 	Headers map[string]string `json:"headers,omitempty"`
 }
 
+// FileDownloadResponse is a presigned download. Headers must be sent with the request, which is
+// the case for files of buckets encrypted with a customer key; share downloads never carry them.
 type FileDownloadResponse struct {
-	ID  string `json:"id"`
-	URL string `json:"url"`
+	ID      string            `json:"id"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 type FileDownloadQuery struct {
@@ -7,14 +7,32 @@ import (
 )
 
 type Invite struct {
-	ID        uuid.UUID `gorm:"default:(-)"                                                       json:"id"`
-	Email     string    `gorm:"not null;default:null;index:idx_invite_unique,unique"              json:"email"      validate:"required,email"`
-	Group     Group     `gorm:"not null;default:null;index:idx_invite_unique"                     json:"group"      validate:"required,oneof=owner contributor viewer"`
-	BucketID  uuid.UUID `gorm:"not null;index:idx_invite_unique"                                  json:"bucket_id"`
-	Bucket    Bucket    `gorm:"foreignKey:BucketID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"  json:"bucket"`
-	CreatedBy uuid.UUID `gorm:"not null"                                                          json:"-"`
-	User      User      `gorm:"foreignKey:CreatedBy;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
-	CreatedAt time.Time `                                                                         json:"created_at"`
+	ID        uuid.UUID  `gorm:"default:(-)"                                                       json:"id"`
+	Email     string     `gorm:"not null;default:null;index:idx_invite_unique,unique"              json:"email"      validate:"required,email"`
+	Group     Group      `gorm:"not null;default:null;index:idx_invite_unique"                     json:"group"      validate:"required,oneof=owner contributor viewer"`
+	BucketID  uuid.UUID  `gorm:"not null;index:idx_invite_unique"                                  json:"bucket_id"`
+	Bucket    Bucket     `gorm:"foreignKey:BucketID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"  json:"bucket"`
+	CreatedBy uuid.UUID  `gorm:"not null"                                                          json:"-"`
+	User      User       `gorm:"foreignKey:CreatedBy;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	CreatedAt time.Time  `                                                                         json:"created_at"`
+	ExpiresAt *time.Time `gorm:"default:null"                                                      json:"expires_at"`
+}
+
+// IsExpired reports whether the invite can no longer be accepted.
+// Invites created before expiry was introduced have no deadline.
+func (i Invite) IsExpired() bool {
+	return i.ExpiresAt != nil && time.Now().After(*i.ExpiresAt)
+}
+
+type AdminInviteListItem struct {
+	ID         uuid.UUID    `json:"id"`
+	Email      string       `json:"email"`
+	Group      Group        `json:"group"`
+	BucketID   uuid.UUID    `json:"bucket_id"`
+	BucketName string       `json:"bucket_name"`
+	InvitedBy  UserActivity `json:"invited_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ExpiresAt  *time.Time   `json:"expires_at"`
 }
 
 type InviteChallengeCreateBody struct {
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BucketLifecycleRule bounds how long files of a bucket live. The rule with an empty extension
+// applies to the whole bucket; a rule for an extension overrides its non-nil fields for files
+// with that extension. InactiveDays moves files nobody downloaded for that many days to the trash.
+type BucketLifecycleRule struct {
+	ID                uuid.UUID `gorm:"default:(-)" json:"id"`
+	BucketID          uuid.UUID `gorm:"not null"    json:"bucket_id"`
+	Extension         string    `gorm:"not null"    json:"extension"`
+	DefaultExpiryDays *int      `                   json:"default_expiry_days"`
+	MaxExpiryDays     *int      `                   json:"max_expiry_days"`
+	InactiveDays      *int      `                   json:"inactive_days"`
+	CreatedAt         time.Time `                   json:"created_at"`
+	UpdatedAt         time.Time `                   json:"updated_at"`
+}
+
+type BucketLifecycleRuleBody struct {
+	Extension         string `json:"extension"           validate:"omitempty,alphanum,max=32"`
+	DefaultExpiryDays *int   `json:"default_expiry_days" validate:"omitempty,gte=1,lte=36500"`
+	MaxExpiryDays     *int   `json:"max_expiry_days"     validate:"omitempty,gte=1,lte=36500"`
+	InactiveDays      *int   `json:"inactive_days"       validate:"omitempty,gte=1,lte=36500"`
+}
+
+// BucketLifecycleBody replaces every lifecycle rule of a bucket; an empty list removes them.
+type BucketLifecycleBody struct {
+	Rules []BucketLifecycleRuleBody `json:"rules" validate:"max=100,dive"`
+}
@@ -15,18 +15,33 @@ const (
 	GroupViewer      Group = "viewer"
 )
 
+// NotificationDelivery controls whether a member's notifications are sent as they happen
+// or collected into a periodic digest.
+type NotificationDelivery string
+
+const (
+	NotificationDeliveryImmediate NotificationDelivery = "immediate"
+	NotificationDeliveryHourly    NotificationDelivery = "hourly"
+	NotificationDeliveryDaily     NotificationDelivery = "daily"
+)
+
 type Membership struct {
-	ID                    uuid.UUID      `gorm:"default:(-)"                                     json:"id"`
-	UserID                uuid.UUID      `gorm:"not null;uniqueIndex:idx_user_bucket"            json:"user_id"`
-	User                  User           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"   json:"user,omitempty"`
-	BucketID              uuid.UUID      `gorm:"not null;uniqueIndex:idx_user_bucket"            json:"bucket_id"`
-	Bucket                Bucket         `gorm:"foreignKey:BucketID;constraint:OnDelete:CASCADE" json:"bucket,omitempty"`
-	Group                 Group          `gorm:"not null"                                        json:"group"                  validate:"required,oneof=owner contributor viewer"`
-	UploadNotifications   bool           `gorm:"not null;default:true"                           json:"upload_notifications"`
-	DownloadNotifications bool           `gorm:"not null;default:false"                          json:"download_notifications"`
-	CreatedAt             time.Time      `                                                       json:"created_at"`
-	UpdatedAt             time.Time      `                                                       json:"updated_at"`
-	DeletedAt             gorm.DeletedAt `gorm:"index"                                           json:"-"`
+	ID                    uuid.UUID            `gorm:"default:(-)"                                     json:"id"`
+	UserID                uuid.UUID            `gorm:"not null;uniqueIndex:idx_user_bucket"            json:"user_id"`
+	User                  User                 `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"   json:"user,omitempty"`
+	BucketID              uuid.UUID            `gorm:"not null;uniqueIndex:idx_user_bucket"            json:"bucket_id"`
+	Bucket                Bucket               `gorm:"foreignKey:BucketID;constraint:OnDelete:CASCADE" json:"bucket,omitempty"`
+	Group                 Group                `gorm:"not null"                                        json:"group"                  validate:"required,oneof=owner contributor viewer"`
+	UploadNotifications   bool                 `gorm:"not null;default:true"                           json:"upload_notifications"`
+	DownloadNotifications bool                 `gorm:"not null;default:false"                          json:"download_notifications"`
+	DeleteNotifications   bool                 `gorm:"not null;default:false"                          json:"delete_notifications"`
+	RestoreNotifications  bool                 `gorm:"not null;default:false"                          json:"restore_notifications"`
+	ShareNotifications    bool                 `gorm:"not null;default:false"                          json:"share_notifications"`
+	MemberNotifications   bool                 `gorm:"not null;default:false"                          json:"member_notifications"`
+	NotificationDelivery  NotificationDelivery `gorm:"not null;default:immediate"                      json:"notification_delivery"`
+	CreatedAt             time.Time            `                                                       json:"created_at"`
+	UpdatedAt             time.Time            `                                                       json:"updated_at"`
+	DeletedAt             gorm.DeletedAt       `gorm:"index"                                           json:"-"`
 }
 
 type MembershipCreateBody struct {
@@ -42,4 +57,10 @@ type MembershipUpdateBody struct {
 type MembershipNotificationBody struct {
 	UploadNotifications   *bool `json:"upload_notifications"   validate:"required,boolean"`
 	DownloadNotifications *bool `json:"download_notifications" validate:"required,boolean"`
+	DeleteNotifications   *bool `json:"delete_notifications"   validate:"omitempty,boolean"`
+	RestoreNotifications  *bool `json:"restore_notifications"  validate:"omitempty,boolean"`
+	ShareNotifications    *bool `json:"share_notifications"    validate:"omitempty,boolean"`
+	MemberNotifications   *bool `json:"member_notifications"   validate:"omitempty,boolean"`
+
+	NotificationDelivery *NotificationDelivery `json:"notification_delivery" validate:"omitempty,oneof=immediate hourly daily"`
 }
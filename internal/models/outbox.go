@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+)
+
+type OutboxEvent struct {
+	ID        uuid.UUID    `gorm:"default:(-)"              json:"id"`
+	MessageID string       `gorm:"not null"                 json:"message_id"`
+	EventType string       `gorm:"not null"                 json:"event_type"`
+	Payload   string       `gorm:"not null"                 json:"payload"`
+	Status    OutboxStatus `gorm:"not null;default:pending" json:"status"`
+	Attempts  int          `gorm:"not null;default:0"       json:"attempts"`
+	LastError *string      `                                json:"last_error,omitempty"`
+	CreatedAt time.Time    `gorm:"index"                    json:"created_at"`
+	SentAt    *time.Time   `                                json:"sent_at,omitempty"`
+}
@@ -15,17 +15,23 @@ type Profile struct {
 }
 
 type WorkerConfig struct {
-	ObjectDeletion   WorkerMode
-	BucketEvents     WorkerMode
-	TrashCleanup     WorkerMode
-	GarbageCollector WorkerMode
+	ObjectDeletion     WorkerMode
+	BucketEvents       WorkerMode
+	TrashCleanup       WorkerMode
+	GarbageCollector   WorkerMode
+	OutboxRelay        WorkerMode
+	NotificationDigest WorkerMode
+	NotificationRetry  WorkerMode
 }
 
 func (w WorkerConfig) AnyEnabled() bool {
 	return w.ObjectDeletion != WorkerModeDisabled ||
 		w.BucketEvents != WorkerModeDisabled ||
 		w.TrashCleanup != WorkerModeDisabled ||
-		w.GarbageCollector != WorkerModeDisabled
+		w.GarbageCollector != WorkerModeDisabled ||
+		w.OutboxRelay != WorkerModeDisabled ||
+		w.NotificationDigest != WorkerModeDisabled ||
+		w.NotificationRetry != WorkerModeDisabled
 }
 
 func (p Profile) NeedsEvents() bool {
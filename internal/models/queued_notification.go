@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// QueuedNotification is an email persisted before delivery so it survives SMTP outages.
+// The payload holds the encrypted template data and is cleared once the email has been sent
+// or has expired.
+type QueuedNotification struct {
+	ID            uuid.UUID          `gorm:"default:(-)"              json:"id"`
+	Recipient     string             `gorm:"not null"                 json:"recipient"`
+	Subject       string             `gorm:"not null"                 json:"subject"`
+	Template      string             `gorm:"not null"                 json:"template"`
+	Payload       string             `gorm:"not null"                 json:"-"`
+	Status        NotificationStatus `gorm:"not null;default:pending" json:"status"`
+	Attempts      int                `gorm:"not null;default:0"       json:"attempts"`
+	NextAttemptAt time.Time          `gorm:"not null"                 json:"next_attempt_at"`
+	LastError     *string            `                                json:"last_error,omitempty"`
+	CreatedAt     time.Time          `                                json:"created_at"`
+	UpdatedAt     time.Time          `                                json:"updated_at"`
+	SentAt        *time.Time         `                                json:"sent_at,omitempty"`
+	ExpiresAt     *time.Time         `                                json:"expires_at,omitempty"`
+}
+
+// IsExpired reports whether the notification data, e.g. a challenge code, is no longer valid.
+func (n QueuedNotification) IsExpired(now time.Time) bool {
+	return n.ExpiresAt != nil && !n.ExpiresAt.After(now)
+}
+
+type QueuedNotificationQueryParams struct {
+	Status NotificationStatus `json:"status" validate:"omitempty,oneof=pending sent failed"`
+}
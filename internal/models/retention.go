@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy sets the minimum time files of the buckets it is assigned to are kept after
+// their upload. A locked policy behaves like WORM storage: files cannot even be trashed before
+// the period ends, the period can only be extended and the policy cannot be removed from a bucket.
+type RetentionPolicy struct {
+	ID            uuid.UUID `gorm:"default:(-)"           json:"id"`
+	Name          string    `gorm:"not null;default:null" json:"name"`
+	RetentionDays int       `gorm:"not null"              json:"retention_days"`
+	Locked        bool      `gorm:"not null"              json:"locked"`
+	CreatedBy     uuid.UUID `gorm:"not null"              json:"-"`
+	CreatedAt     time.Time `                             json:"created_at"`
+	UpdatedAt     time.Time `                             json:"updated_at"`
+}
+
+type RetentionPolicyActivity struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	RetentionDays int       `json:"retention_days"`
+	Locked        bool      `json:"locked"`
+}
+
+func (p *RetentionPolicy) ToActivity() RetentionPolicyActivity {
+	return RetentionPolicyActivity{
+		ID:            p.ID,
+		Name:          p.Name,
+		RetentionDays: p.RetentionDays,
+		Locked:        p.Locked,
+	}
+}
+
+type RetentionPolicyBody struct {
+	Name          string `json:"name"           validate:"required,max=100"`
+	RetentionDays int    `json:"retention_days" validate:"gte=1,lte=36500"`
+	Locked        bool   `json:"locked"`
+}
+
+type BucketRetentionBody struct {
+	RetentionPolicyID *uuid.UUID `json:"retention_policy_id"`
+}
+
+// LegalHold freezes a bucket, a folder and everything below it, or a single file. Held objects
+// cannot be trashed, purged or expired until every hold covering them is released.
+type LegalHold struct {
+	ID         uuid.UUID  `gorm:"default:(-)"           json:"id"`
+	BucketID   uuid.UUID  `gorm:"not null"              json:"bucket_id"`
+	FolderID   *uuid.UUID `gorm:"default:null"          json:"folder_id,omitempty"`
+	FileID     *uuid.UUID `gorm:"default:null"          json:"file_id,omitempty"`
+	Reason     string     `gorm:"not null;default:null" json:"reason"`
+	CreatedBy  uuid.UUID  `gorm:"not null"              json:"created_by"`
+	CreatedAt  time.Time  `                             json:"created_at"`
+	ReleasedBy *uuid.UUID `gorm:"default:null"          json:"released_by,omitempty"`
+	ReleasedAt *time.Time `gorm:"default:null"          json:"released_at,omitempty"`
+}
+
+func (h *LegalHold) IsActive() bool {
+	return h.ReleasedAt == nil
+}
+
+type LegalHoldActivity struct {
+	ID       uuid.UUID  `json:"id"`
+	BucketID uuid.UUID  `json:"bucket_id"`
+	FolderID *uuid.UUID `json:"folder_id,omitempty"`
+	FileID   *uuid.UUID `json:"file_id,omitempty"`
+	Reason   string     `json:"reason"`
+}
+
+func (h *LegalHold) ToActivity() LegalHoldActivity {
+	return LegalHoldActivity{
+		ID:       h.ID,
+		BucketID: h.BucketID,
+		FolderID: h.FolderID,
+		FileID:   h.FileID,
+		Reason:   h.Reason,
+	}
+}
+
+type LegalHoldCreateBody struct {
+	BucketID uuid.UUID  `json:"bucket_id" validate:"required"`
+	FolderID *uuid.UUID `json:"folder_id" validate:"excluded_with=FileID"`
+	FileID   *uuid.UUID `json:"file_id"   validate:"excluded_with=FolderID"`
+	Reason   string     `json:"reason"    validate:"required,max=500"`
+}
+
+type LegalHoldQueryParams struct {
+	Status string `json:"status" validate:"omitempty,oneof=active released all"`
+}
@@ -16,27 +16,37 @@ const (
 )
 
 type Share struct {
-	ID                uuid.UUID      `gorm:"default:(-)"            json:"id"`
-	Name              string         `gorm:"not null"               json:"name"`
-	BucketID          uuid.UUID      `gorm:"not null"               json:"bucket_id"`
-	Bucket            Bucket         `                              json:"-"`
-	FolderID          *uuid.UUID     `gorm:"default:null"           json:"folder_id,omitempty"`
-	Folder            *Folder        `gorm:"foreignKey:FolderID"    json:"-"`
-	ExpiresAt         *time.Time     `gorm:"default:null"           json:"expires_at,omitempty"`
-	MaxViews          *int           `gorm:"default:null"           json:"max_views,omitempty"`
-	CurrentViews      int            `gorm:"not null;default:0"     json:"current_views"`
-	HashedPassword    string         `gorm:"default:null"           json:"-"`
-	PasswordProtected bool           `gorm:"-"                      json:"password_protected"`
-	Type              ShareType      `gorm:"not null"               json:"type"`
-	AllowUpload       bool           `gorm:"not null;default:false" json:"allow_upload"`
-	MaxUploads        *int           `gorm:"default:null"           json:"max_uploads,omitempty"`
-	CurrentUploads    int            `gorm:"not null;default:0"     json:"current_uploads"`
-	MaxUploadSize     *int64         `gorm:"default:null"           json:"max_upload_size,omitempty"`
-	Files             []ShareFile    `                              json:"files,omitempty"`
-	CreatedBy         uuid.UUID      `gorm:"not null"               json:"created_by"`
-	CreatedAt         time.Time      `                              json:"created_at"`
-	UpdatedAt         time.Time      `                              json:"updated_at"`
-	DeletedAt         gorm.DeletedAt `                              json:"deleted_at"`
+	ID                  uuid.UUID        `gorm:"default:(-)"            json:"id"`
+	Name                string           `gorm:"not null"               json:"name"`
+	BucketID            uuid.UUID        `gorm:"not null"               json:"bucket_id"`
+	Bucket              Bucket           `                              json:"-"`
+	FolderID            *uuid.UUID       `gorm:"default:null"           json:"folder_id,omitempty"`
+	Folder              *Folder          `gorm:"foreignKey:FolderID"    json:"-"`
+	ExpiresAt           *time.Time       `gorm:"default:null"           json:"expires_at,omitempty"`
+	MaxViews            *int             `gorm:"default:null"           json:"max_views,omitempty"`
+	CurrentViews        int              `gorm:"not null;default:0"     json:"current_views"`
+	HashedPassword      string           `gorm:"default:null"           json:"-"`
+	PasswordProtected   bool             `gorm:"-"                      json:"password_protected"`
+	Type                ShareType        `gorm:"not null"               json:"type"`
+	AllowUpload         bool             `gorm:"not null;default:false" json:"allow_upload"`
+	MaxUploads          *int             `gorm:"default:null"           json:"max_uploads,omitempty"`
+	CurrentUploads      int              `gorm:"not null;default:0"     json:"current_uploads"`
+	MaxUploadSize       *int64           `gorm:"default:null"           json:"max_upload_size,omitempty"`
+	RequireApproval     bool             `gorm:"not null;default:false" json:"require_approval"`
+	MaxDownloads        *int             `gorm:"default:null"           json:"max_downloads,omitempty"`
+	CurrentDownloads    int              `gorm:"not null;default:0"     json:"current_downloads"`
+	Files               []ShareFile      `                              json:"files,omitempty"`
+	IPAllowlist         []string         `gorm:"serializer:json"        json:"ip_allowlist,omitempty"`
+	IPDenylist          []string         `gorm:"serializer:json"        json:"ip_denylist,omitempty"`
+	RecipientRestricted bool             `gorm:"not null;default:false" json:"recipient_restricted"`
+	Recipients          []ShareRecipient `                              json:"recipients,omitempty"`
+	Deliveries          []ShareDelivery  `                              json:"deliveries,omitempty"`
+	VerifiedRecipient   string           `gorm:"-"                      json:"-"`
+	Client              ClientInfo       `gorm:"-"                      json:"-"`
+	CreatedBy           uuid.UUID        `gorm:"not null"               json:"created_by"`
+	CreatedAt           time.Time        `                              json:"created_at"`
+	UpdatedAt           time.Time        `                              json:"updated_at"`
+	DeletedAt           gorm.DeletedAt   `                              json:"deleted_at"`
 }
 
 type ShareActivity struct {
@@ -54,46 +64,161 @@ func (s *Share) ToActivity() ShareActivity {
 }
 
 type ShareFile struct {
+	ID               uuid.UUID `gorm:"default:(-)"        json:"id"`
+	ShareID          uuid.UUID `gorm:"not null"           json:"share_id"`
+	FileID           uuid.UUID `gorm:"not null"           json:"file_id"`
+	File             File      `                          json:"file"`
+	MaxDownloads     *int      `gorm:"default:null"       json:"max_downloads,omitempty"`
+	CurrentDownloads int       `gorm:"not null;default:0" json:"current_downloads"`
+}
+
+// ShareDelivery records a share link emailed to a recipient from safebucket.
+type ShareDelivery struct {
+	ID           uuid.UUID  `gorm:"default:(-)"            json:"id"`
+	ShareID      uuid.UUID  `gorm:"not null"               json:"share_id"`
+	Email        string     `gorm:"not null"               json:"email"`
+	SentBy       *uuid.UUID `gorm:"default:null"           json:"sent_by,omitempty"`
+	PasswordSent bool       `gorm:"not null;default:false" json:"password_sent"`
+	CreatedAt    time.Time  `                              json:"created_at"`
+}
+
+type ShareAccessType string
+
+const (
+	ShareAccessTypeView     ShareAccessType = "view"
+	ShareAccessTypeDownload ShareAccessType = "download"
+)
+
+// ShareAccess records a single view of a share listing or download of one of its files,
+// with the client that performed it. It backs the owner-facing share analytics.
+type ShareAccess struct {
+	ID             uuid.UUID       `gorm:"default:(-)"  json:"id"`
+	ShareID        uuid.UUID       `gorm:"not null"     json:"share_id"`
+	FileID         *uuid.UUID      `gorm:"default:null" json:"file_id,omitempty"`
+	Type           ShareAccessType `gorm:"not null"     json:"type"`
+	IPAddress      string          `gorm:"not null"     json:"ip_address"`
+	UserAgent      string          `gorm:"not null"     json:"user_agent"`
+	RecipientEmail string          `gorm:"not null"     json:"recipient_email,omitempty"`
+	CreatedAt      time.Time       `                    json:"created_at"`
+}
+
+type ShareFileAnalytics struct {
+	FileID           uuid.UUID  `json:"file_id"`
+	Name             string     `json:"name"`
+	Downloads        int        `json:"downloads"`
+	MaxDownloads     *int       `json:"max_downloads,omitempty"`
+	LastDownloadedAt *time.Time `json:"last_downloaded_at,omitempty"`
+}
+
+type ShareAnalyticsResponse struct {
+	ShareID   uuid.UUID            `json:"share_id"`
+	Views     int                  `json:"views"`
+	Downloads int                  `json:"downloads"`
+	Files     []ShareFileAnalytics `json:"files"`
+	Accesses  []ShareAccess        `json:"accesses"`
+}
+
+// ShareRecipient is a full email address or a domain allowed to open a recipient-restricted
+// share. Visitors prove ownership of a matching email with a one-time code, and the verified
+// email is carried by their share token as Share.VerifiedRecipient.
+type ShareRecipient struct {
 	ID      uuid.UUID `gorm:"default:(-)" json:"id"`
 	ShareID uuid.UUID `gorm:"not null"    json:"share_id"`
-	FileID  uuid.UUID `gorm:"not null"    json:"file_id"`
-	File    File      `                   json:"file"`
+	Value   string    `gorm:"not null"    json:"value"`
 }
 
 type PublicShareResponse struct {
-	ID             uuid.UUID  `json:"id"`
-	Name           string     `json:"name"`
-	Type           ShareType  `json:"type"`
-	AllowUpload    bool       `json:"allow_upload"`
-	MaxUploadSize  *int64     `json:"max_upload_size"`
-	MaxUploads     *int       `json:"max_uploads"`
-	CurrentUploads int        `json:"current_uploads"`
-	ExpiresAt      *time.Time `json:"expires_at"`
-	MaxViews       *int       `json:"max_views"`
-	CurrentViews   int        `json:"current_views"`
-	Files          []File     `json:"files"`
-	Folders        []Folder   `json:"folders"`
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	Type            ShareType  `json:"type"`
+	AllowUpload     bool       `json:"allow_upload"`
+	MaxUploadSize   *int64     `json:"max_upload_size"`
+	MaxUploads      *int       `json:"max_uploads"`
+	CurrentUploads  int        `json:"current_uploads"`
+	RequireApproval bool       `json:"require_approval"`
+	Encrypted       bool       `json:"encrypted"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	MaxViews        *int       `json:"max_views"`
+	CurrentViews    int        `json:"current_views"`
+	MaxDownloads    *int       `json:"max_downloads"`
+	Files           []File     `json:"files"`
+	Folders         []Folder   `json:"folders"`
 }
 
 type ShareUploadBody struct {
-	Name     string     `json:"name"      validate:"required,filename,max=255"`
-	FolderID *uuid.UUID `json:"folder_id" validate:"omitempty,uuid"`
-	Size     int64      `json:"size"      validate:"required,gte=1,maxuploadsize"`
+	Name       string          `json:"name"                 validate:"required,filename,max=255"`
+	FolderID   *uuid.UUID      `json:"folder_id"            validate:"omitempty,uuid"`
+	Size       int64           `json:"size"                 validate:"required,gte=1,maxuploadsize"`
+	Encryption *FileEncryption `json:"encryption,omitempty" validate:"omitempty"`
 }
 
 type ShareAuthBody struct {
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+type ShareOTPRequestBody struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+}
+
+type ShareOTPVerifyBody struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+	Code  string `json:"code"  validate:"required,len=6,alphanum"`
+}
+
 type ShareCreateBody struct {
-	Name          string      `json:"name"            validate:"required,min=1,max=255"`
-	Type          ShareType   `json:"type"            validate:"required,oneof=files folder bucket"`
-	FolderID      *uuid.UUID  `json:"folder_id"       validate:"required_if=Type folder,omitempty,uuid"`
-	FileIDs       []uuid.UUID `json:"file_ids"        validate:"required_if=Type files,omitempty,dive,uuid"`
-	ExpiresAt     *time.Time  `json:"expires_at"      validate:"omitempty,futuredate"`
-	MaxViews      *int        `json:"max_views"       validate:"omitempty,gte=1"`
-	Password      string      `json:"password"        validate:"omitempty,min=8,max=72"`
-	AllowUpload   bool        `json:"allow_upload"    validate:"excluded_if=Type files"`
-	MaxUploads    *int        `json:"max_uploads"     validate:"omitempty,gte=1"`
-	MaxUploadSize *int64      `json:"max_upload_size" validate:"omitempty,gte=1"`
+	Name             string      `json:"name"               validate:"required,min=1,max=255"`
+	Type             ShareType   `json:"type"               validate:"required,oneof=files folder bucket"`
+	FolderID         *uuid.UUID  `json:"folder_id"          validate:"required_if=Type folder,omitempty,uuid"`
+	FileIDs          []uuid.UUID `json:"file_ids"           validate:"required_if=Type files,omitempty,dive,uuid"`
+	ExpiresAt        *time.Time  `json:"expires_at"         validate:"omitempty,futuredate"`
+	MaxViews         *int        `json:"max_views"          validate:"omitempty,gte=1"`
+	Password         string      `json:"password"           validate:"omitempty,min=8,max=72"`
+	AllowUpload      bool        `json:"allow_upload"       validate:"excluded_if=Type files"`
+	MaxUploads       *int        `json:"max_uploads"        validate:"omitempty,gte=1"`
+	MaxUploadSize    *int64      `json:"max_upload_size"    validate:"omitempty,gte=1"`
+	RequireApproval  bool        `json:"require_approval"   validate:"excluded_unless=AllowUpload true"`
+	Recipients       []string    `json:"recipients"         validate:"excluded_with=Password,omitempty,max=50,dive,email|fqdn"`
+	MaxDownloads     *int        `json:"max_downloads"      validate:"omitempty,gte=1"`
+	MaxFileDownloads *int        `json:"max_file_downloads" validate:"excluded_unless=Type files,omitempty,gte=1"`
+	IPAllowlist      []string    `json:"ip_allowlist"       validate:"omitempty,max=50,dive,cidr|ip"`
+	IPDenylist       []string    `json:"ip_denylist"        validate:"omitempty,max=50,dive,cidr|ip"`
+}
+
+// ShareSendBody emails a share link to a list of recipients. Password is only used to
+// send the link's password in a separate message and must match the share's password.
+type ShareSendBody struct {
+	Emails   []string `json:"emails"   validate:"required,min=1,max=50,dive,email"`
+	Message  string   `json:"message"  validate:"omitempty,max=1000"`
+	Password string   `json:"password" validate:"omitempty,min=8,max=72"`
+}
+
+// ShareUpdateBody patches a share. Omitted fields are left unchanged; the Clear* flags
+// remove an optional limit and cannot be combined with a new value for the same field.
+type ShareUpdateBody struct {
+	Name                  *string     `json:"name"                     validate:"omitempty,min=1,max=255"`
+	ExpiresAt             *time.Time  `json:"expires_at"               validate:"omitempty,futuredate"`
+	ClearExpiresAt        bool        `json:"clear_expires_at"         validate:"excluded_with=ExpiresAt"`
+	MaxViews              *int        `json:"max_views"                validate:"omitempty,gte=1"`
+	ClearMaxViews         bool        `json:"clear_max_views"          validate:"excluded_with=MaxViews"`
+	Password              *string     `json:"password"                 validate:"omitempty,min=8,max=72"`
+	ClearPassword         bool        `json:"clear_password"           validate:"excluded_with=Password"`
+	AllowUpload           *bool       `json:"allow_upload"`
+	MaxUploads            *int        `json:"max_uploads"              validate:"omitempty,gte=1"`
+	ClearMaxUploads       bool        `json:"clear_max_uploads"        validate:"excluded_with=MaxUploads"`
+	MaxUploadSize         *int64      `json:"max_upload_size"          validate:"omitempty,gte=1"`
+	ClearMaxUploadSize    bool        `json:"clear_max_upload_size"    validate:"excluded_with=MaxUploadSize"`
+	RequireApproval       *bool       `json:"require_approval"`
+	MaxDownloads          *int        `json:"max_downloads"            validate:"omitempty,gte=1"`
+	ClearMaxDownloads     bool        `json:"clear_max_downloads"      validate:"excluded_with=MaxDownloads"`
+	MaxFileDownloads      *int        `json:"max_file_downloads"       validate:"omitempty,gte=1"`
+	ClearMaxFileDownloads bool        `json:"clear_max_file_downloads" validate:"excluded_with=MaxFileDownloads"`
+	ResetCounters         bool        `json:"reset_counters"`
+	Recipients            []string    `json:"recipients"               validate:"omitempty,max=50,dive,email|fqdn"`
+	ClearRecipients       bool        `json:"clear_recipients"         validate:"excluded_with=Recipients"`
+	IPAllowlist           []string    `json:"ip_allowlist"             validate:"omitempty,max=50,dive,cidr|ip"`
+	ClearIPAllowlist      bool        `json:"clear_ip_allowlist"       validate:"excluded_with=IPAllowlist"`
+	IPDenylist            []string    `json:"ip_denylist"              validate:"omitempty,max=50,dive,cidr|ip"`
+	ClearIPDenylist       bool        `json:"clear_ip_denylist"        validate:"excluded_with=IPDenylist"`
+	AddFileIDs            []uuid.UUID `json:"add_file_ids"             validate:"omitempty,dive,uuid"`
+	RemoveFileIDs         []uuid.UUID `json:"remove_file_ids"          validate:"omitempty,dive,uuid"`
 }
@@ -36,5 +36,7 @@ type QueryKey struct{}
 type ShareClaims struct {
 	jwt.RegisteredClaims
 
-	ShareID uuid.UUID `json:"share_id"`
+	ShareID     uuid.UUID `json:"share_id"`
+	Email       string    `json:"email,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
 }
@@ -24,6 +24,8 @@ type User struct {
 	ProviderType   ProviderType   `gorm:"not null"                                                 json:"provider_type"`
 	ProviderKey    string         `gorm:"not null;uniqueIndex:idx_email_provider_key"              json:"provider_key"`
 	Role           Role           `gorm:"not null"                                                 json:"role"`
+	EmailBounced   bool           `gorm:"not null"                                                 json:"email_bounced"`
+	LockedUntil    *time.Time     `gorm:"-"                                                        json:"locked_until,omitempty"`
 	CreatedAt      time.Time      `                                                                json:"created_at"`
 	UpdatedAt      time.Time      `                                                                json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index"                                                    json:"-"`
@@ -81,11 +83,29 @@ type UserCreateBody struct {
 	Password  string `json:"password"   validate:"required,min=8,max=72"`
 }
 
+// UserUpdateBody edits the profile only; passwords change through the account endpoint, which
+// enforces the step-up checks and revokes the other sessions.
 type UserUpdateBody struct {
-	FirstName   string `json:"first_name"   validate:"omitempty,max=100"`
-	LastName    string `json:"last_name"    validate:"omitempty,max=100"`
-	OldPassword string `json:"old_password" validate:"omitempty,required_with=NewPassword,max=72"`
-	NewPassword string `json:"new_password" validate:"omitempty,min=8,max=72"`
+	FirstName string `json:"first_name" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name"  validate:"omitempty,max=100"`
+}
+
+// AccountResponse is the signed-in user's own view of their account.
+type AccountResponse struct {
+	User
+	MFAEnabled            bool `json:"mfa_enabled"`
+	PasswordChangeAllowed bool `json:"password_change_allowed"`
+}
+
+type AccountUpdateBody struct {
+	FirstName string `json:"first_name" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name"  validate:"omitempty,max=100"`
+}
+
+type PasswordChangeBody struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password"     validate:"required,min=8,max=72"`
+	Code            string `json:"code"             validate:"omitempty,len=6,numeric"`
 }
 
 type UserStatsResponse struct {
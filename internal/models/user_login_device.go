@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserLoginDevice is an IP and user agent pair a user has signed in from. New pairs trigger a
+// login alert; the fingerprint is a hash of both so lookups stay on an indexed column.
+type UserLoginDevice struct {
+	ID          uuid.UUID `gorm:"default:(-)"                                             json:"id"`
+	UserID      uuid.UUID `gorm:"not null;uniqueIndex:idx_user_login_devices_fingerprint" json:"user_id"`
+	Fingerprint string    `gorm:"not null;uniqueIndex:idx_user_login_devices_fingerprint" json:"-"`
+	IP          string    `gorm:"not null"                                                json:"ip"`
+	UserAgent   string    `gorm:"not null"                                                json:"user_agent"`
+	FirstSeenAt time.Time `gorm:"not null"                                                json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"not null"                                                json:"last_seen_at"`
+}
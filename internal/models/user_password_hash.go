@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserPasswordHash is a password a local user has replaced, kept to enforce the password
+// history rule of the password policy.
+type UserPasswordHash struct {
+	ID             uuid.UUID `gorm:"default:(-)"`
+	UserID         uuid.UUID `gorm:"not null;index:idx_user_password_hashes_user_id"`
+	HashedPassword string    `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"not null;index:idx_user_password_hashes_user_id"`
+}
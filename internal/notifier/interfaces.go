@@ -1,5 +1,12 @@
 package notifier
 
+import "github.com/safebucket/safebucket/internal/models"
+
 type INotifier interface {
 	NotifyFromTemplate(to string, subject string, templateName string, data interface{}) error
 }
+
+type IChannelNotifier interface {
+	NotifyChannel(channelType models.ChannelType, webhookURL string, templateName string, data interface{}) error
+	ValidateWebhookURL(channelType models.ChannelType, webhookURL string) error
+}
@@ -0,0 +1,201 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationMaxAttempts    = 6
+	NotificationRetryBaseDelay = time.Minute
+	NotificationRetryMaxDelay  = time.Hour
+	// NotificationClaimTimeout is how long a delivery attempt holds its entry; an entry whose
+	// sender died mid-attempt becomes due again once it has passed.
+	NotificationClaimTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrRecipientRejected marks a delivery the mail server refused permanently for the
+	// recipient, i.e. a bounce. Such notifications are not retried.
+	ErrRecipientRejected   = errors.New("recipient rejected")
+	ErrNotificationExpired = errors.New("notification expired")
+)
+
+// Expiring is implemented by template data that is useless past a deadline, such as a
+// challenge code. The queue stops delivering it and drops its payload once it has passed.
+type Expiring interface {
+	NotificationExpiresAt() time.Time
+}
+
+// QueuedNotifier persists every notification before handing it to the underlying notifier,
+// so a failed delivery is retried with backoff instead of being dropped. Payloads carry
+// secrets such as reset codes, so they are stored encrypted.
+type QueuedNotifier struct {
+	DB       *gorm.DB
+	Notifier INotifier
+	key      []byte
+}
+
+func NewQueuedNotifier(db *gorm.DB, n INotifier, encryptionKey string) *QueuedNotifier {
+	return &QueuedNotifier{DB: db, Notifier: n, key: []byte(encryptionKey)}
+}
+
+// NotifyFromTemplate queues the notification and attempts a first delivery right away.
+// Only a failure to persist it is returned; delivery failures are left to the retry worker.
+func (q *QueuedNotifier) NotifyFromTemplate(
+	to string,
+	subject string,
+	templateName string,
+	data any,
+) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
+	sealed, err := helpers.EncryptSecret(string(payload), q.key)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt notification payload: %w", err)
+	}
+
+	// The entry is inserted already claimed so the retry worker leaves it to this first attempt.
+	entry := models.QueuedNotification{
+		Recipient:     to,
+		Subject:       subject,
+		Template:      templateName,
+		Payload:       sealed,
+		Status:        models.NotificationStatusPending,
+		NextAttemptAt: time.Now().Add(NotificationClaimTimeout),
+	}
+	if expiring, ok := data.(Expiring); ok {
+		expiresAt := expiring.NotificationExpiresAt()
+		entry.ExpiresAt = &expiresAt
+	}
+	if err = q.DB.Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+
+	q.Deliver(&entry)
+	return nil
+}
+
+// Claim takes a due entry for a delivery attempt. The conditional update only succeeds for
+// one caller, so concurrent workers never send the same notification twice.
+func (q *QueuedNotifier) Claim(entry *models.QueuedNotification) (bool, error) {
+	claimUntil := time.Now().Add(NotificationClaimTimeout)
+	result := q.DB.Model(&models.QueuedNotification{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?",
+			entry.ID, models.NotificationStatusPending, entry.NextAttemptAt).
+		Update("next_attempt_at", claimUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	entry.NextAttemptAt = claimUntil
+	return true, nil
+}
+
+// Deliver sends a queued notification and records the outcome: sent, rescheduled with
+// backoff, or failed once the attempts are exhausted or the recipient bounced.
+func (q *QueuedNotifier) Deliver(entry *models.QueuedNotification) {
+	now := time.Now()
+	if entry.IsExpired(now) {
+		q.expire(entry)
+		return
+	}
+
+	var data map[string]any
+	payload, err := helpers.DecryptSecret(entry.Payload, q.key)
+	if err == nil {
+		err = json.Unmarshal([]byte(payload), &data)
+	}
+	if err == nil {
+		err = q.Notifier.NotifyFromTemplate(entry.Recipient, entry.Subject, entry.Template, data)
+	}
+
+	attempts := entry.Attempts + 1
+
+	if err == nil {
+		if dbErr := q.DB.Model(entry).Updates(map[string]any{
+			"status":     models.NotificationStatusSent,
+			"attempts":   attempts,
+			"payload":    "",
+			"last_error": nil,
+			"sent_at":    now,
+		}).Error; dbErr != nil {
+			zap.L().Error("Failed to mark notification as sent",
+				zap.String("id", entry.ID.String()),
+				zap.Error(dbErr))
+		}
+		q.setBounced(entry.Recipient, false)
+		return
+	}
+
+	bounced := errors.Is(err, ErrRecipientRejected)
+	updates := map[string]any{
+		"attempts":   attempts,
+		"last_error": err.Error(),
+	}
+	if bounced || attempts >= NotificationMaxAttempts {
+		updates["status"] = models.NotificationStatusFailed
+	} else {
+		updates["next_attempt_at"] = now.Add(RetryDelay(attempts))
+	}
+
+	zap.L().Warn("Failed to deliver notification",
+		zap.String("id", entry.ID.String()),
+		zap.String("template", entry.Template),
+		zap.Int("attempts", attempts),
+		zap.Bool("bounced", bounced),
+		zap.Error(err))
+
+	if dbErr := q.DB.Model(entry).Updates(updates).Error; dbErr != nil {
+		zap.L().Error("Failed to record notification failure",
+			zap.String("id", entry.ID.String()),
+			zap.Error(dbErr))
+	}
+	if bounced {
+		q.setBounced(entry.Recipient, true)
+	}
+}
+
+// expire gives up on a notification whose data is no longer valid and drops its payload.
+func (q *QueuedNotifier) expire(entry *models.QueuedNotification) {
+	if err := q.DB.Model(entry).Updates(map[string]any{
+		"status":     models.NotificationStatusFailed,
+		"payload":    "",
+		"last_error": ErrNotificationExpired.Error(),
+	}).Error; err != nil {
+		zap.L().Error("Failed to expire notification",
+			zap.String("id", entry.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// RetryDelay doubles the wait after every failed attempt, capped at NotificationRetryMaxDelay.
+func RetryDelay(attempts int) time.Duration {
+	delay := NotificationRetryBaseDelay
+	for i := 1; i < attempts && delay < NotificationRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, NotificationRetryMaxDelay)
+}
+
+func (q *QueuedNotifier) setBounced(email string, bounced bool) {
+	if err := q.DB.Model(&models.User{}).
+		Where("email = ? AND email_bounced = ?", email, !bounced).
+		Update("email_bounced", bounced).Error; err != nil {
+		zap.L().Error("Failed to update email bounced flag", zap.Error(err))
+	}
+}
@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"html/template"
 	"io/fs"
@@ -121,12 +122,29 @@ func (s *SMTPNotifier) NotifyFromTemplate(
 		return err
 	}
 	if err := msg.To(to); err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrRecipientRejected, err)
 	}
 	msg.Subject(subject)
 	if err := msg.SetBodyHTMLTemplate(tmpl, data); err != nil {
 		return err
 	}
 
-	return s.client.DialAndSend(msg)
+	if err := s.client.DialAndSend(msg); err != nil {
+		if isRecipientRejection(err) {
+			return fmt.Errorf("%w: %w", ErrRecipientRejected, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// isRecipientRejection reports whether the server refused the recipient with a permanent
+// (5xx) reply, as opposed to a transient outage worth retrying.
+func isRecipientRejection(err error) bool {
+	var sendErr *mail.SendError
+	if !errors.As(err, &sendErr) {
+		return false
+	}
+	return sendErr.Reason == mail.ErrSMTPRcptTo && sendErr.ErrorCode() >= 500
 }
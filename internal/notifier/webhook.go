@@ -0,0 +1,213 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/mails"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const webhookTimeout = 10 * time.Second
+
+var (
+	ErrWebhookHostNotAllowed = errors.New("webhook host not allowed for channel type")
+	ErrWebhookAddressBlocked = errors.New("webhook resolves to a non-public address")
+)
+
+// teamsWebhookDomains hold the incoming webhooks of Teams connectors and of the Power Automate
+// workflows that replace them.
+var teamsWebhookDomains = []string{".webhook.office.com", ".logic.azure.com", ".api.powerplatform.com"}
+
+// WebhookNotifier posts notifications rendered from the plain-text templates to chat
+// incoming webhooks (Slack, Microsoft Teams, Mattermost). Webhook URLs are user input, so
+// they are limited to the hosts of each service and never reach internal addresses.
+type WebhookNotifier struct {
+	client          *http.Client
+	mattermostHosts []string
+	templates       map[string]*template.Template
+}
+
+// NewWebhookNotifier returns a notifier for the public Slack and Teams webhooks and for the
+// self-hosted Mattermost servers listed in mattermostHosts.
+func NewWebhookNotifier(mattermostHosts []string) *WebhookNotifier {
+	hosts := make([]string, len(mattermostHosts))
+	for i, host := range mattermostHosts {
+		hosts[i] = strings.ToLower(host)
+	}
+
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: rejectNonPublicAddress}
+	return &WebhookNotifier{
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: webhookTimeout},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		mattermostHosts: hosts,
+		templates:       parseTextTemplates(),
+	}
+}
+
+// ValidateWebhookURL checks that the webhook URL points at the service of the channel type:
+// hooks.slack.com for Slack, the Microsoft webhook domains for Teams and the configured
+// servers for Mattermost. Slack and Teams webhooks must use HTTPS.
+func (w *WebhookNotifier) ValidateWebhookURL(channelType models.ChannelType, webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	host := strings.ToLower(u.Hostname())
+
+	var allowed bool
+	switch channelType {
+	case models.ChannelTypeSlack:
+		allowed = u.Scheme == "https" && host == "hooks.slack.com"
+	case models.ChannelTypeTeams:
+		allowed = u.Scheme == "https" && slices.ContainsFunc(teamsWebhookDomains, func(domain string) bool {
+			return strings.HasSuffix(host, domain)
+		})
+	case models.ChannelTypeMattermost:
+		allowed = (u.Scheme == "https" || u.Scheme == "http") && slices.Contains(w.mattermostHosts, host)
+	}
+	if !allowed {
+		return ErrWebhookHostNotAllowed
+	}
+
+	return nil
+}
+
+// rejectNonPublicAddress runs once the host has been resolved, so names that point at or are
+// rebound to loopback, private or link-local addresses are refused as well.
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return ErrWebhookAddressBlocked
+	}
+
+	return nil
+}
+
+func parseTextTemplates() map[string]*template.Template {
+	templates := make(map[string]*template.Template)
+
+	entries, err := fs.ReadDir(mails.TemplatesFS, ".")
+	if err != nil {
+		zap.L().Fatal("failed to read embedded text templates", zap.Error(err))
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".txt") {
+			continue
+		}
+
+		tmpl, parseErr := template.ParseFS(mails.TemplatesFS, name)
+		if parseErr != nil {
+			zap.L().Fatal("failed to parse text template", zap.String("template", name), zap.Error(parseErr))
+		}
+
+		templates[strings.TrimSuffix(name, ".txt")] = tmpl
+	}
+
+	return templates
+}
+
+func (w *WebhookNotifier) NotifyChannel(
+	channelType models.ChannelType,
+	webhookURL string,
+	templateName string,
+	data any,
+) error {
+	if err := w.ValidateWebhookURL(channelType, webhookURL); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(templateName, "/\\.") {
+		return fmt.Errorf("invalid template name: %s", templateName)
+	}
+
+	tmpl, ok := w.templates[templateName]
+	if !ok {
+		return fmt.Errorf("unknown text template: %s", templateName)
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return fmt.Errorf("failed to render text template: %w", err)
+	}
+
+	payload, err := json.Marshal(webhookPayload(channelType, strings.TrimSpace(text.String())))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// webhookPayload wraps the rendered text in the body each service expects. Slack and
+// Mattermost share the same incoming webhook format; Teams workflows expect an Adaptive Card.
+func webhookPayload(channelType models.ChannelType, text string) any {
+	if channelType == models.ChannelTypeTeams {
+		return map[string]any{
+			"type": "message",
+			"attachments": []map[string]any{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]any{{
+						"type": "TextBlock",
+						"text": text,
+						"wrap": true,
+					}},
+				},
+			}},
+		}
+	}
+
+	return map[string]string{"text": text}
+}
@@ -0,0 +1,192 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/models"
+)
+
+type webhookStandIn struct {
+	server   *httptest.Server
+	requests []map[string]any
+}
+
+func newWebhookStandIn(t *testing.T, status int) *webhookStandIn {
+	t.Helper()
+
+	standIn := &webhookStandIn{}
+	standIn.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		_ = json.Unmarshal(body, &payload)
+		standIn.requests = append(standIn.requests, payload)
+		if status >= 300 && status < 400 {
+			w.Header().Set("Location", "/followed")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(standIn.server.Close)
+
+	return standIn
+}
+
+// notifier returns a notifier whose connections all land on the stand-in, so the real webhook
+// hosts pass the URL checks without leaving the machine.
+func (s *webhookStandIn) notifier() *WebhookNotifier {
+	n := NewWebhookNotifier([]string{"chat.example.com"})
+	addr := s.server.Listener.Addr().String()
+	n.client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // test stand-in certificate
+	}
+	return n
+}
+
+var testWebhookURLs = map[models.ChannelType]string{
+	models.ChannelTypeSlack:      "https://hooks.slack.com/services/T000/B000/XXXX",
+	models.ChannelTypeTeams:      "https://contoso.webhook.office.com/webhookb2/abc",
+	models.ChannelTypeMattermost: "https://chat.example.com/hooks/abc",
+}
+
+var testActivity = map[string]string{
+	"ActionText": `alice@example.com uploaded "report.pdf" to bucket "Finance".`,
+	"WebURL":     "http://localhost:3000",
+	"BucketID":   "6f1c1f4e-4a43-4c6e-9a0c-2d4f8b8d9a10",
+}
+
+const testActivityText = "alice@example.com uploaded \"report.pdf\" to bucket \"Finance\".\n" +
+	"http://localhost:3000/buckets/6f1c1f4e-4a43-4c6e-9a0c-2d4f8b8d9a10"
+
+func TestWebhookNotifier_SlackAndMattermostPayload(t *testing.T) {
+	for _, channelType := range []models.ChannelType{models.ChannelTypeSlack, models.ChannelTypeMattermost} {
+		standIn := newWebhookStandIn(t, http.StatusOK)
+		n := standIn.notifier()
+
+		err := n.NotifyChannel(channelType, testWebhookURLs[channelType], "file_activity", testActivity)
+		if err != nil {
+			t.Fatalf("%s: NotifyChannel failed: %v", channelType, err)
+		}
+
+		if len(standIn.requests) != 1 {
+			t.Fatalf("%s: expected 1 request, got %d", channelType, len(standIn.requests))
+		}
+		if standIn.requests[0]["text"] != testActivityText {
+			t.Errorf("%s: unexpected text %q", channelType, standIn.requests[0]["text"])
+		}
+	}
+}
+
+func TestWebhookNotifier_TeamsPayload(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusAccepted)
+	n := standIn.notifier()
+
+	webhookURL := testWebhookURLs[models.ChannelTypeTeams]
+	if err := n.NotifyChannel(models.ChannelTypeTeams, webhookURL, "file_activity", testActivity); err != nil {
+		t.Fatalf("NotifyChannel failed: %v", err)
+	}
+
+	if len(standIn.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(standIn.requests))
+	}
+
+	attachments, ok := standIn.requests[0]["attachments"].([]any)
+	if !ok || len(attachments) != 1 {
+		t.Fatalf("expected a single adaptive card attachment, got %v", standIn.requests[0]["attachments"])
+	}
+	content := attachments[0].(map[string]any)["content"].(map[string]any)
+	block := content["body"].([]any)[0].(map[string]any)
+	if block["text"] != testActivityText {
+		t.Errorf("unexpected card text %q", block["text"])
+	}
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	failing := newWebhookStandIn(t, http.StatusInternalServerError)
+	n := failing.notifier()
+	webhookURL := testWebhookURLs[models.ChannelTypeSlack]
+
+	if err := n.NotifyChannel(models.ChannelTypeSlack, webhookURL, "file_activity", testActivity); err == nil {
+		t.Error("expected an error for a non-2xx response")
+	}
+
+	if err := n.NotifyChannel(models.ChannelTypeSlack, webhookURL, "unknown", testActivity); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+
+	if err := n.NotifyChannel(models.ChannelTypeSlack, webhookURL, "../base", testActivity); err == nil {
+		t.Error("expected an error for an invalid template name")
+	}
+}
+
+func TestWebhookNotifier_ValidateWebhookURL(t *testing.T) {
+	n := NewWebhookNotifier([]string{"Chat.Example.com"})
+
+	tests := []struct {
+		channelType models.ChannelType
+		url         string
+		allowed     bool
+	}{
+		{models.ChannelTypeSlack, "https://hooks.slack.com/services/T000/B000/XXXX", true},
+		{models.ChannelTypeSlack, "http://hooks.slack.com/services/T000/B000/XXXX", false},
+		{models.ChannelTypeSlack, "https://hooks.slack.com.evil.test/services", false},
+		{models.ChannelTypeSlack, "https://contoso.webhook.office.com/webhookb2/abc", false},
+		{models.ChannelTypeTeams, "https://contoso.webhook.office.com/webhookb2/abc", true},
+		{models.ChannelTypeTeams, "https://prod-01.westeurope.logic.azure.com/workflows/abc", true},
+		{models.ChannelTypeTeams, "https://webhook.office.com.evil.test/abc", false},
+		{models.ChannelTypeTeams, "https://169.254.169.254/latest/meta-data", false},
+		{models.ChannelTypeMattermost, "https://chat.example.com/hooks/abc", true},
+		{models.ChannelTypeMattermost, "http://chat.example.com:8065/hooks/abc", true},
+		{models.ChannelTypeMattermost, "https://other.example.com/hooks/abc", false},
+		{models.ChannelTypeMattermost, "ftp://chat.example.com/hooks/abc", false},
+	}
+
+	for _, tt := range tests {
+		err := n.ValidateWebhookURL(tt.channelType, tt.url)
+		if tt.allowed && err != nil {
+			t.Errorf("%s %s: expected the URL to be allowed, got %v", tt.channelType, tt.url, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrWebhookHostNotAllowed) {
+			t.Errorf("%s %s: expected ErrWebhookHostNotAllowed, got %v", tt.channelType, tt.url, err)
+		}
+	}
+}
+
+func TestWebhookNotifier_RefusesNonPublicAddresses(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusOK)
+	_, port, _ := net.SplitHostPort(standIn.server.Listener.Addr().String())
+
+	// localhost is configured as a Mattermost server, so only the dial check stands in the way.
+	n := NewWebhookNotifier([]string{"localhost"})
+	err := n.NotifyChannel(models.ChannelTypeMattermost, "https://localhost:"+port+"/hooks/abc", "file_activity",
+		testActivity)
+
+	if !errors.Is(err, ErrWebhookAddressBlocked) {
+		t.Fatalf("expected ErrWebhookAddressBlocked, got %v", err)
+	}
+	if len(standIn.requests) != 0 {
+		t.Errorf("expected no request to reach the loopback server, got %d", len(standIn.requests))
+	}
+}
+
+func TestWebhookNotifier_DoesNotFollowRedirects(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusFound)
+	n := standIn.notifier()
+
+	webhookURL := testWebhookURLs[models.ChannelTypeSlack]
+	if err := n.NotifyChannel(models.ChannelTypeSlack, webhookURL, "file_activity", testActivity); err == nil {
+		t.Error("expected an error for a redirect response")
+	}
+	if len(standIn.requests) != 1 {
+		t.Errorf("expected the redirect not to be followed, got %d requests", len(standIn.requests))
+	}
+}
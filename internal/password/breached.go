@@ -0,0 +1,98 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is the hash format of the breached password corpus
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	sha1HexLength = 40
+	// rangePrefixLength matches the prefix size of the Pwned Passwords range API.
+	rangePrefixLength = 5
+)
+
+// BreachedList is an offline copy of a breached password corpus, indexed like the Pwned
+// Passwords range API: hashes are grouped by their first five hex characters and a lookup only
+// ever compares suffixes within one group.
+type BreachedList struct {
+	ranges map[string][]string
+}
+
+// LoadBreachedList reads a file of upper or lower case SHA-1 hashes, one per line, optionally
+// followed by ":COUNT" as in the HIBP downloads. Empty lines and lines starting with "#" are
+// skipped.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached passwords file: %w", err)
+	}
+	defer file.Close()
+
+	list := &BreachedList{ranges: map[string][]string{}}
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(entry, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("breached passwords file line %d: not a SHA-1 hash", line)
+		}
+		if _, decodeErr := hex.DecodeString(hash); decodeErr != nil {
+			return nil, fmt.Errorf("breached passwords file line %d: not a SHA-1 hash", line)
+		}
+
+		prefix := hash[:rangePrefixLength]
+		list.ranges[prefix] = append(list.ranges[prefix], hash[rangePrefixLength:])
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached passwords file: %w", err)
+	}
+
+	for prefix := range list.ranges {
+		slices.Sort(list.ranges[prefix])
+	}
+	return list, nil
+}
+
+// Range returns the sorted hash suffixes sharing the given five-character prefix.
+func (l *BreachedList) Range(prefix string) []string {
+	if l == nil {
+		return nil
+	}
+	return l.ranges[strings.ToUpper(prefix)]
+}
+
+// Contains reports whether the password appears in the list. A nil list contains nothing.
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // lookup key of the breached password corpus
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := slices.BinarySearch(l.Range(hash[:rangePrefixLength]), hash[rangePrefixLength:])
+	return found
+}
+
+// Size returns the number of hashes loaded.
+func (l *BreachedList) Size() int {
+	if l == nil {
+		return 0
+	}
+	total := 0
+	for _, suffixes := range l.ranges {
+		total += len(suffixes)
+	}
+	return total
+}
@@ -0,0 +1,66 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password" and "letmein".
+const breachedFixture = `# sample corpus
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824
+b7a875fc1ea228b9061041b7cec4bd3c52ab3ce3
+
+`
+
+func writeBreachedFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadBreachedList(t *testing.T) {
+	list, err := LoadBreachedList(writeBreachedFile(t, breachedFixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, list.Size())
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("letmein"))
+	assert.False(t, list.Contains("Correct-Horse-9"))
+	assert.Equal(t, []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, list.Range("5baa6"))
+}
+
+func TestLoadBreachedList_RejectsMalformedLines(t *testing.T) {
+	_, err := LoadBreachedList(writeBreachedFile(t, "not-a-hash:12\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestLoadBreachedList_MissingFile(t *testing.T) {
+	_, err := LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestNilBreachedListContainsNothing(t *testing.T) {
+	var list *BreachedList
+	assert.False(t, list.Contains("password"))
+	assert.Equal(t, 0, list.Size())
+}
+
+func TestNewPolicy_RejectsBreachedPasswords(t *testing.T) {
+	policy, err := NewPolicy(models.PasswordPolicy{
+		MinLength:             8,
+		BreachedPasswordsFile: writeBreachedFile(t, breachedFixture),
+	})
+	require.NoError(t, err)
+
+	requireCode(t, policy.Validate("password", "jane@example.com"), apierrors.CodePasswordBreached)
+	require.NoError(t, policy.Validate("Correct-Horse-9", "jane@example.com"))
+}
@@ -0,0 +1,104 @@
+package password
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+)
+
+// minEmailFragment is the shortest email local part checked by DisallowEmail, so short
+// addresses such as "jo@" do not reject every password containing "jo".
+const minEmailFragment = 3
+
+// Policy enforces the platform password rules. The zero value only applies the request
+// validation limits, which keeps services usable without a configured policy.
+type Policy struct {
+	Rules    models.PasswordPolicy
+	Breached *BreachedList
+}
+
+// NewPolicy builds the policy from configuration and loads the breached password list when
+// one is configured.
+func NewPolicy(rules models.PasswordPolicy) (Policy, error) {
+	policy := Policy{Rules: rules}
+	if rules.BreachedPasswordsFile == "" {
+		return policy, nil
+	}
+
+	breached, err := LoadBreachedList(rules.BreachedPasswordsFile)
+	if err != nil {
+		return Policy{}, err
+	}
+	policy.Breached = breached
+	return policy, nil
+}
+
+// Validate checks a candidate password against the length, character class, email and
+// breached password rules. Password history is checked separately as it needs the database.
+func (p Policy) Validate(password string, email string) error {
+	if utf8.RuneCountInString(password) < p.Rules.MinLength {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordTooShort)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.Rules.RequireUppercase && !hasUpper:
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordMissingUppercase)
+	case p.Rules.RequireLowercase && !hasLower:
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordMissingLowercase)
+	case p.Rules.RequireDigit && !hasDigit:
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordMissingDigit)
+	case p.Rules.RequireSymbol && !hasSymbol:
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordMissingSymbol)
+	}
+
+	if p.Rules.DisallowEmail && containsEmail(password, email) {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordContainsEmail)
+	}
+
+	if p.Breached.Contains(password) {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordBreached)
+	}
+
+	return nil
+}
+
+// HistoryDepth returns how many previous hashes, besides the current one, are kept and checked
+// for reuse.
+func (p Policy) HistoryDepth() int {
+	if p.Rules.HistorySize <= 1 {
+		return 0
+	}
+	return p.Rules.HistorySize - 1
+}
+
+func containsEmail(password string, email string) bool {
+	lowered := strings.ToLower(password)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+	if strings.Contains(lowered, email) {
+		return true
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+	return utf8.RuneCountInString(local) >= minEmailFragment && strings.Contains(lowered, local)
+}
@@ -0,0 +1,70 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *apierrors.APIError
+	require.True(t, errors.As(err, &apiErr), "expected APIError, got %T: %v", err, err)
+	assert.Equal(t, code, apiErr.Code)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	strict := Policy{Rules: models.PasswordPolicy{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DisallowEmail:    true,
+	}}
+
+	tests := []struct {
+		name     string
+		password string
+		code     string
+	}{
+		{"too short", "Ab1!", apierrors.CodePasswordTooShort},
+		{"missing uppercase", "lowercase-only-1", apierrors.CodePasswordMissingUppercase},
+		{"missing lowercase", "UPPERCASE-ONLY-1", apierrors.CodePasswordMissingLowercase},
+		{"missing digit", "No-Digits-Here", apierrors.CodePasswordMissingDigit},
+		{"missing symbol", "NoSymbolsHere1", apierrors.CodePasswordMissingSymbol},
+		{"contains local part", "Jane.Doe-2024!x", apierrors.CodePasswordContainsEmail},
+		{"valid", "Correct-Horse-9", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := strict.Validate(tt.password, "jane.doe@example.com")
+			if tt.code == "" {
+				require.NoError(t, err)
+				return
+			}
+			requireCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestPolicy_ValidateZeroValue(t *testing.T) {
+	require.NoError(t, Policy{}.Validate("x", "jane@example.com"))
+}
+
+func TestPolicy_ShortLocalPartIsIgnored(t *testing.T) {
+	policy := Policy{Rules: models.PasswordPolicy{DisallowEmail: true}}
+	require.NoError(t, policy.Validate("jolly-good-password", "jo@example.com"))
+	requireCode(t, policy.Validate("x-JO@EXAMPLE.COM-x", "jo@example.com"), apierrors.CodePasswordContainsEmail)
+}
+
+func TestPolicy_HistoryDepth(t *testing.T) {
+	assert.Equal(t, 0, Policy{}.HistoryDepth())
+	assert.Equal(t, 0, Policy{Rules: models.PasswordPolicy{HistorySize: 1}}.HistoryDepth())
+	assert.Equal(t, 4, Policy{Rules: models.PasswordPolicy{HistorySize: 5}}.HistoryDepth())
+}
@@ -36,4 +36,6 @@ var (
 	ResourceUser      = defineResource("user")
 	ResourceMFADevice = defineResource("mfa_device")
 	ResourceShare     = defineResource("share")
+	ResourceRetention = defineResource("retention_policy")
+	ResourceLegalHold = defineResource("legal_hold")
 )
@@ -0,0 +1,390 @@
+package retention
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Action is the kind of deletion a retention check is evaluated for.
+type Action int
+
+const (
+	// ActionTrash moves an object to the trash. Only locked retention policies block it.
+	ActionTrash Action = iota
+	// ActionPurge permanently deletes an object, by hand, on expiry or when its trash expires.
+	ActionPurge
+)
+
+// StoredStatuses are the statuses of files whose content belongs to the bucket. Uploads in
+// progress and share uploads awaiting approval are never protected.
+var StoredStatuses = []models.FileStatus{
+	models.FileStatusUploaded,
+	models.FileStatusDeleted,
+	models.FileStatusRestoring,
+}
+
+type set map[uuid.UUID]struct{}
+
+func (s set) has(id uuid.UUID) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s set) keys() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Scope is a snapshot of the active legal holds and assigned retention policies. Held folders
+// are expanded to their whole subtree when the scope is loaded, so checking a file only needs
+// its direct parent.
+type Scope struct {
+	now      time.Time
+	holds    int
+	buckets  set
+	folders  set
+	files    set
+	policies map[uuid.UUID]models.RetentionPolicy
+}
+
+// LoadScope loads the legal holds and retention policies of a single bucket, or of every
+// bucket when bucketID is nil.
+func LoadScope(db *gorm.DB, bucketID *uuid.UUID) (*Scope, error) {
+	scope := &Scope{
+		now:      time.Now(),
+		buckets:  set{},
+		folders:  set{},
+		files:    set{},
+		policies: map[uuid.UUID]models.RetentionPolicy{},
+	}
+
+	holdsQuery := db.Where("released_at IS NULL")
+	if bucketID != nil {
+		holdsQuery = holdsQuery.Where("bucket_id = ?", *bucketID)
+	}
+
+	var holds []models.LegalHold
+	if err := holdsQuery.Find(&holds).Error; err != nil {
+		return nil, err
+	}
+
+	scope.holds = len(holds)
+	var heldFolders []uuid.UUID
+	for _, hold := range holds {
+		switch {
+		case hold.FileID != nil:
+			scope.files[*hold.FileID] = struct{}{}
+		case hold.FolderID != nil:
+			heldFolders = append(heldFolders, *hold.FolderID)
+		default:
+			scope.buckets[hold.BucketID] = struct{}{}
+		}
+	}
+
+	subtree, err := FolderSubtree(db, heldFolders)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range subtree {
+		scope.folders[id] = struct{}{}
+	}
+
+	bucketsQuery := db.Unscoped().Model(&models.Bucket{}).Where("retention_policy_id IS NOT NULL")
+	if bucketID != nil {
+		bucketsQuery = bucketsQuery.Where("id = ?", *bucketID)
+	}
+
+	var buckets []models.Bucket
+	if err = bucketsQuery.Select("id", "retention_policy_id").Find(&buckets).Error; err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return scope, nil
+	}
+
+	policyIDs := make([]uuid.UUID, 0, len(buckets))
+	for _, bucket := range buckets {
+		policyIDs = append(policyIDs, *bucket.RetentionPolicyID)
+	}
+
+	var policies []models.RetentionPolicy
+	if err = db.Where("id IN ?", policyIDs).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]models.RetentionPolicy, len(policies))
+	for _, policy := range policies {
+		byID[policy.ID] = policy
+	}
+	for _, bucket := range buckets {
+		if policy, ok := byID[*bucket.RetentionPolicyID]; ok {
+			scope.policies[bucket.ID] = policy
+		}
+	}
+
+	return scope, nil
+}
+
+// FolderSubtree returns the given folders and all their descendants, trashed or not.
+func FolderSubtree(db *gorm.DB, roots []uuid.UUID) ([]uuid.UUID, error) {
+	seen := set{}
+	frontier := make([]uuid.UUID, 0, len(roots))
+	for _, id := range roots {
+		if !seen.has(id) {
+			seen[id] = struct{}{}
+			frontier = append(frontier, id)
+		}
+	}
+
+	for len(frontier) > 0 {
+		var children []uuid.UUID
+		if err := db.Unscoped().Model(&models.Folder{}).
+			Where("folder_id IN ?", frontier).
+			Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+
+		frontier = frontier[:0]
+		for _, id := range children {
+			if !seen.has(id) {
+				seen[id] = struct{}{}
+				frontier = append(frontier, id)
+			}
+		}
+	}
+
+	return seen.keys(), nil
+}
+
+// Held reports whether a legal hold covers the file, directly or through its folder or bucket.
+func (s *Scope) Held(file models.File) bool {
+	return s.buckets.has(file.BucketID) ||
+		s.files.has(file.ID) ||
+		(file.FolderID != nil && s.folders.has(*file.FolderID))
+}
+
+// RetainedUntil returns the end of the retention period of the file and whether it is still
+// running.
+func (s *Scope) RetainedUntil(file models.File) (time.Time, bool) {
+	policy, ok := s.policies[file.BucketID]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	until := file.CreatedAt.AddDate(0, 0, policy.RetentionDays)
+	return until, s.now.Before(until)
+}
+
+// Check returns a conflict error when the file cannot be deleted with the given action.
+func (s *Scope) Check(file models.File, action Action) error {
+	if s.Held(file) {
+		return apierrors.New(http.StatusConflict, apierrors.CodeLegalHoldActive)
+	}
+
+	_, retained := s.RetainedUntil(file)
+	if retained && (action == ActionPurge || s.policies[file.BucketID].Locked) {
+		return apierrors.New(http.StatusConflict, apierrors.CodeRetentionPeriodActive)
+	}
+
+	return nil
+}
+
+// ObjectLock returns the lock the storage provider should enforce on the file. Only locked
+// policies are mirrored, since a compliance-mode retention can never be shortened again.
+func (s *Scope) ObjectLock(file models.File) storage.ObjectLock {
+	lock := storage.ObjectLock{LegalHold: s.Held(file)}
+	if until, retained := s.RetainedUntil(file); retained && s.policies[file.BucketID].Locked {
+		lock.RetainUntil = &until
+	}
+	return lock
+}
+
+// protectedFiles builds the condition matching files of the files table that the action
+// cannot delete. An empty condition means nothing is protected.
+func (s *Scope) protectedFiles(action Action) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if len(s.buckets) > 0 {
+		conditions = append(conditions, "bucket_id IN ?")
+		args = append(args, s.buckets.keys())
+	}
+	if len(s.folders) > 0 {
+		conditions = append(conditions, "(folder_id IS NOT NULL AND folder_id IN ?)")
+		args = append(args, s.folders.keys())
+	}
+	if len(s.files) > 0 {
+		conditions = append(conditions, "id IN ?")
+		args = append(args, s.files.keys())
+	}
+	for bucketID, policy := range s.policies {
+		if action == ActionTrash && !policy.Locked {
+			continue
+		}
+		conditions = append(conditions, "(bucket_id = ? AND created_at > ?)")
+		args = append(args, bucketID, s.now.AddDate(0, 0, -policy.RetentionDays))
+	}
+
+	return strings.Join(conditions, " OR "), args
+}
+
+// ExcludeProtectedFiles narrows a files query to the files the action can delete, so batch
+// jobs never keep picking up the same protected rows.
+func (s *Scope) ExcludeProtectedFiles(query *gorm.DB, action Action) *gorm.DB {
+	condition, args := s.protectedFiles(action)
+	if condition == "" {
+		return query
+	}
+	return query.Where("NOT ("+condition+")", args...)
+}
+
+// firstProtectedFile returns the error of the first file of the query the action cannot delete.
+func (s *Scope) firstProtectedFile(query *gorm.DB, action Action) error {
+	condition, args := s.protectedFiles(action)
+	if condition == "" {
+		return nil
+	}
+
+	var files []models.File
+	if err := query.Where("("+condition+")", args...).Limit(1).Find(&files).Error; err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	return s.Check(files[0], action)
+}
+
+// CheckFile returns a conflict error when a legal hold or the retention policy of its bucket
+// prevents the file from being deleted.
+func CheckFile(db *gorm.DB, file models.File, action Action) error {
+	if !slices.Contains(StoredStatuses, file.Status) {
+		return nil
+	}
+
+	scope, err := LoadScope(db, &file.BucketID)
+	if err != nil {
+		return err
+	}
+	return scope.Check(file, action)
+}
+
+// CheckFolder returns a conflict error when the folder, one of its ancestors or anything
+// below it is under legal hold, or when it contains files that are still retained.
+func CheckFolder(db *gorm.DB, folder models.Folder, action Action) error {
+	scope, err := LoadScope(db, &folder.BucketID)
+	if err != nil {
+		return err
+	}
+
+	if scope.buckets.has(folder.BucketID) || scope.folders.has(folder.ID) {
+		return apierrors.New(http.StatusConflict, apierrors.CodeLegalHoldActive)
+	}
+
+	subtree, err := FolderSubtree(db, []uuid.UUID{folder.ID})
+	if err != nil {
+		return err
+	}
+	for _, id := range subtree {
+		if scope.folders.has(id) {
+			return apierrors.New(http.StatusConflict, apierrors.CodeLegalHoldActive)
+		}
+	}
+
+	return scope.firstProtectedFile(
+		db.Unscoped().Where("folder_id IN ? AND status IN ?", subtree, StoredStatuses),
+		action,
+	)
+}
+
+// ProtectedFolders returns the folders of the batch the action cannot delete: folders under a
+// legal hold, or whose subtree holds a held or retained file. The whole batch is resolved with
+// one query per tree level and a single files query, so batch jobs need not call CheckFolder on
+// every folder.
+func (s *Scope) ProtectedFolders(db *gorm.DB, folders []models.Folder, action Action) (map[uuid.UUID]bool, error) {
+	protected := map[uuid.UUID]bool{}
+	rootOf := map[uuid.UUID]uuid.UUID{}
+	frontier := make([]uuid.UUID, 0, len(folders))
+	for _, folder := range folders {
+		if s.buckets.has(folder.BucketID) {
+			protected[folder.ID] = true
+		}
+		rootOf[folder.ID] = folder.ID
+		frontier = append(frontier, folder.ID)
+	}
+
+	for len(frontier) > 0 {
+		var children []models.Folder
+		if err := db.Unscoped().
+			Select("id", "folder_id").
+			Where("folder_id IN ?", frontier).
+			Find(&children).Error; err != nil {
+			return nil, err
+		}
+
+		frontier = frontier[:0]
+		for _, child := range children {
+			if _, seen := rootOf[child.ID]; !seen {
+				rootOf[child.ID] = rootOf[*child.FolderID]
+				frontier = append(frontier, child.ID)
+			}
+		}
+	}
+
+	subtree := make([]uuid.UUID, 0, len(rootOf))
+	for id, root := range rootOf {
+		if s.folders.has(id) {
+			protected[root] = true
+		}
+		subtree = append(subtree, id)
+	}
+
+	condition, args := s.protectedFiles(action)
+	if condition == "" || len(subtree) == 0 {
+		return protected, nil
+	}
+
+	var holding []uuid.UUID
+	if err := db.Unscoped().Model(&models.File{}).
+		Where("folder_id IN ? AND status IN ?", subtree, StoredStatuses).
+		Where("("+condition+")", args...).
+		Distinct().
+		Pluck("folder_id", &holding).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range holding {
+		protected[rootOf[id]] = true
+	}
+
+	return protected, nil
+}
+
+// CheckBucket returns a conflict error when the bucket has an active legal hold or still
+// holds files within their retention period.
+func CheckBucket(db *gorm.DB, bucketID uuid.UUID) error {
+	scope, err := LoadScope(db, &bucketID)
+	if err != nil {
+		return err
+	}
+
+	if scope.holds > 0 {
+		return apierrors.New(http.StatusConflict, apierrors.CodeLegalHoldActive)
+	}
+
+	return scope.firstProtectedFile(
+		db.Unscoped().Where("bucket_id = ? AND status IN ?", bucketID, StoredStatuses),
+		ActionPurge,
+	)
+}
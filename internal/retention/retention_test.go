@@ -0,0 +1,270 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/database"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	db     *gorm.DB
+	user   models.User
+	bucket models.Bucket
+	parent models.Folder
+	child  models.Folder
+}
+
+func setupFixture(t *testing.T) fixture {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	database.RunMigrations(sqlDB, database.DialectSQLite)
+	database.RegisterCallbacks(db)
+
+	user := models.User{
+		Email:        "retention@example.com",
+		ProviderType: models.LocalProviderType,
+		ProviderKey:  string(models.LocalProviderType),
+		Role:         models.RoleAdmin,
+	}
+	require.NoError(t, db.Create(&user).Error)
+
+	bucket := models.Bucket{Name: "records", CreatedBy: user.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+
+	parent := models.Folder{Name: "contracts", BucketID: bucket.ID}
+	require.NoError(t, db.Create(&parent).Error)
+	child := models.Folder{Name: "2026", BucketID: bucket.ID, FolderID: &parent.ID}
+	require.NoError(t, db.Create(&child).Error)
+
+	return fixture{db: db, user: user, bucket: bucket, parent: parent, child: child}
+}
+
+// UpdateColumn bypasses GORM's auto-timestamp hook so the backdated created_at sticks.
+func (f fixture) file(t *testing.T, folderID *uuid.UUID, createdAt time.Time) models.File {
+	t.Helper()
+
+	file := models.File{
+		Name:     "file-" + uuid.NewString(),
+		Status:   models.FileStatusUploaded,
+		BucketID: f.bucket.ID,
+		FolderID: folderID,
+	}
+	require.NoError(t, f.db.Create(&file).Error)
+	require.NoError(t, f.db.Model(&file).UpdateColumn("created_at", createdAt).Error)
+	require.NoError(t, f.db.First(&file, "id = ?", file.ID).Error)
+	return file
+}
+
+func (f fixture) hold(t *testing.T, folderID, fileID *uuid.UUID) models.LegalHold {
+	t.Helper()
+
+	hold := models.LegalHold{
+		BucketID:  f.bucket.ID,
+		FolderID:  folderID,
+		FileID:    fileID,
+		Reason:    "litigation",
+		CreatedBy: f.user.ID,
+	}
+	require.NoError(t, f.db.Create(&hold).Error)
+	return hold
+}
+
+func (f fixture) assign(t *testing.T, days int, locked bool) {
+	t.Helper()
+
+	policy := models.RetentionPolicy{Name: "policy", RetentionDays: days, Locked: locked, CreatedBy: f.user.ID}
+	require.NoError(t, f.db.Create(&policy).Error)
+	require.NoError(t, f.db.Model(&f.bucket).Update("retention_policy_id", policy.ID).Error)
+}
+
+func requireConflict(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var apiErr *apierrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, code, apiErr.Code)
+}
+
+func TestCheckFile_LegalHolds(t *testing.T) {
+	t.Run("file hold blocks trash and purge", func(t *testing.T) {
+		f := setupFixture(t)
+		file := f.file(t, nil, time.Now())
+		f.hold(t, nil, &file.ID)
+
+		requireConflict(t, CheckFile(f.db, file, ActionTrash), apierrors.CodeLegalHoldActive)
+		requireConflict(t, CheckFile(f.db, file, ActionPurge), apierrors.CodeLegalHoldActive)
+	})
+
+	t.Run("folder hold covers nested files", func(t *testing.T) {
+		f := setupFixture(t)
+		nested := f.file(t, &f.child.ID, time.Now())
+		outside := f.file(t, nil, time.Now())
+		f.hold(t, &f.parent.ID, nil)
+
+		requireConflict(t, CheckFile(f.db, nested, ActionPurge), apierrors.CodeLegalHoldActive)
+		assert.NoError(t, CheckFile(f.db, outside, ActionPurge))
+	})
+
+	t.Run("released hold no longer applies", func(t *testing.T) {
+		f := setupFixture(t)
+		file := f.file(t, nil, time.Now())
+		hold := f.hold(t, nil, nil)
+		require.NoError(t, f.db.Model(&hold).Update("released_at", time.Now()).Error)
+
+		assert.NoError(t, CheckFile(f.db, file, ActionPurge))
+	})
+
+	t.Run("uploads in progress are not protected", func(t *testing.T) {
+		f := setupFixture(t)
+		file := f.file(t, nil, time.Now())
+		file.Status = models.FileStatusUploading
+		f.hold(t, nil, nil)
+
+		assert.NoError(t, CheckFile(f.db, file, ActionPurge))
+	})
+}
+
+func TestCheckFile_RetentionPolicies(t *testing.T) {
+	t.Run("unlocked policy blocks purge but not trash", func(t *testing.T) {
+		f := setupFixture(t)
+		f.assign(t, 30, false)
+		file := f.file(t, nil, time.Now().AddDate(0, 0, -10))
+
+		assert.NoError(t, CheckFile(f.db, file, ActionTrash))
+		requireConflict(t, CheckFile(f.db, file, ActionPurge), apierrors.CodeRetentionPeriodActive)
+	})
+
+	t.Run("locked policy blocks trash", func(t *testing.T) {
+		f := setupFixture(t)
+		f.assign(t, 30, true)
+		file := f.file(t, nil, time.Now().AddDate(0, 0, -10))
+
+		requireConflict(t, CheckFile(f.db, file, ActionTrash), apierrors.CodeRetentionPeriodActive)
+	})
+
+	t.Run("elapsed period allows deletion", func(t *testing.T) {
+		f := setupFixture(t)
+		f.assign(t, 30, true)
+		file := f.file(t, nil, time.Now().AddDate(0, 0, -31))
+
+		assert.NoError(t, CheckFile(f.db, file, ActionPurge))
+	})
+}
+
+func TestCheckFolder(t *testing.T) {
+	t.Run("hold on a descendant blocks the ancestor", func(t *testing.T) {
+		f := setupFixture(t)
+		f.hold(t, &f.child.ID, nil)
+
+		requireConflict(t, CheckFolder(f.db, f.parent, ActionTrash), apierrors.CodeLegalHoldActive)
+	})
+
+	t.Run("retained file in the subtree blocks purge", func(t *testing.T) {
+		f := setupFixture(t)
+		f.assign(t, 30, false)
+		f.file(t, &f.child.ID, time.Now())
+
+		assert.NoError(t, CheckFolder(f.db, f.parent, ActionTrash))
+		requireConflict(t, CheckFolder(f.db, f.parent, ActionPurge), apierrors.CodeRetentionPeriodActive)
+	})
+}
+
+func TestCheckBucket(t *testing.T) {
+	t.Run("any active hold blocks deletion", func(t *testing.T) {
+		f := setupFixture(t)
+		f.hold(t, &f.child.ID, nil)
+
+		requireConflict(t, CheckBucket(f.db, f.bucket.ID), apierrors.CodeLegalHoldActive)
+	})
+
+	t.Run("retained files block deletion", func(t *testing.T) {
+		f := setupFixture(t)
+		f.assign(t, 30, false)
+		f.file(t, nil, time.Now())
+
+		requireConflict(t, CheckBucket(f.db, f.bucket.ID), apierrors.CodeRetentionPeriodActive)
+	})
+
+	t.Run("unprotected bucket can be deleted", func(t *testing.T) {
+		f := setupFixture(t)
+		f.file(t, nil, time.Now())
+
+		assert.NoError(t, CheckBucket(f.db, f.bucket.ID))
+	})
+}
+
+func TestScope_ExcludeProtectedFiles(t *testing.T) {
+	f := setupFixture(t)
+	f.assign(t, 30, false)
+	retained := f.file(t, nil, time.Now())
+	held := f.file(t, &f.child.ID, time.Now().AddDate(0, 0, -60))
+	free := f.file(t, nil, time.Now().AddDate(0, 0, -60))
+	f.hold(t, &f.parent.ID, nil)
+
+	scope, err := LoadScope(f.db, nil)
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	require.NoError(t, scope.ExcludeProtectedFiles(f.db.Model(&models.File{}), ActionPurge).Pluck("id", &ids).Error)
+	assert.Equal(t, []uuid.UUID{free.ID}, ids)
+
+	lock := scope.ObjectLock(held)
+	assert.True(t, lock.LegalHold)
+	assert.Nil(t, lock.RetainUntil, "unlocked policies are not mirrored to storage")
+	assert.False(t, scope.ObjectLock(retained).LegalHold)
+}
+
+func TestScope_ProtectedFolders(t *testing.T) {
+	f := setupFixture(t)
+	f.assign(t, 30, false)
+	f.hold(t, &f.child.ID, nil)
+
+	retained := models.Folder{Name: "invoices", BucketID: f.bucket.ID}
+	require.NoError(t, f.db.Create(&retained).Error)
+	nested := models.Folder{Name: "2026", BucketID: f.bucket.ID, FolderID: &retained.ID}
+	require.NoError(t, f.db.Create(&nested).Error)
+	f.file(t, &nested.ID, time.Now())
+
+	free := models.Folder{Name: "drafts", BucketID: f.bucket.ID}
+	require.NoError(t, f.db.Create(&free).Error)
+	f.file(t, &free.ID, time.Now().AddDate(0, 0, -60))
+
+	scope, err := LoadScope(f.db, nil)
+	require.NoError(t, err)
+
+	roots := []models.Folder{f.parent, retained, free}
+	protected, err := scope.ProtectedFolders(f.db, roots, ActionPurge)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{f.parent.ID: true, retained.ID: true}, protected)
+
+	protected, err = scope.ProtectedFolders(f.db, roots, ActionTrash)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{f.parent.ID: true}, protected, "unlocked policies only block purges")
+}
+
+func TestScope_ObjectLockLockedPolicy(t *testing.T) {
+	f := setupFixture(t)
+	f.assign(t, 30, true)
+	file := f.file(t, nil, time.Now())
+
+	scope, err := LoadScope(f.db, &f.bucket.ID)
+	require.NoError(t, err)
+
+	lock := scope.ObjectLock(file)
+	assert.False(t, lock.LegalHold)
+	require.NotNil(t, lock.RetainUntil)
+	assert.WithinDuration(t, file.CreatedAt.AddDate(0, 0, 30), *lock.RetainUntil, time.Second)
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/handlers"
+	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/messaging"
+	m "github.com/safebucket/safebucket/internal/middlewares"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AccountService serves the signed-in user's own account. Unlike UserService it never takes a
+// user ID from the URL: every operation applies to the user of the access token.
+type AccountService struct {
+	DB                 *gorm.DB
+	Cache              cache.ICache
+	AuthConfig         models.AuthConfig
+	Providers          configuration.Providers
+	Publisher          messaging.IPublisher
+	ActivityLogger     activity.IActivityLogger
+	PasswordPolicy     password.Policy
+	RefreshTokenExpiry int
+}
+
+func (s AccountService) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", handlers.GetOneHandler(s.GetAccount))
+	r.With(m.Validate[models.AccountUpdateBody]).Patch("/", handlers.BodyHandler(s.UpdateAccount))
+
+	r.With(m.Validate[models.PasswordChangeBody]).Post(
+		"/password",
+		handlers.AuthFlowHandler(s.AuthConfig.CookieSecureForce, s.ChangePassword),
+	)
+
+	r.Get("/key", handlers.GetOneHandler(s.GetUserKey))
+	r.With(m.Validate[models.UserKeyBody]).Put("/key", handlers.BodyHandler(s.UpdateUserKey))
+	return r
+}
+
+func (s AccountService) GetAccount(
+	_ *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+) (models.AccountResponse, error) {
+	user, err := s.loadUser(claims.UserID)
+	if err != nil {
+		return models.AccountResponse{}, err
+	}
+
+	return models.AccountResponse{
+		User:                  user,
+		MFAEnabled:            user.HasMFAEnabled(),
+		PasswordChangeAllowed: user.ProviderType == models.LocalProviderType,
+	}, nil
+}
+
+func (s AccountService) UpdateAccount(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+	body models.AccountUpdateBody,
+) error {
+	result := s.DB.Model(&models.User{ID: claims.UserID}).Updates(models.User{
+		FirstName: body.FirstName,
+		LastName:  body.LastName,
+	})
+	if result.Error != nil {
+		logger.Error("Failed to update account", zap.Error(result.Error))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
+	}
+	return nil
+}
+
+// ChangePassword sets a new password for a local user. The current password is always
+// required, and users with a verified MFA device must also confirm a TOTP code. Every other
+// session is revoked so a stolen session does not survive the change.
+func (s AccountService) ChangePassword(
+	_ bool,
+	client models.ClientInfo,
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+	body models.PasswordChangeBody,
+) (handlers.AuthFlowResult, error) {
+	user, err := s.loadUser(claims.UserID)
+	if err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+	if user.ProviderType != models.LocalProviderType {
+		return handlers.AuthFlowResult{}, apierrors.New(http.StatusForbidden, apierrors.CodePasswordChangeNotAllowed)
+	}
+
+	// Wrong current passwords count towards the login lockout, so a hijacked session cannot be
+	// used to guess the password either.
+	guard := s.loginGuard()
+	account := cache.LoginAccountSubject(user.ProviderKey, user.Email)
+	if err = guard.check(logger, client, account); err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+
+	stepUp := s.stepUp()
+	if err = stepUp.verifyMFAStepUp(logger, &user, body.CurrentPassword, body.Code); err != nil {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == apierrors.CodeInvalidPassword {
+			guard.recordFailure(logger, client, account, &user)
+		}
+		return handlers.AuthFlowResult{}, err
+	}
+	guard.recordSuccess(logger, account)
+
+	if user.HasMFAEnabled() {
+		if err = stepUp.verifyTOTPStepUp(logger, &user, body.Code); err != nil {
+			return handlers.AuthFlowResult{}, err
+		}
+	}
+
+	if err = checkNewPassword(logger, s.DB, s.PasswordPolicy, &user, body.NewPassword); err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+
+	hash, err := h.CreateHash(body.NewPassword)
+	if err != nil {
+		logger.Error("Failed to hash new password", zap.Error(err))
+		return handlers.AuthFlowResult{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if archiveErr := archivePassword(tx, s.PasswordPolicy, &user); archiveErr != nil {
+			logger.Error("Failed to archive previous password", zap.Error(archiveErr))
+			return archiveErr
+		}
+		return tx.Model(&user).Update("hashed_password", hash).Error
+	})
+	if err != nil {
+		logger.Error("Failed to change password", zap.Error(err))
+		return handlers.AuthFlowResult{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
+	if revokeErr := cache.RevokeOtherSessions(
+		s.Cache, user.ID.String(), claims.SID, time.Duration(s.RefreshTokenExpiry)*time.Minute,
+	); revokeErr != nil {
+		logger.Error("Failed to revoke other sessions after password change", zap.Error(revokeErr))
+	}
+
+	action := models.Activity{
+		Message: activity.PasswordChanged,
+		Object:  user.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     activity.PasswordChanged,
+			UserID:     user.ID.String(),
+			ObjectType: rbac.ResourceUser.String(),
+		}),
+	}
+	if logErr := s.ActivityLogger.Send(action); logErr != nil {
+		logger.Error("Failed to log password change", zap.Error(logErr))
+	}
+
+	event := events.NewPasswordChanged(
+		s.Publisher,
+		user.Email,
+		client.IP,
+		time.Now().Format("January 2, 2006 at 3:04 PM MST"),
+		s.AuthConfig.WebURL,
+	)
+	event.Trigger()
+
+	return handlers.AuthFlowResult{Status: http.StatusNoContent}, nil
+}
+
+// GetUserKey returns the public key the user registered for encrypted buckets, with the sealed
+// backup of its private key when one was uploaded.
+func (s AccountService) GetUserKey(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+) (models.UserKey, error) {
+	var key models.UserKey
+	result := s.DB.Where("user_id = ?", claims.UserID).Find(&key)
+	if result.Error != nil {
+		logger.Error("Failed to load user key", zap.Error(result.Error))
+		return models.UserKey{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		return models.UserKey{}, apierrors.New(http.StatusNotFound, apierrors.CodeUserKeyNotFound)
+	}
+	return key, nil
+}
+
+// UpdateUserKey registers a new key pair for the user. Data keys wrapped with the previous public
+// key can no longer be unwrapped, so they are dropped and the user waits in every encrypted bucket
+// until another member wraps the data keys again.
+func (s AccountService) UpdateUserKey(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+	body models.UserKeyBody,
+) error {
+	user, err := s.loadUser(claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	key := models.UserKey{
+		UserID:              user.ID,
+		Algorithm:           body.Algorithm,
+		PublicKey:           body.PublicKey,
+		EncryptedPrivateKey: body.EncryptedPrivateKey,
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if deleteErr := tx.Where("user_id = ?", user.ID).Delete(&models.BucketKey{}).Error; deleteErr != nil {
+			return deleteErr
+		}
+		if deleteErr := tx.Where("user_id = ?", user.ID).Delete(&models.UserKey{}).Error; deleteErr != nil {
+			return deleteErr
+		}
+		return tx.Create(&key).Error
+	})
+	if err != nil {
+		logger.Error("Failed to update user key", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	action := models.Activity{
+		Message: activity.UserKeyUpdated,
+		Object:  user.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     activity.UserKeyUpdated,
+			UserID:     user.ID.String(),
+			ObjectType: rbac.ResourceUser.String(),
+		}),
+	}
+	if logErr := s.ActivityLogger.Send(action); logErr != nil {
+		logger.Error("Failed to log user key update", zap.Error(logErr))
+	}
+
+	return nil
+}
+
+func (s AccountService) loadUser(userID uuid.UUID) (models.User, error) {
+	var user models.User
+	result := s.DB.Preload("MFADevices", "is_verified = ?", true).Where("id = ?", userID).Find(&user)
+	if result.Error != nil {
+		return models.User{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		return models.User{}, apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
+	}
+	return user, nil
+}
+
+func (s AccountService) stepUp() MFAService {
+	return MFAService{DB: s.DB, Cache: s.Cache, AuthConfig: s.AuthConfig, Providers: s.Providers}
+}
+
+func (s AccountService) loginGuard() loginGuard {
+	return loginGuard{
+		Cache:          s.Cache,
+		Publisher:      s.Publisher,
+		ActivityLogger: s.ActivityLogger,
+		WebURL:         s.AuthConfig.WebURL,
+	}
+}
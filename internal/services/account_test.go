@@ -0,0 +1,140 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const accountTestEncryptionKey = "01234567890123456789012345678901"
+
+func newAccountTest(t *testing.T) (AccountService, *capturePublisher, *models.User) {
+	t.Helper()
+
+	f := newAuthTestFixture(t, "current-password")
+	return AccountService{
+		DB:    f.DB,
+		Cache: f.Cache,
+		AuthConfig: models.AuthConfig{
+			MFAEncryptionKey: accountTestEncryptionKey,
+			WebURL:           "https://safebucket.example",
+		},
+		Publisher:          f.Publisher,
+		ActivityLogger:     &MockActivityLogger{},
+		RefreshTokenExpiry: 60,
+	}, f.Publisher, f.User
+}
+
+func changePassword(service AccountService, user *models.User, sid string, body models.PasswordChangeBody) error {
+	_, err := service.ChangePassword(false, models.ClientInfo{IP: "203.0.113.7"}, zap.NewNop(),
+		models.UserClaims{UserID: user.ID, SID: sid}, uuid.UUIDs{}, body)
+	return err
+}
+
+func TestChangePassword_RevokesOtherSessions(t *testing.T) {
+	service, publisher, user := newAccountTest(t)
+	require.NoError(t, cache.CreateSession(service.Cache, user.ID.String(), "current"))
+	require.NoError(t, cache.CreateSession(service.Cache, user.ID.String(), "other"))
+
+	require.NoError(t, changePassword(service, user, "current", models.PasswordChangeBody{
+		CurrentPassword: "current-password",
+		NewPassword:     "brand-new-password",
+	}))
+
+	var updated models.User
+	require.NoError(t, service.DB.First(&updated, "id = ?", user.ID).Error)
+	match, err := argon2id.ComparePasswordAndHash("brand-new-password", updated.HashedPassword)
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	active, err := cache.IsSessionActive(service.Cache, user.ID.String(), "current", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, active, "the session used for the change must survive")
+	active, err = cache.IsSessionActive(service.Cache, user.ID.String(), "other", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, events.PasswordChangedName, publisher.messages[0].Metadata.Get("type"))
+}
+
+func TestChangePassword_RejectsWrongCurrentPassword(t *testing.T) {
+	service, publisher, user := newAccountTest(t)
+
+	err := changePassword(service, user, "current", models.PasswordChangeBody{
+		CurrentPassword: "wrong-password",
+		NewPassword:     "brand-new-password",
+	})
+	requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeInvalidPassword)
+	assert.Empty(t, publisher.messages)
+}
+
+func TestChangePassword_RejectsNonLocalUsers(t *testing.T) {
+	service, _, user := newAccountTest(t)
+	require.NoError(t, service.DB.Model(user).Update("provider_type", models.OIDCProviderType).Error)
+
+	err := changePassword(service, user, "current", models.PasswordChangeBody{
+		CurrentPassword: "current-password",
+		NewPassword:     "brand-new-password",
+	})
+	requireAPIError(t, err, http.StatusForbidden, apierrors.CodePasswordChangeNotAllowed)
+}
+
+func TestChangePassword_RequiresTOTPWhenMFAEnabled(t *testing.T) {
+	service, _, user := newAccountTest(t)
+
+	key, err := helpers.GenerateTOTPSecret(user.Email)
+	require.NoError(t, err)
+	encrypted, err := helpers.EncryptSecret(key.Secret, []byte(accountTestEncryptionKey))
+	require.NoError(t, err)
+	require.NoError(t, service.DB.Create(&models.MFADevice{
+		UserID:          user.ID,
+		Name:            "Phone",
+		EncryptedSecret: encrypted,
+		IsDefault:       true,
+		IsVerified:      true,
+	}).Error)
+
+	body := models.PasswordChangeBody{CurrentPassword: "current-password", NewPassword: "brand-new-password"}
+	err = changePassword(service, user, "current", body)
+	requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeBadRequest)
+
+	body.Code, err = totp.GenerateCode(key.Secret, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, changePassword(service, user, "current", body))
+}
+
+func TestChangePassword_RejectsReusedPassword(t *testing.T) {
+	service, _, user := newAccountTest(t)
+	service.PasswordPolicy = password.Policy{Rules: models.PasswordPolicy{MinLength: 8, HistorySize: 3}}
+
+	err := changePassword(service, user, "current", models.PasswordChangeBody{
+		CurrentPassword: "current-password",
+		NewPassword:     "current-password",
+	})
+	requireAPIError(t, err, http.StatusBadRequest, apierrors.CodePasswordReused)
+}
+
+func TestGetAccount_ReportsPasswordChangeAndMFA(t *testing.T) {
+	service, _, user := newAccountTest(t)
+
+	account, err := service.GetAccount(zap.NewNop(), models.UserClaims{UserID: user.ID}, uuid.UUIDs{})
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, account.Email)
+	assert.True(t, account.PasswordChangeAllowed)
+	assert.False(t, account.MFAEnabled)
+}
@@ -4,8 +4,10 @@ import (
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/cache"
 	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/database"
 	"github.com/safebucket/safebucket/internal/handlers"
 	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/messaging"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
@@ -21,6 +23,7 @@ type AdminService struct {
 	DB             *gorm.DB
 	Cache          cache.ICache
 	ActivityLogger activity.IActivityLogger
+	Publisher      messaging.IPublisher
 	Config         models.Configuration
 }
 
@@ -38,9 +41,61 @@ func (s AdminService) Routes() chi.Router {
 	r.With(m.AuthorizeRole(models.RoleAdmin)).
 		Get("/buckets", handlers.GetListHandler(s.GetBucketList))
 
+	r.With(m.AuthorizeRole(models.RoleAdmin)).
+		Get("/invites", handlers.GetListHandler(s.GetInviteList))
+
 	r.With(m.AuthorizeRole(models.RoleAdmin)).
 		Get("/settings", handlers.GetOneHandler(s.GetSettings))
 
+	r.With(m.AuthorizeRole(models.RoleAdmin)).
+		With(m.Validate[models.BucketRetentionBody]).
+		Put("/buckets/{id0}/retention-policy", handlers.BodyHandler(s.UpdateBucketRetention))
+
+	r.Route("/retention-policies", func(r chi.Router) {
+		r.Use(m.AuthorizeRole(models.RoleAdmin))
+
+		r.Get("/", handlers.GetListHandler(s.GetRetentionPolicyList))
+		r.With(m.Validate[models.RetentionPolicyBody]).
+			Post("/", handlers.CreateHandler(s.CreateRetentionPolicy))
+
+		r.Route("/{id0}", func(r chi.Router) {
+			r.With(m.Validate[models.RetentionPolicyBody]).
+				Patch("/", handlers.BodyHandler(s.UpdateRetentionPolicy))
+			r.Delete("/", handlers.DeleteHandler(s.DeleteRetentionPolicy))
+		})
+	})
+
+	r.Route("/legal-holds", func(r chi.Router) {
+		r.Use(m.AuthorizeRole(models.RoleAdmin))
+
+		r.With(m.ValidateQuery[models.LegalHoldQueryParams]).
+			Get("/", handlers.GetListWithQueryHandler(s.GetLegalHoldList))
+		r.With(m.Validate[models.LegalHoldCreateBody]).
+			Post("/", handlers.CreateHandler(s.CreateLegalHold))
+		r.Post("/{id0}/release", handlers.ActionHandler(s.ReleaseLegalHold))
+	})
+
+	r.Route("/notifications", func(r chi.Router) {
+		r.Use(m.AuthorizeRole(models.RoleAdmin))
+
+		r.With(m.ValidateQuery[models.QueuedNotificationQueryParams]).
+			Get("/", handlers.GetListWithQueryHandler(s.GetNotificationList))
+		r.Post("/{id0}/resend", handlers.ActionHandler(s.ResendNotification))
+	})
+
+	r.Route("/dead-letters", func(r chi.Router) {
+		r.Use(m.AuthorizeRole(models.RoleAdmin))
+
+		r.With(m.ValidateQuery[models.DeadLetterQueryParams]).
+			Get("/", handlers.GetOneWithQueryHandler(s.GetDeadLetterList))
+
+		r.Route("/{id0}", func(r chi.Router) {
+			r.Get("/", handlers.GetOneHandler(s.GetDeadLetter))
+			r.Post("/replay", handlers.ActionHandler(s.ReplayDeadLetter))
+			r.Delete("/", handlers.DeleteHandler(s.DiscardDeadLetter))
+		})
+	})
+
 	return r
 }
 
@@ -51,19 +106,20 @@ func (s AdminService) GetStats(
 	queryParams models.AdminStatsQueryParams,
 ) (models.AdminStatsResponse, error) {
 	var response models.AdminStatsResponse
+	db := database.ReadReplica(s.DB)
 
-	s.DB.Model(&models.User{}).Count(&response.TotalUsers)
+	db.Model(&models.User{}).Count(&response.TotalUsers)
 
-	s.DB.Model(&models.Bucket{}).Count(&response.TotalBuckets)
+	db.Model(&models.Bucket{}).Count(&response.TotalBuckets)
 
-	s.DB.Model(&models.File{}).
+	db.Model(&models.File{}).
 		Where("status = ?", models.FileStatusUploaded).
 		Count(&response.TotalFiles)
 
-	s.DB.Model(&models.Folder{}).Count(&response.TotalFolders)
+	db.Model(&models.Folder{}).Count(&response.TotalFolders)
 
 	var totalStorage *int64
-	s.DB.Model(&models.File{}).
+	db.Model(&models.File{}).
 		Where("status = ?", models.FileStatusUploaded).
 		Select("COALESCE(SUM(size), 0)").
 		Scan(&totalStorage)
@@ -79,7 +135,7 @@ func (s AdminService) GetStats(
 	timeSeries, err := s.ActivityLogger.CountByHour(searchCriteria, queryParams.Days)
 	if err != nil {
 		zap.L().Error("Failed to get uploads per hour from Loki, falling back to DB", zap.Error(err))
-		response.SharedFilesPerHour = sql.GetSharedFilesByHour(s.DB, queryParams.Days)
+		response.SharedFilesPerHour = sql.GetSharedFilesByHour(db, queryParams.Days)
 	} else {
 		response.SharedFilesPerHour = timeSeries
 	}
@@ -144,11 +200,14 @@ func (s AdminService) GetSettings(
 	}
 
 	coverage := models.WorkerSettings{
-		HTTPServer:       status(configuration.CoverageHTTPServer, true),
-		ObjectDeletion:   status(configuration.WorkerObjectDeletion, deletionQueued),
-		BucketEvents:     status(configuration.WorkerBucketEvents, bucketQueued),
-		TrashCleanup:     status(configuration.WorkerTrashCleanup, confirmsUploads),
-		GarbageCollector: status(configuration.WorkerGarbageCollector, true),
+		HTTPServer:         status(configuration.CoverageHTTPServer, true),
+		ObjectDeletion:     status(configuration.WorkerObjectDeletion, deletionQueued),
+		BucketEvents:       status(configuration.WorkerBucketEvents, bucketQueued),
+		TrashCleanup:       status(configuration.WorkerTrashCleanup, confirmsUploads),
+		GarbageCollector:   status(configuration.WorkerGarbageCollector, true),
+		OutboxRelay:        status(configuration.WorkerOutboxRelay, true),
+		NotificationDigest: status(configuration.WorkerNotificationDigest, true),
+		NotificationRetry:  status(configuration.WorkerNotificationRetry, true),
 	}
 
 	return models.NewAdminSettingsResponse(s.Config, platforms, coverage), nil
@@ -202,3 +261,35 @@ func (s AdminService) GetBucketList(
 
 	return result
 }
+
+func (s AdminService) GetInviteList(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	_ uuid.UUIDs,
+) []models.AdminInviteListItem {
+	var invites []models.Invite
+	if err := database.ReadReplica(s.DB).
+		Preload("Bucket").
+		Preload("User").
+		Order("created_at DESC").
+		Find(&invites).Error; err != nil {
+		logger.Error("Failed to fetch invites", zap.Error(err))
+		return []models.AdminInviteListItem{}
+	}
+
+	result := make([]models.AdminInviteListItem, 0, len(invites))
+	for _, invite := range invites {
+		result = append(result, models.AdminInviteListItem{
+			ID:         invite.ID,
+			Email:      invite.Email,
+			Group:      invite.Group,
+			BucketID:   invite.BucketID,
+			BucketName: invite.Bucket.Name,
+			InvitedBy:  invite.User.ToActivity(),
+			CreatedAt:  invite.CreatedAt,
+			ExpiresAt:  invite.ExpiresAt,
+		})
+	}
+
+	return result
+}
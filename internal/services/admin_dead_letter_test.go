@@ -0,0 +1,91 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetDeadLetterListPages(t *testing.T) {
+	db, _ := setupSQLiteTestDB(t)
+	service := AdminService{DB: db}
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	for i := range 5 {
+		require.NoError(t, db.Create(&models.DeadLetterEvent{
+			MessageID: uuid.NewString(),
+			EventType: "BucketPurge",
+			Worker:    "object_deletion",
+			Payload:   "{}",
+			Metadata:  map[string]string{},
+			LastError: "boom",
+			// Two entries share a timestamp so the cursor has to break the tie on the ID.
+			CreatedAt: createdAt.Add(-time.Duration(i/2) * time.Minute),
+		}).Error)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	query := models.DeadLetterQueryParams{Limit: 2}
+	for pages := 1; ; pages++ {
+		page, err := service.GetDeadLetterList(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{}, query)
+		require.NoError(t, err)
+		for _, entry := range page.Data {
+			assert.False(t, seen[entry.ID], "entry returned twice")
+			seen[entry.ID] = true
+		}
+
+		if page.NextCursor == nil {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		query.Cursor = *page.NextCursor
+	}
+	assert.Len(t, seen, 5)
+
+	_, err := service.GetDeadLetterList(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{},
+		models.DeadLetterQueryParams{Cursor: uuid.NewString()})
+	requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeBadRequest)
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	db, _ := setupSQLiteTestDB(t)
+	publisher := &capturePublisher{}
+	service := AdminService{DB: db, Publisher: publisher}
+
+	newEntry := func(eventType string) models.DeadLetterEvent {
+		entry := models.DeadLetterEvent{
+			MessageID: uuid.NewString(),
+			EventType: eventType,
+			Worker:    "object_deletion",
+			Payload:   "{}",
+			Metadata:  map[string]string{"type": eventType, "attempts": "5"},
+			LastError: "boom",
+		}
+		require.NoError(t, db.Create(&entry).Error)
+		return entry
+	}
+
+	for _, eventType := range []string{"", "RenamedEvent", "BucketPurgePayload"} {
+		entry := newEntry(eventType)
+		err := service.ReplayDeadLetter(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{entry.ID})
+		requireAPIError(t, err, http.StatusConflict, apierrors.CodeDeadLetterReplayFailed)
+	}
+	assert.Empty(t, publisher.messages, "unknown event types are never published")
+
+	entry := newEntry("BucketPurge")
+	require.NoError(t, service.ReplayDeadLetter(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{entry.ID}))
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, "0", publisher.messages[0].Metadata.Get("attempts"))
+
+	var count int64
+	require.NoError(t, db.Model(&models.DeadLetterEvent{}).Where("id = ?", entry.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
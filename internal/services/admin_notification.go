@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/database"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const adminNotificationListLimit = 200
+
+func (s AdminService) GetNotificationList(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	_ uuid.UUIDs,
+	query models.QueuedNotificationQueryParams,
+) []models.QueuedNotification {
+	db := database.ReadReplica(s.DB)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	var entries []models.QueuedNotification
+	if err := db.Order("created_at DESC").Limit(adminNotificationListLimit).Find(&entries).Error; err != nil {
+		logger.Error("Failed to fetch queued notifications", zap.Error(err))
+		return []models.QueuedNotification{}
+	}
+
+	return entries
+}
+
+// ResendNotification puts a failed notification back in the queue with a fresh attempts
+// count; the notification retry worker delivers it on its next run. Notifications whose data
+// expired, e.g. a reset code, are refused: the user has to start the flow again.
+func (s AdminService) ResendNotification(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	var entry models.QueuedNotification
+	err := s.DB.Where("id = ?", ids[0]).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeNotificationNotFound)
+	}
+	if err != nil {
+		logger.Error("Failed to fetch queued notification", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeFetchFailed)
+	}
+
+	if entry.Status != models.NotificationStatusFailed {
+		return apierrors.New(http.StatusConflict, apierrors.CodeNotificationNotFailed)
+	}
+
+	if entry.IsExpired(time.Now()) || entry.Payload == "" {
+		return apierrors.New(http.StatusGone, apierrors.CodeNotificationExpired)
+	}
+
+	if err = s.DB.Model(&entry).Updates(map[string]any{
+		"status":          models.NotificationStatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}).Error; err != nil {
+		logger.Error("Failed to requeue notification", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	logger.Info("Requeued failed notification",
+		zap.String("id", entry.ID.String()),
+		zap.String("template", entry.Template),
+		zap.String("admin_id", claims.UserID.String()))
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createFailedNotification(t *testing.T, service AdminService, payload string, expiresAt *time.Time) uuid.UUID {
+	t.Helper()
+
+	entry := models.QueuedNotification{
+		Recipient:     "alice@example.com",
+		Subject:       "Password Reset Request",
+		Template:      "password_reset",
+		Payload:       payload,
+		Status:        models.NotificationStatusFailed,
+		Attempts:      6,
+		NextAttemptAt: time.Now(),
+		ExpiresAt:     expiresAt,
+	}
+	require.NoError(t, service.DB.Create(&entry).Error)
+	return entry.ID
+}
+
+func TestResendNotification(t *testing.T) {
+	db, _ := setupSQLiteTestDB(t)
+	service := AdminService{DB: db}
+
+	valid := time.Now().Add(time.Hour)
+	id := createFailedNotification(t, service, "sealed", &valid)
+
+	require.NoError(t, service.ResendNotification(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{id}))
+
+	var entry models.QueuedNotification
+	require.NoError(t, db.First(&entry, "id = ?", id).Error)
+	assert.Equal(t, models.NotificationStatusPending, entry.Status)
+	assert.Zero(t, entry.Attempts)
+}
+
+func TestResendNotificationRefusesExpired(t *testing.T) {
+	db, _ := setupSQLiteTestDB(t)
+	service := AdminService{DB: db}
+
+	expired := time.Now().Add(-time.Minute)
+	for _, id := range []uuid.UUID{
+		createFailedNotification(t, service, "sealed", &expired),
+		createFailedNotification(t, service, "", nil),
+	} {
+		err := service.ResendNotification(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{id})
+		requireAPIError(t, err, http.StatusGone, apierrors.CodeNotificationExpired)
+	}
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// retentionError passes legal hold and retention conflicts through and hides any other
+// failure behind an internal server error.
+func retentionError(logger *zap.Logger, err error) error {
+	var apiErr *apierrors.APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	logger.Error("Failed to evaluate retention", zap.Error(err))
+	return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+}
+
+func (s AdminService) sendRetentionActivity(
+	logger *zap.Logger,
+	message string,
+	object any,
+	fields models.ActivityFields,
+) {
+	if err := s.ActivityLogger.Send(models.Activity{
+		Message: message,
+		Object:  object,
+		Filter:  activity.NewLogFilter(fields),
+	}); err != nil {
+		logger.Error("Failed to log retention activity", zap.Error(err))
+	}
+}
+
+func (s AdminService) GetRetentionPolicyList(
+	_ *zap.Logger,
+	_ models.UserClaims,
+	_ uuid.UUIDs,
+) []models.RetentionPolicy {
+	var policies []models.RetentionPolicy
+	s.DB.Order("name").Find(&policies)
+	return policies
+}
+
+func (s AdminService) CreateRetentionPolicy(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+	body models.RetentionPolicyBody,
+) (models.RetentionPolicy, error) {
+	policy := models.RetentionPolicy{
+		Name:          body.Name,
+		RetentionDays: body.RetentionDays,
+		Locked:        body.Locked,
+		CreatedBy:     claims.UserID,
+	}
+	if err := s.DB.Create(&policy).Error; err != nil {
+		logger.Error("Failed to create retention policy", zap.Error(err))
+		return models.RetentionPolicy{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeCreateFailed)
+	}
+
+	s.sendRetentionActivity(logger, activity.RetentionPolicyCreated, policy.ToActivity(), models.ActivityFields{
+		Action:     rbac.ActionCreate.String(),
+		ObjectType: rbac.ResourceRetention.String(),
+		UserID:     claims.UserID.String(),
+	})
+
+	return policy, nil
+}
+
+// UpdateRetentionPolicy renames or changes a policy. Locked policies can only be extended and
+// stay locked; buckets using the policy get their object locks resynchronised.
+func (s AdminService) UpdateRetentionPolicy(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	ids uuid.UUIDs,
+	body models.RetentionPolicyBody,
+) error {
+	var policy models.RetentionPolicy
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ids[0]).Find(&policy).RowsAffected == 0 {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeRetentionPolicyNotFound)
+		}
+
+		if policy.Locked && (!body.Locked || body.RetentionDays < policy.RetentionDays) {
+			return apierrors.New(http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+		}
+
+		updates := map[string]any{
+			"name":           body.Name,
+			"retention_days": body.RetentionDays,
+			"locked":         body.Locked,
+		}
+		if err := tx.Model(&policy).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		var bucketIDs []uuid.UUID
+		if err := tx.Model(&models.Bucket{}).
+			Where("retention_policy_id = ?", policy.ID).
+			Pluck("id", &bucketIDs).Error; err != nil {
+			return err
+		}
+		for _, bucketID := range bucketIDs {
+			event := events.NewObjectLockSync(s.Publisher, bucketID, nil, nil)
+			if err := event.Enqueue(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return retentionError(logger, err)
+	}
+
+	s.sendRetentionActivity(logger, activity.RetentionPolicyUpdated, policy.ToActivity(), models.ActivityFields{
+		Action:     rbac.ActionUpdate.String(),
+		ObjectType: rbac.ResourceRetention.String(),
+		UserID:     claims.UserID.String(),
+	})
+
+	return nil
+}
+
+// DeleteRetentionPolicy removes a policy that is no longer assigned to any bucket.
+func (s AdminService) DeleteRetentionPolicy(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	var policy models.RetentionPolicy
+	if s.DB.Where("id = ?", ids[0]).Find(&policy).RowsAffected == 0 {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeRetentionPolicyNotFound)
+	}
+
+	var assigned int64
+	if err := s.DB.Model(&models.Bucket{}).
+		Where("retention_policy_id = ?", policy.ID).
+		Count(&assigned).Error; err != nil {
+		logger.Error("Failed to count buckets using retention policy", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+	}
+	if assigned > 0 {
+		if policy.Locked {
+			return apierrors.New(http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+		}
+		return apierrors.New(http.StatusConflict, apierrors.CodeRetentionPolicyInUse)
+	}
+
+	if err := s.DB.Delete(&policy).Error; err != nil {
+		logger.Error("Failed to delete retention policy", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+	}
+
+	s.sendRetentionActivity(logger, activity.RetentionPolicyDeleted, policy.ToActivity(), models.ActivityFields{
+		Action:     rbac.ActionDelete.String(),
+		ObjectType: rbac.ResourceRetention.String(),
+		UserID:     claims.UserID.String(),
+	})
+
+	return nil
+}
+
+// UpdateBucketRetention assigns or removes the retention policy of a bucket. A locked policy
+// can only be replaced by another locked policy that retains files at least as long.
+func (s AdminService) UpdateBucketRetention(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	ids uuid.UUIDs,
+	body models.BucketRetentionBody,
+) error {
+	var bucket models.Bucket
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ids[0]).Find(&bucket).RowsAffected == 0 {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+		}
+
+		var next models.RetentionPolicy
+		if body.RetentionPolicyID != nil &&
+			tx.Where("id = ?", *body.RetentionPolicyID).Find(&next).RowsAffected == 0 {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeRetentionPolicyNotFound)
+		}
+
+		if bucket.RetentionPolicyID != nil {
+			var current models.RetentionPolicy
+			if err := tx.Where("id = ?", *bucket.RetentionPolicyID).First(&current).Error; err != nil {
+				return err
+			}
+			if current.Locked &&
+				(body.RetentionPolicyID == nil || !next.Locked || next.RetentionDays < current.RetentionDays) {
+				return apierrors.New(http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+			}
+		}
+
+		if err := tx.Model(&bucket).Update("retention_policy_id", body.RetentionPolicyID).Error; err != nil {
+			return err
+		}
+
+		event := events.NewObjectLockSync(s.Publisher, bucket.ID, nil, nil)
+		return event.Enqueue(tx)
+	})
+	if err != nil {
+		return retentionError(logger, err)
+	}
+
+	s.sendRetentionActivity(logger, activity.BucketRetentionUpdated, bucket.ToActivity(), models.ActivityFields{
+		Action:     rbac.ActionUpdate.String(),
+		BucketID:   bucket.ID.String(),
+		ObjectType: rbac.ResourceBucket.String(),
+		UserID:     claims.UserID.String(),
+	})
+
+	return nil
+}
+
+func (s AdminService) GetLegalHoldList(
+	_ *zap.Logger,
+	_ models.UserClaims,
+	_ uuid.UUIDs,
+	queryParams models.LegalHoldQueryParams,
+) []models.LegalHold {
+	query := s.DB.Order("created_at DESC")
+	switch queryParams.Status {
+	case "released":
+		query = query.Where("released_at IS NOT NULL")
+	case "all":
+	default:
+		query = query.Where("released_at IS NULL")
+	}
+
+	var holds []models.LegalHold
+	query.Find(&holds)
+	return holds
+}
+
+// CreateLegalHold places a hold on a bucket, or on a folder or file of that bucket. Trashed
+// objects can be held too, which stops their trash from expiring.
+func (s AdminService) CreateLegalHold(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	_ uuid.UUIDs,
+	body models.LegalHoldCreateBody,
+) (models.LegalHold, error) {
+	if s.DB.Where("id = ?", body.BucketID).Find(&models.Bucket{}).RowsAffected == 0 {
+		return models.LegalHold{}, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+	if body.FolderID != nil && s.DB.Unscoped().
+		Where("id = ? AND bucket_id = ?", *body.FolderID, body.BucketID).
+		Find(&models.Folder{}).RowsAffected == 0 {
+		return models.LegalHold{}, apierrors.New(http.StatusNotFound, apierrors.CodeFolderNotFound)
+	}
+	if body.FileID != nil && s.DB.Unscoped().
+		Where("id = ? AND bucket_id = ?", *body.FileID, body.BucketID).
+		Find(&models.File{}).RowsAffected == 0 {
+		return models.LegalHold{}, apierrors.New(http.StatusNotFound, apierrors.CodeFileNotFound)
+	}
+
+	hold := models.LegalHold{
+		BucketID:  body.BucketID,
+		FolderID:  body.FolderID,
+		FileID:    body.FileID,
+		Reason:    body.Reason,
+		CreatedBy: claims.UserID,
+	}
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&hold).Error; err != nil {
+			return err
+		}
+		event := events.NewObjectLockSync(s.Publisher, hold.BucketID, hold.FolderID, hold.FileID)
+		return event.Enqueue(tx)
+	})
+	if err != nil {
+		logger.Error("Failed to create legal hold", zap.Error(err))
+		return models.LegalHold{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeCreateFailed)
+	}
+
+	s.sendRetentionActivity(logger, activity.LegalHoldPlaced, hold.ToActivity(), legalHoldFields(hold, claims))
+
+	return hold, nil
+}
+
+// ReleaseLegalHold ends a hold. Released holds are kept for the audit trail.
+func (s AdminService) ReleaseLegalHold(
+	logger *zap.Logger,
+	claims models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	var hold models.LegalHold
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ids[0]).Find(&hold).RowsAffected == 0 {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeLegalHoldNotFound)
+		}
+		if !hold.IsActive() {
+			return apierrors.New(http.StatusConflict, apierrors.CodeLegalHoldAlreadyReleased)
+		}
+
+		now := time.Now()
+		hold.ReleasedAt = &now
+		hold.ReleasedBy = &claims.UserID
+		if err := tx.Model(&hold).Updates(map[string]any{
+			"released_at": hold.ReleasedAt,
+			"released_by": hold.ReleasedBy,
+		}).Error; err != nil {
+			return err
+		}
+
+		event := events.NewObjectLockSync(s.Publisher, hold.BucketID, hold.FolderID, hold.FileID)
+		return event.Enqueue(tx)
+	})
+	if err != nil {
+		return retentionError(logger, err)
+	}
+
+	s.sendRetentionActivity(logger, activity.LegalHoldReleased, hold.ToActivity(), legalHoldFields(hold, claims))
+
+	return nil
+}
+
+func legalHoldFields(hold models.LegalHold, claims models.UserClaims) models.ActivityFields {
+	fields := models.ActivityFields{
+		Action:     rbac.ActionUpdate.String(),
+		BucketID:   hold.BucketID.String(),
+		ObjectType: rbac.ResourceLegalHold.String(),
+		UserID:     claims.UserID.String(),
+	}
+	if hold.FolderID != nil {
+		fields.FolderID = hold.FolderID.String()
+	}
+	if hold.FileID != nil {
+		fields.FileID = hold.FileID.String()
+	}
+	return fields
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRetentionTest(t *testing.T) (AdminService, models.UserClaims, models.Bucket) {
+	t.Helper()
+
+	db, user := setupSQLiteTestDB(t)
+	bucket := models.Bucket{Name: "records", CreatedBy: user.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+
+	return AdminService{DB: db, Publisher: &capturePublisher{}, ActivityLogger: &MockActivityLogger{}},
+		models.UserClaims{UserID: user.ID}, bucket
+}
+
+func createPolicy(
+	t *testing.T,
+	service AdminService,
+	claims models.UserClaims,
+	days int,
+	locked bool,
+) models.RetentionPolicy {
+	t.Helper()
+
+	policy, err := service.CreateRetentionPolicy(zap.NewNop(), claims, uuid.UUIDs{},
+		models.RetentionPolicyBody{Name: "policy", RetentionDays: days, Locked: locked})
+	require.NoError(t, err)
+	return policy
+}
+
+func TestUpdateRetentionPolicy_LockedCanOnlyBeExtended(t *testing.T) {
+	service, claims, _ := newRetentionTest(t)
+	policy := createPolicy(t, service, claims, 30, true)
+	ids := uuid.UUIDs{policy.ID}
+
+	err := service.UpdateRetentionPolicy(zap.NewNop(), claims, ids,
+		models.RetentionPolicyBody{Name: "policy", RetentionDays: 10, Locked: true})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+
+	err = service.UpdateRetentionPolicy(zap.NewNop(), claims, ids,
+		models.RetentionPolicyBody{Name: "policy", RetentionDays: 30, Locked: false})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+
+	require.NoError(t, service.UpdateRetentionPolicy(zap.NewNop(), claims, ids,
+		models.RetentionPolicyBody{Name: "policy", RetentionDays: 60, Locked: true}))
+
+	var stored models.RetentionPolicy
+	require.NoError(t, service.DB.First(&stored, "id = ?", policy.ID).Error)
+	assert.Equal(t, 60, stored.RetentionDays)
+}
+
+func TestUpdateBucketRetention_LockedPolicyCannotBeWeakened(t *testing.T) {
+	service, claims, bucket := newRetentionTest(t)
+	locked := createPolicy(t, service, claims, 30, true)
+	shorter := createPolicy(t, service, claims, 10, true)
+	unlocked := createPolicy(t, service, claims, 90, false)
+	ids := uuid.UUIDs{bucket.ID}
+
+	require.NoError(t, service.UpdateBucketRetention(zap.NewNop(), claims, ids,
+		models.BucketRetentionBody{RetentionPolicyID: &locked.ID}))
+
+	for _, body := range []models.BucketRetentionBody{
+		{RetentionPolicyID: nil},
+		{RetentionPolicyID: &shorter.ID},
+		{RetentionPolicyID: &unlocked.ID},
+	} {
+		err := service.UpdateBucketRetention(zap.NewNop(), claims, ids, body)
+		requireAPIError(t, err, http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+	}
+
+	err := service.DeleteRetentionPolicy(zap.NewNop(), claims, uuid.UUIDs{locked.ID})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeRetentionPolicyLocked)
+}
+
+func TestLegalHold_BlocksBucketDeletionUntilReleased(t *testing.T) {
+	service, claims, bucket := newRetentionTest(t)
+	buckets := BucketService{DB: service.DB, Publisher: service.Publisher, ActivityLogger: service.ActivityLogger}
+
+	hold, err := service.CreateLegalHold(zap.NewNop(), claims, uuid.UUIDs{},
+		models.LegalHoldCreateBody{BucketID: bucket.ID, Reason: "litigation"})
+	require.NoError(t, err)
+
+	err = buckets.DeleteBucket(zap.NewNop(), claims, uuid.UUIDs{bucket.ID})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeLegalHoldActive)
+
+	require.NoError(t, service.ReleaseLegalHold(zap.NewNop(), claims, uuid.UUIDs{hold.ID}))
+	err = service.ReleaseLegalHold(zap.NewNop(), claims, uuid.UUIDs{hold.ID})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeLegalHoldAlreadyReleased)
+
+	require.NoError(t, buckets.DeleteBucket(zap.NewNop(), claims, uuid.UUIDs{bucket.ID}))
+}
+
+func TestCreateLegalHold_RejectsFileFromAnotherBucket(t *testing.T) {
+	service, claims, bucket := newRetentionTest(t)
+	other := models.Bucket{Name: "other", CreatedBy: claims.UserID}
+	require.NoError(t, service.DB.Create(&other).Error)
+	file := models.File{Name: "report.pdf", Status: models.FileStatusUploaded, BucketID: other.ID}
+	require.NoError(t, service.DB.Create(&file).Error)
+
+	_, err := service.CreateLegalHold(zap.NewNop(), claims, uuid.UUIDs{},
+		models.LegalHoldCreateBody{BucketID: bucket.ID, FileID: &file.ID, Reason: "audit"})
+	requireAPIError(t, err, http.StatusNotFound, apierrors.CodeFileNotFound)
+}
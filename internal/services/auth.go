@@ -15,6 +15,7 @@ import (
 	"github.com/safebucket/safebucket/internal/mfa"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
 	"github.com/safebucket/safebucket/internal/rbac"
 	"github.com/safebucket/safebucket/internal/sql"
 	"github.com/safebucket/safebucket/internal/tracing"
@@ -33,6 +34,7 @@ type AuthService struct {
 	Providers      configuration.Providers
 	Publisher      messaging.IPublisher
 	ActivityLogger activity.IActivityLogger
+	PasswordPolicy password.Policy
 }
 
 func (s AuthService) Routes() chi.Router {
@@ -53,11 +55,16 @@ func (s AuthService) Routes() chi.Router {
 	r.Route("/providers", func(r chi.Router) {
 		r.Get("/", handlers.GetListHandler(s.GetProviderList))
 		r.Route("/{provider}", func(r chi.Router) {
-			r.Get("/begin", handlers.OpenIDBeginHandler(s.OpenIDBegin))
+			r.Get("/begin", s.beginHandler())
 			r.Get(
 				"/callback",
 				handlers.OpenIDCallbackHandler(s.AuthConfig.WebURL, s.AuthConfig.CookieSecureForce, s.OpenIDCallback),
 			)
+			r.Get("/metadata", handlers.SAMLMetadataHandler(s.SAMLMetadata))
+			r.Post(
+				"/acs",
+				handlers.SAMLACSHandler(s.AuthConfig.WebURL, s.AuthConfig.CookieSecureForce, s.SAMLCallback),
+			)
 			r.With(m.Validate[models.AuthLoginBody]).Post(
 				"/login",
 				handlers.AuthFlowProviderHandler(s.AuthConfig.CookieSecureForce, s.LDAPLogin),
@@ -67,8 +74,24 @@ func (s AuthService) Routes() chi.Router {
 	return r
 }
 
+// beginHandler starts a redirect sign-in; SAML providers share the OIDC entry point so the web
+// app does not need to know which protocol a provider speaks.
+func (s AuthService) beginHandler() http.HandlerFunc {
+	openIDBegin := handlers.OpenIDBeginHandler(s.OpenIDBegin)
+	samlBegin := handlers.SAMLBeginHandler(s.SAMLBegin)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if provider, ok := s.Providers[chi.URLParam(r, "provider")]; ok && provider.Type == models.SAMLProviderType {
+			samlBegin(w, r)
+			return
+		}
+		openIDBegin(w, r)
+	}
+}
+
 func (s AuthService) Login(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	_ models.UserClaims,
 	_ uuid.UUIDs,
@@ -80,6 +103,12 @@ func (s AuthService) Login(
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusForbidden, apierrors.CodeForbidden)
 	}
 
+	guard := s.loginGuard()
+	account := cache.LoginAccountSubject(string(models.LocalProviderType), body.Email)
+	if err := guard.check(logger, client, account); err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+
 	user, found, err := sql.FindUserByIdentityProvider(
 		s.DB, body.Email, models.LocalProviderType, string(models.LocalProviderType), true,
 	)
@@ -91,19 +120,23 @@ func (s AuthService) Login(
 		)
 	}
 	if !found {
+		guard.recordFailure(logger, client, account, nil)
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
 	}
 
 	match, err := argon2id.ComparePasswordAndHash(body.Password, user.HashedPassword)
 	if err != nil || !match {
+		guard.recordFailure(logger, client, account, &user)
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
 	}
+	guard.recordSuccess(logger, account)
 
-	return s.finalizeLogin(isSecure, logger, &user, provider.Type, provider.Name, provider.MFARequired)
+	return s.finalizeLogin(isSecure, client, logger, &user, provider.Type, provider.Name, provider.MFARequired)
 }
 
 func (s AuthService) finalizeLogin(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	user *models.User,
 	providerType models.ProviderType,
@@ -130,12 +163,8 @@ func (s AuthService) finalizeLogin(
 		return handlers.AuthFlowResult{}, err
 	}
 
-	if err = cache.CreateSession(s.Cache, user.ID.String(), sid); err != nil {
-		logger.Error("Failed to create session", zap.Error(err))
-		return handlers.AuthFlowResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if err = s.sessionStarter().start(logger, user, sid, client); err != nil {
+		return handlers.AuthFlowResult{}, err
 	}
 
 	action := models.Activity{
@@ -246,7 +275,7 @@ func (s AuthService) Verify(
 	return claims, nil
 }
 
-func (s AuthService) Refresh(logger *zap.Logger, refreshTokenStr string) (string, error) {
+func (s AuthService) Refresh(logger *zap.Logger, client models.ClientInfo, refreshTokenStr string) (string, error) {
 	refreshToken, err := h.ParseRefreshToken(s.AuthConfig.TokenSecret, refreshTokenStr)
 	if err != nil {
 		return "", apierrors.New(http.StatusUnauthorized, apierrors.CodeUnauthorized)
@@ -266,6 +295,13 @@ func (s AuthService) Refresh(logger *zap.Logger, refreshTokenStr string) (string
 		return "", apierrors.New(http.StatusUnauthorized, apierrors.CodeSessionRevoked)
 	}
 
+	fallback := cache.SessionMetadata{IP: client.IP, UserAgent: client.UserAgent, AuthProvider: refreshToken.Provider}
+	if touchErr := cache.TouchSession(
+		s.Cache, refreshToken.UserID.String(), refreshToken.SID, fallback, maxAge,
+	); touchErr != nil {
+		logger.Warn("Failed to update session last-seen time", zap.Error(touchErr))
+	}
+
 	var user models.User
 	result := s.DB.Where("id = ?", refreshToken.UserID).First(&user)
 	if result.RowsAffected == 0 {
@@ -317,7 +353,7 @@ func (s AuthService) refreshHandler() http.HandlerFunc {
 			return
 		}
 
-		newAccessToken, err := s.Refresh(logger, refreshTokenStr)
+		newAccessToken, err := s.Refresh(logger, models.ClientInfoFromContext(r.Context()), refreshTokenStr)
 		if err != nil {
 			handlers.WriteError(span, w, err)
 			return
@@ -381,6 +417,7 @@ func (s AuthService) Me(
 
 func (s AuthService) VerifyMFALogin(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	claims models.UserClaims,
 	_ uuid.UUIDs,
@@ -483,12 +520,8 @@ func (s AuthService) VerifyMFALogin(
 		return handlers.AuthFlowResult{}, err
 	}
 
-	if sessionErr := cache.CreateSession(s.Cache, user.ID.String(), sid); sessionErr != nil {
-		logger.Error("Failed to create session", zap.Error(sessionErr))
-		return handlers.AuthFlowResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if sessionErr := s.sessionStarter().start(logger, &user, sid, client); sessionErr != nil {
+		return handlers.AuthFlowResult{}, sessionErr
 	}
 
 	action := models.Activity{
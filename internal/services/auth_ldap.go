@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	ldapclient "github.com/safebucket/safebucket/internal/auth/ldap"
+	"github.com/safebucket/safebucket/internal/cache"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/handlers"
 	h "github.com/safebucket/safebucket/internal/helpers"
@@ -17,6 +18,7 @@ import (
 
 func (s AuthService) LDAPLogin(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	providerKey string,
 	body models.AuthLoginBody,
@@ -31,10 +33,20 @@ func (s AuthService) LDAPLogin(
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusForbidden, apierrors.CodeForbidden)
 	}
 
+	guard := s.loginGuard()
+	account := cache.LoginAccountSubject(providerKey, body.Email)
+	if err := guard.check(logger, client, account); err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+
 	ldapUser, err := ldapclient.AuthenticateAndFetch(*provider.LDAPConfig, body.Email, body.Password)
 	if err != nil {
+		if errors.Is(err, ldapclient.ErrInvalidCredentials) {
+			guard.recordFailure(logger, client, account, s.findLDAPUser(logger, body.Email, providerKey))
+		}
 		return handlers.AuthFlowResult{}, mapLDAPAuthError(logger, providerKey, err)
 	}
+	guard.recordSuccess(logger, account)
 
 	email := normalizeExternalEmail(ldapUser.Email)
 
@@ -65,7 +77,25 @@ func (s AuthService) LDAPLogin(
 		}
 	}
 
-	return s.finalizeLogin(isSecure, logger, &user, models.LDAPProviderType, provider.Name, provider.MFARequired)
+	return s.finalizeLogin(
+		isSecure, client, logger, &user, models.LDAPProviderType, provider.Name, provider.MFARequired,
+	)
+}
+
+// findLDAPUser returns the account already provisioned for the LDAP login, if any, so a lockout
+// can be reported to its owner.
+func (s AuthService) findLDAPUser(logger *zap.Logger, email string, providerKey string) *models.User {
+	user, found, err := sql.FindUserByIdentityProvider(
+		s.DB, normalizeExternalEmail(email), models.LDAPProviderType, providerKey, false,
+	)
+	if err != nil {
+		logger.Warn("Failed to look up LDAP user", zap.Error(err))
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return &user
 }
 
 func mapLDAPAuthError(logger *zap.Logger, providerKey string, err error) error {
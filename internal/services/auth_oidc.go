@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/safebucket/safebucket/internal/activity"
-	"github.com/safebucket/safebucket/internal/cache"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	h "github.com/safebucket/safebucket/internal/helpers"
 	"github.com/safebucket/safebucket/internal/mfa"
@@ -95,7 +94,16 @@ func (s AuthService) OpenIDCallback(
 		}
 	}
 
-	verifiedCount, countErr := sql.CountVerifiedMFADevices(s.DB, searchUser.ID)
+	client := models.ClientInfoFromContext(ctx)
+	return s.completeRedirectLogin(logger, client, &searchUser, provider.MFARequired, providerKey)
+}
+
+// completeRedirectLogin finishes an OIDC or SAML sign-in: users with verified devices, or
+// signing in through a provider that requires MFA, get an MFA token instead of a session.
+func (s AuthService) completeRedirectLogin(
+	logger *zap.Logger, client models.ClientInfo, user *models.User, mfaRequired bool, providerKey string,
+) (models.OIDCCallbackResult, error) {
+	verifiedCount, countErr := sql.CountVerifiedMFADevices(s.DB, user.ID)
 	if countErr != nil {
 		logger.Error("Failed to count verified MFA devices", zap.Error(countErr))
 		return models.OIDCCallbackResult{}, apierrors.New(
@@ -104,27 +112,23 @@ func (s AuthService) OpenIDCallback(
 		)
 	}
 
-	if provider.MFARequired || verifiedCount > 0 {
-		mfaToken, mfaErr := mfa.HandleMFARequired(logger, s.AuthConfig, &searchUser)
+	if mfaRequired || verifiedCount > 0 {
+		mfaToken, mfaErr := mfa.HandleMFARequired(logger, s.AuthConfig, user)
 		if mfaErr != nil {
 			return models.OIDCCallbackResult{}, mfaErr
 		}
 		return models.OIDCCallbackResult{MFAToken: mfaToken, MFARequired: true}, nil
 	}
 
-	return s.issueOIDCSession(logger, &searchUser, providerKey)
+	return s.issueRedirectSession(logger, client, user, providerKey)
 }
 
-func (s AuthService) issueOIDCSession(
-	logger *zap.Logger, user *models.User, providerKey string,
+func (s AuthService) issueRedirectSession(
+	logger *zap.Logger, client models.ClientInfo, user *models.User, providerKey string,
 ) (models.OIDCCallbackResult, error) {
 	sid := uuid.New().String()
-	if sessionErr := cache.CreateSession(s.Cache, user.ID.String(), sid); sessionErr != nil {
-		logger.Error("Failed to create session", zap.Error(sessionErr))
-		return models.OIDCCallbackResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if sessionErr := s.sessionStarter().start(logger, user, sid, client); sessionErr != nil {
+		return models.OIDCCallbackResult{}, sessionErr
 	}
 
 	accessToken, err := h.NewAccessToken(s.AuthConfig.TokenSecret, user, providerKey, sid)
@@ -152,7 +156,7 @@ func (s AuthService) issueOIDCSession(
 			Action:       activity.UserLoggedIn,
 			UserID:       user.ID.String(),
 			ObjectType:   rbac.ResourceUser.String(),
-			ProviderType: string(models.OIDCProviderType),
+			ProviderType: string(user.ProviderType),
 			ProviderName: s.Providers[providerKey].Name,
 		}),
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/safebucket/safebucket/internal/messaging"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
 	"github.com/safebucket/safebucket/internal/rbac"
 
 	"github.com/alexedwards/argon2id"
@@ -32,6 +33,7 @@ type AuthPasswordResetService struct {
 	AuthConfig     models.AuthConfig
 	Publisher      messaging.IPublisher
 	ActivityLogger activity.IActivityLogger
+	PasswordPolicy password.Policy
 }
 
 func NewAuthPasswordResetService(s AuthService) AuthPasswordResetService {
@@ -41,6 +43,7 @@ func NewAuthPasswordResetService(s AuthService) AuthPasswordResetService {
 		AuthConfig:     s.AuthConfig,
 		Publisher:      s.Publisher,
 		ActivityLogger: s.ActivityLogger,
+		PasswordPolicy: s.PasswordPolicy,
 	}
 }
 
@@ -127,6 +130,7 @@ func (s AuthPasswordResetService) RequestPasswordReset(
 		secret,
 		user.Email,
 		challenge.ID.String(),
+		expiresAt,
 		s.AuthConfig.WebURL,
 	)
 	event.Trigger()
@@ -136,6 +140,7 @@ func (s AuthPasswordResetService) RequestPasswordReset(
 
 func (s AuthPasswordResetService) ValidatePasswordReset(
 	isSecure bool,
+	_ models.ClientInfo,
 	logger *zap.Logger,
 	_ models.UserClaims,
 	ids uuid.UUIDs,
@@ -253,6 +258,7 @@ func (s AuthPasswordResetService) ValidatePasswordReset(
 
 func (s AuthPasswordResetService) CompletePasswordReset(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	claims models.UserClaims,
 	ids uuid.UUIDs,
@@ -292,6 +298,10 @@ func (s AuthPasswordResetService) CompletePasswordReset(
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusForbidden, apierrors.CodeMFARequired)
 	}
 
+	if policyErr := checkNewPassword(logger, s.DB, s.PasswordPolicy, user, body.NewPassword); policyErr != nil {
+		return handlers.AuthFlowResult{}, policyErr
+	}
+
 	hashedPassword, err := h.CreateHash(body.NewPassword)
 	if err != nil {
 		logger.Error("Failed to hash new password", zap.Error(err))
@@ -302,6 +312,10 @@ func (s AuthPasswordResetService) CompletePasswordReset(
 	}
 
 	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if archiveErr := archivePassword(tx, s.PasswordPolicy, user); archiveErr != nil {
+			logger.Error("Failed to archive previous password", zap.Error(archiveErr))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
 		if updateErr := tx.Model(user).Update("hashed_password", hashedPassword).Error; updateErr != nil {
 			logger.Error("Failed to update password", zap.Error(updateErr))
 			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
@@ -344,12 +358,8 @@ func (s AuthPasswordResetService) CompletePasswordReset(
 	}
 
 	sid := uuid.New().String()
-	if sessionErr := cache.CreateSession(s.Cache, user.ID.String(), sid); sessionErr != nil {
-		logger.Error("Failed to create session", zap.Error(sessionErr))
-		return handlers.AuthFlowResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if sessionErr := s.sessionStarter().start(logger, user, sid, client); sessionErr != nil {
+		return handlers.AuthFlowResult{}, sessionErr
 	}
 
 	accessToken, err := h.NewAccessToken(
@@ -109,7 +109,7 @@ func TestCompletePasswordReset(t *testing.T) {
 		urlChallengeID := uuid.New()
 		jwtChallengeID := uuid.New()
 
-		_, err := svc.CompletePasswordReset(false,
+		_, err := svc.CompletePasswordReset(false, models.ClientInfo{},
 			zap.NewNop(),
 			models.UserClaims{UserID: uuid.New(), ChallengeID: &jwtChallengeID},
 			uuid.UUIDs{urlChallengeID},
@@ -124,7 +124,7 @@ func TestCompletePasswordReset(t *testing.T) {
 		svc, mock, cleanup := newPasswordResetTestService(t)
 		defer cleanup()
 
-		_, err := svc.CompletePasswordReset(false,
+		_, err := svc.CompletePasswordReset(false, models.ClientInfo{},
 			zap.NewNop(),
 			models.UserClaims{UserID: uuid.New(), ChallengeID: nil},
 			uuid.UUIDs{uuid.New()},
@@ -146,7 +146,7 @@ func TestCompletePasswordReset(t *testing.T) {
 			WithArgs(challengeID, models.ChallengeTypePasswordReset, jwtUserID, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
-		_, err := svc.CompletePasswordReset(false,
+		_, err := svc.CompletePasswordReset(false, models.ClientInfo{},
 			zap.NewNop(),
 			models.UserClaims{UserID: jwtUserID, ChallengeID: &challengeID},
 			uuid.UUIDs{challengeID},
@@ -182,7 +182,7 @@ func TestCompletePasswordReset(t *testing.T) {
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectCommit()
 
-		_, err := svc.CompletePasswordReset(false,
+		_, err := svc.CompletePasswordReset(false, models.ClientInfo{},
 			zap.NewNop(),
 			models.UserClaims{UserID: userID, ChallengeID: &challengeID},
 			uuid.UUIDs{challengeID},
@@ -224,7 +224,7 @@ func TestCompletePasswordReset(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "mfa_devices"`).
 			WillReturnRows(mfaDeviceRows)
 
-		_, err := svc.CompletePasswordReset(false,
+		_, err := svc.CompletePasswordReset(false, models.ClientInfo{},
 			zap.NewNop(),
 			models.UserClaims{UserID: userID, ChallengeID: &challengeID, MFA: false},
 			uuid.UUIDs{challengeID},
@@ -274,7 +274,7 @@ func TestValidatePasswordReset_ExpiredChallenge(t *testing.T) {
 
 	mock.ExpectRollback()
 
-	_, err = svc.ValidatePasswordReset(false,
+	_, err = svc.ValidatePasswordReset(false, models.ClientInfo{},
 		zap.NewNop(),
 		models.UserClaims{},
 		uuid.UUIDs{challengeID},
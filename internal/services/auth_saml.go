@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/sql"
+
+	"github.com/crewjam/saml"
+	"go.uber.org/zap"
+)
+
+// Attribute names used when a SAML provider leaves its attribute map empty.
+const (
+	samlDefaultEmailAttribute     = "email"
+	samlDefaultFirstNameAttribute = "givenName"
+	samlDefaultLastNameAttribute  = "sn"
+)
+
+type samlProfile struct {
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+func (s AuthService) samlProvider(providerKey string) (*saml.ServiceProvider, *models.SAMLConfiguration, error) {
+	provider, ok := s.Providers[providerKey]
+	if !ok || provider.Type != models.SAMLProviderType || provider.SAML == nil {
+		return nil, nil, apierrors.New(http.StatusNotFound, apierrors.CodeProviderNotFound)
+	}
+	return provider.SAML, provider.SAMLConfig, nil
+}
+
+func (s AuthService) SAMLMetadata(providerKey string) ([]byte, error) {
+	sp, _, err := s.samlProvider(providerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if err != nil {
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	return metadata, nil
+}
+
+// SAMLBegin starts an SP-initiated sign-in. The request ID is kept in the cache under a random
+// relay state so the assertion consumer can match the IdP response without relying on cookies,
+// which browsers drop on the IdP's cross-site POST.
+func (s AuthService) SAMLBegin(logger *zap.Logger, providerKey string) (string, error) {
+	sp, _, err := s.samlProvider(providerKey)
+	if err != nil {
+		return "", err
+	}
+
+	relayState, err := h.RandString(16)
+	if err != nil {
+		logger.Error("Failed to generate SAML relay state", zap.Error(err))
+		return "", apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	req, err := sp.MakeAuthenticationRequest(
+		sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding,
+	)
+	if err != nil {
+		logger.Error("Failed to build SAML authentication request", zap.Error(err))
+		return "", apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	if cacheErr := cache.SetSAMLRequest(s.Cache, relayState, req.ID); cacheErr != nil {
+		logger.Error("Failed to store SAML request", zap.Error(cacheErr))
+		return "", apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	redirectURL, err := req.Redirect(relayState, sp)
+	if err != nil {
+		logger.Error("Failed to encode SAML authentication request", zap.Error(err))
+		return "", apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	return redirectURL.String(), nil
+}
+
+// SAMLCallback consumes the IdP response posted to the assertion consumer service. Responses
+// to an SP-initiated request must answer the request stored for their relay state, and an
+// unknown relay state is refused. Responses without a relay state are only accepted when the
+// provider allows IdP-initiated sign-in. Each assertion can be used once.
+func (s AuthService) SAMLCallback(
+	ctx context.Context, logger *zap.Logger, providerKey string, samlResponse string, relayState string,
+) (models.OIDCCallbackResult, error) {
+	configured, cfg, err := s.samlProvider(providerKey)
+	if err != nil {
+		return models.OIDCCallbackResult{}, err
+	}
+
+	if samlResponse == "" {
+		return models.OIDCCallbackResult{}, apierrors.New(http.StatusBadRequest, apierrors.CodeSAMLResponseMissing)
+	}
+
+	var requestIDs []string
+	if relayState != "" {
+		requestID, found, consumeErr := cache.ConsumeSAMLRequest(s.Cache, relayState)
+		if consumeErr != nil {
+			logger.Error("Failed to look up SAML request", zap.Error(consumeErr))
+			return models.OIDCCallbackResult{}, apierrors.New(
+				http.StatusInternalServerError,
+				apierrors.CodeInternalServerError,
+			)
+		}
+		if !found {
+			return models.OIDCCallbackResult{}, apierrors.New(http.StatusBadRequest, apierrors.CodeSAMLRequestNotFound)
+		}
+		requestIDs = []string{requestID}
+	}
+
+	// Copy the service provider so the IdP-initiated flag only applies to this response.
+	sp := *configured
+	if len(requestIDs) == 0 {
+		if !cfg.AllowIDPInitiated {
+			return models.OIDCCallbackResult{}, apierrors.New(http.StatusBadRequest, apierrors.CodeSAMLRequestNotFound)
+		}
+		sp.AllowIDPInitiated = true
+	}
+
+	rawResponse, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return models.OIDCCallbackResult{}, apierrors.New(http.StatusBadRequest, apierrors.CodeSAMLResponseInvalid)
+	}
+
+	assertion, err := sp.ParseXMLResponse(rawResponse, requestIDs)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) {
+			err = invalid.PrivateErr
+		}
+		logger.Warn("Rejected SAML response", zap.String("provider", providerKey), zap.Error(err))
+		return models.OIDCCallbackResult{}, apierrors.New(http.StatusUnauthorized, apierrors.CodeSAMLResponseInvalid)
+	}
+
+	if err = s.consumeSAMLAssertion(logger, providerKey, assertion); err != nil {
+		return models.OIDCCallbackResult{}, err
+	}
+
+	profile := mapSAMLProfile(assertion, cfg.AttributeMap)
+	if profile.Email == "" {
+		return models.OIDCCallbackResult{}, apierrors.New(http.StatusBadRequest, apierrors.CodeSAMLEmailMissing)
+	}
+
+	if !h.IsDomainAllowed(profile.Email, s.Providers[providerKey].Domains) {
+		logger.Debug("Domain not allowed")
+		return models.OIDCCallbackResult{}, apierrors.New(http.StatusForbidden, apierrors.CodeForbidden)
+	}
+
+	user, err := s.upsertSAMLUser(logger, providerKey, profile)
+	if err != nil {
+		return models.OIDCCallbackResult{}, err
+	}
+
+	client := models.ClientInfoFromContext(ctx)
+	return s.completeRedirectLogin(logger, client, &user, s.Providers[providerKey].MFARequired, providerKey)
+}
+
+// consumeSAMLAssertion refuses an assertion that was already used to sign in. Its ID is kept
+// for as long as the assertion would still validate: the issue delay and the conditions
+// window, both widened by the allowed clock skew.
+func (s AuthService) consumeSAMLAssertion(logger *zap.Logger, providerKey string, assertion *saml.Assertion) error {
+	expiresAt := assertion.IssueInstant.Add(saml.MaxIssueDelay)
+	if assertion.Conditions != nil && assertion.Conditions.NotOnOrAfter.After(expiresAt) {
+		expiresAt = assertion.Conditions.NotOnOrAfter
+	}
+	ttl := max(time.Until(expiresAt)+saml.MaxClockSkew, time.Second)
+
+	first, err := cache.MarkSAMLAssertionUsed(s.Cache, providerKey, assertion.ID, ttl)
+	if err != nil {
+		logger.Error("Failed to record SAML assertion", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	if !first {
+		logger.Warn("Rejected replayed SAML assertion",
+			zap.String("provider", providerKey),
+			zap.String("assertion_id", assertion.ID))
+		return apierrors.New(http.StatusUnauthorized, apierrors.CodeSAMLResponseInvalid)
+	}
+	return nil
+}
+
+func (s AuthService) upsertSAMLUser(logger *zap.Logger, providerKey string, profile samlProfile) (models.User, error) {
+	user, found, err := sql.FindUserByIdentityProvider(
+		s.DB, profile.Email, models.SAMLProviderType, providerKey, false,
+	)
+	if err != nil {
+		logger.Error("Failed to look up SAML user", zap.Error(err))
+		return models.User{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	if !found {
+		user = models.User{
+			FirstName:    profile.FirstName,
+			LastName:     profile.LastName,
+			Email:        profile.Email,
+			ProviderType: models.SAMLProviderType,
+			ProviderKey:  providerKey,
+			Role:         models.RoleUser,
+		}
+		if createErr := sql.CreateUserWithInvites(logger, s.DB, &user); createErr != nil {
+			logger.Error("Failed to create SAML user", zap.Error(createErr))
+			return models.User{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+		return user, nil
+	}
+
+	// The IdP owns the profile: keep names in sync with the latest assertion.
+	updates := map[string]any{}
+	if profile.FirstName != "" && profile.FirstName != user.FirstName {
+		updates["first_name"] = profile.FirstName
+	}
+	if profile.LastName != "" && profile.LastName != user.LastName {
+		updates["last_name"] = profile.LastName
+	}
+	if len(updates) > 0 {
+		if updateErr := s.DB.Model(&user).Updates(updates).Error; updateErr != nil {
+			logger.Warn("Failed to sync SAML user profile", zap.Error(updateErr))
+		}
+	}
+	return user, nil
+}
+
+// mapSAMLProfile reads the user profile from the assertion attributes, matching either the
+// attribute name or its friendly name. The email falls back to an email-formatted NameID.
+func mapSAMLProfile(assertion *saml.Assertion, attributeMap models.SAMLAttributeMap) samlProfile {
+	values := map[string]string{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			if len(attribute.Values) == 0 {
+				continue
+			}
+			value := strings.TrimSpace(attribute.Values[0].Value)
+			values[attribute.Name] = value
+			if attribute.FriendlyName != "" {
+				values[attribute.FriendlyName] = value
+			}
+		}
+	}
+
+	lookup := func(name string, fallback string) string {
+		if name == "" {
+			name = fallback
+		}
+		return values[name]
+	}
+
+	profile := samlProfile{
+		Email:     lookup(attributeMap.Email, samlDefaultEmailAttribute),
+		FirstName: lookup(attributeMap.FirstName, samlDefaultFirstNameAttribute),
+		LastName:  lookup(attributeMap.LastName, samlDefaultLastNameAttribute),
+	}
+
+	if profile.Email == "" && assertion.Subject != nil && assertion.Subject.NameID != nil &&
+		assertion.Subject.NameID.Format == string(saml.EmailAddressNameIDFormat) {
+		profile.Email = strings.TrimSpace(assertion.Subject.NameID.Value)
+	}
+	return profile
+}
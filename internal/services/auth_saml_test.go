@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func samlAttribute(name, friendlyName, value string) saml.Attribute {
+	return saml.Attribute{Name: name, FriendlyName: friendlyName, Values: []saml.AttributeValue{{Value: value}}}
+}
+
+func TestMapSAMLProfile(t *testing.T) {
+	t.Run("uses the default attribute names", func(t *testing.T) {
+		assertion := &saml.Assertion{AttributeStatements: []saml.AttributeStatement{{
+			Attributes: []saml.Attribute{
+				samlAttribute("urn:oid:0.9.2342.19200300.100.1.3", "email", " jane@example.com "),
+				samlAttribute("urn:oid:2.5.4.42", "givenName", "Jane"),
+				samlAttribute("urn:oid:2.5.4.4", "sn", "Doe"),
+			},
+		}}}
+
+		profile := mapSAMLProfile(assertion, models.SAMLAttributeMap{})
+		assert.Equal(t, samlProfile{Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"}, profile)
+	})
+
+	t.Run("honours a custom attribute map", func(t *testing.T) {
+		assertion := &saml.Assertion{AttributeStatements: []saml.AttributeStatement{{
+			Attributes: []saml.Attribute{
+				samlAttribute("http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress", "", "a@b.io"),
+				samlAttribute("first", "", "Ada"),
+				samlAttribute("last", "", "Lovelace"),
+			},
+		}}}
+
+		profile := mapSAMLProfile(assertion, models.SAMLAttributeMap{
+			Email:     "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+			FirstName: "first",
+			LastName:  "last",
+		})
+		assert.Equal(t, samlProfile{Email: "a@b.io", FirstName: "Ada", LastName: "Lovelace"}, profile)
+	})
+
+	t.Run("falls back to an email NameID", func(t *testing.T) {
+		assertion := &saml.Assertion{Subject: &saml.Subject{NameID: &saml.NameID{
+			Format: string(saml.EmailAddressNameIDFormat),
+			Value:  "nameid@example.com",
+		}}}
+
+		profile := mapSAMLProfile(assertion, models.SAMLAttributeMap{})
+		assert.Equal(t, "nameid@example.com", profile.Email)
+	})
+
+	t.Run("ignores an opaque NameID", func(t *testing.T) {
+		assertion := &saml.Assertion{Subject: &saml.Subject{NameID: &saml.NameID{
+			Format: string(saml.PersistentNameIDFormat),
+			Value:  "a1b2c3",
+		}}}
+
+		profile := mapSAMLProfile(assertion, models.SAMLAttributeMap{})
+		assert.Empty(t, profile.Email)
+	})
+}
+
+func TestSAMLCallback_RejectsUnsolicitedResponse(t *testing.T) {
+	service := AuthService{
+		Cache: &MockCache{},
+		Providers: configuration.Providers{
+			"corp": {
+				Name:       "Corp",
+				Type:       models.SAMLProviderType,
+				SAML:       &saml.ServiceProvider{},
+				SAMLConfig: &models.SAMLConfiguration{AllowIDPInitiated: false},
+			},
+		},
+	}
+
+	_, err := service.SAMLCallback(context.Background(), zap.NewNop(), "corp", "PHNhbWw+", "unknown-relay")
+	require.Error(t, err)
+
+	var apiErr *apierrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, apierrors.CodeSAMLRequestNotFound, apiErr.Code)
+}
+
+func TestSAMLCallback_RejectsUnknownRelayState(t *testing.T) {
+	service := AuthService{
+		Cache: &MockCache{},
+		Providers: configuration.Providers{
+			"corp": {
+				Name:       "Corp",
+				Type:       models.SAMLProviderType,
+				SAML:       &saml.ServiceProvider{},
+				SAMLConfig: &models.SAMLConfiguration{AllowIDPInitiated: true},
+			},
+		},
+	}
+
+	// An expired or forged relay state must not fall back to the IdP-initiated flow.
+	_, err := service.SAMLCallback(context.Background(), zap.NewNop(), "corp", "PHNhbWw+", "unknown-relay")
+	requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeSAMLRequestNotFound)
+}
+
+func TestConsumeSAMLAssertion_RejectsReplay(t *testing.T) {
+	mc := cache.NewMemoryCache()
+	service := AuthService{Cache: mc}
+
+	assertion := &saml.Assertion{
+		ID:           "id-4f2a",
+		IssueInstant: time.Now(),
+		Conditions:   &saml.Conditions{NotOnOrAfter: time.Now().Add(5 * time.Minute)},
+	}
+
+	require.NoError(t, service.consumeSAMLAssertion(zap.NewNop(), "corp", assertion))
+
+	err := service.consumeSAMLAssertion(zap.NewNop(), "corp", assertion)
+	requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeSAMLResponseInvalid)
+
+	ttl, err := mc.TTL("saml:assertion:corp:id-4f2a")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 5*time.Minute, "the ID is kept until the assertion expires")
+	assert.LessOrEqual(t, ttl, 5*time.Minute+saml.MaxClockSkew)
+
+	require.NoError(t, service.consumeSAMLAssertion(zap.NewNop(), "other", assertion),
+		"assertion IDs are tracked per provider")
+}
@@ -104,6 +104,7 @@ func TestLogin_UserHasMFA_ConfigMFADisabled_RequiresMFA(t *testing.T) {
 
 		response, err := service.Login(
 			false,
+			models.ClientInfo{},
 			logger,
 			models.UserClaims{},
 			uuid.UUIDs{},
@@ -188,6 +189,7 @@ func TestLogin_UserNoMFA_ConfigMFADisabled_NoMFARequired(t *testing.T) {
 
 		response, err := service.Login(
 			false,
+			models.ClientInfo{},
 			logger,
 			models.UserClaims{},
 			uuid.UUIDs{},
@@ -268,6 +270,7 @@ func TestLogin_UserNoMFA_ProviderMFAEnabled_RequiresMFA(t *testing.T) {
 
 		response, err := service.Login(
 			false,
+			models.ClientInfo{},
 			logger,
 			models.UserClaims{},
 			uuid.UUIDs{},
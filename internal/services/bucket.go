@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/cache"
 	c "github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/database"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/handlers"
@@ -16,7 +18,9 @@ import (
 	"github.com/safebucket/safebucket/internal/messaging"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 	"github.com/safebucket/safebucket/internal/storage"
 
 	"github.com/go-chi/chi/v5"
@@ -26,14 +30,17 @@ import (
 )
 
 type BucketService struct {
-	DB                 *gorm.DB
-	Cache              cache.ICache
-	Storage            storage.IStorage
-	Publisher          messaging.IPublisher
-	Providers          c.Providers
-	ActivityLogger     activity.IActivityLogger
-	WebURL             string
-	TrashRetentionDays int
+	DB                   *gorm.DB
+	Cache                cache.ICache
+	Storage              storage.IStorage
+	Publisher            messaging.IPublisher
+	Providers            c.Providers
+	ActivityLogger       activity.IActivityLogger
+	WebURL               string
+	TrashRetentionDays   int
+	InviteExpiryDays     int
+	Channels             notifier.IChannelNotifier
+	StorageEncryptionKey string
 }
 
 func (s BucketService) Routes() chi.Router {
@@ -43,7 +50,7 @@ func (s BucketService) Routes() chi.Router {
 		Get("/", handlers.GetListHandler(s.GetBucketList))
 
 	r.With(m.AuthorizeRole(models.RoleUser)).
-		With(m.Validate[models.BucketCreateUpdateBody]).
+		With(m.Validate[models.BucketCreateBody]).
 		Post("/", handlers.CreateHandler(s.CreateBucket))
 
 	r.With(m.AuthorizeRole(models.RoleGuest)).
@@ -67,20 +74,22 @@ func (s BucketService) Routes() chi.Router {
 			Get("/activity", handlers.GetOneWithQueryHandler(s.GetBucketActivity))
 
 		r.Mount("/members", BucketMemberService{
-			DB:             s.DB,
-			Providers:      s.Providers,
-			Publisher:      s.Publisher,
-			ActivityLogger: s.ActivityLogger,
-			WebURL:         s.WebURL,
+			DB:               s.DB,
+			Providers:        s.Providers,
+			Publisher:        s.Publisher,
+			ActivityLogger:   s.ActivityLogger,
+			WebURL:           s.WebURL,
+			InviteExpiryDays: s.InviteExpiryDays,
 		}.Routes())
 
 		r.Mount("/", BucketFileService{
-			DB:                 s.DB,
-			Cache:              s.Cache,
-			Storage:            s.Storage,
-			Publisher:          s.Publisher,
-			ActivityLogger:     s.ActivityLogger,
-			TrashRetentionDays: s.TrashRetentionDays,
+			DB:                   s.DB,
+			Cache:                s.Cache,
+			Storage:              s.Storage,
+			Publisher:            s.Publisher,
+			ActivityLogger:       s.ActivityLogger,
+			TrashRetentionDays:   s.TrashRetentionDays,
+			StorageEncryptionKey: s.StorageEncryptionKey,
 		}.Routes())
 
 		r.Mount("/folders", BucketFolderService{
@@ -91,9 +100,29 @@ func (s BucketService) Routes() chi.Router {
 			TrashRetentionDays: s.TrashRetentionDays,
 		}.Routes())
 
+		r.Mount("/channels", BucketChannelService{
+			DB:             s.DB,
+			Channels:       s.Channels,
+			ActivityLogger: s.ActivityLogger,
+			WebURL:         s.WebURL,
+		}.Routes())
+
+		r.Mount("/keys", BucketKeyService{
+			DB:             s.DB,
+			ActivityLogger: s.ActivityLogger,
+		}.Routes())
+
+		r.Mount("/lifecycle", BucketLifecycleService{
+			DB:             s.DB,
+			ActivityLogger: s.ActivityLogger,
+		}.Routes())
+
 		r.Mount("/shares", BucketShareService{
 			DB:             s.DB,
+			Providers:      s.Providers,
+			Publisher:      s.Publisher,
 			ActivityLogger: s.ActivityLogger,
+			WebURL:         s.WebURL,
 		}.Routes())
 	})
 
@@ -104,12 +133,40 @@ func (s BucketService) CreateBucket(
 	logger *zap.Logger,
 	user models.UserClaims,
 	_ uuid.UUIDs,
-	body models.BucketCreateUpdateBody,
+	body models.BucketCreateBody,
 ) (models.Bucket, error) {
+	if body.Encryption != nil {
+		var userKey models.UserKey
+		result := s.DB.Where("user_id = ?", user.UserID).Find(&userKey)
+		if result.Error != nil {
+			logger.Error("Failed to load user key", zap.Error(result.Error))
+			return models.Bucket{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+		if result.RowsAffected == 0 {
+			return models.Bucket{}, apierrors.New(http.StatusBadRequest, apierrors.CodeUserKeyNotFound)
+		}
+		if userKey.ID != body.Encryption.UserKeyID {
+			return models.Bucket{}, apierrors.New(http.StatusConflict, apierrors.CodeUserKeyOutdated)
+		}
+	}
+
+	sse, sseErr := newBucketSSE(logger, s.Storage, s.StorageEncryptionKey, body.ServerSideEncryption)
+	if sseErr != nil {
+		return models.Bucket{}, sseErr
+	}
+
 	var newBucket models.Bucket
 
 	err := s.DB.Transaction(func(tx *gorm.DB) error {
-		newBucket = models.Bucket{Name: body.Name, CreatedBy: user.UserID}
+		newBucket = models.Bucket{
+			Name:           body.Name,
+			Encrypted:      body.Encryption != nil,
+			KeyVersion:     1,
+			SSEMode:        sse.SSEMode,
+			SSEKeyID:       sse.SSEKeyID,
+			SSECustomerKey: sse.SSECustomerKey,
+			CreatedBy:      user.UserID,
+		}
 		res := tx.Create(&newBucket)
 
 		if res.Error != nil {
@@ -123,6 +180,21 @@ func (s BucketService) CreateBucket(
 			return err
 		}
 
+		if body.Encryption != nil {
+			err = tx.Create(&models.BucketKey{
+				BucketID:   newBucket.ID,
+				UserID:     user.UserID,
+				UserKeyID:  body.Encryption.UserKeyID,
+				KeyVersion: newBucket.KeyVersion,
+				WrappedKey: body.Encryption.WrappedKey,
+				CreatedBy:  &user.UserID,
+			}).Error
+			if err != nil {
+				logger.Error("Failed to store bucket key", zap.Error(err))
+				return err
+			}
+		}
+
 		action := models.Activity{
 			Message: activity.BucketCreated,
 			Object:  newBucket.ToActivity(),
@@ -181,12 +253,13 @@ func (s BucketService) GetBucketList(
 	_ uuid.UUIDs,
 ) []models.Bucket {
 	var buckets []models.Bucket
+	db := database.ReadReplica(s.DB)
 	if !user.Valid() {
 		logger.Warn("Invalid user claims", zap.String("user_id", user.UserID.String()))
 		return []models.Bucket{}
 	}
 
-	memberships, err := rbac.GetUserBuckets(s.DB, user.UserID)
+	memberships, err := rbac.GetUserBuckets(db, user.UserID)
 	if err != nil {
 		logger.Error(
 			"Error retrieving user buckets",
@@ -205,7 +278,7 @@ func (s BucketService) GetBucketList(
 		return []models.Bucket{}
 	}
 
-	if err = s.DB.Where("id IN ?", bucketIDs).Find(&buckets).Error; err != nil {
+	if err = db.Where("id IN ?", bucketIDs).Find(&buckets).Error; err != nil {
 		logger.Error("Error querying buckets", zap.Error(err))
 		return []models.Bucket{}
 	}
@@ -220,11 +293,12 @@ func (s BucketService) GetBucket(
 	queryParams models.BucketQueryParams,
 ) (models.Bucket, error) {
 	bucketID := ids[0]
+	db := database.ReadReplica(s.DB)
 	var bucket models.Bucket
 	bucket.Files = []models.File{}
 	bucket.Folders = []models.Folder{}
 
-	result := s.DB.Where("id = ?", bucketID).First(&bucket)
+	result := db.Where("id = ?", bucketID).First(&bucket)
 	if result.RowsAffected == 0 {
 		return bucket, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
 	}
@@ -238,7 +312,7 @@ func (s BucketService) GetBucket(
 
 	switch status {
 	case "deleted":
-		fileResult := s.DB.Unscoped().
+		fileResult := db.Unscoped().
 			Where(
 				"bucket_id = ? AND deleted_at IS NOT NULL AND (status IS NULL OR status != ?) AND (expires_at IS NULL OR expires_at > ?)",
 				bucketID,
@@ -257,7 +331,7 @@ func (s BucketService) GetBucket(
 			}
 		}
 
-		folderResult := s.DB.Unscoped().
+		folderResult := db.Unscoped().
 			Where(
 				"bucket_id = ? AND deleted_at IS NOT NULL AND status != ?",
 				bucketID,
@@ -276,19 +350,24 @@ func (s BucketService) GetBucket(
 		}
 
 	case "all":
-		result = s.DB.Where("bucket_id = ? AND (expires_at IS NULL OR expires_at > ?)", bucketID, now).Find(&files)
+		result = db.Where(
+			"bucket_id = ? AND (expires_at IS NULL OR expires_at > ?) AND (status IS NULL OR status != ?)",
+			bucketID,
+			now,
+			models.FileStatusPending,
+		).Find(&files)
 		if result.RowsAffected > 0 {
 			bucket.Files = files
 		}
 
-		result = s.DB.Where("bucket_id = ?", bucketID).Find(&folders)
+		result = db.Where("bucket_id = ?", bucketID).Find(&folders)
 		if result.RowsAffected > 0 {
 			bucket.Folders = folders
 		}
 
 	case "uploading":
 		expirationTime := time.Now().Add(-c.UploadPolicyExpirationInMinutes * time.Minute)
-		result = s.DB.Where(
+		result = db.Where(
 			"bucket_id = ? AND status = ? AND created_at > ?",
 			bucketID,
 			models.FileStatusUploading,
@@ -303,7 +382,7 @@ func (s BucketService) GetBucket(
 		fallthrough
 	default:
 		expirationTime := now.Add(-c.UploadPolicyExpirationInMinutes * time.Minute)
-		result = s.DB.Where(
+		result = db.Where(
 			"bucket_id = ? AND (expires_at IS NULL OR expires_at > ?) AND (status = ? OR (status = ? AND created_at > ?))",
 			bucketID,
 			now,
@@ -316,7 +395,7 @@ func (s BucketService) GetBucket(
 			bucket.Files = files
 		}
 
-		result = s.DB.Where("bucket_id = ?", bucketID).Find(&folders)
+		result = db.Where("bucket_id = ?", bucketID).Find(&folders)
 
 		if result.RowsAffected > 0 {
 			bucket.Folders = folders
@@ -381,6 +460,10 @@ func (s BucketService) DeleteBucket(
 			return apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
 		}
 
+		if err := retention.CheckBucket(tx, bucket.ID); err != nil {
+			return retentionError(logger, err)
+		}
+
 		if _, err := gorm.G[models.Bucket](tx).Where("id = ?", bucket.ID).Delete(context.Background()); err != nil {
 			return err
 		}
@@ -408,10 +491,13 @@ func (s BucketService) DeleteBucket(
 		}
 
 		event := events.NewBucketPurge(s.Publisher, bucket.ID, user.UserID)
-		event.Trigger()
-		return nil
+		return event.Enqueue(tx)
 	})
 	if err != nil {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) {
+			return err
+		}
 		logger.Error("Failed to delete bucket", zap.Error(err))
 		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
 	}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/database"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/handlers"
+	m "github.com/safebucket/safebucket/internal/middlewares"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BucketChannelService manages the chat webhooks bucket notifications are routed to.
+type BucketChannelService struct {
+	DB             *gorm.DB
+	Channels       notifier.IChannelNotifier
+	ActivityLogger activity.IActivityLogger
+	WebURL         string
+}
+
+func (s BucketChannelService) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(m.AuthorizeGroup(s.DB, models.GroupOwner, 0))
+
+	r.Get("/", handlers.GetListHandler(s.ListChannels))
+	r.With(m.Validate[models.BucketChannelCreateBody]).
+		Post("/", handlers.CreateHandler(s.CreateChannel))
+
+	r.Route("/{id1}", func(r chi.Router) {
+		r.With(m.Validate[models.BucketChannelUpdateBody]).
+			Patch("/", handlers.BodyHandler(s.UpdateChannel))
+		r.Delete("/", handlers.DeleteHandler(s.DeleteChannel))
+		r.Post("/test", handlers.ActionHandler(s.TestChannel))
+	})
+
+	return r
+}
+
+func (s BucketChannelService) ListChannels(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+) []models.BucketChannel {
+	var channels []models.BucketChannel
+	err := database.ReadReplica(s.DB).
+		Where("bucket_id = ?", ids[0]).
+		Order("created_at ASC").
+		Find(&channels).Error
+	if err != nil {
+		logger.Error("Failed to list bucket channels", zap.Error(err))
+		return []models.BucketChannel{}
+	}
+
+	for i := range channels {
+		channels[i] = channels[i].Redacted()
+	}
+
+	return channels
+}
+
+func (s BucketChannelService) CreateChannel(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+	body models.BucketChannelCreateBody,
+) (models.BucketChannel, error) {
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ?", ids[0]).First(&bucket).Error; err != nil {
+		return models.BucketChannel{}, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+
+	if err := s.Channels.ValidateWebhookURL(body.Type, body.WebhookURL); err != nil {
+		return models.BucketChannel{}, apierrors.New(http.StatusBadRequest, apierrors.CodeChannelWebhookNotAllowed)
+	}
+
+	channel := models.BucketChannel{
+		BucketID:        bucket.ID,
+		Type:            body.Type,
+		Name:            body.Name,
+		WebhookURL:      body.WebhookURL,
+		NotifyUploads:   body.NotifyUploads,
+		NotifyDownloads: body.NotifyDownloads,
+		NotifyShares:    body.NotifyShares,
+		CreatedBy:       &user.UserID,
+	}
+
+	if err := s.DB.Create(&channel).Error; err != nil {
+		logger.Error("Failed to create bucket channel", zap.Error(err))
+		return models.BucketChannel{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	s.logChannelActivity(logger, activity.ChannelCreated, bucket, user)
+
+	return channel.Redacted(), nil
+}
+
+func (s BucketChannelService) UpdateChannel(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+	body models.BucketChannelUpdateBody,
+) error {
+	channel, err := s.getChannel(ids[0], ids[1])
+	if err != nil {
+		return err
+	}
+
+	if body.WebhookURL != nil {
+		if err = s.Channels.ValidateWebhookURL(channel.Type, *body.WebhookURL); err != nil {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeChannelWebhookNotAllowed)
+		}
+	}
+
+	if err = s.DB.Model(&channel).Updates(body).Error; err != nil {
+		logger.Error("Failed to update bucket channel", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	return nil
+}
+
+func (s BucketChannelService) DeleteChannel(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	channel, err := s.getChannel(ids[0], ids[1])
+	if err != nil {
+		return err
+	}
+
+	if err = s.DB.Delete(&channel).Error; err != nil {
+		logger.Error("Failed to delete bucket channel", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+	}
+
+	var bucket models.Bucket
+	if err = s.DB.Where("id = ?", channel.BucketID).First(&bucket).Error; err == nil {
+		s.logChannelActivity(logger, activity.ChannelDeleted, bucket, user)
+	}
+
+	return nil
+}
+
+// TestChannel posts a confirmation message so owners can check the webhook before relying on it.
+func (s BucketChannelService) TestChannel(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	channel, err := s.getChannel(ids[0], ids[1])
+	if err != nil {
+		return err
+	}
+
+	var bucket models.Bucket
+	if err = s.DB.Where("id = ?", channel.BucketID).First(&bucket).Error; err != nil {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+
+	data := map[string]string{
+		"BucketID":   bucket.ID.String(),
+		"BucketName": bucket.Name,
+		"WebURL":     s.WebURL,
+	}
+	if err = s.Channels.NotifyChannel(channel.Type, channel.WebhookURL, "channel_test", data); err != nil {
+		logger.Warn("Failed to deliver channel test message",
+			zap.String("channel_id", channel.ID.String()),
+			zap.Error(err))
+		return apierrors.New(http.StatusBadGateway, apierrors.CodeChannelDeliveryFailed)
+	}
+
+	return nil
+}
+
+func (s BucketChannelService) getChannel(bucketID, channelID uuid.UUID) (models.BucketChannel, error) {
+	var channel models.BucketChannel
+	err := s.DB.Where("id = ? AND bucket_id = ?", channelID, bucketID).First(&channel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return channel, apierrors.New(http.StatusNotFound, apierrors.CodeChannelNotFound)
+	}
+	if err != nil {
+		return channel, apierrors.New(http.StatusInternalServerError, apierrors.CodeFetchFailed)
+	}
+	return channel, nil
+}
+
+func (s BucketChannelService) logChannelActivity(
+	logger *zap.Logger,
+	message string,
+	bucket models.Bucket,
+	user models.UserClaims,
+) {
+	if err := s.ActivityLogger.Send(models.Activity{
+		Message: message,
+		Object:  bucket.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     rbac.ActionUpdate.String(),
+			ObjectType: rbac.ResourceBucket.String(),
+			BucketID:   bucket.ID.String(),
+			UserID:     user.UserID.String(),
+		}),
+	}); err != nil {
+		logger.Error("Failed to log channel activity", zap.Error(err))
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/database"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/handlers"
@@ -16,6 +17,7 @@ import (
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 	"github.com/safebucket/safebucket/internal/sql"
 	"github.com/safebucket/safebucket/internal/storage"
 
@@ -27,12 +29,13 @@ import (
 )
 
 type BucketFileService struct {
-	DB                 *gorm.DB
-	Cache              cache.ICache
-	Storage            storage.IStorage
-	Publisher          messaging.IPublisher
-	ActivityLogger     activity.IActivityLogger
-	TrashRetentionDays int
+	DB                   *gorm.DB
+	Cache                cache.ICache
+	Storage              storage.IStorage
+	Publisher            messaging.IPublisher
+	ActivityLogger       activity.IActivityLogger
+	TrashRetentionDays   int
+	StorageEncryptionKey string
 }
 
 func (s BucketFileService) Routes() chi.Router {
@@ -42,6 +45,9 @@ func (s BucketFileService) Routes() chi.Router {
 		With(m.Validate[models.FileUploadBody]).
 		Post("/files", handlers.CreateHandler(s.UploadFile))
 
+	r.With(m.AuthorizeGroup(s.DB, models.GroupContributor, 0)).
+		Get("/files/pending", handlers.GetListHandler(s.ListPendingFiles))
+
 	r.Route("/files/{id1}", func(r chi.Router) {
 		r.With(m.AuthorizeGroup(s.DB, models.GroupContributor, 0)).
 			With(m.Validate[models.FilePatchBody]).
@@ -50,6 +56,12 @@ func (s BucketFileService) Routes() chi.Router {
 		r.With(m.AuthorizeGroup(s.DB, models.GroupContributor, 0)).
 			Delete("/", handlers.DeleteHandler(s.DeleteFile))
 
+		r.With(m.AuthorizeGroup(s.DB, models.GroupContributor, 0)).
+			Post("/approve", handlers.ActionHandler(s.ApproveFile))
+
+		r.With(m.AuthorizeGroup(s.DB, models.GroupContributor, 0)).
+			Post("/reject", handlers.ActionHandler(s.RejectFile))
+
 		r.With(m.AuthorizeGroup(s.DB, models.GroupViewer, 0)).
 			With(m.ValidateQuery[models.FileDownloadQuery]).
 			Get("/url", handlers.GetOneWithQueryHandler(s.DownloadFile))
@@ -90,18 +102,38 @@ func (s BucketFileService) UploadFile(
 		return models.FileUploadResponse{}, apierrors.New(http.StatusConflict, apierrors.CodeFileAlreadyExists)
 	}
 
+	if err := checkFileEncryption(bucket, body.Encryption); err != nil {
+		return models.FileUploadResponse{}, err
+	}
+
+	extension := h.ExtensionFromName(body.Name)
+	expiresAt, err := lifecycleExpiresAt(logger, s.DB, bucket.ID, extension, body.ExpiresAt)
+	if err != nil {
+		return models.FileUploadResponse{}, err
+	}
+
+	encryption, err := objectEncryption(bucket, s.StorageEncryptionKey)
+	if err != nil {
+		logger.Error("Failed to load bucket encryption key", zap.Error(err))
+		return models.FileUploadResponse{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
 	file := &models.File{
-		Status:    models.FileStatusUploading,
-		Name:      body.Name,
-		Extension: h.ExtensionFromName(body.Name),
-		BucketID:  bucket.ID,
-		FolderID:  body.FolderID,
-		Size:      body.Size,
-		ExpiresAt: body.ExpiresAt,
+		Status:     models.FileStatusUploading,
+		Name:       body.Name,
+		Extension:  extension,
+		BucketID:   bucket.ID,
+		FolderID:   body.FolderID,
+		Size:       body.Size,
+		ExpiresAt:  expiresAt,
+		Encryption: body.Encryption,
 	}
 
 	var response models.FileUploadResponse
-	err := s.DB.Transaction(func(tx *gorm.DB) error {
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
 		res := tx.Create(file)
 		if res.Error != nil {
 			return res.Error
@@ -117,6 +149,7 @@ func (s BucketFileService) UploadFile(
 				"file_id":   file.ID.String(),
 				"user_id":   user.UserID.String(),
 			},
+			encryption,
 		)
 		if presignErr != nil {
 			logger.Error("Presign upload failed", zap.Error(presignErr))
@@ -207,6 +240,12 @@ func (s BucketFileService) HandleUploadedStatus(
 
 		objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
 
+		encryption, encErr := loadObjectEncryption(tx, file.BucketID, s.StorageEncryptionKey)
+		if encErr != nil {
+			logger.Error("Failed to load bucket encryption key", zap.Error(encErr))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+
 		multipart, isMultipart, cacheErr := cache.GetMultipartState(s.Cache, file.ID.String())
 		if cacheErr != nil {
 			logger.Error("Failed to read multipart state", zap.Error(cacheErr))
@@ -221,6 +260,7 @@ func (s BucketFileService) HandleUploadedStatus(
 					"file_id":   file.ID.String(),
 					"user_id":   user.UserID.String(),
 				},
+				encryption,
 			); completeErr != nil {
 				if errors.Is(completeErr, storage.ErrMultipartPartMismatch) {
 					return apierrors.New(http.StatusBadRequest, apierrors.CodeMultipartSizeMismatch)
@@ -231,7 +271,7 @@ func (s BucketFileService) HandleUploadedStatus(
 			}
 		}
 
-		if _, err := s.Storage.StatObject(objectPath); err != nil {
+		if _, err := s.Storage.StatObject(objectPath, encryption); err != nil {
 			logger.Error("File not found in storage",
 				zap.Error(err),
 				zap.String("path", objectPath),
@@ -244,6 +284,11 @@ func (s BucketFileService) HandleUploadedStatus(
 			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
 		}
 
+		if err := enqueueObjectLock(tx, s.Storage, s.Publisher, file); err != nil {
+			logger.Error("Failed to enqueue object lock sync", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+
 		if isMultipart {
 			if delErr := cache.DeleteMultipartState(s.Cache, file.ID.String()); delErr != nil {
 				logger.Warn("Failed to delete multipart state from cache", zap.Error(delErr))
@@ -314,6 +359,17 @@ func (s BucketFileService) DownloadFile(
 		)
 	}
 
+	// Pending uploads can only be previewed by the reviewers who may approve them.
+	if file.Status == models.FileStatusPending && user.Role != models.RoleAdmin {
+		canReview, accessErr := rbac.HasBucketAccess(s.DB, user.UserID, bucketID, models.GroupContributor)
+		if accessErr != nil {
+			return models.FileDownloadResponse{}, accessErr
+		}
+		if !canReview {
+			return models.FileDownloadResponse{}, apierrors.New(http.StatusNotFound, apierrors.CodeFileNotFound)
+		}
+	}
+
 	objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
 
 	var inlineContentType string
@@ -321,9 +377,19 @@ func (s BucketFileService) DownloadFile(
 		inlineContentType = h.PreviewMimeFromExtension(file.Extension)
 	}
 
-	url, err := s.Storage.PresignedGetObject(objectPath, storage.GetObjectOptions{
+	encryption, err := loadObjectEncryption(s.DB, bucketID, s.StorageEncryptionKey)
+	if err != nil {
+		logger.Error("Failed to load bucket encryption key", zap.Error(err))
+		return models.FileDownloadResponse{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
+	download, err := s.Storage.PresignedGetObject(objectPath, storage.GetObjectOptions{
 		InlineContentType: inlineContentType,
 		DownloadFilename:  file.Name,
+		Encryption:        encryption,
 	})
 	if err != nil {
 		logger.Error("Generate presigned URL failed", zap.Error(err))
@@ -346,18 +412,13 @@ func (s BucketFileService) DownloadFile(
 		return models.FileDownloadResponse{}, err
 	}
 
-	var bucket models.Bucket
-	if dbErr := s.DB.Where("id = ?", bucketID).First(&bucket).Error; dbErr == nil {
-		evt := events.NewFileActivityNotification(
-			s.Publisher, events.FileActivityDownload, events.FileActivitySourceUser,
-			bucketID, bucket.Name, file.Name, user.UserID, user.Email,
-		)
-		evt.Trigger()
-	}
+	recordDownload(logger, s.DB, file.ID)
+	notifyBucketActivity(s.DB, s.Publisher, events.FileActivityDownload, bucketID, file.Name, user)
 
 	return models.FileDownloadResponse{
-		ID:  file.ID.String(),
-		URL: url,
+		ID:      file.ID.String(),
+		URL:     download.URL,
+		Headers: download.Headers,
 	}, nil
 }
 
@@ -366,7 +427,7 @@ func (s BucketFileService) TrashFile(
 	user models.UserClaims,
 	file models.File,
 ) error {
-	return s.DB.Transaction(func(tx *gorm.DB) error {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
 		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("id = ? AND bucket_id = ?", file.ID, file.BucketID).
 			First(&file)
@@ -387,6 +448,10 @@ func (s BucketFileService) TrashFile(
 			return apierrors.New(http.StatusConflict, apierrors.CodeInvalidFileStatusTransition)
 		}
 
+		if err := retention.CheckFile(tx, file, retention.ActionTrash); err != nil {
+			return retentionError(logger, err)
+		}
+
 		updates := map[string]interface{}{
 			"status":     models.FileStatusDeleted,
 			"deleted_by": user.UserID,
@@ -432,6 +497,13 @@ func (s BucketFileService) TrashFile(
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	notifyBucketActivity(s.DB, s.Publisher, events.FileActivityDelete, file.BucketID, file.Name, user)
+
+	return nil
 }
 
 func (s BucketFileService) restoreParentFolders(
@@ -553,6 +625,8 @@ func (s BucketFileService) RestoreFile(
 		// Don't return error - the database is already updated
 	}
 
+	notifyBucketActivity(s.DB, s.Publisher, events.FileActivityRestore, restoredFile.BucketID, restoredFile.Name, user)
+
 	return nil
 }
 
@@ -580,6 +654,10 @@ func (s BucketFileService) PurgeFile(
 			return apierrors.New(http.StatusConflict, apierrors.CodeFileNotInTrash)
 		}
 
+		if err := retention.CheckFile(tx, file, retention.ActionPurge); err != nil {
+			return retentionError(logger, err)
+		}
+
 		objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
 
 		if multipart, isMultipart, _ := cache.GetMultipartState(s.Cache, file.ID.String()); isMultipart {
@@ -630,3 +708,200 @@ func (s BucketFileService) PurgeFile(
 		return nil
 	})
 }
+
+// ListPendingFiles returns the files uploaded through shares that are awaiting approval.
+func (s BucketFileService) ListPendingFiles(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+) []models.File {
+	var files []models.File
+	err := database.ReadReplica(s.DB).
+		Where("bucket_id = ? AND status = ?", ids[0], models.FileStatusPending).
+		Order("created_at DESC").
+		Find(&files).Error
+	if err != nil {
+		logger.Error("Failed to list pending files", zap.Error(err))
+		return []models.File{}
+	}
+
+	return files
+}
+
+// ApproveFile publishes a pending share upload to the bucket and notifies its members.
+func (s BucketFileService) ApproveFile(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	bucketID, fileID := ids[0], ids[1]
+
+	var file models.File
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.lockPendingFile(tx, logger, bucketID, fileID, &file); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&file).Update("status", models.FileStatusUploaded).Error; err != nil {
+			logger.Error("Failed to approve file", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+
+		if err := enqueueObjectLock(tx, s.Storage, s.Publisher, file); err != nil {
+			logger.Error("Failed to enqueue object lock sync", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+
+		if err := s.ActivityLogger.Send(models.Activity{
+			Message: activity.FileApproved,
+			Object:  file.ToActivity(),
+			Filter: activity.NewLogFilter(models.ActivityFields{
+				Action:     rbac.ActionUpdate.String(),
+				BucketID:   bucketID.String(),
+				FileID:     file.ID.String(),
+				ObjectType: rbac.ResourceFile.String(),
+				ShareID:    shareIDString(file.ShareID),
+				UserID:     user.UserID.String(),
+			}),
+		}); err != nil {
+			logger.Warn("Failed to log file approval activity", zap.Error(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Members are told about the upload once it is approved, as if it had just landed through the share.
+	if file.ShareID != nil {
+		var share models.Share
+		var bucket models.Bucket
+		var creator models.User
+		if s.DB.Unscoped().Where("id = ?", file.ShareID).First(&share).Error == nil &&
+			s.DB.Where("id = ?", bucketID).First(&bucket).Error == nil &&
+			s.DB.Where("id = ?", share.CreatedBy).First(&creator).Error == nil {
+			evt := events.NewFileActivityNotification(
+				s.Publisher, events.FileActivityUpload, events.FileActivitySourceShare,
+				bucketID, bucket.Name, file.Name, creator.ID, creator.Email,
+			)
+			evt.Trigger()
+		}
+	}
+
+	return nil
+}
+
+// RejectFile discards a pending share upload and purges it from storage.
+func (s BucketFileService) RejectFile(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	bucketID, fileID := ids[0], ids[1]
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		var file models.File
+		if err := s.lockPendingFile(tx, logger, bucketID, fileID, &file); err != nil {
+			return err
+		}
+
+		objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
+		if err := s.Storage.RemoveObject(objectPath); err != nil {
+			logger.Warn("Failed to delete rejected file from storage",
+				zap.Error(err),
+				zap.String("path", objectPath))
+		}
+
+		if err := tx.Unscoped().Delete(&file).Error; err != nil {
+			logger.Error("Failed to delete rejected file from database", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+		}
+
+		if err := s.ActivityLogger.Send(models.Activity{
+			Message: activity.FileRejected,
+			Object:  file.ToActivity(),
+			Filter: activity.NewLogFilter(models.ActivityFields{
+				Action:     rbac.ActionDelete.String(),
+				BucketID:   bucketID.String(),
+				FileID:     file.ID.String(),
+				ObjectType: rbac.ResourceFile.String(),
+				ShareID:    shareIDString(file.ShareID),
+				UserID:     user.UserID.String(),
+			}),
+		}); err != nil {
+			logger.Warn("Failed to log file rejection activity", zap.Error(err))
+		}
+
+		return nil
+	})
+}
+
+func (s BucketFileService) lockPendingFile(
+	tx *gorm.DB,
+	logger *zap.Logger,
+	bucketID, fileID uuid.UUID,
+	file *models.File,
+) error {
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND bucket_id = ?", fileID, bucketID).
+		First(file)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeFileNotFound)
+		}
+		logger.Error("Failed to fetch pending file", zap.Error(result.Error))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeFetchFailed)
+	}
+
+	if file.Status != models.FileStatusPending {
+		return apierrors.New(http.StatusConflict, apierrors.CodeFileNotPending)
+	}
+
+	return nil
+}
+
+func shareIDString(shareID *uuid.UUID) string {
+	if shareID == nil {
+		return ""
+	}
+	return shareID.String()
+}
+
+// notifyBucketActivity lets the other bucket members know about an action, subject to their
+// notification preferences.
+func notifyBucketActivity(
+	db *gorm.DB,
+	publisher messaging.IPublisher,
+	activityType events.FileActivityType,
+	bucketID uuid.UUID,
+	name string,
+	user models.UserClaims,
+) {
+	var bucket models.Bucket
+	if err := db.Where("id = ?", bucketID).First(&bucket).Error; err != nil {
+		return
+	}
+
+	evt := events.NewFileActivityNotification(
+		publisher, activityType, events.FileActivitySourceUser,
+		bucketID, bucket.Name, name, user.UserID, user.Email,
+	)
+	evt.Trigger()
+}
+
+// enqueueObjectLock mirrors legal holds and locked retention onto a file that has just been
+// stored, when the storage provider supports object lock.
+func enqueueObjectLock(
+	tx *gorm.DB,
+	store storage.IStorage,
+	publisher messaging.IPublisher,
+	file models.File,
+) error {
+	if !store.SupportsObjectLock() {
+		return nil
+	}
+
+	event := events.NewObjectLockSync(publisher, file.BucketID, nil, &file.ID)
+	return event.Enqueue(tx)
+}
@@ -14,6 +14,7 @@ import (
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 	"github.com/safebucket/safebucket/internal/storage"
 
 	"github.com/go-chi/chi/v5"
@@ -225,28 +226,43 @@ func (s BucketFolderService) TrashFolder(
 		return apierrors.New(http.StatusConflict, apierrors.CodeFolderRestoreInProgress)
 	}
 
+	if err := retention.CheckFolder(s.DB, folder, retention.ActionTrash); err != nil {
+		return retentionError(logger, err)
+	}
+
 	updates := map[string]interface{}{
 		"status":     models.FolderStatusDeleted,
 		"deleted_by": user.UserID,
 	}
-	if err := s.DB.Model(&folder).Updates(updates).Error; err != nil {
-		logger.Error("Failed to update folder for trashing", zap.Error(err))
-		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
-	}
 
-	if err := s.DB.Delete(&folder).Error; err != nil {
-		logger.Error("Failed to soft delete folder", zap.Error(err))
-		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&folder).Updates(updates).Error; err != nil {
+			logger.Error("Failed to update folder for trashing", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+
+		if err := tx.Delete(&folder).Error; err != nil {
+			logger.Error("Failed to soft delete folder", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+		}
+
+		event := events.NewFolderTrash(s.Publisher, folder.BucketID, folder.ID, user.UserID)
+		if err := event.Enqueue(tx); err != nil {
+			logger.Error("Failed to enqueue folder trash event", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	objectPath := path.Join("buckets", folder.BucketID.String(), folder.ID.String())
-	if err := s.Storage.MarkAsTrashed(objectPath, folder); err != nil {
+	if err = s.Storage.MarkAsTrashed(objectPath, folder); err != nil {
 		logger.Warn("Failed to create trash marker for folder", zap.Error(err))
 	}
 
-	event := events.NewFolderTrash(s.Publisher, folder.BucketID, folder.ID, user.UserID)
-	event.Trigger()
-
 	action := models.Activity{
 		Message: activity.FolderTrashed,
 		Object:  folder.ToActivity(),
@@ -263,6 +279,8 @@ func (s BucketFolderService) TrashFolder(
 		logger.Error("Failed to log trash activity", zap.Error(err))
 	}
 
+	notifyBucketActivity(s.DB, s.Publisher, events.FileActivityDelete, folder.BucketID, folder.Name, user)
+
 	logger.Info("Folder trash initiated (async)",
 		zap.String("folder", folder.Name),
 		zap.String("folder_id", folder.ID.String()))
@@ -353,6 +371,12 @@ func (s BucketFolderService) RestoreFolder(
 
 		restoredFolder = lockedFolder
 
+		event := events.NewFolderRestore(s.Publisher, lockedFolder.BucketID, lockedFolder.ID, user.UserID)
+		if enqueueErr := event.Enqueue(tx); enqueueErr != nil {
+			logger.Error("Failed to enqueue folder restore event", zap.Error(enqueueErr))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+
 		return nil
 	})
 
@@ -367,9 +391,6 @@ func (s BucketFolderService) RestoreFolder(
 		logger.Warn("Failed to remove trash marker for folder", zap.Error(storageErr))
 	}
 
-	event := events.NewFolderRestore(s.Publisher, restoredFolder.BucketID, restoredFolder.ID, user.UserID)
-	event.Trigger()
-
 	action := models.Activity{
 		Message: activity.FolderRestored,
 		Object:  restoredFolder.ToActivity(),
@@ -386,6 +407,10 @@ func (s BucketFolderService) RestoreFolder(
 		logger.Error("Failed to log restore activity", zap.Error(activityErr))
 	}
 
+	notifyBucketActivity(
+		s.DB, s.Publisher, events.FileActivityRestore, restoredFolder.BucketID, restoredFolder.Name, user,
+	)
+
 	logger.Info("Folder restore initiated (async)",
 		zap.String("folder", restoredFolder.Name),
 		zap.String("folder_id", restoredFolder.ID.String()))
@@ -402,8 +427,15 @@ func (s BucketFolderService) PurgeFolder(
 		return apierrors.New(http.StatusConflict, apierrors.CodeFolderNotInTrash)
 	}
 
+	if err := retention.CheckFolder(s.DB, folder, retention.ActionPurge); err != nil {
+		return retentionError(logger, err)
+	}
+
 	event := events.NewFolderPurge(s.Publisher, folder.BucketID, folder.ID, user.UserID)
-	event.Trigger()
+	if err := event.Enqueue(s.DB); err != nil {
+		logger.Error("Failed to enqueue folder purge event", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+	}
 
 	action := models.Activity{
 		Message: activity.FolderDeleted,
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/database"
+	"github.com/safebucket/safebucket/internal/e2ee"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/handlers"
+	m "github.com/safebucket/safebucket/internal/middlewares"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BucketKeyService stores the wrapped data keys of client-side encrypted buckets. Members unwrap
+// their copy locally; the server never handles a data key in the clear.
+type BucketKeyService struct {
+	DB             *gorm.DB
+	ActivityLogger activity.IActivityLogger
+}
+
+func (s BucketKeyService) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(m.AuthorizeGroup(s.DB, models.GroupViewer, 0)).
+		Get("/", handlers.GetListHandler(s.GetBucketKeys))
+
+	r.With(m.AuthorizeGroup(s.DB, models.GroupViewer, 0)).
+		Get("/pending", handlers.GetListHandler(s.GetPendingBucketKeys))
+
+	r.With(m.AuthorizeGroup(s.DB, models.GroupViewer, 0)).
+		With(m.Validate[models.BucketKeysBody]).
+		Put("/", handlers.BodyHandler(s.ShareBucketKeys))
+
+	return r
+}
+
+// GetBucketKeys returns every version of the bucket data key wrapped for the current user.
+func (s BucketKeyService) GetBucketKeys(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) []models.BucketKey {
+	var keys []models.BucketKey
+	err := database.ReadReplica(s.DB).
+		Where("bucket_id = ? AND user_id = ?", ids[0], user.UserID).
+		Order("key_version ASC").
+		Find(&keys).Error
+	if err != nil {
+		logger.Error("Failed to list bucket keys", zap.Error(err))
+		return []models.BucketKey{}
+	}
+
+	return keys
+}
+
+// GetPendingBucketKeys lists the members the current user can wrap a data key for: the versions
+// they hold, and the current version when nobody holds it yet after a rotation.
+func (s BucketKeyService) GetPendingBucketKeys(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) []models.PendingBucketKey {
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ? AND encrypted = ?", ids[0], true).First(&bucket).Error; err != nil {
+		return []models.PendingBucketKey{}
+	}
+
+	pending, err := e2ee.Pending(s.DB, bucket)
+	if err != nil {
+		logger.Error("Failed to list pending bucket keys", zap.Error(err))
+		return []models.PendingBucketKey{}
+	}
+
+	var held []int
+	if err = s.DB.Model(&models.BucketKey{}).
+		Where("bucket_id = ? AND user_id = ?", bucket.ID, user.UserID).
+		Pluck("key_version", &held).Error; err != nil {
+		logger.Error("Failed to list held bucket keys", zap.Error(err))
+		return []models.PendingBucketKey{}
+	}
+
+	canWrap := map[int]bool{}
+	for _, version := range held {
+		canWrap[version] = true
+	}
+	if !canWrap[bucket.KeyVersion] {
+		holders, holdersErr := e2ee.Holders(s.DB, bucket.ID, bucket.KeyVersion)
+		if holdersErr != nil {
+			logger.Error("Failed to list bucket key holders", zap.Error(holdersErr))
+			return []models.PendingBucketKey{}
+		}
+		canWrap[bucket.KeyVersion] = len(holders) == 0
+	}
+
+	result := []models.PendingBucketKey{}
+	for _, member := range pending {
+		if canWrap[member.KeyVersion] {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+// ShareBucketKeys stores a version of the bucket data key wrapped for other members. Only holders
+// of that version can share it. The first holders of a new version after a rotation must wrap it
+// for every pending member at once.
+func (s BucketKeyService) ShareBucketKeys(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+	body models.BucketKeysBody,
+) error {
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ?", ids[0]).First(&bucket).Error; err != nil {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+	if !bucket.Encrypted {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeBucketNotEncrypted)
+	}
+	if body.KeyVersion > bucket.KeyVersion {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeBucketKeyVersionInvalid)
+	}
+
+	holders, err := e2ee.Holders(s.DB, bucket.ID, body.KeyVersion)
+	if err != nil {
+		logger.Error("Failed to list bucket key holders", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	initial := len(holders) == 0
+	if !holders[user.UserID] && (!initial || body.KeyVersion != bucket.KeyVersion) {
+		return apierrors.New(http.StatusForbidden, apierrors.CodeBucketKeyNotHeld)
+	}
+
+	pending, err := e2ee.Pending(s.DB, bucket)
+	if err != nil {
+		logger.Error("Failed to list pending bucket keys", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	waiting := map[uuid.UUID]models.PendingBucketKey{}
+	for _, member := range pending {
+		if member.KeyVersion == body.KeyVersion {
+			waiting[member.UserID] = member
+		}
+	}
+
+	keys := make([]models.BucketKey, 0, len(body.Keys))
+	for _, key := range body.Keys {
+		if holders[key.UserID] {
+			return apierrors.New(http.StatusConflict, apierrors.CodeBucketKeyExists)
+		}
+		member, ok := waiting[key.UserID]
+		if !ok {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeBucketKeyNotMember)
+		}
+		if member.UserKeyID != key.UserKeyID {
+			return apierrors.New(http.StatusConflict, apierrors.CodeUserKeyOutdated)
+		}
+		delete(waiting, key.UserID)
+
+		keys = append(keys, models.BucketKey{
+			BucketID:   bucket.ID,
+			UserID:     key.UserID,
+			UserKeyID:  key.UserKeyID,
+			KeyVersion: body.KeyVersion,
+			WrappedKey: key.WrappedKey,
+			CreatedBy:  &user.UserID,
+		})
+	}
+
+	if initial && len(waiting) > 0 {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeBucketKeyIncomplete)
+	}
+
+	// The unique index on (bucket_id, user_id, key_version) rejects a concurrent submission for the
+	// same members, so a version never ends up with two different data keys.
+	if err = s.DB.Create(&keys).Error; err != nil {
+		logger.Error("Failed to store bucket keys", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	if err = s.ActivityLogger.Send(models.Activity{
+		Message: activity.BucketKeysShared,
+		Object:  bucket.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     rbac.ActionGrant.String(),
+			ObjectType: rbac.ResourceBucket.String(),
+			BucketID:   bucket.ID.String(),
+			UserID:     user.UserID.String(),
+		}),
+	}); err != nil {
+		logger.Error("Failed to log bucket keys activity", zap.Error(err))
+	}
+
+	return nil
+}
+
+// checkFileEncryption enforces that files of encrypted buckets, and only those, carry encryption
+// parameters, and that they were encrypted with the current data key of the bucket.
+func checkFileEncryption(bucket models.Bucket, encryption *models.FileEncryption) error {
+	if !bucket.Encrypted {
+		if encryption != nil {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeFileEncryptionNotAllowed)
+		}
+		return nil
+	}
+
+	if encryption == nil {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeFileEncryptionRequired)
+	}
+	if encryption.KeyVersion != bucket.KeyVersion {
+		return apierrors.New(http.StatusConflict, apierrors.CodeFileEncryptionKeyOutdated)
+	}
+	return nil
+}
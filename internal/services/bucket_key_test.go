@@ -0,0 +1,160 @@
+package services
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func wrapped(name string) string { return base64.StdEncoding.EncodeToString([]byte(name)) }
+
+func registerTestUserKey(t *testing.T, db *gorm.DB, user *models.User) models.UserKey {
+	t.Helper()
+
+	service := AccountService{DB: db, ActivityLogger: &MockActivityLogger{}}
+	claims := models.UserClaims{UserID: user.ID, Email: user.Email}
+	require.NoError(t, service.UpdateUserKey(zap.NewNop(), claims, nil, models.UserKeyBody{
+		Algorithm: "RSA-OAEP-256",
+		PublicKey: wrapped(user.Email),
+	}))
+
+	key, err := service.GetUserKey(zap.NewNop(), claims, nil)
+	require.NoError(t, err)
+	return key
+}
+
+func TestEncryptedBucketKeys(t *testing.T) {
+	db, owner := setupSQLiteTestDB(t)
+	member := &models.User{
+		Email:        "john@example.com",
+		ProviderType: models.LocalProviderType,
+		ProviderKey:  "local",
+		Role:         models.RoleUser,
+	}
+	require.NoError(t, db.Create(member).Error)
+
+	ownerKey := registerTestUserKey(t, db, owner)
+	memberKey := registerTestUserKey(t, db, member)
+
+	ownerClaims := models.UserClaims{UserID: owner.ID, Email: owner.Email}
+	memberClaims := models.UserClaims{UserID: member.ID, Email: member.Email}
+	buckets := BucketService{DB: db, ActivityLogger: &MockActivityLogger{}}
+	keys := BucketKeyService{DB: db, ActivityLogger: &MockActivityLogger{}}
+
+	_, err := buckets.CreateBucket(zap.NewNop(), ownerClaims, nil, models.BucketCreateBody{
+		Name:       "secrets",
+		Encryption: &models.BucketEncryptionBody{UserKeyID: uuid.New(), WrappedKey: wrapped("v1")},
+	})
+	requireAPIError(t, err, http.StatusConflict, apierrors.CodeUserKeyOutdated)
+
+	bucket, err := buckets.CreateBucket(zap.NewNop(), ownerClaims, nil, models.BucketCreateBody{
+		Name:       "secrets",
+		Encryption: &models.BucketEncryptionBody{UserKeyID: ownerKey.ID, WrappedKey: wrapped("v1")},
+	})
+	require.NoError(t, err)
+	assert.True(t, bucket.Encrypted)
+	ids := uuid.UUIDs{bucket.ID}
+
+	require.NoError(t, rbac.CreateMembership(db, member.ID, bucket.ID, models.GroupContributor))
+
+	t.Run("new members are pending for holders only", func(t *testing.T) {
+		pending := keys.GetPendingBucketKeys(zap.NewNop(), ownerClaims, ids)
+		require.Len(t, pending, 1)
+		assert.Equal(t, member.ID, pending[0].UserID)
+		assert.Equal(t, memberKey.ID, pending[0].UserKeyID)
+		assert.Equal(t, 1, pending[0].KeyVersion)
+
+		assert.Empty(t, keys.GetPendingBucketKeys(zap.NewNop(), memberClaims, ids))
+
+		err = keys.ShareBucketKeys(zap.NewNop(), memberClaims, ids, models.BucketKeysBody{
+			KeyVersion: 1,
+			Keys:       []models.BucketKeyBody{{UserID: member.ID, UserKeyID: memberKey.ID, WrappedKey: wrapped("x")}},
+		})
+		requireAPIError(t, err, http.StatusForbidden, apierrors.CodeBucketKeyNotHeld)
+	})
+
+	t.Run("wraps must target the current public key", func(t *testing.T) {
+		err = keys.ShareBucketKeys(zap.NewNop(), ownerClaims, ids, models.BucketKeysBody{
+			KeyVersion: 1,
+			Keys:       []models.BucketKeyBody{{UserID: member.ID, UserKeyID: ownerKey.ID, WrappedKey: wrapped("v1")}},
+		})
+		requireAPIError(t, err, http.StatusConflict, apierrors.CodeUserKeyOutdated)
+
+		require.NoError(t, keys.ShareBucketKeys(zap.NewNop(), ownerClaims, ids, models.BucketKeysBody{
+			KeyVersion: 1,
+			Keys:       []models.BucketKeyBody{{UserID: member.ID, UserKeyID: memberKey.ID, WrappedKey: wrapped("v1")}},
+		}))
+		require.Len(t, keys.GetBucketKeys(zap.NewNop(), memberClaims, ids), 1)
+		assert.Empty(t, keys.GetPendingBucketKeys(zap.NewNop(), ownerClaims, ids))
+	})
+
+	t.Run("uploads follow the bucket encryption", func(t *testing.T) {
+		require.NoError(t, checkFileEncryption(bucket, &models.FileEncryption{KeyVersion: 1}))
+		requireAPIError(t, checkFileEncryption(bucket, nil),
+			http.StatusBadRequest, apierrors.CodeFileEncryptionRequired)
+		requireAPIError(t, checkFileEncryption(models.Bucket{}, &models.FileEncryption{KeyVersion: 1}),
+			http.StatusBadRequest, apierrors.CodeFileEncryptionNotAllowed)
+
+		encryption := &models.FileEncryption{Cipher: "AES-256-GCM", ChunkSize: 65536, KeyVersion: 1, Nonce: "bm9uY2U="}
+		encrypted := models.File{Name: "plan.pdf", BucketID: bucket.ID, Encryption: encryption}
+		plain := models.File{Name: "notes.txt", BucketID: bucket.ID}
+		require.NoError(t, db.Create(&[]*models.File{&encrypted, &plain}).Error)
+
+		var stored []models.File
+		require.NoError(t, db.Where("bucket_id = ?", bucket.ID).Order("name").Find(&stored).Error)
+		require.Len(t, stored, 2)
+		assert.Nil(t, stored[0].Encryption)
+		assert.Equal(t, encryption, stored[1].Encryption)
+	})
+
+	t.Run("removing a member rotates the data key", func(t *testing.T) {
+		third := &models.User{
+			Email:        "alice@example.com",
+			ProviderType: models.LocalProviderType,
+			ProviderKey:  "local",
+			Role:         models.RoleUser,
+		}
+		require.NoError(t, db.Create(third).Error)
+		require.NoError(t, rbac.CreateMembership(db, third.ID, bucket.ID, models.GroupViewer))
+
+		members := BucketMemberService{DB: db, Publisher: &capturePublisher{}, ActivityLogger: &MockActivityLogger{}}
+		members.deleteMember(zap.NewNop(), ownerClaims, bucket, models.BucketMember{
+			UserID: third.ID, Email: third.Email, Status: "active",
+		})
+		members.deleteMember(zap.NewNop(), ownerClaims, bucket, models.BucketMember{
+			UserID: member.ID, Email: member.Email, Status: "active",
+		})
+
+		require.NoError(t, db.First(&bucket, "id = ?", bucket.ID).Error)
+		assert.Equal(t, 3, bucket.KeyVersion, "every encrypted bucket departure moves the key version")
+		assert.Empty(t, keys.GetBucketKeys(zap.NewNop(), memberClaims, ids))
+		requireAPIError(t, checkFileEncryption(bucket, &models.FileEncryption{KeyVersion: 1}),
+			http.StatusConflict, apierrors.CodeFileEncryptionKeyOutdated)
+
+		pending := keys.GetPendingBucketKeys(zap.NewNop(), ownerClaims, ids)
+		require.Len(t, pending, 1)
+		assert.Equal(t, owner.ID, pending[0].UserID)
+		assert.Equal(t, 3, pending[0].KeyVersion)
+
+		require.NoError(t, keys.ShareBucketKeys(zap.NewNop(), ownerClaims, ids, models.BucketKeysBody{
+			KeyVersion: 3,
+			Keys:       []models.BucketKeyBody{{UserID: owner.ID, UserKeyID: ownerKey.ID, WrappedKey: wrapped("v3")}},
+		}))
+		assert.Len(t, keys.GetBucketKeys(zap.NewNop(), ownerClaims, ids), 2)
+	})
+
+	t.Run("a new user key drops the previous wraps", func(t *testing.T) {
+		registerTestUserKey(t, db, owner)
+		assert.Empty(t, keys.GetBucketKeys(zap.NewNop(), ownerClaims, ids))
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/database"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/handlers"
+	"github.com/safebucket/safebucket/internal/lifecycle"
+	m "github.com/safebucket/safebucket/internal/middlewares"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BucketLifecycleService manages the rules bounding how long the files of a bucket are kept.
+type BucketLifecycleService struct {
+	DB             *gorm.DB
+	ActivityLogger activity.IActivityLogger
+}
+
+func (s BucketLifecycleService) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(m.AuthorizeGroup(s.DB, models.GroupViewer, 0)).
+		Get("/", handlers.GetListHandler(s.GetLifecycleRules))
+
+	r.With(m.AuthorizeGroup(s.DB, models.GroupOwner, 0)).
+		With(m.Validate[models.BucketLifecycleBody]).
+		Put("/", handlers.BodyHandler(s.UpdateLifecycleRules))
+
+	return r
+}
+
+func (s BucketLifecycleService) GetLifecycleRules(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+) []models.BucketLifecycleRule {
+	var rules []models.BucketLifecycleRule
+	err := database.ReadReplica(s.DB).
+		Where("bucket_id = ?", ids[0]).
+		Order("extension ASC").
+		Find(&rules).Error
+	if err != nil {
+		logger.Error("Failed to list bucket lifecycle rules", zap.Error(err))
+		return []models.BucketLifecycleRule{}
+	}
+
+	return rules
+}
+
+// UpdateLifecycleRules replaces the rules of a bucket. They apply to files uploaded afterwards,
+// except for the inactivity period which the garbage collector checks on every file.
+func (s BucketLifecycleService) UpdateLifecycleRules(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+	body models.BucketLifecycleBody,
+) error {
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ?", ids[0]).First(&bucket).Error; err != nil {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+
+	rules := make([]models.BucketLifecycleRule, 0, len(body.Rules))
+	seen := map[string]bool{}
+	for _, rule := range body.Rules {
+		extension := lifecycle.NormalizeExtension(rule.Extension)
+		if seen[extension] {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeLifecycleRuleDuplicate)
+		}
+		seen[extension] = true
+
+		if rule.DefaultExpiryDays == nil && rule.MaxExpiryDays == nil && rule.InactiveDays == nil {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeLifecycleRuleInvalid)
+		}
+		if rule.DefaultExpiryDays != nil && rule.MaxExpiryDays != nil &&
+			*rule.DefaultExpiryDays > *rule.MaxExpiryDays {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeLifecycleRuleInvalid)
+		}
+
+		rules = append(rules, models.BucketLifecycleRule{
+			BucketID:          bucket.ID,
+			Extension:         extension,
+			DefaultExpiryDays: rule.DefaultExpiryDays,
+			MaxExpiryDays:     rule.MaxExpiryDays,
+			InactiveDays:      rule.InactiveDays,
+		})
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bucket_id = ?", bucket.ID).Delete(&models.BucketLifecycleRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		logger.Error("Failed to update bucket lifecycle rules", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	if err = s.ActivityLogger.Send(models.Activity{
+		Message: activity.BucketLifecycleUpdated,
+		Object:  bucket.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     rbac.ActionUpdate.String(),
+			ObjectType: rbac.ResourceBucket.String(),
+			BucketID:   bucket.ID.String(),
+			UserID:     user.UserID.String(),
+		}),
+	}); err != nil {
+		logger.Error("Failed to log bucket lifecycle activity", zap.Error(err))
+	}
+
+	return nil
+}
+
+// lifecycleExpiresAt applies the lifecycle rules of a bucket to the expiration date requested
+// for a new file.
+func lifecycleExpiresAt(
+	logger *zap.Logger,
+	db *gorm.DB,
+	bucketID uuid.UUID,
+	extension string,
+	requested *time.Time,
+) (*time.Time, error) {
+	policy, err := lifecycle.Resolve(db, bucketID, extension)
+	if err != nil {
+		logger.Error("Failed to load bucket lifecycle rules", zap.Error(err))
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	return policy.ExpiresAt(requested, time.Now())
+}
+
+// recordDownload keeps the file out of the inactivity lifecycle rule. UpdateColumn leaves
+// updated_at alone, which tracks changes to the file itself.
+func recordDownload(logger *zap.Logger, db *gorm.DB, fileID uuid.UUID) {
+	err := db.Model(&models.File{}).Where("id = ?", fileID).UpdateColumn("last_downloaded_at", time.Now()).Error
+	if err != nil {
+		logger.Warn("Failed to record file download", zap.String("file_id", fileID.String()), zap.Error(err))
+	}
+}
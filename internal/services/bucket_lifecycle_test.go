@@ -0,0 +1,70 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func lifecycleDays(v int) *int { return &v }
+
+func TestUpdateLifecycleRules(t *testing.T) {
+	db, user := setupSQLiteTestDB(t)
+	bucket := models.Bucket{Name: "uploads", CreatedBy: user.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+
+	service := BucketLifecycleService{DB: db, ActivityLogger: &MockActivityLogger{}}
+	claims := models.UserClaims{UserID: user.ID}
+	ids := uuid.UUIDs{bucket.ID}
+
+	t.Run("extensions are unique regardless of case", func(t *testing.T) {
+		err := service.UpdateLifecycleRules(zap.NewNop(), claims, ids, models.BucketLifecycleBody{
+			Rules: []models.BucketLifecycleRuleBody{
+				{Extension: "PDF", DefaultExpiryDays: lifecycleDays(7)},
+				{Extension: "pdf", MaxExpiryDays: lifecycleDays(30)},
+			},
+		})
+		requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeLifecycleRuleDuplicate)
+	})
+
+	t.Run("default expiry cannot exceed the maximum", func(t *testing.T) {
+		err := service.UpdateLifecycleRules(zap.NewNop(), claims, ids, models.BucketLifecycleBody{
+			Rules: []models.BucketLifecycleRuleBody{
+				{DefaultExpiryDays: lifecycleDays(60), MaxExpiryDays: lifecycleDays(30)},
+			},
+		})
+		requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeLifecycleRuleInvalid)
+	})
+
+	t.Run("rules are replaced and enforced on upload", func(t *testing.T) {
+		require.NoError(t, service.UpdateLifecycleRules(zap.NewNop(), claims, ids, models.BucketLifecycleBody{
+			Rules: []models.BucketLifecycleRuleBody{{Extension: "PDF", MaxExpiryDays: lifecycleDays(30)}},
+		}))
+		require.NoError(t, service.UpdateLifecycleRules(zap.NewNop(), claims, ids, models.BucketLifecycleBody{
+			Rules: []models.BucketLifecycleRuleBody{
+				{DefaultExpiryDays: lifecycleDays(30)},
+				{Extension: "tmp", MaxExpiryDays: lifecycleDays(1)},
+			},
+		}))
+
+		rules := service.GetLifecycleRules(zap.NewNop(), claims, ids)
+		require.Len(t, rules, 2)
+		assert.Empty(t, rules[0].Extension)
+		assert.Equal(t, "tmp", rules[1].Extension)
+
+		expiresAt, err := lifecycleExpiresAt(zap.NewNop(), db, bucket.ID, "pdf", nil)
+		require.NoError(t, err)
+		require.NotNil(t, expiresAt)
+
+		tooLate := expiresAt.AddDate(0, 0, 1)
+		_, err = lifecycleExpiresAt(zap.NewNop(), db, bucket.ID, "TMP", &tooLate)
+		requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeFileExpiryExceedsLimit)
+	})
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/e2ee"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/handlers"
@@ -22,11 +24,12 @@ import (
 )
 
 type BucketMemberService struct {
-	DB             *gorm.DB
-	Providers      configuration.Providers
-	Publisher      messaging.IPublisher
-	ActivityLogger activity.IActivityLogger
-	WebURL         string
+	DB               *gorm.DB
+	Providers        configuration.Providers
+	Publisher        messaging.IPublisher
+	ActivityLogger   activity.IActivityLogger
+	WebURL           string
+	InviteExpiryDays int
 }
 
 func (s BucketMemberService) Routes() chi.Router {
@@ -43,6 +46,13 @@ func (s BucketMemberService) Routes() chi.Router {
 		With(m.Validate[models.MembershipNotificationBody]).
 		Patch("/notifications", handlers.BodyHandler(s.UpdateNotificationPreferences))
 
+	r.Route("/invites/{id1}", func(r chi.Router) {
+		r.Use(m.AuthorizeGroup(s.DB, models.GroupOwner, 0))
+
+		r.Post("/resend", handlers.ActionHandler(s.ResendInvite))
+		r.Delete("/", handlers.DeleteHandler(s.RevokeInvite))
+	})
+
 	return r
 }
 
@@ -80,6 +90,11 @@ func (s BucketMemberService) GetBucketMembers(
 			Status:                "active",
 			UploadNotifications:   membership.UploadNotifications,
 			DownloadNotifications: membership.DownloadNotifications,
+			DeleteNotifications:   membership.DeleteNotifications,
+			RestoreNotifications:  membership.RestoreNotifications,
+			ShareNotifications:    membership.ShareNotifications,
+			MemberNotifications:   membership.MemberNotifications,
+			NotificationDelivery:  membership.NotificationDelivery,
 		})
 	}
 
@@ -94,9 +109,11 @@ func (s BucketMemberService) GetBucketMembers(
 			}
 
 			membersList = append(membersList, models.BucketMember{
-				Email:  invite.Email,
-				Group:  invite.Group,
-				Status: "invited",
+				InviteID:  &invite.ID,
+				Email:     invite.Email,
+				Group:     invite.Group,
+				Status:    "invited",
+				ExpiresAt: invite.ExpiresAt,
 			})
 		}
 	}
@@ -237,11 +254,13 @@ func (s BucketMemberService) addMember(
 		result := tx.Where("email = ?", invite.Email).Find(&invitee)
 
 		if result.RowsAffected == 0 {
+			expiresAt := s.inviteExpiration()
 			inviteRecord := models.Invite{
 				Email:     invite.Email,
 				Group:     invite.Group,
 				BucketID:  bucket.ID,
 				CreatedBy: user.UserID,
+				ExpiresAt: &expiresAt,
 			}
 
 			if err := tx.Create(&inviteRecord).Error; err != nil {
@@ -303,7 +322,10 @@ func (s BucketMemberService) addMember(
 	})
 	if err != nil {
 		logger.Error("Failed to add member", zap.Error(err))
+		return
 	}
+
+	s.notifyMemberChange(bucket, invite.Email, user)
 }
 
 func (s BucketMemberService) updateMember(
@@ -312,6 +334,7 @@ func (s BucketMemberService) updateMember(
 	bucket models.Bucket,
 	member models.BucketMemberToUpdate,
 ) {
+	changed := false
 	err := s.DB.Transaction(func(tx *gorm.DB) error {
 		if member.Status == "invited" {
 			updateResult := tx.Model(&models.Invite{}).
@@ -351,10 +374,16 @@ func (s BucketMemberService) updateMember(
 			return err
 		}
 
+		changed = true
 		return nil
 	})
 	if err != nil {
 		logger.Error("Failed to update member", zap.Error(err))
+		return
+	}
+
+	if changed {
+		s.notifyMemberChange(bucket, member.Email, user)
 	}
 }
 
@@ -364,6 +393,7 @@ func (s BucketMemberService) deleteMember(
 	bucket models.Bucket,
 	member models.BucketMember,
 ) {
+	changed := false
 	err := s.DB.Transaction(func(tx *gorm.DB) error {
 		if member.Status == "invited" {
 			deleteResult := tx.Where(
@@ -384,6 +414,11 @@ func (s BucketMemberService) deleteMember(
 				logger.Error("Failed to delete membership", zap.Error(err))
 				return err
 			}
+
+			if err = e2ee.RevokeMember(tx, bucket.ID, member.UserID); err != nil {
+				logger.Error("Failed to revoke bucket keys", zap.Error(err))
+				return err
+			}
 		}
 
 		action := models.Activity{
@@ -403,9 +438,145 @@ func (s BucketMemberService) deleteMember(
 			return err
 		}
 
+		changed = true
 		return nil
 	})
 	if err != nil {
 		logger.Error("Failed to delete member", zap.Error(err))
+		return
+	}
+
+	if changed {
+		s.notifyMemberChange(bucket, member.Email, user)
+	}
+}
+
+func (s BucketMemberService) inviteExpiration() time.Time {
+	return time.Now().Add(time.Duration(s.InviteExpiryDays) * 24 * time.Hour)
+}
+
+func (s BucketMemberService) ResendInvite(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	bucketID, inviteID := ids[0], ids[1]
+
+	providerCfg, ok := s.Providers[user.Provider]
+	if !ok {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeUnknownUserProvider)
 	}
+	if !providerCfg.SharingOptions.Allowed {
+		return apierrors.New(http.StatusForbidden, apierrors.CodeSharingDisabledForProvider)
+	}
+
+	var invite models.Invite
+	result := s.DB.Preload("Bucket").
+		Where("id = ? AND bucket_id = ?", inviteID, bucketID).
+		Find(&invite)
+	if result.Error != nil {
+		logger.Error("Failed to fetch invite", zap.Error(result.Error))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeFetchFailed)
+	}
+	if result.RowsAffected == 0 {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeInviteNotFound)
+	}
+
+	expiresAt := s.inviteExpiration()
+	if err := s.DB.Model(&invite).Update("expires_at", expiresAt).Error; err != nil {
+		logger.Error("Failed to extend invite expiration", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	invitationEvent := events.NewUserInvitation(
+		s.Publisher,
+		invite.Email,
+		user.Email,
+		invite.Bucket,
+		invite.Group,
+		invite.ID.String(),
+		s.WebURL,
+	)
+	invitationEvent.Trigger()
+
+	action := models.Activity{
+		Message: activity.InviteResent,
+		Object:  invite.Bucket.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:            rbac.ActionGrant.String(),
+			ObjectType:        rbac.ResourceBucket.String(),
+			BucketID:          bucketID.String(),
+			UserID:            user.UserID.String(),
+			InviteID:          invite.ID.String(),
+			BucketMemberEmail: invite.Email,
+		}),
+	}
+	if err := s.ActivityLogger.Send(action); err != nil {
+		logger.Error("Failed to log invite resend activity", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (s BucketMemberService) RevokeInvite(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+) error {
+	bucketID, inviteID := ids[0], ids[1]
+
+	var invite models.Invite
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("Bucket").
+			Where("id = ? AND bucket_id = ?", inviteID, bucketID).
+			Find(&invite)
+		if result.Error != nil {
+			logger.Error("Failed to fetch invite", zap.Error(result.Error))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeFetchFailed)
+		}
+		if result.RowsAffected == 0 {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeInviteNotFound)
+		}
+
+		if err := tx.Where("invite_id = ?", invite.ID).Delete(&models.Challenge{}).Error; err != nil {
+			logger.Error("Failed to delete invite challenges", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+		}
+
+		if err := tx.Delete(&invite).Error; err != nil {
+			logger.Error("Failed to revoke invite", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeDeleteFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	action := models.Activity{
+		Message: activity.InviteRevoked,
+		Object:  invite.Bucket.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:            rbac.ActionGrant.String(),
+			ObjectType:        rbac.ResourceBucket.String(),
+			BucketID:          bucketID.String(),
+			UserID:            user.UserID.String(),
+			InviteID:          invite.ID.String(),
+			BucketMemberEmail: invite.Email,
+		}),
+	}
+	if logErr := s.ActivityLogger.Send(action); logErr != nil {
+		logger.Error("Failed to log invite revocation activity", zap.Error(logErr))
+	}
+
+	return nil
+}
+
+func (s BucketMemberService) notifyMemberChange(bucket models.Bucket, memberEmail string, user models.UserClaims) {
+	evt := events.NewFileActivityNotification(
+		s.Publisher, events.FileActivityMember, events.FileActivitySourceUser,
+		bucket.ID, bucket.Name, memberEmail, user.UserID, user.Email,
+	)
+	evt.Trigger()
 }
@@ -2,24 +2,36 @@ package services
 
 import (
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/database"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/handlers"
 	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/messaging"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/rbac"
 
+	"github.com/alexedwards/argon2id"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BucketShareService struct {
 	DB             *gorm.DB
+	Providers      configuration.Providers
+	Publisher      messaging.IPublisher
 	ActivityLogger activity.IActivityLogger
+	WebURL         string
 }
 
 func (s BucketShareService) Routes() chi.Router {
@@ -29,6 +41,11 @@ func (s BucketShareService) Routes() chi.Router {
 	r.With(authorize).Get("/", handlers.GetListHandler(s.ListShares))
 	r.With(authorize, m.Validate[models.ShareCreateBody]).
 		Post("/", handlers.CreateHandler(s.CreateShare))
+	r.With(authorize, m.Validate[models.ShareUpdateBody]).
+		Patch("/{id1}", handlers.BodyHandler(s.UpdateShare))
+	r.With(authorize, m.Validate[models.ShareSendBody]).
+		Post("/{id1}/send", handlers.CreateHandler(s.SendShare))
+	r.With(authorize).Get("/{id1}/analytics", handlers.GetOneHandler(s.GetShareAnalytics))
 	r.With(authorize).Delete("/{id1}", handlers.DeleteHandler(s.DeleteShare))
 
 	return r
@@ -46,6 +63,10 @@ func (s BucketShareService) CreateShare(
 	if s.DB.Where("id = ?", bucketID).Find(&bucket).RowsAffected == 0 {
 		return models.Share{}, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
 	}
+	// Share visitors could only read or write SSE-C objects if they were handed the customer key.
+	if bucket.SSEMode == models.SSEModeCustomer {
+		return models.Share{}, apierrors.New(http.StatusBadRequest, apierrors.CodeShareCustomerKeyBucket)
+	}
 
 	if body.Type == models.ShareTypeFolder {
 		var folder models.Folder
@@ -76,18 +97,34 @@ func (s BucketShareService) CreateShare(
 		hashedPassword = hash
 	}
 
+	// IP rules left empty fall back to the defaults of the creator's auth provider.
+	ipAllowlist, ipDenylist := body.IPAllowlist, body.IPDenylist
+	defaultAllowlist, defaultDenylist := s.defaultShareIPRules(user)
+	if len(ipAllowlist) == 0 {
+		ipAllowlist = defaultAllowlist
+	}
+	if len(ipDenylist) == 0 {
+		ipDenylist = defaultDenylist
+	}
+
 	share := &models.Share{
-		Name:           body.Name,
-		BucketID:       bucketID,
-		FolderID:       body.FolderID,
-		ExpiresAt:      body.ExpiresAt,
-		MaxViews:       body.MaxViews,
-		HashedPassword: hashedPassword,
-		Type:           body.Type,
-		AllowUpload:    body.AllowUpload,
-		MaxUploads:     body.MaxUploads,
-		MaxUploadSize:  body.MaxUploadSize,
-		CreatedBy:      user.UserID,
+		Name:            body.Name,
+		BucketID:        bucketID,
+		FolderID:        body.FolderID,
+		ExpiresAt:       body.ExpiresAt,
+		MaxViews:        body.MaxViews,
+		HashedPassword:  hashedPassword,
+		Type:            body.Type,
+		AllowUpload:     body.AllowUpload,
+		MaxUploads:      body.MaxUploads,
+		MaxUploadSize:   body.MaxUploadSize,
+		MaxDownloads:    body.MaxDownloads,
+		RequireApproval: body.RequireApproval,
+		IPAllowlist:     ipAllowlist,
+		IPDenylist:      ipDenylist,
+		CreatedBy:       user.UserID,
+
+		RecipientRestricted: len(body.Recipients) > 0,
 	}
 
 	txErr := s.DB.Transaction(func(tx *gorm.DB) error {
@@ -100,8 +137,9 @@ func (s BucketShareService) CreateShare(
 			shareFiles := make([]models.ShareFile, len(body.FileIDs))
 			for i, fileID := range body.FileIDs {
 				shareFiles[i] = models.ShareFile{
-					ShareID: share.ID,
-					FileID:  fileID,
+					ShareID:      share.ID,
+					FileID:       fileID,
+					MaxDownloads: body.MaxFileDownloads,
 				}
 			}
 			if err := tx.Create(&shareFiles).Error; err != nil {
@@ -111,6 +149,15 @@ func (s BucketShareService) CreateShare(
 			share.Files = shareFiles
 		}
 
+		if share.RecipientRestricted {
+			recipients := buildShareRecipients(share.ID, body.Recipients)
+			if err := tx.Create(&recipients).Error; err != nil {
+				logger.Error("Failed to create share recipients", zap.Error(err))
+				return err
+			}
+			share.Recipients = recipients
+		}
+
 		if err := s.ActivityLogger.Send(models.Activity{
 			Message: activity.ShareCreated,
 			Object:  share.ToActivity(),
@@ -134,6 +181,8 @@ func (s BucketShareService) CreateShare(
 
 	share.PasswordProtected = hashedPassword != ""
 
+	notifyBucketActivity(s.DB, s.Publisher, events.FileActivityShare, bucketID, share.Name, user)
+
 	return *share, nil
 }
 
@@ -145,8 +194,12 @@ func (s BucketShareService) ListShares(
 	bucketID := ids[0]
 
 	var shares []models.Share
-	err := s.DB.Where("bucket_id = ?", bucketID).
+	err := database.ReadReplica(s.DB).Where("bucket_id = ?", bucketID).
 		Preload("Files.File").
+		Preload("Recipients").
+		Preload("Deliveries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at DESC")
+		}).
 		Order("created_at DESC").
 		Find(&shares).Error
 
@@ -162,6 +215,545 @@ func (s BucketShareService) ListShares(
 	return shares
 }
 
+func (s BucketShareService) UpdateShare(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+	body models.ShareUpdateBody,
+) error {
+	bucketID, shareID := ids[0], ids[1]
+
+	updates := map[string]interface{}{}
+
+	if body.Name != nil {
+		updates["name"] = *body.Name
+	}
+	if body.ExpiresAt != nil {
+		updates["expires_at"] = *body.ExpiresAt
+	} else if body.ClearExpiresAt {
+		updates["expires_at"] = nil
+	}
+	if body.MaxViews != nil {
+		updates["max_views"] = *body.MaxViews
+	} else if body.ClearMaxViews {
+		updates["max_views"] = nil
+	}
+	if body.MaxUploads != nil {
+		updates["max_uploads"] = *body.MaxUploads
+	} else if body.ClearMaxUploads {
+		updates["max_uploads"] = nil
+	}
+	if body.MaxUploadSize != nil {
+		updates["max_upload_size"] = *body.MaxUploadSize
+	} else if body.ClearMaxUploadSize {
+		updates["max_upload_size"] = nil
+	}
+	if body.MaxDownloads != nil {
+		updates["max_downloads"] = *body.MaxDownloads
+	} else if body.ClearMaxDownloads {
+		updates["max_downloads"] = nil
+	}
+	if body.AllowUpload != nil {
+		updates["allow_upload"] = *body.AllowUpload
+	}
+	if body.RequireApproval != nil {
+		updates["require_approval"] = *body.RequireApproval
+	}
+	if body.ResetCounters {
+		updates["current_views"] = 0
+		updates["current_uploads"] = 0
+		updates["current_downloads"] = 0
+	}
+
+	if body.Password != nil {
+		hash, err := h.CreateHash(*body.Password)
+		if err != nil {
+			logger.Error("Failed to hash share password", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+		updates["hashed_password"] = hash
+	} else if body.ClearPassword {
+		updates["hashed_password"] = nil
+	}
+
+	var share models.Share
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND bucket_id = ?", shareID, bucketID).
+			First(&share)
+		if result.Error != nil {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeShareNotFound)
+		}
+
+		if body.AllowUpload != nil && *body.AllowUpload && share.Type == models.ShareTypeFiles {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeShareUploadNotAllowed)
+		}
+
+		passwordProtected := (share.HashedPassword != "" && !body.ClearPassword) || body.Password != nil
+		recipientRestricted := (share.RecipientRestricted && !body.ClearRecipients) || len(body.Recipients) > 0
+		if passwordProtected && recipientRestricted {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeShareRecipientsWithPassword)
+		}
+
+		filesChanged := len(body.AddFileIDs) > 0 || len(body.RemoveFileIDs) > 0
+		fileLimitChanged := body.MaxFileDownloads != nil || body.ClearMaxFileDownloads
+		if (filesChanged || fileLimitChanged) && share.Type != models.ShareTypeFiles {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeShareFilesNotEditable)
+		}
+
+		if body.Name != nil {
+			share.Name = *body.Name
+		}
+
+		if len(updates) > 0 {
+			if err := tx.Model(&share).Updates(updates).Error; err != nil {
+				logger.Error("Failed to update share", zap.Error(err))
+				return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+			}
+		}
+
+		if ipRules, columns := s.shareIPRulesUpdate(user, body); len(columns) > 0 {
+			if err := tx.Model(&share).Select(columns).Updates(ipRules).Error; err != nil {
+				logger.Error("Failed to update share IP rules", zap.Error(err))
+				return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+			}
+		}
+
+		if filesChanged {
+			if err := s.updateShareFiles(logger, tx, share, body); err != nil {
+				return err
+			}
+		}
+
+		if fileLimitChanged || (body.ResetCounters && share.Type == models.ShareTypeFiles) {
+			if err := s.updateShareFileLimits(logger, tx, share, body); err != nil {
+				return err
+			}
+		}
+
+		if len(body.Recipients) > 0 || body.ClearRecipients {
+			if err := s.replaceShareRecipients(logger, tx, share, body.Recipients); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.ActivityLogger.Send(models.Activity{
+		Message: activity.ShareUpdated,
+		Object:  share.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     rbac.ActionUpdate.String(),
+			ObjectType: rbac.ResourceShare.String(),
+			BucketID:   bucketID.String(),
+			ShareID:    shareID.String(),
+			UserID:     user.UserID.String(),
+		}),
+	}); err != nil {
+		logger.Error("Failed to log share update activity", zap.Error(err))
+	}
+
+	return nil
+}
+
+// updateShareFiles applies the file additions and removals of a files share. Files that
+// are already shared are skipped, and the share must keep at least one file.
+func (s BucketShareService) updateShareFiles(
+	logger *zap.Logger,
+	tx *gorm.DB,
+	share models.Share,
+	body models.ShareUpdateBody,
+) error {
+	if len(body.RemoveFileIDs) > 0 {
+		result := tx.Where("share_id = ? AND file_id IN ?", share.ID, body.RemoveFileIDs).
+			Delete(&models.ShareFile{})
+		if result.Error != nil {
+			logger.Error("Failed to remove share files", zap.Error(result.Error))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+		if result.RowsAffected != int64(len(body.RemoveFileIDs)) {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeShareFileNotInShare)
+		}
+	}
+
+	if len(body.AddFileIDs) > 0 {
+		var count int64
+		tx.Model(&models.File{}).
+			Where("id IN ? AND bucket_id = ? AND status = ?", body.AddFileIDs, share.BucketID, models.FileStatusUploaded).
+			Count(&count)
+		if count != int64(len(body.AddFileIDs)) {
+			return apierrors.New(http.StatusNotFound, apierrors.CodeFileNotFound)
+		}
+
+		var existing []uuid.UUID
+		if err := tx.Model(&models.ShareFile{}).
+			Where("share_id = ? AND file_id IN ?", share.ID, body.AddFileIDs).
+			Pluck("file_id", &existing).Error; err != nil {
+			logger.Error("Failed to list share files", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+
+		var shareFiles []models.ShareFile
+		for _, fileID := range body.AddFileIDs {
+			if !slices.Contains(existing, fileID) {
+				shareFiles = append(shareFiles, models.ShareFile{ShareID: share.ID, FileID: fileID})
+				existing = append(existing, fileID)
+			}
+		}
+
+		if len(shareFiles) > 0 {
+			if err := tx.Create(&shareFiles).Error; err != nil {
+				logger.Error("Failed to add share files", zap.Error(err))
+				return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+			}
+		}
+	}
+
+	var remaining int64
+	tx.Model(&models.ShareFile{}).Where("share_id = ?", share.ID).Count(&remaining)
+	if remaining == 0 {
+		return apierrors.New(http.StatusBadRequest, apierrors.CodeShareFilesEmpty)
+	}
+
+	return nil
+}
+
+// defaultShareIPRules returns the IP lists applied to the shares of the user's auth provider
+// when none are set.
+func (s BucketShareService) defaultShareIPRules(user models.UserClaims) ([]string, []string) {
+	provider, ok := s.Providers[user.Provider]
+	if !ok {
+		return nil, nil
+	}
+	return provider.SharingOptions.IPAllowlist, provider.SharingOptions.IPDenylist
+}
+
+// shareIPRulesUpdate returns the IP lists to write and their columns. Cleared lists go back to
+// the provider defaults, as on creation. The lists are written through a struct update so their
+// JSON serializer applies.
+func (s BucketShareService) shareIPRulesUpdate(
+	user models.UserClaims,
+	body models.ShareUpdateBody,
+) (models.Share, []string) {
+	var rules models.Share
+	var columns []string
+	defaultAllowlist, defaultDenylist := s.defaultShareIPRules(user)
+
+	if len(body.IPAllowlist) > 0 {
+		rules.IPAllowlist = body.IPAllowlist
+		columns = append(columns, "ip_allowlist")
+	} else if body.ClearIPAllowlist {
+		rules.IPAllowlist = defaultAllowlist
+		columns = append(columns, "ip_allowlist")
+	}
+	if len(body.IPDenylist) > 0 {
+		rules.IPDenylist = body.IPDenylist
+		columns = append(columns, "ip_denylist")
+	} else if body.ClearIPDenylist {
+		rules.IPDenylist = defaultDenylist
+		columns = append(columns, "ip_denylist")
+	}
+
+	return rules, columns
+}
+
+// updateShareFileLimits applies the per-file download limit to every file of a files share,
+// including the ones added by the same update, and resets their counters when requested.
+func (s BucketShareService) updateShareFileLimits(
+	logger *zap.Logger,
+	tx *gorm.DB,
+	share models.Share,
+	body models.ShareUpdateBody,
+) error {
+	updates := map[string]interface{}{}
+	if body.MaxFileDownloads != nil {
+		updates["max_downloads"] = *body.MaxFileDownloads
+	} else if body.ClearMaxFileDownloads {
+		updates["max_downloads"] = nil
+	}
+	if body.ResetCounters {
+		updates["current_downloads"] = 0
+	}
+
+	if err := tx.Model(&models.ShareFile{}).Where("share_id = ?", share.ID).Updates(updates).Error; err != nil {
+		logger.Error("Failed to update share file limits", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	return nil
+}
+
+// replaceShareRecipients swaps the recipient list of a share. An empty list lifts the
+// restriction, and pending access codes are dropped so removed recipients cannot use them.
+func (s BucketShareService) replaceShareRecipients(
+	logger *zap.Logger,
+	tx *gorm.DB,
+	share models.Share,
+	values []string,
+) error {
+	if err := tx.Where("share_id = ?", share.ID).Delete(&models.ShareRecipient{}).Error; err != nil {
+		logger.Error("Failed to remove share recipients", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	if err := tx.Where("share_id = ? AND type = ?", share.ID, models.ChallengeTypeShareAccess).
+		Delete(&models.Challenge{}).Error; err != nil {
+		logger.Error("Failed to remove share access challenges", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	if len(values) > 0 {
+		recipients := buildShareRecipients(share.ID, values)
+		if err := tx.Create(&recipients).Error; err != nil {
+			logger.Error("Failed to create share recipients", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+		}
+	}
+
+	if err := tx.Model(&share).Update("recipient_restricted", len(values) > 0).Error; err != nil {
+		logger.Error("Failed to update share restriction", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeUpdateFailed)
+	}
+
+	return nil
+}
+
+func buildShareRecipients(shareID uuid.UUID, values []string) []models.ShareRecipient {
+	recipients := make([]models.ShareRecipient, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = h.NormalizeShareRecipient(value)
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		recipients = append(recipients, models.ShareRecipient{ShareID: shareID, Value: value})
+	}
+	return recipients
+}
+
+// GetShareAnalytics summarises the recorded accesses of a share: totals per access type,
+// downloads per file and the most recent individual accesses.
+func (s BucketShareService) GetShareAnalytics(
+	logger *zap.Logger,
+	_ models.UserClaims,
+	ids uuid.UUIDs,
+) (models.ShareAnalyticsResponse, error) {
+	bucketID, shareID := ids[0], ids[1]
+	db := database.ReadReplica(s.DB)
+
+	var share models.Share
+	if db.Where("id = ? AND bucket_id = ?", shareID, bucketID).
+		Preload("Files").
+		Find(&share).RowsAffected == 0 {
+		return models.ShareAnalyticsResponse{}, apierrors.New(http.StatusNotFound, apierrors.CodeShareNotFound)
+	}
+
+	response := models.ShareAnalyticsResponse{
+		ShareID:  share.ID,
+		Files:    []models.ShareFileAnalytics{},
+		Accesses: []models.ShareAccess{},
+	}
+
+	var downloads []models.ShareAccess
+	if err := db.Select("file_id", "created_at").
+		Where("share_id = ? AND type = ?", share.ID, models.ShareAccessTypeDownload).
+		Find(&downloads).Error; err != nil {
+		logger.Error("Failed to list share downloads", zap.Error(err))
+		return models.ShareAnalyticsResponse{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	var views int64
+	if err := db.Model(&models.ShareAccess{}).
+		Where("share_id = ? AND type = ?", share.ID, models.ShareAccessTypeView).
+		Count(&views).Error; err != nil {
+		logger.Error("Failed to count share views", zap.Error(err))
+		return models.ShareAnalyticsResponse{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	response.Views = int(views)
+	response.Downloads = len(downloads)
+
+	perFile := map[uuid.UUID]*models.ShareFileAnalytics{}
+	var order []uuid.UUID
+	track := func(fileID uuid.UUID) *models.ShareFileAnalytics {
+		if entry, ok := perFile[fileID]; ok {
+			return entry
+		}
+		entry := &models.ShareFileAnalytics{FileID: fileID}
+		perFile[fileID] = entry
+		order = append(order, fileID)
+		return entry
+	}
+
+	for _, shareFile := range share.Files {
+		track(shareFile.FileID).MaxDownloads = shareFile.MaxDownloads
+	}
+	for _, download := range downloads {
+		if download.FileID == nil {
+			continue
+		}
+		entry := track(*download.FileID)
+		entry.Downloads++
+		if entry.LastDownloadedAt == nil || download.CreatedAt.After(*entry.LastDownloadedAt) {
+			downloadedAt := download.CreatedAt
+			entry.LastDownloadedAt = &downloadedAt
+		}
+	}
+
+	if len(order) > 0 {
+		var files []models.File
+		db.Unscoped().Select("id", "name").Where("id IN ?", order).Find(&files)
+		for _, file := range files {
+			perFile[file.ID].Name = file.Name
+		}
+	}
+
+	for _, fileID := range order {
+		response.Files = append(response.Files, *perFile[fileID])
+	}
+
+	if err := db.Where("share_id = ?", share.ID).
+		Order("created_at DESC").
+		Limit(configuration.ShareAnalyticsAccessLimit).
+		Find(&response.Accesses).Error; err != nil {
+		logger.Error("Failed to list share accesses", zap.Error(err))
+		return models.ShareAnalyticsResponse{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	return response, nil
+}
+
+// SendShare emails the share link to each recipient and records the deliveries on the share.
+// When a password is given, it is checked against the share and mailed in a separate message.
+// Shares of encrypted buckets cannot be sent this way.
+func (s BucketShareService) SendShare(
+	logger *zap.Logger,
+	user models.UserClaims,
+	ids uuid.UUIDs,
+	body models.ShareSendBody,
+) ([]models.ShareDelivery, error) {
+	bucketID, shareID := ids[0], ids[1]
+
+	providerCfg, ok := s.Providers[user.Provider]
+	if !ok {
+		return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeUnknownUserProvider)
+	}
+	if !providerCfg.SharingOptions.Allowed {
+		return nil, apierrors.New(http.StatusForbidden, apierrors.CodeSharingDisabledForProvider)
+	}
+
+	var emails []string
+	for _, email := range body.Emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if !h.IsDomainAllowed(email, providerCfg.SharingOptions.Domains) {
+			return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeShareEmailDomainNotAllowed)
+		}
+		if !slices.Contains(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+
+	var share models.Share
+	result := s.DB.Where("id = ? AND bucket_id = ?", shareID, bucketID).First(&share)
+	if result.Error != nil {
+		return nil, apierrors.New(http.StatusNotFound, apierrors.CodeShareNotFound)
+	}
+	if share.ExpiresAt != nil && share.ExpiresAt.Before(time.Now()) {
+		return nil, apierrors.New(http.StatusGone, apierrors.CodeShareExpired)
+	}
+
+	// Links to encrypted buckets carry the data key in their URL fragment, which only the client
+	// that created the share knows: a link mailed by the server would be unusable.
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ?", bucketID).First(&bucket).Error; err != nil {
+		return nil, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+	if bucket.Encrypted {
+		return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeShareSendEncryptedBucket)
+	}
+
+	if body.Password != "" {
+		if share.HashedPassword == "" {
+			return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeShareNotPasswordProtected)
+		}
+		match, err := argon2id.ComparePasswordAndHash(body.Password, share.HashedPassword)
+		if err != nil || !match {
+			return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeSharePasswordInvalid)
+		}
+	}
+
+	deliveries := make([]models.ShareDelivery, len(emails))
+	for i, email := range emails {
+		deliveries[i] = models.ShareDelivery{
+			ShareID:      share.ID,
+			Email:        email,
+			SentBy:       &user.UserID,
+			PasswordSent: body.Password != "",
+		}
+	}
+
+	txErr := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&deliveries).Error; err != nil {
+			logger.Error("Failed to create share deliveries", zap.Error(err))
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			event := events.NewShareSent(
+				s.Publisher,
+				delivery.Email,
+				user.Email,
+				share.ID.String(),
+				share.Name,
+				body.Message,
+				share.ExpiresAt,
+				share.HashedPassword != "",
+				s.WebURL,
+			)
+			if err := event.Enqueue(tx); err != nil {
+				logger.Error("Failed to enqueue share sent event", zap.Error(err))
+				return err
+			}
+
+			if err := s.ActivityLogger.Send(models.Activity{
+				Message: activity.ShareSent,
+				Object:  share.ToActivity(),
+				Filter: activity.NewLogFilter(models.ActivityFields{
+					Action:         rbac.ActionUpdate.String(),
+					ObjectType:     rbac.ResourceShare.String(),
+					BucketID:       bucketID.String(),
+					ShareID:        share.ID.String(),
+					UserID:         user.UserID.String(),
+					RecipientEmail: delivery.Email,
+				}),
+			}); err != nil {
+				logger.Error("Failed to log share sent activity", zap.Error(err))
+			}
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	// The password never goes through the outbox so it is not persisted in clear text.
+	if body.Password != "" {
+		for _, delivery := range deliveries {
+			event := events.NewSharePasswordSent(s.Publisher, delivery.Email, user.Email, share.Name, body.Password)
+			event.Trigger()
+		}
+	}
+
+	return deliveries, nil
+}
+
 func (s BucketShareService) DeleteShare(
 	logger *zap.Logger,
 	user models.UserClaims,
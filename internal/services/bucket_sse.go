@@ -0,0 +1,94 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newBucketSSE checks the server-side encryption requested for a new bucket against the storage
+// provider and returns the bucket fields to store. Customer keys are sealed with the storage
+// encryption key, so they can only be used once that key is configured.
+func newBucketSSE(
+	logger *zap.Logger,
+	store storage.IStorage,
+	sealingKey string,
+	body *models.BucketSSEBody,
+) (models.Bucket, error) {
+	if body == nil {
+		return models.Bucket{}, nil
+	}
+
+	encryption := storage.ObjectEncryption{KeyID: body.KeyID}
+	if body.Mode == models.SSEModeCustomer {
+		if sealingKey == "" {
+			return models.Bucket{}, apierrors.New(http.StatusBadRequest, apierrors.CodeBucketSSEUnsupported)
+		}
+		key, err := base64.StdEncoding.DecodeString(body.CustomerKey)
+		if err != nil || len(key) != 32 {
+			return models.Bucket{}, apierrors.New(http.StatusBadRequest, apierrors.CodeBucketSSEKeyInvalid)
+		}
+		encryption.CustomerKey = key
+	}
+
+	if err := store.ValidateEncryption(encryption); err != nil {
+		if errors.Is(err, storage.ErrEncryptionNotSupported) {
+			return models.Bucket{}, apierrors.New(http.StatusBadRequest, apierrors.CodeBucketSSEUnsupported)
+		}
+		logger.Warn("Storage rejected bucket encryption key", zap.Error(err))
+		return models.Bucket{}, apierrors.New(http.StatusBadRequest, apierrors.CodeBucketSSEKeyInvalid)
+	}
+
+	bucket := models.Bucket{SSEMode: body.Mode, SSEKeyID: body.KeyID}
+	if body.Mode == models.SSEModeCustomer {
+		sealed, err := h.EncryptSecret(body.CustomerKey, []byte(sealingKey))
+		if err != nil {
+			logger.Error("Failed to seal bucket customer key", zap.Error(err))
+			return models.Bucket{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+		bucket.SSECustomerKey = sealed
+	}
+
+	return bucket, nil
+}
+
+// objectEncryption returns the server-side encryption every storage request on the objects of
+// the bucket must carry.
+func objectEncryption(bucket models.Bucket, sealingKey string) (storage.ObjectEncryption, error) {
+	switch bucket.SSEMode {
+	case models.SSEModeKMS:
+		return storage.ObjectEncryption{KeyID: bucket.SSEKeyID}, nil
+	case models.SSEModeCustomer:
+		encoded, err := h.DecryptSecret(bucket.SSECustomerKey, []byte(sealingKey))
+		if err != nil {
+			return storage.ObjectEncryption{}, err
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return storage.ObjectEncryption{}, err
+		}
+		return storage.ObjectEncryption{CustomerKey: key}, nil
+	default:
+		return storage.ObjectEncryption{}, nil
+	}
+}
+
+// loadObjectEncryption is objectEncryption for callers that only know the bucket ID.
+func loadObjectEncryption(db *gorm.DB, bucketID uuid.UUID, sealingKey string) (storage.ObjectEncryption, error) {
+	var bucket models.Bucket
+	if err := db.Select("id", "sse_mode", "sse_key_id", "sse_customer_key").
+		Where("id = ?", bucketID).
+		First(&bucket).Error; err != nil {
+		return storage.ObjectEncryption{}, err
+	}
+	return objectEncryption(bucket, sealingKey)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type sseStubStorage struct {
+	storage.IStorage
+	err       error
+	validated []storage.ObjectEncryption
+}
+
+func (s *sseStubStorage) ValidateEncryption(encryption storage.ObjectEncryption) error {
+	s.validated = append(s.validated, encryption)
+	return s.err
+}
+
+func TestBucketServerSideEncryption(t *testing.T) {
+	db, owner := setupSQLiteTestDB(t)
+	claims := models.UserClaims{UserID: owner.ID, Email: owner.Email}
+	customerKey := bytes.Repeat([]byte{0x42}, 32)
+	encodedKey := base64.StdEncoding.EncodeToString(customerKey)
+	const sealingKey = "0123456789abcdef0123456789abcdef"
+
+	newService := func(store storage.IStorage, key string) BucketService {
+		return BucketService{DB: db, Storage: store, ActivityLogger: &MockActivityLogger{}, StorageEncryptionKey: key}
+	}
+
+	t.Run("kms keys are validated by the provider and stored by name", func(t *testing.T) {
+		store := &sseStubStorage{}
+		bucket, err := newService(store, "").CreateBucket(zap.NewNop(), claims, nil, models.BucketCreateBody{
+			Name:                 "regulated",
+			ServerSideEncryption: &models.BucketSSEBody{Mode: models.SSEModeKMS, KeyID: "alias/regulated"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []storage.ObjectEncryption{{KeyID: "alias/regulated"}}, store.validated)
+
+		encryption, err := loadObjectEncryption(db, bucket.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, storage.ObjectEncryption{KeyID: "alias/regulated"}, encryption)
+	})
+
+	t.Run("customer keys are sealed at rest", func(t *testing.T) {
+		store := &sseStubStorage{}
+		bucket, err := newService(store, sealingKey).CreateBucket(zap.NewNop(), claims, nil, models.BucketCreateBody{
+			Name:                 "sealed",
+			ServerSideEncryption: &models.BucketSSEBody{Mode: models.SSEModeCustomer, CustomerKey: encodedKey},
+		})
+		require.NoError(t, err)
+		require.Len(t, store.validated, 1)
+		assert.Equal(t, customerKey, store.validated[0].CustomerKey)
+
+		var stored models.Bucket
+		require.NoError(t, db.First(&stored, "id = ?", bucket.ID).Error)
+		assert.Equal(t, models.SSEModeCustomer, stored.SSEMode)
+		assert.NotEmpty(t, stored.SSECustomerKey)
+		assert.NotContains(t, stored.SSECustomerKey, encodedKey)
+
+		encryption, err := objectEncryption(stored, sealingKey)
+		require.NoError(t, err)
+		assert.Equal(t, customerKey, encryption.CustomerKey)
+	})
+
+	t.Run("rejected settings leave no bucket behind", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			store *sseStubStorage
+			key   string
+			body  models.BucketSSEBody
+			code  string
+		}{
+			{
+				name:  "customer key without a sealing key",
+				store: &sseStubStorage{},
+				body:  models.BucketSSEBody{Mode: models.SSEModeCustomer, CustomerKey: encodedKey},
+				code:  apierrors.CodeBucketSSEUnsupported,
+			},
+			{
+				name:  "customer key of the wrong length",
+				store: &sseStubStorage{},
+				key:   sealingKey,
+				body:  models.BucketSSEBody{Mode: models.SSEModeCustomer, CustomerKey: "c2hvcnQ="},
+				code:  apierrors.CodeBucketSSEKeyInvalid,
+			},
+			{
+				name:  "mode unsupported by the provider",
+				store: &sseStubStorage{err: storage.ErrEncryptionNotSupported},
+				key:   sealingKey,
+				body:  models.BucketSSEBody{Mode: models.SSEModeCustomer, CustomerKey: encodedKey},
+				code:  apierrors.CodeBucketSSEUnsupported,
+			},
+			{
+				name:  "key refused by the provider",
+				store: &sseStubStorage{err: errors.New("AccessDenied")},
+				body:  models.BucketSSEBody{Mode: models.SSEModeKMS, KeyID: "alias/missing"},
+				code:  apierrors.CodeBucketSSEKeyInvalid,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				body := tc.body
+				_, err := newService(tc.store, tc.key).CreateBucket(zap.NewNop(), claims, nil, models.BucketCreateBody{
+					Name:                 "rejected",
+					ServerSideEncryption: &body,
+				})
+				requireAPIError(t, err, http.StatusBadRequest, tc.code)
+			})
+		}
+
+		var count int64
+		require.NoError(t, db.Model(&models.Bucket{}).Where("name = ?", "rejected").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestCustomerKeyBucketsCannotBeShared(t *testing.T) {
+	db, owner := setupSQLiteTestDB(t)
+	claims := models.UserClaims{UserID: owner.ID, Email: owner.Email}
+	const sealingKey = "0123456789abcdef0123456789abcdef"
+
+	bucket, err := BucketService{
+		DB:                   db,
+		Storage:              &sseStubStorage{},
+		ActivityLogger:       &MockActivityLogger{},
+		StorageEncryptionKey: sealingKey,
+	}.CreateBucket(zap.NewNop(), claims, nil, models.BucketCreateBody{
+		Name: "sealed",
+		ServerSideEncryption: &models.BucketSSEBody{
+			Mode:        models.SSEModeCustomer,
+			CustomerKey: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x42}, 32)),
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("new shares are refused", func(t *testing.T) {
+		_, err := BucketShareService{DB: db, ActivityLogger: &MockActivityLogger{}}.CreateShare(
+			zap.NewNop(), claims, []uuid.UUID{bucket.ID},
+			models.ShareCreateBody{Name: "leak", Type: models.ShareTypeBucket},
+		)
+		requireAPIError(t, err, http.StatusBadRequest, apierrors.CodeShareCustomerKeyBucket)
+	})
+
+	t.Run("existing shares never hand out the key", func(t *testing.T) {
+		file := models.File{Name: "report.pdf", BucketID: bucket.ID, Status: models.FileStatusUploaded}
+		require.NoError(t, db.Create(&file).Error)
+		share := models.Share{
+			Name: "legacy", BucketID: bucket.ID, Type: models.ShareTypeBucket, AllowUpload: true, CreatedBy: owner.ID,
+		}
+		require.NoError(t, db.Create(&share).Error)
+
+		service := PublicShareService{DB: db, ActivityLogger: &MockActivityLogger{}, StorageEncryptionKey: sealingKey}
+		_, err := service.DownloadShareFile(
+			zap.NewNop(), share, uuid.UUIDs{share.ID, file.ID}, models.FileDownloadQuery{Context: "download"},
+		)
+		requireAPIError(t, err, http.StatusConflict, apierrors.CodeShareCustomerKeyBucket)
+
+		_, err = service.UploadShareFile(zap.NewNop(), share, uuid.UUIDs{share.ID}, models.ShareUploadBody{
+			Name: "upload.pdf",
+			Size: 10,
+		})
+		requireAPIError(t, err, http.StatusConflict, apierrors.CodeShareCustomerKeyBucket)
+	})
+}
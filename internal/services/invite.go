@@ -16,6 +16,7 @@ import (
 	"github.com/safebucket/safebucket/internal/messaging"
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
 	"github.com/safebucket/safebucket/internal/rbac"
 	"github.com/safebucket/safebucket/internal/sql"
 	"github.com/safebucket/safebucket/internal/storage"
@@ -36,6 +37,7 @@ type InviteService struct {
 	Publisher      messaging.IPublisher
 	Providers      configuration.Providers
 	ActivityLogger activity.IActivityLogger
+	PasswordPolicy password.Policy
 }
 
 func (s InviteService) Routes() chi.Router {
@@ -115,10 +117,11 @@ func (s InviteService) handleInviteChallengeFailedAttempt(
 
 func (s InviteService) createUserFromInvite(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	invite *models.Invite,
 	challenge *models.Challenge,
-	password string,
+	newPassword string,
 	inviteID uuid.UUID,
 ) (handlers.AuthFlowResult, error) {
 	newUser := models.User{
@@ -132,7 +135,11 @@ func (s InviteService) createUserFromInvite(
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusConflict, apierrors.CodeUserAlreadyExists)
 	}
 
-	hashedPassword, err := h.CreateHash(password)
+	if policyErr := s.PasswordPolicy.Validate(newPassword, invite.Email); policyErr != nil {
+		return handlers.AuthFlowResult{}, policyErr
+	}
+
+	hashedPassword, err := h.CreateHash(newPassword)
 	if err != nil {
 		logger.Error("Failed to hash password", zap.Error(err))
 		return handlers.AuthFlowResult{}, apierrors.New(
@@ -187,12 +194,8 @@ func (s InviteService) createUserFromInvite(
 	}
 
 	sid := uuid.New().String()
-	if sessionErr := cache.CreateSession(s.Cache, newUser.ID.String(), sid); sessionErr != nil {
-		logger.Error("Failed to create session", zap.Error(sessionErr))
-		return handlers.AuthFlowResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if sessionErr := s.sessionStarter().start(logger, &newUser, sid, client); sessionErr != nil {
+		return handlers.AuthFlowResult{}, sessionErr
 	}
 
 	accessToken, err := h.NewAccessToken(
@@ -276,6 +279,11 @@ func (s InviteService) CreateInviteChallenge(
 		return nil, apierrors.New(http.StatusNotFound, apierrors.CodeInviteNotFound)
 	}
 
+	if invite.IsExpired() {
+		logger.Debug("Invite expired", zap.String("invite_id", inviteID.String()))
+		return nil, apierrors.New(http.StatusGone, apierrors.CodeInviteExpired)
+	}
+
 	s.DB.Where("invite_id = ? AND type = ?", invite.ID, models.ChallengeTypeInvite).
 		Delete(&models.Challenge{})
 
@@ -322,6 +330,7 @@ func (s InviteService) CreateInviteChallenge(
 
 func (s InviteService) ValidateInviteChallenge(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	_ models.UserClaims,
 	ids uuid.UUIDs,
@@ -360,6 +369,11 @@ func (s InviteService) ValidateInviteChallenge(
 			return apierrors.New(http.StatusGone, apierrors.CodeChallengeExpired)
 		}
 
+		if invite.IsExpired() {
+			tx.Delete(&challenge)
+			return apierrors.New(http.StatusGone, apierrors.CodeInviteExpired)
+		}
+
 		if !h.IsDomainAllowed(
 			challenge.Invite.Email,
 			s.Providers[string(models.LocalProviderType)].Domains,
@@ -383,5 +397,5 @@ func (s InviteService) ValidateInviteChallenge(
 		return handlers.AuthFlowResult{}, err
 	}
 
-	return s.createUserFromInvite(isSecure, logger, invite, &challenge, body.NewPassword, inviteID)
+	return s.createUserFromInvite(isSecure, client, logger, invite, &challenge, body.NewPassword, inviteID)
 }
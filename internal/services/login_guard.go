@@ -0,0 +1,130 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+
+	"go.uber.org/zap"
+)
+
+// loginGuard enforces the progressive lockout of password sign-ins. Failures are counted per
+// account and per client IP; the per-IP budget is larger so a shared NAT is not locked out by a
+// single user mistyping their password.
+type loginGuard struct {
+	Cache          cache.ICache
+	Publisher      messaging.IPublisher
+	ActivityLogger activity.IActivityLogger
+	WebURL         string
+}
+
+func (s AuthService) loginGuard() loginGuard {
+	return loginGuard{
+		Cache:          s.Cache,
+		Publisher:      s.Publisher,
+		ActivityLogger: s.ActivityLogger,
+		WebURL:         s.AuthConfig.WebURL,
+	}
+}
+
+// check rejects the attempt while the account or the client IP is locked out. It fails closed
+// when the cache is unavailable.
+func (g loginGuard) check(logger *zap.Logger, client models.ClientInfo, account string) error {
+	remaining, err := cache.LoginLockRemaining(g.Cache, account)
+	if err != nil {
+		logger.Error("Failed to read account lockout", zap.Error(err))
+		return apierrors.New(http.StatusServiceUnavailable, apierrors.CodeServiceUnavailable)
+	}
+	if remaining > 0 {
+		return apierrors.New(http.StatusTooManyRequests, apierrors.CodeAccountLocked)
+	}
+
+	if client.IP == "" {
+		return nil
+	}
+	remaining, err = cache.LoginLockRemaining(g.Cache, cache.LoginIPSubject(client.IP))
+	if err != nil {
+		logger.Error("Failed to read IP lockout", zap.Error(err))
+		return apierrors.New(http.StatusServiceUnavailable, apierrors.CodeServiceUnavailable)
+	}
+	if remaining > 0 {
+		return apierrors.New(http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded)
+	}
+	return nil
+}
+
+// recordFailure counts a failed sign-in. When it locks out an existing user, the lockout is
+// logged and the user is told by email; user is nil when the email matched no account.
+func (g loginGuard) recordFailure(
+	logger *zap.Logger, client models.ClientInfo, account string, user *models.User,
+) {
+	if client.IP != "" {
+		lockout, err := cache.RecordLoginFailure(
+			g.Cache, cache.LoginIPSubject(client.IP), configuration.LoginIPMaxFailures,
+		)
+		if err != nil {
+			logger.Error("Failed to record IP login failure", zap.Error(err))
+		} else if lockout > 0 {
+			logger.Warn("Client IP locked out after failed logins",
+				zap.String("ip", client.IP),
+				zap.Duration("lockout", lockout),
+			)
+		}
+	}
+
+	lockout, err := cache.RecordLoginFailure(g.Cache, account, configuration.LoginAccountMaxFailures)
+	if err != nil {
+		logger.Error("Failed to record account login failure", zap.Error(err))
+		return
+	}
+	if lockout == 0 || user == nil {
+		return
+	}
+
+	lockedUntil := time.Now().UTC().Add(lockout)
+	logger.Warn("Account locked out after failed logins",
+		zap.String("user_id", user.ID.String()),
+		zap.Duration("lockout", lockout),
+	)
+
+	action := models.Activity{
+		Message: activity.UserLocked,
+		Object:  user.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     activity.UserLocked,
+			UserID:     user.ID.String(),
+			ObjectType: rbac.ResourceUser.String(),
+		}),
+	}
+	if logErr := g.ActivityLogger.Send(action); logErr != nil {
+		logger.Error("Failed to log account lockout activity", zap.Error(logErr))
+	}
+
+	if g.Publisher == nil {
+		return
+	}
+	event := events.NewAccountLocked(
+		g.Publisher,
+		user.Email,
+		client.IP,
+		lockedUntil.Format(time.RFC1123),
+		g.WebURL,
+	)
+	event.Trigger()
+}
+
+// recordSuccess forgets the pending failures of the account. IP failures are kept so a valid
+// account cannot be used to reset the budget of a client guessing other passwords.
+func (g loginGuard) recordSuccess(logger *zap.Logger, account string) {
+	if err := cache.ClearLoginFailures(g.Cache, account); err != nil {
+		logger.Warn("Failed to clear login failures", zap.Error(err))
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newLoginGuardTest(t *testing.T) (AuthService, *capturePublisher, *models.User) {
+	t.Helper()
+
+	f := newAuthTestFixture(t, "correct-password")
+	return AuthService{
+		DB:    f.DB,
+		Cache: f.Cache,
+		AuthConfig: models.AuthConfig{
+			TokenSecret:      "test-secret-key-for-jwt-signing",
+			MFAEncryptionKey: "01234567890123456789012345678901",
+			WebURL:           "https://safebucket.example",
+		},
+		Providers: configuration.Providers{
+			"local": {Name: "Local", Type: models.LocalProviderType, Domains: []string{}},
+		},
+		Publisher:      f.Publisher,
+		ActivityLogger: &MockActivityLogger{},
+	}, f.Publisher, f.User
+}
+
+func attemptLogin(service AuthService, client models.ClientInfo, email string, password string) error {
+	_, err := service.Login(false, client, zap.NewNop(), models.UserClaims{}, uuid.UUIDs{},
+		models.AuthLoginBody{Email: email, Password: password})
+	return err
+}
+
+func TestLogin_LocksAccountAfterRepeatedFailures(t *testing.T) {
+	service, publisher, user := newLoginGuardTest(t)
+	client := models.ClientInfo{IP: "203.0.113.7"}
+
+	for range configuration.LoginAccountMaxFailures {
+		err := attemptLogin(service, client, user.Email, "wrong-password")
+		requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
+	}
+
+	err := attemptLogin(service, client, user.Email, "correct-password")
+	requireAPIError(t, err, http.StatusTooManyRequests, apierrors.CodeAccountLocked)
+
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, events.AccountLockedName, publisher.messages[0].Metadata.Get("type"))
+}
+
+func TestLogin_LocksUnknownAccountsSilently(t *testing.T) {
+	service, publisher, _ := newLoginGuardTest(t)
+
+	for range configuration.LoginAccountMaxFailures {
+		err := attemptLogin(service, models.ClientInfo{}, "nobody@example.com", "wrong-password")
+		requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
+	}
+
+	err := attemptLogin(service, models.ClientInfo{}, "nobody@example.com", "wrong-password")
+	requireAPIError(t, err, http.StatusTooManyRequests, apierrors.CodeAccountLocked)
+	assert.Empty(t, publisher.messages)
+}
+
+func TestLogin_LocksClientIPAcrossAccounts(t *testing.T) {
+	service, _, user := newLoginGuardTest(t)
+	client := models.ClientInfo{IP: "203.0.113.7"}
+
+	for range configuration.LoginIPMaxFailures {
+		email := uuid.NewString() + "@example.com"
+		err := attemptLogin(service, client, email, "wrong-password")
+		requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
+	}
+
+	err := attemptLogin(service, client, user.Email, "correct-password")
+	requireAPIError(t, err, http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded)
+
+	require.NoError(t, attemptLogin(service, models.ClientInfo{IP: "198.51.100.1"}, user.Email, "correct-password"))
+}
+
+func TestLogin_SuccessResetsAccountFailures(t *testing.T) {
+	service, _, user := newLoginGuardTest(t)
+
+	for range configuration.LoginAccountMaxFailures - 1 {
+		_ = attemptLogin(service, models.ClientInfo{}, user.Email, "wrong-password")
+	}
+	require.NoError(t, attemptLogin(service, models.ClientInfo{}, user.Email, "correct-password"))
+
+	err := attemptLogin(service, models.ClientInfo{}, user.Email, "wrong-password")
+	requireAPIError(t, err, http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
+}
+
+func TestUnlockUser_LiftsLockout(t *testing.T) {
+	service, _, user := newLoginGuardTest(t)
+	for range configuration.LoginAccountMaxFailures {
+		_ = attemptLogin(service, models.ClientInfo{}, user.Email, "wrong-password")
+	}
+
+	users := UserService{DB: service.DB, Cache: service.Cache, ActivityLogger: &MockActivityLogger{}}
+	locked, err := users.GetUser(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{user.ID})
+	require.NoError(t, err)
+	require.NotNil(t, locked.LockedUntil)
+
+	require.NoError(t, users.UnlockUser(zap.NewNop(), models.UserClaims{UserID: uuid.New()}, uuid.UUIDs{user.ID}))
+
+	unlocked, err := users.GetUser(zap.NewNop(), models.UserClaims{}, uuid.UUIDs{user.ID})
+	require.NoError(t, err)
+	assert.Nil(t, unlocked.LockedUntil)
+	require.NoError(t, attemptLogin(service, models.ClientInfo{}, user.Email, "correct-password"))
+}
@@ -218,6 +218,7 @@ func (s MFAService) GetDevice(
 
 func (s MFAService) VerifyDevice(
 	isSecure bool,
+	client models.ClientInfo,
 	logger *zap.Logger,
 	claims models.UserClaims,
 	ids uuid.UUIDs,
@@ -390,12 +391,8 @@ func (s MFAService) VerifyDevice(
 		return handlers.AuthFlowResult{}, err
 	}
 
-	if sessionErr := cache.CreateSession(s.Cache, userID.String(), sid); sessionErr != nil {
-		logger.Error("Failed to create session", zap.Error(sessionErr))
-		return handlers.AuthFlowResult{}, apierrors.New(
-			http.StatusInternalServerError,
-			apierrors.CodeInternalServerError,
-		)
+	if sessionErr := s.sessionStarter().start(logger, &user, sid, client); sessionErr != nil {
+		return handlers.AuthFlowResult{}, sessionErr
 	}
 
 	return handlers.AuthFlowResult{
@@ -592,7 +589,7 @@ func (s MFAService) RemoveDevice(
 }
 
 func (s MFAService) verifyMFAStepUp(logger *zap.Logger, user *models.User, password, code string) error {
-	if user.ProviderType == models.OIDCProviderType {
+	if user.ProviderType.IsRedirectFlow() {
 		return s.verifyTOTPStepUp(logger, user, code)
 	}
 	return s.verifyProviderPassword(logger, user, password)
@@ -658,7 +655,7 @@ func (s MFAService) verifyTOTPStepUp(logger *zap.Logger, user *models.User, code
 }
 
 func (s MFAService) verifyAddDeviceStepUp(logger *zap.Logger, user *models.User, password, code string) error {
-	if user.ProviderType == models.OIDCProviderType {
+	if user.ProviderType.IsRedirectFlow() {
 		verifiedCount, err := sql.CountVerifiedMFADevices(s.DB, user.ID)
 		if err != nil {
 			logger.Error("Failed to count verified MFA devices for step-up", zap.Error(err))
@@ -709,8 +706,8 @@ func (s MFAService) verifyProviderPassword(logger *zap.Logger, user *models.User
 		}
 		return nil
 
-	case models.OIDCProviderType:
-		logger.Error("verifyProviderPassword reached for OIDC user", zap.String("user_id", user.ID.String()))
+	case models.OIDCProviderType, models.SAMLProviderType:
+		logger.Error("verifyProviderPassword reached for redirect-flow user", zap.String("user_id", user.ID.String()))
 		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
 
 	default:
@@ -25,6 +25,7 @@ import (
 type MockCache struct{}
 
 func (m *MockCache) Get(_ string) (string, error)                            { return "", cache.ErrKeyNotFound }
+func (m *MockCache) Set(_ string, _ string, _ time.Duration) error           { return nil }
 func (m *MockCache) SetNX(_ string, _ string, _ time.Duration) (bool, error) { return true, nil }
 func (m *MockCache) Del(_ string) error                                      { return nil }
 func (m *MockCache) Incr(_ string) (int64, error)                            { return 1, nil }
@@ -121,6 +122,7 @@ func TestVerifyDevice_Security_PrivilegeEscalation(t *testing.T) {
 
 		response, err := service.VerifyDevice(
 			false,
+			models.ClientInfo{},
 			logger,
 			claims,
 			uuid.UUIDs{deviceID},
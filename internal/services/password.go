@@ -0,0 +1,57 @@
+package services
+
+import (
+	"net/http"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
+	"github.com/safebucket/safebucket/internal/sql"
+
+	"github.com/alexedwards/argon2id"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// checkNewPassword applies the password policy to a password replacing the user's current one,
+// including the history rule.
+func checkNewPassword(
+	logger *zap.Logger, db *gorm.DB, policy password.Policy, user *models.User, newPassword string,
+) error {
+	if err := policy.Validate(newPassword, user.Email); err != nil {
+		return err
+	}
+	if policy.Rules.HistorySize == 0 {
+		return nil
+	}
+
+	previous, err := sql.ListPasswordHashes(db, user.ID, policy.HistoryDepth())
+	if err != nil {
+		logger.Error("Failed to load password history", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	for _, hash := range append([]string{user.HashedPassword}, previous...) {
+		if hash == "" {
+			continue
+		}
+		match, compareErr := argon2id.ComparePasswordAndHash(newPassword, hash)
+		if compareErr != nil {
+			logger.Warn("Failed to compare password history entry", zap.Error(compareErr))
+			continue
+		}
+		if match {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodePasswordReused)
+		}
+	}
+	return nil
+}
+
+// archivePassword keeps the hash being replaced for the history rule. It must run in the
+// transaction that stores the new hash.
+func archivePassword(tx *gorm.DB, policy password.Policy, user *models.User) error {
+	if policy.Rules.HistorySize == 0 {
+		return nil
+	}
+	return sql.ArchivePasswordHash(tx, user.ID, user.HashedPassword, policy.HistoryDepth())
+}
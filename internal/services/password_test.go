@@ -0,0 +1,74 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	h "github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/password"
+	"github.com/safebucket/safebucket/internal/sql"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// changeTestPassword replaces the user's password the way the services do once the policy
+// accepted it.
+func changeTestPassword(t *testing.T, db *gorm.DB, policy password.Policy, user *models.User, newPassword string) {
+	t.Helper()
+	hash, err := h.CreateHash(newPassword)
+	require.NoError(t, err)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		if err = archivePassword(tx, policy, user); err != nil {
+			return err
+		}
+		return tx.Model(user).Update("hashed_password", hash).Error
+	}))
+}
+
+func TestCheckNewPassword_RejectsRecentPasswords(t *testing.T) {
+	db, user := setupSQLiteTestDB(t)
+	policy := password.Policy{Rules: models.PasswordPolicy{MinLength: 8, HistorySize: 3}}
+
+	for _, pw := range []string{"first-password", "second-password", "third-password"} {
+		require.NoError(t, checkNewPassword(zap.NewNop(), db, policy, user, pw))
+		changeTestPassword(t, db, policy, user, pw)
+	}
+
+	// The current password and the two before it are remembered.
+	for _, pw := range []string{"third-password", "second-password", "first-password"} {
+		requireAPIError(t, checkNewPassword(zap.NewNop(), db, policy, user, pw),
+			http.StatusBadRequest, apierrors.CodePasswordReused)
+	}
+
+	changeTestPassword(t, db, policy, user, "fourth-password")
+	require.NoError(t, checkNewPassword(zap.NewNop(), db, policy, user, "first-password"))
+
+	hashes, err := sql.ListPasswordHashes(db, user.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, hashes, policy.HistoryDepth())
+}
+
+func TestCheckNewPassword_NoHistoryAllowsReuse(t *testing.T) {
+	db, user := setupSQLiteTestDB(t)
+	policy := password.Policy{Rules: models.PasswordPolicy{MinLength: 8}}
+
+	changeTestPassword(t, db, policy, user, "same-password")
+	require.NoError(t, checkNewPassword(zap.NewNop(), db, policy, user, "same-password"))
+
+	var count int64
+	require.NoError(t, db.Model(&models.UserPasswordHash{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCheckNewPassword_AppliesPolicy(t *testing.T) {
+	db, user := setupSQLiteTestDB(t)
+	policy := password.Policy{Rules: models.PasswordPolicy{MinLength: 12}}
+
+	requireAPIError(t, checkNewPassword(zap.NewNop(), db, policy, user, "short-pw"),
+		http.StatusBadRequest, apierrors.CodePasswordTooShort)
+}
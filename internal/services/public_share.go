@@ -4,10 +4,12 @@ import (
 	"errors"
 	"net/http"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/handlers"
@@ -33,21 +35,27 @@ type PublicShareService struct {
 	ActivityLogger        activity.IActivityLogger
 	Publisher             messaging.IPublisher
 	TokenSecret           string
+	WebURL                string
 	CookieSecureForce     bool
 	AllowRedirectDownload bool
+	StorageEncryptionKey  string
 }
 
 func (s PublicShareService) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	r.Route("/{id0}", func(r chi.Router) {
-		r.Use(m.ValidateShareAccess(s.DB))
+		r.Use(m.ValidateShareAccess(s.DB, s.ActivityLogger))
 
 		r.With(m.Validate[models.ShareAuthBody]).
 			Post("/auth", handlers.ShareAuthHandler(s.CookieSecureForce, s.AuthenticateShare))
+		r.With(m.Validate[models.ShareOTPRequestBody]).
+			Post("/otp", handlers.ShareCreateHandler(s.RequestShareAccessCode))
+		r.With(m.Validate[models.ShareOTPVerifyBody]).
+			Post("/otp/verify", handlers.ShareAuthHandler(s.CookieSecureForce, s.VerifyShareAccessCode))
 
 		r.Group(func(r chi.Router) {
-			r.Use(m.ValidateShareToken(s.TokenSecret))
+			r.Use(m.ValidateShareToken(s.DB, s.TokenSecret))
 
 			r.Get("/", handlers.ShareGetOneHandler(s.ListShareItems))
 			r.Get("/download", handlers.ShareDownloadRedirectHandler(s.DownloadSingleShareFile))
@@ -79,7 +87,169 @@ func (s PublicShareService) AuthenticateShare(
 		return handlers.AuthFlowResult{}, apierrors.New(http.StatusUnauthorized, apierrors.CodeSharePasswordInvalid)
 	}
 
-	token, err := h.NewShareAccessToken(s.TokenSecret, share.ID)
+	token, err := h.NewShareAccessToken(s.TokenSecret, share, "")
+	if err != nil {
+		logger.Error("Failed to create share access token", zap.Error(err))
+		return handlers.AuthFlowResult{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
+	return handlers.AuthFlowResult{
+		Status:  http.StatusOK,
+		Body:    struct{}{},
+		Cookies: handlers.BuildShareCookie(isSecure, share.ID.String(), token),
+	}, nil
+}
+
+// RequestShareAccessCode emails a one-time code to a visitor of a recipient-restricted
+// share. Emails that are not allowed get the same response so the list cannot be probed.
+func (s PublicShareService) RequestShareAccessCode(
+	logger *zap.Logger,
+	share models.Share,
+	_ uuid.UUIDs,
+	body models.ShareOTPRequestBody,
+) (any, error) {
+	if !share.RecipientRestricted {
+		return nil, apierrors.New(http.StatusBadRequest, apierrors.CodeShareNotRecipientRestricted)
+	}
+
+	// Variants of one address share a single issuance budget.
+	email := h.NormalizeShareRecipient(body.Email)
+	if err := enforceEmailIssuanceLimit(
+		logger,
+		s.Cache,
+		string(models.ChallengeTypeShareAccess),
+		email,
+		configuration.SecurityShareAccessMaxPerEmailPerHour,
+	); err != nil {
+		return nil, err
+	}
+
+	var recipients []models.ShareRecipient
+	if err := s.DB.Where("share_id = ?", share.ID).Find(&recipients).Error; err != nil {
+		logger.Error("Failed to list share recipients", zap.Error(err))
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	if !h.MatchShareRecipient(recipients, email) {
+		logger.Info("Share access code requested for a non-recipient",
+			zap.String("share_id", share.ID.String()))
+		return nil, nil
+	}
+
+	secret, err := h.GenerateSecret()
+	if err != nil {
+		logger.Error("Failed to generate secret", zap.Error(err))
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	hashedSecret, err := h.CreateHash(secret)
+	if err != nil {
+		logger.Error("Failed to hash secret", zap.Error(err))
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	expiresAt := time.Now().Add(configuration.SecurityChallengeExpirationMinutes * time.Minute)
+	challenge := models.Challenge{
+		Type:         models.ChallengeTypeShareAccess,
+		ShareID:      &share.ID,
+		Email:        &email,
+		HashedSecret: hashedSecret,
+		ExpiresAt:    &expiresAt,
+		AttemptsLeft: configuration.SecurityChallengeMaxFailedAttempts,
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if txErr := tx.Where("share_id = ? AND email = ? AND type = ?",
+			share.ID, email, models.ChallengeTypeShareAccess).
+			Delete(&models.Challenge{}).Error; txErr != nil {
+			return txErr
+		}
+		return tx.Create(&challenge).Error
+	})
+	if err != nil {
+		logger.Error("Failed to create challenge", zap.Error(err))
+		return nil, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	event := events.NewShareAccessChallenge(
+		s.Publisher,
+		secret,
+		email,
+		share.ID.String(),
+		share.Name,
+		s.WebURL,
+	)
+	event.Trigger()
+
+	return nil, nil
+}
+
+// VerifyShareAccessCode checks the code sent to a recipient and issues a share token that
+// carries the verified email.
+func (s PublicShareService) VerifyShareAccessCode(
+	isSecure bool,
+	logger *zap.Logger,
+	share models.Share,
+	_ uuid.UUIDs,
+	body models.ShareOTPVerifyBody,
+) (handlers.AuthFlowResult, error) {
+	if !share.RecipientRestricted {
+		return handlers.AuthFlowResult{}, apierrors.New(
+			http.StatusBadRequest,
+			apierrors.CodeShareNotRecipientRestricted,
+		)
+	}
+
+	email := h.NormalizeShareRecipient(body.Email)
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		var challenge models.Challenge
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("share_id = ? AND email = ? AND type = ?", share.ID, email, models.ChallengeTypeShareAccess).
+			Find(&challenge)
+
+		if result.RowsAffected == 0 {
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeInvalidRequest)
+		}
+
+		if challenge.ExpiresAt != nil && time.Now().After(*challenge.ExpiresAt) {
+			tx.Delete(&challenge)
+			return apierrors.New(http.StatusBadRequest, apierrors.CodeInvalidRequest)
+		}
+
+		match, err := argon2id.ComparePasswordAndHash(
+			strings.ToUpper(body.Code),
+			challenge.HashedSecret,
+		)
+		if err != nil || !match {
+			challenge.AttemptsLeft--
+
+			if challenge.AttemptsLeft <= 0 {
+				logger.Warn("Share access challenge soft deleted due to too many failed attempts",
+					zap.String("challenge_id", challenge.ID.String()),
+					zap.String("share_id", share.ID.String()))
+				tx.Delete(&challenge)
+
+				return apierrors.New(http.StatusForbidden, apierrors.CodeChallengeLocked)
+			}
+
+			if updateErr := tx.Save(&challenge).Error; updateErr != nil {
+				logger.Error("Failed to update attempts counter", zap.Error(updateErr))
+				return updateErr
+			}
+			return apierrors.New(http.StatusUnauthorized, apierrors.CodeWrongCode)
+		}
+
+		return tx.Delete(&challenge).Error
+	})
+	if err != nil {
+		return handlers.AuthFlowResult{}, err
+	}
+
+	token, err := h.NewShareAccessToken(s.TokenSecret, share, email)
 	if err != nil {
 		logger.Error("Failed to create share access token", zap.Error(err))
 		return handlers.AuthFlowResult{}, apierrors.New(
@@ -115,19 +285,35 @@ func (s PublicShareService) ListShareItems(
 		return models.PublicShareResponse{}, apierrors.New(http.StatusForbidden, apierrors.CodeShareMaxViewsReached)
 	}
 
+	if err := s.DB.Create(newShareAccess(share, models.ShareAccessTypeView, nil)).Error; err != nil {
+		logger.Error("Failed to record share view", zap.Error(err))
+	}
+
+	var bucket models.Bucket
+	if err := s.DB.Where("id = ?", share.BucketID).First(&bucket).Error; err != nil {
+		logger.Error("Failed to load share bucket", zap.Error(err))
+		return models.PublicShareResponse{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
 	response := models.PublicShareResponse{
-		ID:             share.ID,
-		Name:           share.Name,
-		Type:           share.Type,
-		AllowUpload:    share.AllowUpload,
-		MaxUploadSize:  share.MaxUploadSize,
-		MaxUploads:     share.MaxUploads,
-		CurrentUploads: share.CurrentUploads,
-		ExpiresAt:      share.ExpiresAt,
-		MaxViews:       share.MaxViews,
-		CurrentViews:   share.CurrentViews + 1,
-		Files:          []models.File{},
-		Folders:        []models.Folder{},
+		ID:              share.ID,
+		Name:            share.Name,
+		Type:            share.Type,
+		AllowUpload:     share.AllowUpload,
+		MaxUploadSize:   share.MaxUploadSize,
+		MaxUploads:      share.MaxUploads,
+		CurrentUploads:  share.CurrentUploads,
+		RequireApproval: share.RequireApproval,
+		Encrypted:       bucket.Encrypted,
+		ExpiresAt:       share.ExpiresAt,
+		MaxViews:        share.MaxViews,
+		CurrentViews:    share.CurrentViews + 1,
+		MaxDownloads:    share.MaxDownloads,
+		Files:           []models.File{},
+		Folders:         []models.Folder{},
 	}
 
 	now := time.Now()
@@ -137,6 +323,7 @@ func (s PublicShareService) ListShareItems(
 		var files []models.File
 		s.DB.Joins("JOIN share_files ON share_files.file_id = files.id").
 			Where("share_files.share_id = ?", share.ID).
+			Where("share_files.max_downloads IS NULL OR share_files.current_downloads < share_files.max_downloads").
 			Where("files.status = ?", models.FileStatusUploaded).
 			Where("files.expires_at IS NULL OR files.expires_at > ?", now).
 			Find(&files)
@@ -191,11 +378,26 @@ func (s PublicShareService) DownloadShareFile(
 		inlineContentType = h.PreviewMimeFromExtension(file.Extension)
 	}
 
-	url, err := s.Storage.PresignedGetObject(
+	encryption, err := loadObjectEncryption(s.DB, share.BucketID, s.StorageEncryptionKey)
+	if err != nil {
+		logger.Error("Failed to load bucket encryption key", zap.Error(err))
+		return models.FileDownloadResponse{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+	// Reading an SSE-C object takes the customer key in the request headers, which must never
+	// reach a share visitor.
+	if len(encryption.CustomerKey) > 0 {
+		return models.FileDownloadResponse{}, apierrors.New(http.StatusConflict, apierrors.CodeShareCustomerKeyBucket)
+	}
+
+	download, err := s.Storage.PresignedGetObject(
 		path.Join("buckets", share.BucketID.String(), file.ID.String()),
 		storage.GetObjectOptions{
 			InlineContentType: inlineContentType,
 			DownloadFilename:  file.Name,
+			Encryption:        encryption,
 		},
 	)
 	if err != nil {
@@ -206,15 +408,26 @@ func (s PublicShareService) DownloadShareFile(
 		)
 	}
 
+	if query.Context == "preview" {
+		err = s.checkShareDownloadsLeft(logger, share, file.ID)
+	} else {
+		err = s.consumeShareDownload(logger, share, file.ID)
+	}
+	if err != nil {
+		return models.FileDownloadResponse{}, err
+	}
+	recordDownload(logger, s.DB, file.ID)
+
 	if activityErr := s.ActivityLogger.Send(models.Activity{
 		Message: activity.ShareFileDownloaded,
 		Object:  file.ToActivity(),
 		Filter: activity.NewLogFilter(models.ActivityFields{
-			Action:     rbac.ActionDownload.String(),
-			ObjectType: rbac.ResourceFile.String(),
-			BucketID:   share.BucketID.String(),
-			FileID:     fileID.String(),
-			ShareID:    share.ID.String(),
+			Action:         rbac.ActionDownload.String(),
+			ObjectType:     rbac.ResourceFile.String(),
+			BucketID:       share.BucketID.String(),
+			FileID:         fileID.String(),
+			ShareID:        share.ID.String(),
+			RecipientEmail: share.VerifiedRecipient,
 		}),
 	}); activityErr != nil {
 		logger.Error("Failed to log share download activity", zap.Error(activityErr))
@@ -223,10 +436,83 @@ func (s PublicShareService) DownloadShareFile(
 
 	return models.FileDownloadResponse{
 		ID:  file.ID.String(),
-		URL: url,
+		URL: download.URL,
 	}, nil
 }
 
+// consumeShareDownload counts a download against the share and, for files shares, against
+// the shared file, and records it for the share analytics. Both counters are incremented
+// conditionally in one transaction so concurrent downloads cannot exceed either limit.
+func (s PublicShareService) consumeShareDownload(logger *zap.Logger, share models.Share, fileID uuid.UUID) error {
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Share{}).
+			Where("id = ? AND (max_downloads IS NULL OR current_downloads < max_downloads)", share.ID).
+			UpdateColumn("current_downloads", gorm.Expr("current_downloads + 1"))
+		if result.Error != nil {
+			logger.Error("Failed to increment share downloads", zap.Error(result.Error))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+		if result.RowsAffected == 0 {
+			return apierrors.New(http.StatusForbidden, apierrors.CodeShareMaxDownloadsReached)
+		}
+
+		if share.Type == models.ShareTypeFiles {
+			result = tx.Model(&models.ShareFile{}).
+				Where("share_id = ? AND file_id = ?", share.ID, fileID).
+				Where("max_downloads IS NULL OR current_downloads < max_downloads").
+				UpdateColumn("current_downloads", gorm.Expr("current_downloads + 1"))
+			if result.Error != nil {
+				logger.Error("Failed to increment share file downloads", zap.Error(result.Error))
+				return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+			}
+			if result.RowsAffected == 0 {
+				return apierrors.New(http.StatusForbidden, apierrors.CodeShareFileMaxDownloadsReached)
+			}
+		}
+
+		if err := tx.Create(newShareAccess(share, models.ShareAccessTypeDownload, &fileID)).Error; err != nil {
+			logger.Error("Failed to record share download", zap.Error(err))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+
+		return nil
+	})
+}
+
+// checkShareDownloadsLeft lets previews through without consuming a download, as long as the
+// share and shared file still have downloads left.
+func (s PublicShareService) checkShareDownloadsLeft(logger *zap.Logger, share models.Share, fileID uuid.UUID) error {
+	if share.MaxDownloads != nil && share.CurrentDownloads >= *share.MaxDownloads {
+		return apierrors.New(http.StatusForbidden, apierrors.CodeShareMaxDownloadsReached)
+	}
+
+	if share.Type != models.ShareTypeFiles {
+		return nil
+	}
+
+	var shareFile models.ShareFile
+	if err := s.DB.Where("share_id = ? AND file_id = ?", share.ID, fileID).First(&shareFile).Error; err != nil {
+		logger.Error("Failed to load shared file", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+	if shareFile.MaxDownloads != nil && shareFile.CurrentDownloads >= *shareFile.MaxDownloads {
+		return apierrors.New(http.StatusForbidden, apierrors.CodeShareFileMaxDownloadsReached)
+	}
+
+	return nil
+}
+
+func newShareAccess(share models.Share, accessType models.ShareAccessType, fileID *uuid.UUID) *models.ShareAccess {
+	return &models.ShareAccess{
+		ShareID:        share.ID,
+		FileID:         fileID,
+		Type:           accessType,
+		IPAddress:      share.Client.IP,
+		UserAgent:      share.Client.UserAgent,
+		RecipientEmail: share.VerifiedRecipient,
+	}
+}
+
 func (s PublicShareService) DownloadSingleShareFile(
 	logger *zap.Logger,
 	share models.Share,
@@ -324,17 +610,47 @@ func (s PublicShareService) UploadShareFile(
 		return models.FileUploadResponse{}, apierrors.New(http.StatusConflict, apierrors.CodeFileAlreadyExists)
 	}
 
+	var bucket models.Bucket
+	if s.DB.Where("id = ?", share.BucketID).Find(&bucket).RowsAffected == 0 {
+		return models.FileUploadResponse{}, apierrors.New(http.StatusNotFound, apierrors.CodeBucketNotFound)
+	}
+	// Presigned SSE-C uploads carry the customer key in their headers.
+	if bucket.SSEMode == models.SSEModeCustomer {
+		return models.FileUploadResponse{}, apierrors.New(http.StatusConflict, apierrors.CodeShareCustomerKeyBucket)
+	}
+	if err := checkFileEncryption(bucket, body.Encryption); err != nil {
+		return models.FileUploadResponse{}, err
+	}
+
+	extension := h.ExtensionFromName(body.Name)
+	expiresAt, err := lifecycleExpiresAt(logger, s.DB, share.BucketID, extension, nil)
+	if err != nil {
+		return models.FileUploadResponse{}, err
+	}
+
+	encryption, err := objectEncryption(bucket, s.StorageEncryptionKey)
+	if err != nil {
+		logger.Error("Failed to load bucket encryption key", zap.Error(err))
+		return models.FileUploadResponse{}, apierrors.New(
+			http.StatusInternalServerError,
+			apierrors.CodeInternalServerError,
+		)
+	}
+
 	file := &models.File{
-		Status:    models.FileStatusUploading,
-		Name:      body.Name,
-		Extension: h.ExtensionFromName(body.Name),
-		BucketID:  share.BucketID,
-		FolderID:  folderID,
-		Size:      int(body.Size),
+		Status:     models.FileStatusUploading,
+		Name:       body.Name,
+		Extension:  extension,
+		BucketID:   share.BucketID,
+		FolderID:   folderID,
+		ShareID:    &share.ID,
+		Size:       int(body.Size),
+		ExpiresAt:  expiresAt,
+		Encryption: body.Encryption,
 	}
 
 	var response models.FileUploadResponse
-	err := s.DB.Transaction(func(tx *gorm.DB) error {
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
 		if txErr := tx.Create(file).Error; txErr != nil {
 			return txErr
 		}
@@ -349,6 +665,7 @@ func (s PublicShareService) UploadShareFile(
 				"file_id":   file.ID.String(),
 				"share_id":  share.ID.String(),
 			},
+			encryption,
 		)
 		if presignErr != nil {
 			logger.Error("Presign upload failed", zap.Error(presignErr))
@@ -370,11 +687,12 @@ func (s PublicShareService) UploadShareFile(
 			Message: activity.ShareFileUploaded,
 			Object:  file.ToActivity(),
 			Filter: activity.NewLogFilter(models.ActivityFields{
-				Action:     rbac.ActionCreate.String(),
-				ObjectType: rbac.ResourceFile.String(),
-				BucketID:   share.BucketID.String(),
-				FileID:     file.ID.String(),
-				ShareID:    share.ID.String(),
+				Action:         rbac.ActionCreate.String(),
+				ObjectType:     rbac.ResourceFile.String(),
+				BucketID:       share.BucketID.String(),
+				FileID:         file.ID.String(),
+				ShareID:        share.ID.String(),
+				RecipientEmail: share.VerifiedRecipient,
 			}),
 		}); activityErr != nil {
 			logger.Warn("Failed to log share upload activity", zap.Error(activityErr))
@@ -434,6 +752,12 @@ func (s PublicShareService) ConfirmShareUpload(
 
 		objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
 
+		encryption, encErr := loadObjectEncryption(tx, file.BucketID, s.StorageEncryptionKey)
+		if encErr != nil {
+			logger.Error("Failed to load bucket encryption key", zap.Error(encErr))
+			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+		}
+
 		multipart, isMultipart, cacheErr := cache.GetMultipartState(s.Cache, file.ID.String())
 		if cacheErr != nil {
 			logger.Error("Failed to read multipart state", zap.Error(cacheErr))
@@ -448,6 +772,7 @@ func (s PublicShareService) ConfirmShareUpload(
 					"file_id":   file.ID.String(),
 					"share_id":  share.ID.String(),
 				},
+				encryption,
 			); completeErr != nil {
 				if errors.Is(completeErr, storage.ErrMultipartPartMismatch) {
 					return apierrors.New(http.StatusBadRequest, apierrors.CodeMultipartSizeMismatch)
@@ -458,7 +783,7 @@ func (s PublicShareService) ConfirmShareUpload(
 			}
 		}
 
-		if _, statErr := s.Storage.StatObject(objectPath); statErr != nil {
+		if _, statErr := s.Storage.StatObject(objectPath, encryption); statErr != nil {
 			logger.Error("File not found in storage",
 				zap.Error(statErr),
 				zap.String("path", objectPath),
@@ -466,11 +791,24 @@ func (s PublicShareService) ConfirmShareUpload(
 			return apierrors.New(http.StatusNotFound, apierrors.CodeFileNotInStorage)
 		}
 
-		if txErr := tx.Model(&file).Update("status", models.FileStatusUploaded).Error; txErr != nil {
+		// Uploads through a share that requires approval stay hidden until a reviewer approves them.
+		status := models.FileStatusUploaded
+		if share.RequireApproval {
+			status = models.FileStatusPending
+		}
+
+		if txErr := tx.Model(&file).Update("status", status).Error; txErr != nil {
 			logger.Error("Failed to update file status", zap.Error(txErr))
 			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
 		}
 
+		if status == models.FileStatusUploaded {
+			if lockErr := enqueueObjectLock(tx, s.Storage, s.Publisher, file); lockErr != nil {
+				logger.Error("Failed to enqueue object lock sync", zap.Error(lockErr))
+				return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+			}
+		}
+
 		if isMultipart {
 			if delErr := cache.DeleteMultipartState(s.Cache, file.ID.String()); delErr != nil {
 				logger.Warn("Failed to delete multipart state from cache", zap.Error(delErr))
@@ -481,11 +819,12 @@ func (s PublicShareService) ConfirmShareUpload(
 			Message: activity.ShareFileUploaded,
 			Object:  file.ToActivity(),
 			Filter: activity.NewLogFilter(models.ActivityFields{
-				Action:     rbac.ActionCreate.String(),
-				ObjectType: rbac.ResourceFile.String(),
-				BucketID:   share.BucketID.String(),
-				FileID:     file.ID.String(),
-				ShareID:    share.ID.String(),
+				Action:         rbac.ActionCreate.String(),
+				ObjectType:     rbac.ResourceFile.String(),
+				BucketID:       share.BucketID.String(),
+				FileID:         file.ID.String(),
+				ShareID:        share.ID.String(),
+				RecipientEmail: share.VerifiedRecipient,
 			}),
 		}); activityErr != nil {
 			logger.Warn("Failed to log share upload activity", zap.Error(activityErr))
@@ -495,8 +834,12 @@ func (s PublicShareService) ConfirmShareUpload(
 		if dbErr := tx.Where("id = ?", share.BucketID).First(&bucket).Error; dbErr == nil {
 			var user models.User
 			if dbErr = tx.Where("id = ?", share.CreatedBy).First(&user).Error; dbErr == nil {
+				notificationType := events.FileActivityUpload
+				if share.RequireApproval {
+					notificationType = events.FileActivityPendingApproval
+				}
 				evt := events.NewFileActivityNotification(
-					s.Publisher, events.FileActivityUpload, events.FileActivitySourceShare,
+					s.Publisher, notificationType, events.FileActivitySourceShare,
 					share.BucketID, bucket.Name, file.Name, share.CreatedBy, user.Email,
 				)
 				evt.Trigger()
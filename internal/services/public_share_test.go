@@ -0,0 +1,50 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRequestShareAccessCode_RateLimitsNormalizedEmail(t *testing.T) {
+	db, owner := setupSQLiteTestDB(t)
+	mc := cache.NewMemoryCache()
+	t.Cleanup(mc.Close)
+	publisher := &capturePublisher{}
+	service := PublicShareService{DB: db, Cache: mc, Publisher: publisher}
+
+	bucket := models.Bucket{Name: "contracts", CreatedBy: owner.ID}
+	require.NoError(t, db.Create(&bucket).Error)
+	share := models.Share{
+		Name:                "signed",
+		BucketID:            bucket.ID,
+		Type:                models.ShareTypeBucket,
+		CreatedBy:           owner.ID,
+		RecipientRestricted: true,
+	}
+	require.NoError(t, db.Create(&share).Error)
+	require.NoError(t, db.Create(&models.ShareRecipient{ShareID: share.ID, Value: "bob@example.com"}).Error)
+
+	variants := []string{"bob@example.com", "Bob@example.com", "BOB@example.com", "bob@Example.com", "bob@EXAMPLE.COM"}
+	require.Len(t, variants, configuration.SecurityShareAccessMaxPerEmailPerHour)
+	for _, email := range variants {
+		_, err := service.RequestShareAccessCode(zap.NewNop(), share, uuid.UUIDs{share.ID},
+			models.ShareOTPRequestBody{Email: email})
+		require.NoError(t, err)
+	}
+	assert.Len(t, publisher.messages, len(variants), "every variant matches the recipient")
+
+	_, err := service.RequestShareAccessCode(zap.NewNop(), share, uuid.UUIDs{share.ID},
+		models.ShareOTPRequestBody{Email: strings.ToUpper("bob@example.com")})
+	requireAPIError(t, err, http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded)
+}
@@ -43,9 +43,13 @@ func (s SessionService) maxAge() time.Duration {
 }
 
 type SessionResponse struct {
-	ID        string `json:"id"`
-	IsCurrent bool   `json:"is_current"`
-	CreatedAt string `json:"created_at"`
+	ID           string `json:"id"`
+	IsCurrent    bool   `json:"is_current"`
+	CreatedAt    string `json:"created_at"`
+	LastSeenAt   string `json:"last_seen_at,omitempty"`
+	IP           string `json:"ip,omitempty"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	AuthProvider string `json:"auth_provider,omitempty"`
 }
 
 type SessionListResponse struct {
@@ -67,11 +71,27 @@ func (s SessionService) ListSessions(
 
 	resp := SessionListResponse{Sessions: make([]SessionResponse, 0, len(sessions))}
 	for _, sess := range sessions {
-		resp.Sessions = append(resp.Sessions, SessionResponse{
+		session := SessionResponse{
 			ID:        sess.SID,
 			IsCurrent: sess.SID == claims.SID,
 			CreatedAt: sess.CreatedAt.Format(time.RFC3339),
-		})
+		}
+
+		// Sessions issued before metadata was recorded are listed without client details.
+		meta, found, metaErr := cache.GetSessionMetadata(s.Cache, userID.String(), sess.SID)
+		if metaErr != nil {
+			logger.Warn("Failed to read session metadata", zap.Error(metaErr), zap.String("sid", sess.SID))
+		}
+		if found {
+			session.IP = meta.IP
+			session.UserAgent = meta.UserAgent
+			session.AuthProvider = meta.AuthProvider
+			if !meta.LastSeenAt.IsZero() {
+				session.LastSeenAt = meta.LastSeenAt.Format(time.RFC3339)
+			}
+		}
+
+		resp.Sessions = append(resp.Sessions, session)
 	}
 	return resp, nil
 }
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// sessionStarter registers freshly issued sessions. Every login path goes through it so the
+// session inventory knows which client holds each session and new-login alerts fire uniformly.
+type sessionStarter struct {
+	DB        *gorm.DB
+	Cache     cache.ICache
+	Publisher messaging.IPublisher
+	WebURL    string
+	// Lifetime is the configured refresh token expiry; the session metadata expires with it.
+	Lifetime time.Duration
+}
+
+func (s sessionStarter) start(logger *zap.Logger, user *models.User, sid string, client models.ClientInfo) error {
+	if err := cache.CreateSession(s.Cache, user.ID.String(), sid); err != nil {
+		logger.Error("Failed to create session", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	now := time.Now().UTC()
+	meta := cache.SessionMetadata{
+		IP:           client.IP,
+		UserAgent:    client.UserAgent,
+		AuthProvider: user.ProviderKey,
+		LastSeenAt:   now,
+	}
+	if err := cache.SetSessionMetadata(s.Cache, user.ID.String(), sid, meta, s.Lifetime); err != nil {
+		logger.Warn("Failed to store session metadata", zap.Error(err))
+	}
+
+	s.recordLoginDevice(logger, user, client, now)
+	return nil
+}
+
+// recordLoginDevice remembers the client a user signed in from and publishes a new-login alert
+// the first time a known user shows up from an unseen IP and user agent combination. A user's
+// very first device is recorded silently.
+func (s sessionStarter) recordLoginDevice(
+	logger *zap.Logger, user *models.User, client models.ClientInfo, now time.Time,
+) {
+	if client.IP == "" && client.UserAgent == "" {
+		return
+	}
+
+	fingerprint := loginDeviceFingerprint(client)
+
+	var device models.UserLoginDevice
+	err := s.DB.Where("user_id = ? AND fingerprint = ?", user.ID, fingerprint).First(&device).Error
+	switch {
+	case err == nil:
+		if updateErr := s.DB.Model(&device).Update("last_seen_at", now).Error; updateErr != nil {
+			logger.Warn("Failed to update login device", zap.Error(updateErr))
+		}
+		return
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		logger.Warn("Failed to look up login device", zap.Error(err))
+		return
+	}
+
+	var known int64
+	if countErr := s.DB.Model(&models.UserLoginDevice{}).Where("user_id = ?", user.ID).
+		Count(&known).Error; countErr != nil {
+		logger.Warn("Failed to count login devices", zap.Error(countErr))
+		return
+	}
+
+	device = models.UserLoginDevice{
+		UserID:      user.ID,
+		Fingerprint: fingerprint,
+		IP:          client.IP,
+		UserAgent:   client.UserAgent,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+	if createErr := s.DB.Create(&device).Error; createErr != nil {
+		// A concurrent login from the same client already recorded it.
+		logger.Debug("Failed to record login device", zap.Error(createErr))
+		return
+	}
+
+	if known == 0 || s.Publisher == nil {
+		return
+	}
+
+	event := events.NewNewLoginDetected(
+		s.Publisher,
+		user.Email,
+		client.IP,
+		client.UserAgent,
+		user.ProviderKey,
+		now.Format(time.RFC1123),
+		s.WebURL,
+	)
+	event.Trigger()
+}
+
+func loginDeviceFingerprint(client models.ClientInfo) string {
+	sum := sha256.Sum256([]byte(client.IP + "\n" + client.UserAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+func newSessionStarter(db *gorm.DB, c cache.ICache, publisher messaging.IPublisher, config models.AuthConfig) sessionStarter {
+	return sessionStarter{
+		DB:        db,
+		Cache:     c,
+		Publisher: publisher,
+		WebURL:    config.WebURL,
+		Lifetime:  time.Duration(config.RefreshTokenExpiry) * time.Minute,
+	}
+}
+
+func (s AuthService) sessionStarter() sessionStarter {
+	return newSessionStarter(s.DB, s.Cache, s.Publisher, s.AuthConfig)
+}
+
+func (s MFAService) sessionStarter() sessionStarter {
+	return newSessionStarter(s.DB, s.Cache, s.Publisher, s.AuthConfig)
+}
+
+func (s AuthPasswordResetService) sessionStarter() sessionStarter {
+	return newSessionStarter(s.DB, s.Cache, s.Publisher, s.AuthConfig)
+}
+
+func (s InviteService) sessionStarter() sessionStarter {
+	return newSessionStarter(s.DB, s.Cache, s.Publisher, s.AuthConfig)
+}
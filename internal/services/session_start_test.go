@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
+	"github.com/safebucket/safebucket/internal/database"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/helpers"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type capturePublisher struct {
+	messages []*message.Message
+}
+
+func (p *capturePublisher) Publish(messages ...*message.Message) error {
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *capturePublisher) Close() error { return nil }
+
+// setupSQLiteTestDB returns a migrated in-memory database holding a single local user.
+func setupSQLiteTestDB(t *testing.T) (*gorm.DB, *models.User) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	database.RunMigrations(sqlDB, database.DialectSQLite)
+	database.RegisterCallbacks(db)
+
+	user := &models.User{
+		Email:        "jane@example.com",
+		ProviderType: models.LocalProviderType,
+		ProviderKey:  "local",
+		Role:         models.RoleUser,
+	}
+	require.NoError(t, db.Create(user).Error)
+	return db, user
+}
+
+// authTestFixture holds what the login, account and session tests share: a migrated database
+// with a local user, a memory cache and a publisher capturing the emitted events.
+type authTestFixture struct {
+	DB        *gorm.DB
+	Cache     *cache.MemoryCache
+	Publisher *capturePublisher
+	User      *models.User
+}
+
+// newAuthTestFixture sets up the fixture; a non-empty password is hashed onto the user.
+func newAuthTestFixture(t *testing.T, password string) authTestFixture {
+	t.Helper()
+
+	db, user := setupSQLiteTestDB(t)
+	if password != "" {
+		hash, err := helpers.CreateHash(password)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(user).Update("hashed_password", hash).Error)
+	}
+
+	mc := cache.NewMemoryCache()
+	t.Cleanup(func() { mc.Close() })
+
+	return authTestFixture{DB: db, Cache: mc, Publisher: &capturePublisher{}, User: user}
+}
+
+func newSessionStarterTest(t *testing.T) (sessionStarter, *capturePublisher, *models.User) {
+	t.Helper()
+
+	f := newAuthTestFixture(t, "")
+	config := models.AuthConfig{WebURL: "https://safebucket.example", RefreshTokenExpiry: 30}
+	return newSessionStarter(f.DB, f.Cache, f.Publisher, config), f.Publisher, f.User
+}
+
+func TestSessionStarter_StoresMetadata(t *testing.T) {
+	starter, _, user := newSessionStarterTest(t)
+	client := models.ClientInfo{IP: "203.0.113.7", UserAgent: "Firefox"}
+
+	require.NoError(t, starter.start(zap.NewNop(), user, "sid-1", client))
+
+	meta, found, err := cache.GetSessionMetadata(starter.Cache, user.ID.String(), "sid-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "203.0.113.7", meta.IP)
+	assert.Equal(t, "Firefox", meta.UserAgent)
+	assert.Equal(t, "local", meta.AuthProvider)
+	assert.False(t, meta.LastSeenAt.IsZero())
+
+	ttl, err := starter.Cache.TTL(fmt.Sprintf(configuration.CacheUserSessionMetaKey, user.ID.String(), "sid-1"))
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 29*time.Minute, "the metadata lives as long as the configured refresh token")
+	assert.LessOrEqual(t, ttl, 30*time.Minute)
+}
+
+func TestSessionStarter_AlertsOnlyOnNewDevice(t *testing.T) {
+	starter, publisher, user := newSessionStarterTest(t)
+	laptop := models.ClientInfo{IP: "203.0.113.7", UserAgent: "Firefox"}
+	phone := models.ClientInfo{IP: "198.51.100.1", UserAgent: "Safari"}
+
+	// The first device a user signs in from is recorded without an alert.
+	require.NoError(t, starter.start(zap.NewNop(), user, "sid-1", laptop))
+	assert.Empty(t, publisher.messages)
+
+	require.NoError(t, starter.start(zap.NewNop(), user, "sid-2", laptop))
+	assert.Empty(t, publisher.messages)
+
+	require.NoError(t, starter.start(zap.NewNop(), user, "sid-3", phone))
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, events.NewLoginDetectedName, publisher.messages[0].Metadata.Get("type"))
+
+	var devices int64
+	require.NoError(t, starter.DB.Model(&models.UserLoginDevice{}).Where("user_id = ?", user.ID).Count(&devices).Error)
+	assert.Equal(t, int64(2), devices)
+}
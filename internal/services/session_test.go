@@ -200,3 +200,36 @@ func TestRevokeOtherSessions_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, active)
 }
+
+func TestListSessions_IncludesMetadata(t *testing.T) {
+	svc, mc := newSessionService(t)
+	userID := uuid.New()
+
+	sid := uuid.New().String()
+	legacySID := uuid.New().String()
+	require.NoError(t, cache.CreateSession(mc, userID.String(), sid))
+	require.NoError(t, cache.CreateSession(mc, userID.String(), legacySID))
+	require.NoError(t, cache.SetSessionMetadata(mc, userID.String(), sid, cache.SessionMetadata{
+		IP:           "203.0.113.7",
+		UserAgent:    "Firefox",
+		AuthProvider: "local",
+		LastSeenAt:   time.Now().UTC(),
+	}, time.Hour))
+
+	claims := models.UserClaims{UserID: userID, SID: sid}
+	resp, err := svc.ListSessions(zap.NewNop(), claims, uuid.UUIDs{userID})
+	require.NoError(t, err)
+	require.Len(t, resp.Sessions, 2)
+
+	for _, s := range resp.Sessions {
+		if s.ID == sid {
+			assert.Equal(t, "203.0.113.7", s.IP)
+			assert.Equal(t, "Firefox", s.UserAgent)
+			assert.Equal(t, "local", s.AuthProvider)
+			assert.NotEmpty(t, s.LastSeenAt)
+		} else {
+			assert.Empty(t, s.IP)
+			assert.Empty(t, s.LastSeenAt)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/safebucket/safebucket/internal/activity"
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/e2ee"
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/handlers"
 	h "github.com/safebucket/safebucket/internal/helpers"
@@ -13,10 +15,10 @@ import (
 	m "github.com/safebucket/safebucket/internal/middlewares"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/notifier"
+	"github.com/safebucket/safebucket/internal/password"
 	"github.com/safebucket/safebucket/internal/rbac"
 	"github.com/safebucket/safebucket/internal/sql"
 
-	"github.com/alexedwards/argon2id"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -31,6 +33,7 @@ type UserService struct {
 	Notifier           notifier.INotifier
 	ActivityLogger     activity.IActivityLogger
 	RefreshTokenExpiry int
+	PasswordPolicy     password.Policy
 }
 
 func (s UserService) Routes() chi.Router {
@@ -52,6 +55,9 @@ func (s UserService) Routes() chi.Router {
 		r.With(m.AuthorizeRole(models.RoleAdmin)).
 			Delete("/", handlers.DeleteHandler(s.DeleteUser))
 
+		r.With(m.AuthorizeRole(models.RoleAdmin)).
+			Post("/unlock", handlers.ActionHandler(s.UnlockUser))
+
 		r.With(m.AuthorizeSelfOrAdmin(0)).
 			Get("/stats", handlers.GetOneHandler(s.GetUserStats))
 
@@ -81,6 +87,10 @@ func (s UserService) CreateUser(
 
 	result := s.DB.Where("email = ?", newUser.Email).Find(&newUser)
 	if result.RowsAffected == 0 {
+		if policyErr := s.PasswordPolicy.Validate(body.Password, body.Email); policyErr != nil {
+			return models.User{}, policyErr
+		}
+
 		hash, err := h.CreateHash(body.Password)
 		if err != nil {
 			return models.User{}, apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
@@ -113,14 +123,17 @@ func (s UserService) CreateUser(
 	return models.User{}, apierrors.New(http.StatusConflict, apierrors.CodeUserAlreadyExists)
 }
 
-func (s UserService) GetUserList(_ *zap.Logger, _ models.UserClaims, _ uuid.UUIDs) []models.User {
+func (s UserService) GetUserList(logger *zap.Logger, _ models.UserClaims, _ uuid.UUIDs) []models.User {
 	var users []models.User
 	s.DB.Find(&users)
+	for i := range users {
+		s.fillLockout(logger, &users[i])
+	}
 	return users
 }
 
 func (s UserService) GetUser(
-	_ *zap.Logger,
+	logger *zap.Logger,
 	_ models.UserClaims,
 	ids uuid.UUIDs,
 ) (models.User, error) {
@@ -129,56 +142,74 @@ func (s UserService) GetUser(
 	if result.RowsAffected == 0 {
 		return user, apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
 	}
+	s.fillLockout(logger, &user)
 	return user, nil
 }
 
+// fillLockout reports until when failed sign-ins keep the account locked out.
+func (s UserService) fillLockout(logger *zap.Logger, user *models.User) {
+	if s.Cache == nil {
+		return
+	}
+	remaining, err := cache.LoginLockRemaining(s.Cache, cache.LoginAccountSubject(user.ProviderKey, user.Email))
+	if err != nil {
+		logger.Warn("Failed to read account lockout", zap.Error(err))
+		return
+	}
+	if remaining > 0 {
+		lockedUntil := time.Now().UTC().Add(remaining)
+		user.LockedUntil = &lockedUntil
+	}
+}
+
+// UnlockUser lifts a login lockout before it expires and resets its escalation.
+func (s UserService) UnlockUser(logger *zap.Logger, claims models.UserClaims, ids uuid.UUIDs) error {
+	user, err := sql.GetUserByID(s.DB, ids[0])
+	if err != nil {
+		return err
+	}
+
+	if err = cache.UnlockLogin(s.Cache, cache.LoginAccountSubject(user.ProviderKey, user.Email)); err != nil {
+		logger.Error("Failed to unlock user", zap.Error(err))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
+	}
+
+	action := models.Activity{
+		Message: activity.UserUnlocked,
+		Object:  user.ToActivity(),
+		Filter: activity.NewLogFilter(models.ActivityFields{
+			Action:     activity.UserUnlocked,
+			ObjectType: rbac.ResourceUser.String(),
+			UserID:     claims.UserID.String(),
+		}),
+	}
+	if logErr := s.ActivityLogger.Send(action); logErr != nil {
+		logger.Error("Failed to log user unlock activity", zap.Error(logErr))
+	}
+	return nil
+}
+
 func (s UserService) UpdateUser(
-	_ *zap.Logger,
+	logger *zap.Logger,
 	_ models.UserClaims,
 	ids uuid.UUIDs,
 	body models.UserUpdateBody,
 ) error {
 	user := models.User{ID: ids[0]}
+	result := s.DB.Where(user, "id").Find(&user)
+	if result.RowsAffected == 0 {
+		return apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
+	}
 
 	updatedUser := models.User{
 		FirstName: body.FirstName,
 		LastName:  body.LastName,
 	}
 
-	if body.OldPassword != "" && body.NewPassword != "" {
-		loaded, err := sql.GetUserByID(s.DB, ids[0])
-		if err != nil {
-			return err
-		}
-		if loaded.ProviderType != models.LocalProviderType {
-			return apierrors.New(http.StatusForbidden, apierrors.CodePasswordChangeNotAllowed)
-		}
-		user = loaded
-
-		match, err := argon2id.ComparePasswordAndHash(body.OldPassword, user.HashedPassword)
-		if err != nil {
-			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
-		}
-		if !match {
-			return apierrors.New(http.StatusUnauthorized, apierrors.CodeIncorrectPassword)
-		}
-
-		hash, err := h.CreateHash(body.NewPassword)
-		if err != nil {
-			return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
-		}
-
-		updatedUser.HashedPassword = hash
-	} else {
-		result := s.DB.Where(user, "id").Find(&user)
-		if result.RowsAffected == 0 {
-			return apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
-		}
-	}
-
-	result := s.DB.Model(&user).Updates(updatedUser)
-	if result.RowsAffected == 0 {
-		return apierrors.New(http.StatusNotFound, apierrors.CodeUserNotFound)
+	result = s.DB.Model(&user).Updates(updatedUser)
+	if result.Error != nil {
+		logger.Error("Failed to update user", zap.Error(result.Error))
+		return apierrors.New(http.StatusInternalServerError, apierrors.CodeInternalServerError)
 	}
 	return nil
 }
@@ -209,6 +240,15 @@ func (s UserService) DeleteUser(logger *zap.Logger, user models.UserClaims, ids
 			return result.Error
 		}
 
+		if err := e2ee.RevokeUser(tx, userID); err != nil {
+			logger.Error(
+				"Failed to revoke user bucket keys",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+			return err
+		}
+
 		result = tx.Where("user_id = ?", userID).Delete(&models.Membership{})
 		if result.Error != nil {
 			logger.Error(
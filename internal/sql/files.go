@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func GetFileByID(db *gorm.DB, bucketID uuid.UUID, fileID uuid.UUID) (models.File, error) {
@@ -39,7 +40,8 @@ func GetSharedFilesByHour(db *gorm.DB, days int) []models.TimeSeriesPoint {
 		Where("status = ?", models.FileStatusUploaded).
 		Where("files.created_at >= ?", startDate).
 		Group(hourExpr).
-		Order("timestamp ASC").
+		// The alias is quoted through the dialect because "timestamp" is a keyword in MySQL.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "timestamp"}}).
 		Scan(&result)
 
 	return result
@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListPasswordHashes returns up to limit replaced password hashes of a user, newest first.
+func ListPasswordHashes(db *gorm.DB, userID uuid.UUID, limit int) ([]string, error) {
+	var hashes []string
+	if limit <= 0 {
+		return hashes, nil
+	}
+	err := db.Model(&models.UserPasswordHash{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("hashed_password", &hashes).Error
+	return hashes, err
+}
+
+// ArchivePasswordHash records a replaced password hash and trims the user's history to the
+// keep most recent entries. A keep of zero clears the history.
+func ArchivePasswordHash(db *gorm.DB, userID uuid.UUID, hash string, keep int) error {
+	if keep > 0 && hash != "" {
+		entry := models.UserPasswordHash{UserID: userID, HashedPassword: hash}
+		if err := db.Create(&entry).Error; err != nil {
+			return err
+		}
+	}
+
+	// Histories are a handful of rows, so trim in Go rather than relying on OFFSET without LIMIT,
+	// which MySQL rejects.
+	var ids []uuid.UUID
+	if err := db.Model(&models.UserPasswordHash{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) <= keep {
+		return nil
+	}
+	return db.Where("id IN ?", ids[keep:]).Delete(&models.UserPasswordHash{}).Error
+}
@@ -3,6 +3,7 @@ package sql
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	apierrors "github.com/safebucket/safebucket/internal/errors"
 	"github.com/safebucket/safebucket/internal/models"
@@ -60,7 +61,9 @@ func CreateUserWithInvites(
 		}
 
 		var invites []models.Invite
-		if err := tx.Preload("Bucket").Where("email = ?", user.Email).Find(&invites).Error; err != nil {
+		if err := tx.Preload("Bucket").
+			Where("email = ? AND (expires_at IS NULL OR expires_at > ?)", user.Email, time.Now()).
+			Find(&invites).Error; err != nil {
 			logger.Error("Failed to fetch user invites", zap.Error(err))
 			return err
 		}
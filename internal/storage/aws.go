@@ -25,6 +25,7 @@ type AWSStorage struct {
 	BucketName string
 	storage    *s3.Client
 	presigner  *s3.PresignClient
+	objectLock bool
 }
 
 func NewAWSStorage(bucketName string) IStorage {
@@ -46,7 +47,27 @@ func NewAWSStorage(bucketName string) IStorage {
 
 	presigner := s3.NewPresignClient(client)
 
-	return AWSStorage{BucketName: bucketName, storage: client, presigner: presigner}
+	return AWSStorage{
+		BucketName: bucketName,
+		storage:    client,
+		presigner:  presigner,
+		objectLock: awsObjectLockEnabled(client, bucketName),
+	}
+}
+
+// awsObjectLockEnabled reports whether Object Lock is enabled on the bucket. Buckets without
+// an Object Lock configuration return an error, which is treated as unsupported.
+func awsObjectLockEnabled(client *s3.Client, bucketName string) bool {
+	result, err := client.GetObjectLockConfiguration(context.Background(), &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		zap.L().Debug("Object lock is not available on bucket",
+			zap.String("bucketName", bucketName), zap.Error(err))
+		return false
+	}
+	return result.ObjectLockConfiguration != nil &&
+		result.ObjectLockConfiguration.ObjectLockEnabled == types.ObjectLockEnabledEnabled
 }
 
 func (a AWSStorage) GetBucketName() string {
@@ -57,7 +78,13 @@ func (a AWSStorage) PresignUpload(
 	objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
+	if len(encryption.CustomerKey) > 0 {
+		return PresignedUpload{}, ErrEncryptionNotSupported
+	}
+	sse, kmsKeyID := awsServerSide(encryption)
+
 	ctx := context.Background()
 	expires := c.UploadPolicyExpirationInMinutes * time.Minute
 
@@ -65,10 +92,12 @@ func (a AWSStorage) PresignUpload(
 		presigned, err := a.presigner.PresignPutObject(
 			ctx,
 			&s3.PutObjectInput{
-				Bucket:        aws.String(a.BucketName),
-				Key:           aws.String(objectPath),
-				ContentLength: aws.Int64(int64(size)),
-				Metadata:      metadata,
+				Bucket:               aws.String(a.BucketName),
+				Key:                  aws.String(objectPath),
+				ContentLength:        aws.Int64(int64(size)),
+				Metadata:             metadata,
+				ServerSideEncryption: sse,
+				SSEKMSKeyId:          kmsKeyID,
 			},
 			s3.WithPresignExpires(expires),
 		)
@@ -100,9 +129,11 @@ func (a AWSStorage) PresignUpload(
 	}
 
 	created, err := a.storage.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
-		Bucket:   aws.String(a.BucketName),
-		Key:      aws.String(objectPath),
-		Metadata: metadata,
+		Bucket:               aws.String(a.BucketName),
+		Key:                  aws.String(objectPath),
+		Metadata:             metadata,
+		ServerSideEncryption: sse,
+		SSEKMSKeyId:          kmsKeyID,
 	})
 	if err != nil {
 		return PresignedUpload{}, err
@@ -141,10 +172,80 @@ func (a AWSStorage) PresignUpload(
 	}, nil
 }
 
+// awsServerSide maps a KMS key to the SSE-KMS request fields. Objects keep the bucket default
+// encryption when no key is set.
+func awsServerSide(encryption ObjectEncryption) (types.ServerSideEncryption, *string) {
+	if encryption.KeyID == "" {
+		return "", nil
+	}
+	return types.ServerSideEncryptionAwsKms, aws.String(encryption.KeyID)
+}
+
+// ValidateEncryption only accepts SSE-KMS keys. The key is checked by writing an empty probe
+// object, so unknown keys and missing kms:GenerateDataKey permissions fail early.
+func (a AWSStorage) ValidateEncryption(encryption ObjectEncryption) error {
+	if len(encryption.CustomerKey) > 0 {
+		return ErrEncryptionNotSupported
+	}
+	sse, kmsKeyID := awsServerSide(encryption)
+
+	ctx := context.Background()
+	probePath := encryptionProbePath()
+	if _, err := a.storage.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(a.BucketName),
+		Key:                  aws.String(probePath),
+		Body:                 bytes.NewReader(nil),
+		ServerSideEncryption: sse,
+		SSEKMSKeyId:          kmsKeyID,
+	}); err != nil {
+		return err
+	}
+
+	if err := a.RemoveObject(probePath); err != nil {
+		zap.L().Warn("Failed to remove encryption probe object", zap.String("path", probePath), zap.Error(err))
+	}
+	return nil
+}
+
 func (a AWSStorage) SupportsMultipart() bool {
 	return true
 }
 
+func (a AWSStorage) SupportsObjectLock() bool {
+	return a.objectLock
+}
+
+func (a AWSStorage) SetObjectLock(path string, lock ObjectLock) error {
+	ctx := context.Background()
+
+	status := types.ObjectLockLegalHoldStatusOff
+	if lock.LegalHold {
+		status = types.ObjectLockLegalHoldStatusOn
+	}
+	_, err := a.storage.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+		Bucket:    aws.String(a.BucketName),
+		Key:       aws.String(path),
+		LegalHold: &types.ObjectLockLegalHold{Status: status},
+	})
+	if err != nil {
+		return err
+	}
+
+	if lock.RetainUntil == nil {
+		return nil
+	}
+
+	_, err = a.storage.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+		Bucket: aws.String(a.BucketName),
+		Key:    aws.String(path),
+		Retention: &types.ObjectLockRetention{
+			Mode:            types.ObjectLockRetentionModeCompliance,
+			RetainUntilDate: lock.RetainUntil,
+		},
+	})
+	return err
+}
+
 func (a AWSStorage) ListObjectParts(objectPath, uploadID string) ([]PartInfo, error) {
 	ctx := context.Background()
 
@@ -180,7 +281,7 @@ func (a AWSStorage) ListObjectParts(objectPath, uploadID string) ([]PartInfo, er
 }
 
 func (a AWSStorage) CompleteMultipartUpload(
-	objectPath, uploadID string, parts []PartInfo, _ map[string]string,
+	objectPath, uploadID string, parts []PartInfo, _ map[string]string, _ ObjectEncryption,
 ) error {
 	completeParts := make([]types.CompletedPart, len(parts))
 	for i, part := range parts {
@@ -213,7 +314,7 @@ func (a AWSStorage) AbortMultipartUpload(objectPath, uploadID string) error {
 	return err
 }
 
-func (a AWSStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error) {
+func (a AWSStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error) {
 	req := &s3.GetObjectInput{
 		Bucket: aws.String(a.BucketName),
 		Key:    aws.String(objectPath),
@@ -231,13 +332,13 @@ func (a AWSStorage) PresignedGetObject(objectPath string, opts GetObjectOptions)
 		s3.WithPresignExpires(c.UploadPolicyExpirationInMinutes*time.Minute),
 	)
 	if err != nil {
-		return "", err
+		return PresignedDownload{}, err
 	}
 
-	return resp.URL, nil
+	return PresignedDownload{URL: resp.URL}, nil
 }
 
-func (a AWSStorage) StatObject(path string) (map[string]string, error) {
+func (a AWSStorage) StatObject(path string, _ ObjectEncryption) (map[string]string, error) {
 	file, err := a.storage.HeadObject(context.Background(), &s3.HeadObjectInput{
 		Bucket: aws.String(a.BucketName),
 		Key:    aws.String(path),
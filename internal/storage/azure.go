@@ -41,6 +41,7 @@ type AzureStorage struct {
 	cred           *blob.SharedKeyCredential
 	signer         *azureSharedKeySigner
 	armCred        azcore.TokenCredential
+	objectLock     bool
 }
 
 func NewAzureStorage(config *models.AzureConfiguration) IStorage {
@@ -62,11 +63,14 @@ func NewAzureStorage(config *models.AzureConfiguration) IStorage {
 		zap.L().Fatal("Failed to create Azure container client", zap.Error(err))
 	}
 
-	if _, err = containerClient.GetProperties(context.Background(), nil); err != nil {
+	props, err := containerClient.GetProperties(context.Background(), nil)
+	if err != nil {
 		zap.L().Fatal("Failed to connect to storage or container does not exist",
 			zap.String("container", config.ContainerName), zap.Error(err))
 	}
 
+	immutable := props.IsImmutableStorageWithVersioningEnabled != nil && *props.IsImmutableStorageWithVersioningEnabled
+
 	signer, err := newAzureSharedKeySigner(config.AccountName, accountKey)
 	if err != nil {
 		zap.L().Fatal("Failed to create Azure shared key signer", zap.Error(err))
@@ -82,6 +86,7 @@ func NewAzureStorage(config *models.AzureConfiguration) IStorage {
 		cred:           cred,
 		signer:         signer,
 		armCred:        armCred,
+		objectLock:     immutable,
 	}
 }
 
@@ -110,16 +115,25 @@ func (a *AzureStorage) PresignUpload(
 	objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
+	if len(encryption.CustomerKey) > 0 {
+		return PresignedUpload{}, ErrEncryptionNotSupported
+	}
 	if int64(size) <= c.MultipartPartSize {
-		return a.presignSinglePut(objectPath, size, metadata), nil
+		return a.presignSinglePut(objectPath, size, metadata, encryption.KeyID), nil
 	}
-	return a.presignMultipart(objectPath, size), nil
+	return a.presignMultipart(objectPath, size, encryption.KeyID), nil
 }
 
-func (a *AzureStorage) presignSinglePut(objectPath string, size int, metadata map[string]string) PresignedUpload {
+func (a *AzureStorage) presignSinglePut(
+	objectPath string, size int, metadata map[string]string, scope string,
+) PresignedUpload {
 	extraHeaders := azureMetadataHeaders(metadata)
 	extraHeaders[azureHeaderBlobType] = azureBlobTypeBlock
+	if scope != "" {
+		extraHeaders[azureHeaderScope] = scope
+	}
 
 	const contentType = "application/octet-stream"
 	headers := a.signer.headersForPut(a.containerName, objectPath, url.Values{}, int64(size), contentType, extraHeaders)
@@ -132,15 +146,22 @@ func (a *AzureStorage) presignSinglePut(objectPath string, size int, metadata ma
 	}}
 }
 
-func (a *AzureStorage) presignMultipart(objectPath string, size int) PresignedUpload {
+// presignMultipart signs one Put Block request per part. Blocks must be staged with the same
+// encryption scope the block list is later committed with.
+func (a *AzureStorage) presignMultipart(objectPath string, size int, scope string) PresignedUpload {
 	partSize, partCount := ComputeMultipartLayout(int64(size))
 
+	var extraHeaders map[string]string
+	if scope != "" {
+		extraHeaders = map[string]string{azureHeaderScope: scope}
+	}
+
 	parts := make([]models.FilePartURL, 0, partCount)
 	for partNumber := 1; partNumber <= partCount; partNumber++ {
 		expected := ExpectedPartSize(int64(size), partSize, partNumber, partCount)
 
 		query := url.Values{"comp": {"block"}, "blockid": {azureBlockID(partNumber)}}
-		headers := a.signer.headersForPut(a.containerName, objectPath, query, expected, "", nil)
+		headers := a.signer.headersForPut(a.containerName, objectPath, query, expected, "", extraHeaders)
 
 		parts = append(parts, models.FilePartURL{
 			ID:      partNumber,
@@ -203,6 +224,29 @@ func (a *AzureStorage) SupportsMultipart() bool {
 	return true
 }
 
+func (a *AzureStorage) SupportsObjectLock() bool {
+	return a.objectLock
+}
+
+// SetObjectLock requires version-level immutability on the container. Locked retention
+// periods become locked immutability policies, which can only be extended once set.
+func (a *AzureStorage) SetObjectLock(path string, lock ObjectLock) error {
+	ctx := context.Background()
+	client := a.blobClient(path)
+
+	if _, err := client.SetLegalHold(ctx, lock.LegalHold, nil); err != nil {
+		return err
+	}
+
+	if lock.RetainUntil == nil {
+		return nil
+	}
+
+	mode := blob.ImmutabilityPolicySettingLocked
+	_, err := client.SetImmutabilityPolicy(ctx, *lock.RetainUntil, &blob.SetImmutabilityPolicyOptions{Mode: &mode})
+	return err
+}
+
 func (a *AzureStorage) ListObjectParts(objectPath, _ string) ([]PartInfo, error) {
 	resp, err := a.blockBlobClient(objectPath).
 		GetBlockList(context.Background(), blockblob.BlockListTypeUncommitted, nil)
@@ -226,7 +270,7 @@ func (a *AzureStorage) ListObjectParts(objectPath, _ string) ([]PartInfo, error)
 }
 
 func (a *AzureStorage) CompleteMultipartUpload(
-	objectPath, _ string, parts []PartInfo, metadata map[string]string,
+	objectPath, _ string, parts []PartInfo, metadata map[string]string, encryption ObjectEncryption,
 ) error {
 	sorted := append([]PartInfo(nil), parts...)
 	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PartNumber < sorted[j].PartNumber })
@@ -242,9 +286,12 @@ func (a *AzureStorage) CompleteMultipartUpload(
 
 	ctx := context.Background()
 
-	if _, commitErr := a.blockBlobClient(objectPath).CommitBlockList(
-		ctx, blockIDs, &blockblob.CommitBlockListOptions{Metadata: commitMetadata},
-	); commitErr != nil {
+	commitOptions := &blockblob.CommitBlockListOptions{Metadata: commitMetadata}
+	if encryption.KeyID != "" {
+		commitOptions.CPKScopeInfo = &blob.CPKScopeInfo{EncryptionScope: to.Ptr(encryption.KeyID)}
+	}
+
+	if _, commitErr := a.blockBlobClient(objectPath).CommitBlockList(ctx, blockIDs, commitOptions); commitErr != nil {
 		return fmt.Errorf("commit block list: %w", commitErr)
 	}
 
@@ -263,7 +310,7 @@ func (a *AzureStorage) AbortMultipartUpload(_, _ string) error {
 	return nil
 }
 
-func (a *AzureStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error) {
+func (a *AzureStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error) {
 	values := sas.BlobSignatureValues{
 		ExpiryTime:    time.Now().UTC().Add(c.UploadPolicyExpirationInMinutes * time.Minute),
 		Permissions:   (&sas.BlobPermissions{Read: true}).String(),
@@ -280,13 +327,13 @@ func (a *AzureStorage) PresignedGetObject(objectPath string, opts GetObjectOptio
 
 	qp, err := values.SignWithSharedKey(a.cred)
 	if err != nil {
-		return "", err
+		return PresignedDownload{}, err
 	}
 
-	return a.blobURL(objectPath) + "?" + qp.Encode(), nil
+	return PresignedDownload{URL: a.blobURL(objectPath) + "?" + qp.Encode()}, nil
 }
 
-func (a *AzureStorage) StatObject(objectPath string) (map[string]string, error) {
+func (a *AzureStorage) StatObject(objectPath string, _ ObjectEncryption) (map[string]string, error) {
 	props, err := a.blobClient(objectPath).GetProperties(context.Background(), nil)
 	if err != nil {
 		return nil, err
@@ -328,6 +375,26 @@ func (a *AzureStorage) ListObjects(prefix string, maxKeys int32) ([]string, erro
 	return objects, nil
 }
 
+// ValidateEncryption only accepts encryption scopes. The scope is checked by writing an empty
+// probe blob, which fails when the scope does not exist or is disabled.
+func (a *AzureStorage) ValidateEncryption(encryption ObjectEncryption) error {
+	if len(encryption.CustomerKey) > 0 {
+		return ErrEncryptionNotSupported
+	}
+
+	probePath := encryptionProbePath()
+	if _, err := a.blockBlobClient(probePath).UploadBuffer(context.Background(), nil, &blockblob.UploadBufferOptions{
+		CPKScopeInfo: &blob.CPKScopeInfo{EncryptionScope: to.Ptr(encryption.KeyID)},
+	}); err != nil {
+		return err
+	}
+
+	if err := a.RemoveObject(probePath); err != nil {
+		zap.L().Warn("Failed to remove encryption probe object", zap.String("path", probePath), zap.Error(err))
+	}
+	return nil
+}
+
 func (a *AzureStorage) RemoveObject(objectPath string) error {
 	_, err := a.blobClient(objectPath).Delete(context.Background(), nil)
 	return err
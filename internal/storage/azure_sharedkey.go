@@ -19,6 +19,7 @@ const (
 	azureHeaderDate      = "x-ms-date"
 	azureHeaderVersion   = "x-ms-version"
 	azureHeaderBlobType  = "x-ms-blob-type"
+	azureHeaderScope     = "x-ms-encryption-scope"
 	azureBlobTypeBlock   = "BlockBlob"
 	azureMetaHeaderPfx   = "x-ms-meta-"
 	azureBlockIDDigits   = 32
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// encryptionProbePrefix holds the empty objects written when a bucket encryption key is
+// validated. They are removed right away and never reach the buckets/ or trash/ prefixes.
+const encryptionProbePrefix = "encryption-probe/"
+
+// ErrEncryptionNotSupported is returned when the storage provider cannot apply the requested
+// server-side encryption mode.
+var ErrEncryptionNotSupported = errors.New("server-side encryption mode not supported by storage provider")
+
+// ObjectEncryption selects the customer-managed key the objects of a bucket are encrypted with.
+// KeyID names a key held by the provider: an SSE-KMS key on AWS and S3-compatible stores, a Cloud
+// KMS key on GCP and an encryption scope on Azure. CustomerKey is a 256-bit SSE-C key sent with
+// every request and never kept by the provider. The zero value keeps the provider default.
+type ObjectEncryption struct {
+	KeyID       string
+	CustomerKey []byte
+}
+
+func (e ObjectEncryption) IsZero() bool {
+	return e.KeyID == "" && len(e.CustomerKey) == 0
+}
+
+// PresignedDownload is a presigned GET request. Headers must be sent along with it, which is
+// only the case for objects encrypted with a customer key.
+type PresignedDownload struct {
+	URL     string
+	Headers map[string]string
+}
+
+func encryptionProbePath() string {
+	return encryptionProbePrefix + uuid.NewString()
+}
+
+// minioServerSide converts the encryption to its minio counterpart, or nil for the default.
+func minioServerSide(encryption ObjectEncryption) (encrypt.ServerSide, error) {
+	switch {
+	case len(encryption.CustomerKey) > 0:
+		return encrypt.NewSSEC(encryption.CustomerKey)
+	case encryption.KeyID != "":
+		return encrypt.NewSSEKMS(encryption.KeyID, nil)
+	default:
+		return nil, nil
+	}
+}
+
+// minioCustomerKey returns the SSE-C settings every request on the object must repeat. SSE-KMS
+// is only set when the object is created, so it yields nil.
+func minioCustomerKey(encryption ObjectEncryption) (encrypt.ServerSide, error) {
+	if len(encryption.CustomerKey) == 0 {
+		return nil, nil
+	}
+	return encrypt.NewSSEC(encryption.CustomerKey)
+}
+
+func flattenHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	flat := make(map[string]string, len(header))
+	for key := range header {
+		flat[key] = header.Get(key)
+	}
+	return flat
+}
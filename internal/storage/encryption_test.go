@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3PresignEncryption(t *testing.T) {
+	signingClient, err := newSigningClient(signingClientOptions{
+		externalEndpoint: "http://localhost:9000",
+		accessKey:        "access",
+		secretKey:        "secret",
+		region:           "us-east-1",
+	})
+	require.NoError(t, err)
+
+	customerKey := ObjectEncryption{CustomerKey: bytes.Repeat([]byte{0x42}, 32)}
+	const customerKeyHeader = "X-Amz-Server-Side-Encryption-Customer-Key"
+
+	t.Run("kms uploads sign the key and downloads need no headers", func(t *testing.T) {
+		upload, presignErr := presignS3Upload(nil, signingClient, "safebucket", "buckets/b/f", 1024,
+			map[string]string{"bucket_id": "b"}, ObjectEncryption{KeyID: "alias/regulated"})
+		require.NoError(t, presignErr)
+
+		headers := upload.Response.Parts[0].Headers
+		assert.Equal(t, "aws:kms", headers["X-Amz-Server-Side-Encryption"])
+		assert.Equal(t, "alias/regulated", headers["X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"])
+		assert.Equal(t, "b", headers["X-Amz-Meta-Bucket-Id"])
+		assertSignedHeaders(t, upload.Response.Parts[0].URL, "x-amz-server-side-encryption-aws-kms-key-id")
+
+		download, presignErr := s3PresignedGetObject(signingClient, "safebucket", "buckets/b/f",
+			GetObjectOptions{Encryption: ObjectEncryption{KeyID: "alias/regulated"}})
+		require.NoError(t, presignErr)
+		assert.Empty(t, download.Headers)
+	})
+
+	t.Run("customer keys travel with uploads and downloads", func(t *testing.T) {
+		upload, presignErr := presignS3Upload(nil, signingClient, "safebucket", "buckets/b/f", 1024, nil, customerKey)
+		require.NoError(t, presignErr)
+		assert.NotEmpty(t, upload.Response.Parts[0].Headers[customerKeyHeader])
+		assertSignedHeaders(t, upload.Response.Parts[0].URL, "x-amz-server-side-encryption-customer-key")
+
+		download, presignErr := s3PresignedGetObject(signingClient, "safebucket", "buckets/b/f",
+			GetObjectOptions{DownloadFilename: "plan.pdf", Encryption: customerKey})
+		require.NoError(t, presignErr)
+		assert.Equal(t, upload.Response.Parts[0].Headers[customerKeyHeader], download.Headers[customerKeyHeader])
+		assert.Equal(t, "AES256", download.Headers["X-Amz-Server-Side-Encryption-Customer-Algorithm"])
+		assertSignedHeaders(t, download.URL, "x-amz-server-side-encryption-customer-key")
+	})
+
+	t.Run("customer keys must be 256 bits", func(t *testing.T) {
+		_, presignErr := presignS3Upload(nil, signingClient, "safebucket", "buckets/b/f", 1024, nil,
+			ObjectEncryption{CustomerKey: []byte("short")})
+		assert.Error(t, presignErr)
+	})
+}
+
+func assertSignedHeaders(t *testing.T, presignedURL, header string) {
+	t.Helper()
+
+	parsed, err := url.Parse(presignedURL)
+	require.NoError(t, err)
+	assert.Contains(t, parsed.Query().Get("X-Amz-SignedHeaders"), header)
+}
+
+func TestAzurePresignEncryptionScope(t *testing.T) {
+	store := &AzureStorage{
+		containerName: "safebucket",
+		endpoint:      "https://account.blob.core.windows.net",
+		signer:        &azureSharedKeySigner{accountName: "account", key: []byte("key")},
+	}
+
+	upload, err := store.PresignUpload("buckets/b/f", 1024, nil, ObjectEncryption{KeyID: "regulated-scope"})
+	require.NoError(t, err)
+	assert.Equal(t, "regulated-scope", upload.Response.Parts[0].Headers[azureHeaderScope])
+
+	_, err = store.PresignUpload("buckets/b/f", 1024, nil, ObjectEncryption{CustomerKey: []byte("key")})
+	assert.ErrorIs(t, err, ErrEncryptionNotSupported)
+}
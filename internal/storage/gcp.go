@@ -22,10 +22,14 @@ import (
 	"google.golang.org/api/iterator"
 )
 
+// gcpKMSKeyHeader sets the Cloud KMS key of an object on the XML API.
+const gcpKMSKeyHeader = "x-goog-encryption-kms-key-name"
+
 type GCPStorage struct {
 	BucketName   string
 	storage      *gcs.Client
 	authedClient *http.Client
+	objectLock   bool
 }
 
 func NewGCPStorage(bucketName string) IStorage {
@@ -36,7 +40,7 @@ func NewGCPStorage(bucketName string) IStorage {
 		zap.L().Fatal("Failed to create storage client", zap.Error(err))
 	}
 
-	_, err = client.Bucket(bucketName).Attrs(ctx)
+	attrs, err := client.Bucket(bucketName).Attrs(ctx)
 	if err != nil {
 		zap.L().Fatal("Failed to connect to storage or bucket does not exist",
 			zap.String("bucketName", bucketName),
@@ -52,6 +56,7 @@ func NewGCPStorage(bucketName string) IStorage {
 		BucketName:   bucketName,
 		storage:      client,
 		authedClient: authedClient,
+		objectLock:   attrs.ObjectRetentionMode == "Enabled",
 	}
 }
 
@@ -63,7 +68,12 @@ func (g GCPStorage) PresignUpload(
 	objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
+	if len(encryption.CustomerKey) > 0 {
+		return PresignedUpload{}, ErrEncryptionNotSupported
+	}
+
 	expires := time.Now().Add(c.UploadPolicyExpirationInMinutes * time.Minute)
 
 	headers := map[string]string{}
@@ -77,6 +87,10 @@ func (g GCPStorage) PresignUpload(
 			headers[header] = value
 		}
 	}
+	// Parts of a multipart upload inherit the key set when the upload is initiated.
+	if encryption.KeyID != "" {
+		headers[gcpKMSKeyHeader] = encryption.KeyID
+	}
 
 	if int64(size) <= c.MultipartPartSize {
 		// GCS ignores x-goog-content-length
@@ -154,6 +168,22 @@ func (g GCPStorage) SupportsMultipart() bool {
 	return true
 }
 
+func (g GCPStorage) SupportsObjectLock() bool {
+	return g.objectLock
+}
+
+// SetObjectLock maps legal holds to temporary holds and locked retention periods to
+// locked object retention, which GCS only allows on buckets with object retention enabled.
+func (g GCPStorage) SetObjectLock(path string, lock ObjectLock) error {
+	update := gcs.ObjectAttrsToUpdate{TemporaryHold: lock.LegalHold}
+	if lock.RetainUntil != nil {
+		update.Retention = &gcs.ObjectRetention{Mode: "Locked", RetainUntil: *lock.RetainUntil}
+	}
+
+	_, err := g.storage.Bucket(g.BucketName).Object(path).Update(context.Background(), update)
+	return err
+}
+
 func (g GCPStorage) objectURL(objectPath string) *url.URL {
 	return &url.URL{
 		Scheme: "https",
@@ -264,7 +294,7 @@ func (g GCPStorage) ListObjectParts(objectPath, uploadID string) ([]PartInfo, er
 }
 
 func (g GCPStorage) CompleteMultipartUpload(
-	objectPath, uploadID string, parts []PartInfo, _ map[string]string,
+	objectPath, uploadID string, parts []PartInfo, _ map[string]string, _ ObjectEncryption,
 ) error {
 	type completePart struct {
 		PartNumber int    `xml:"PartNumber"`
@@ -337,7 +367,7 @@ func (g GCPStorage) AbortMultipartUpload(objectPath, uploadID string) error {
 	return nil
 }
 
-func (g GCPStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error) {
+func (g GCPStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error) {
 	signOpts := &gcs.SignedURLOptions{
 		Method:  http.MethodGet,
 		Expires: time.Now().Add(c.UploadPolicyExpirationInMinutes * time.Minute),
@@ -353,10 +383,15 @@ func (g GCPStorage) PresignedGetObject(objectPath string, opts GetObjectOptions)
 		}
 	}
 
-	return g.storage.Bucket(g.BucketName).SignedURL(objectPath, signOpts)
+	signedURL, err := g.storage.Bucket(g.BucketName).SignedURL(objectPath, signOpts)
+	if err != nil {
+		return PresignedDownload{}, err
+	}
+
+	return PresignedDownload{URL: signedURL}, nil
 }
 
-func (g GCPStorage) StatObject(path string) (map[string]string, error) {
+func (g GCPStorage) StatObject(path string, _ ObjectEncryption) (map[string]string, error) {
 	file, err := g.storage.Bucket(g.BucketName).Object(path).Attrs(context.Background())
 	if err != nil {
 		return nil, err
@@ -365,6 +400,26 @@ func (g GCPStorage) StatObject(path string) (map[string]string, error) {
 	return file.Metadata, err
 }
 
+// ValidateEncryption only accepts Cloud KMS keys. The key is checked by writing an empty probe
+// object, which fails when the key does not exist or the service agent cannot use it.
+func (g GCPStorage) ValidateEncryption(encryption ObjectEncryption) error {
+	if len(encryption.CustomerKey) > 0 {
+		return ErrEncryptionNotSupported
+	}
+
+	probePath := encryptionProbePath()
+	writer := g.storage.Bucket(g.BucketName).Object(probePath).NewWriter(context.Background())
+	writer.KMSKeyName = encryption.KeyID
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	if err := g.RemoveObject(probePath); err != nil {
+		zap.L().Warn("Failed to remove encryption probe object", zap.String("path", probePath), zap.Error(err))
+	}
+	return nil
+}
+
 func (g GCPStorage) RemoveObject(path string) error {
 	return g.storage.Bucket(g.BucketName).Object(path).Delete(context.Background())
 }
@@ -20,6 +20,7 @@ const (
 type GetObjectOptions struct {
 	InlineContentType string
 	DownloadFilename  string
+	Encryption        ObjectEncryption
 }
 
 type PartInfo struct {
@@ -29,6 +30,14 @@ type PartInfo struct {
 	LastModified time.Time
 }
 
+// ObjectLock mirrors legal holds and locked retention periods on the stored object, so the
+// storage provider refuses deletions that bypass Safebucket. RetainUntil is applied in
+// compliance mode and can only be extended once set.
+type ObjectLock struct {
+	LegalHold   bool
+	RetainUntil *time.Time
+}
+
 type PresignedUpload struct {
 	Response models.FileUploadResponse
 	UploadID string
@@ -46,13 +55,18 @@ func attachmentDisposition(filename string) string {
 }
 
 type IStorage interface {
-	PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error)
-	PresignUpload(objectPath string, size int, metadata map[string]string) (PresignedUpload, error)
+	PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error)
+	PresignUpload(
+		objectPath string, size int, metadata map[string]string, encryption ObjectEncryption,
+	) (PresignedUpload, error)
 	SupportsMultipart() bool
 	ListObjectParts(path, uploadID string) ([]PartInfo, error)
-	CompleteMultipartUpload(path, uploadID string, parts []PartInfo, metadata map[string]string) error
+	CompleteMultipartUpload(
+		path, uploadID string, parts []PartInfo, metadata map[string]string, encryption ObjectEncryption,
+	) error
 	AbortMultipartUpload(path, uploadID string) error
-	StatObject(path string) (map[string]string, error)
+	StatObject(path string, encryption ObjectEncryption) (map[string]string, error)
+	ValidateEncryption(encryption ObjectEncryption) error
 	ListObjects(prefix string, maxKeys int32) ([]string, error)
 	RemoveObject(path string) error
 	RemoveObjects(paths []string) error
@@ -60,5 +74,7 @@ type IStorage interface {
 	MarkAsTrashed(objectPath string, model interface{}) error
 	UnmarkAsTrashed(objectPath string, model interface{}) error
 	IsTrashMarkerPath(path string) (isMarker bool, originalPath string)
+	SupportsObjectLock() bool
+	SetObjectLock(path string, lock ObjectLock) error
 	GetBucketName() string
 }
@@ -49,6 +49,7 @@ func FinalizeMultipartUpload(
 	objectPath, uploadID string,
 	partSize, fileSize int64,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) error {
 	parts, err := store.ListObjectParts(objectPath, uploadID)
 	if err != nil {
@@ -71,7 +72,7 @@ func FinalizeMultipartUpload(
 		return ErrMultipartPartMismatch
 	}
 
-	if err = store.CompleteMultipartUpload(objectPath, uploadID, parts, metadata); err != nil {
+	if err = store.CompleteMultipartUpload(objectPath, uploadID, parts, metadata, encryption); err != nil {
 		return fmt.Errorf("complete multipart upload: %w", err)
 	}
 
@@ -18,28 +18,37 @@ func (s *stubStorage) ListObjectParts(path, uploadID string) ([]PartInfo, error)
 	return s.listObjectPartsFn(path, uploadID)
 }
 
-func (s *stubStorage) CompleteMultipartUpload(path, uploadID string, parts []PartInfo, _ map[string]string) error {
+func (s *stubStorage) CompleteMultipartUpload(
+	path, uploadID string, parts []PartInfo, _ map[string]string, _ ObjectEncryption,
+) error {
 	if s.completeMultipartFn != nil {
 		return s.completeMultipartFn(path, uploadID, parts)
 	}
 	return nil
 }
 
-func (s *stubStorage) PresignedGetObject(string, GetObjectOptions) (string, error) { return "", nil }
-func (s *stubStorage) PresignUpload(string, int, map[string]string) (PresignedUpload, error) {
+func (s *stubStorage) PresignedGetObject(string, GetObjectOptions) (PresignedDownload, error) {
+	return PresignedDownload{}, nil
+}
+func (s *stubStorage) PresignUpload(string, int, map[string]string, ObjectEncryption) (PresignedUpload, error) {
 	return PresignedUpload{}, nil
 }
-func (s *stubStorage) SupportsMultipart() bool                      { return true }
-func (s *stubStorage) AbortMultipartUpload(string, string) error    { return nil }
-func (s *stubStorage) StatObject(string) (map[string]string, error) { return nil, nil }
-func (s *stubStorage) ListObjects(string, int32) ([]string, error)  { return nil, nil }
-func (s *stubStorage) RemoveObject(string) error                    { return nil }
-func (s *stubStorage) RemoveObjects([]string) error                 { return nil }
-func (s *stubStorage) EnsureTrashLifecyclePolicy(int) error         { return nil }
-func (s *stubStorage) MarkAsTrashed(string, interface{}) error      { return nil }
-func (s *stubStorage) UnmarkAsTrashed(string, interface{}) error    { return nil }
-func (s *stubStorage) IsTrashMarkerPath(string) (bool, string)      { return false, "" }
-func (s *stubStorage) GetBucketName() string                        { return "" }
+func (s *stubStorage) SupportsMultipart() bool                   { return true }
+func (s *stubStorage) AbortMultipartUpload(string, string) error { return nil }
+func (s *stubStorage) StatObject(string, ObjectEncryption) (map[string]string, error) {
+	return nil, nil
+}
+func (s *stubStorage) ValidateEncryption(ObjectEncryption) error   { return nil }
+func (s *stubStorage) ListObjects(string, int32) ([]string, error) { return nil, nil }
+func (s *stubStorage) RemoveObject(string) error                   { return nil }
+func (s *stubStorage) RemoveObjects([]string) error                { return nil }
+func (s *stubStorage) EnsureTrashLifecyclePolicy(int) error        { return nil }
+func (s *stubStorage) MarkAsTrashed(string, interface{}) error     { return nil }
+func (s *stubStorage) UnmarkAsTrashed(string, interface{}) error   { return nil }
+func (s *stubStorage) IsTrashMarkerPath(string) (bool, string)     { return false, "" }
+func (s *stubStorage) SupportsObjectLock() bool                    { return false }
+func (s *stubStorage) SetObjectLock(string, ObjectLock) error      { return nil }
+func (s *stubStorage) GetBucketName() string                       { return "" }
 
 func TestFinalizeMultipartUpload(t *testing.T) {
 	const mib = int64(1 << 20)
@@ -53,7 +62,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil, ObjectEncryption{})
 		assert.ErrorIs(t, err, ErrMultipartPartMismatch)
 	})
 
@@ -67,7 +76,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 64*mib, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 64*mib, nil, ObjectEncryption{})
 		assert.ErrorIs(t, err, ErrMultipartPartMismatch)
 	})
 
@@ -81,7 +90,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil, ObjectEncryption{})
 		assert.ErrorIs(t, err, ErrMultipartPartMismatch)
 	})
 
@@ -92,7 +101,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib, nil, ObjectEncryption{})
 		require.Error(t, err)
 		assert.NotErrorIs(t, err, ErrMultipartPartMismatch)
 	})
@@ -107,7 +116,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib, nil, ObjectEncryption{})
 		require.Error(t, err)
 		assert.NotErrorIs(t, err, ErrMultipartPartMismatch)
 	})
@@ -129,7 +138,7 @@ func TestFinalizeMultipartUpload(t *testing.T) {
 			},
 		}
 
-		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil)
+		err := FinalizeMultipartUpload(store, "path", testUploadID, testPartSize, 32*mib+1, nil, ObjectEncryption{})
 		require.NoError(t, err)
 		assert.True(t, completed)
 	})
@@ -4,12 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"net/url"
 	"path"
 	"strings"
-	"time"
 
-	c "github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/models"
 
 	"github.com/minio/minio-go/v7"
@@ -22,6 +19,7 @@ type RustFSStorage struct {
 	BucketName    string
 	storage       *minio.Client
 	signingClient *minio.Client
+	objectLock    bool
 }
 
 type s3Config struct {
@@ -80,6 +78,7 @@ func newS3Storage(cfg s3Config) IStorage {
 		BucketName:    bucketName,
 		storage:       minioClient,
 		signingClient: signingClient,
+		objectLock:    s3ObjectLockEnabled(minioClient, bucketName),
 	}
 }
 
@@ -111,69 +110,56 @@ func (s RustFSStorage) GetBucketName() string {
 	return s.BucketName
 }
 
-func (s RustFSStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error) {
-	var reqParams url.Values
-	if opts.InlineContentType != "" {
-		reqParams = url.Values{
-			respContentDisposition: []string{"inline"},
-			respContentType:        []string{opts.InlineContentType},
-		}
-	} else if opts.DownloadFilename != "" {
-		reqParams = url.Values{
-			respContentDisposition: []string{attachmentDisposition(opts.DownloadFilename)},
-		}
-	}
-
-	presignedURL, err := s.signingClient.PresignedGetObject(
-		context.Background(),
-		s.BucketName,
-		objectPath,
-		c.UploadPolicyExpirationInMinutes*time.Minute,
-		reqParams,
-	)
-	if err != nil {
-		return "", err
-	}
-
-	return presignedURL.String(), nil
+func (s RustFSStorage) PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error) {
+	return s3PresignedGetObject(s.signingClient, s.BucketName, objectPath, opts)
 }
 
 func (s RustFSStorage) PresignUpload(
 	objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
-	return presignS3Upload(s.storage, s.signingClient, s.BucketName, objectPath, size, metadata)
+	return presignS3Upload(s.storage, s.signingClient, s.BucketName, objectPath, size, metadata, encryption)
 }
 
 func (s RustFSStorage) SupportsMultipart() bool {
 	return true
 }
 
+func (s RustFSStorage) SupportsObjectLock() bool {
+	return s.objectLock
+}
+
+func (s RustFSStorage) SetObjectLock(path string, lock ObjectLock) error {
+	return s3SetObjectLock(s.storage, s.BucketName, path, lock)
+}
+
 func (s RustFSStorage) ListObjectParts(path, uploadID string) ([]PartInfo, error) {
 	return s3ListObjectParts(s.storage, s.BucketName, path, uploadID)
 }
 
-func (s RustFSStorage) CompleteMultipartUpload(path, uploadID string, parts []PartInfo, _ map[string]string) error {
-	return s3CompleteMultipartUpload(s.storage, s.BucketName, path, uploadID, parts)
+func (s RustFSStorage) CompleteMultipartUpload(
+	path, uploadID string, parts []PartInfo, _ map[string]string, encryption ObjectEncryption,
+) error {
+	return s3CompleteMultipartUpload(s.storage, s.BucketName, path, uploadID, parts, encryption)
 }
 
 func (s RustFSStorage) AbortMultipartUpload(path, uploadID string) error {
 	return s3AbortMultipartUpload(s.storage, s.BucketName, path, uploadID)
 }
 
-func (s RustFSStorage) StatObject(path string) (map[string]string, error) {
-	file, err := s.storage.StatObject(
-		context.Background(),
-		s.BucketName,
-		path,
-		minio.StatObjectOptions{},
-	)
-	if err != nil {
-		return nil, err
-	}
+func (s RustFSStorage) StatObject(path string, encryption ObjectEncryption) (map[string]string, error) {
+	return s3StatObject(s.storage, s.BucketName, path, encryption)
+}
 
-	return file.UserMetadata, err
+// ValidateEncryption only accepts SSE-KMS keys. SSE-C would require the customer key for the
+// existence check MarkAsTrashed runs before placing a trash marker.
+func (s RustFSStorage) ValidateEncryption(encryption ObjectEncryption) error {
+	if len(encryption.CustomerKey) > 0 {
+		return ErrEncryptionNotSupported
+	}
+	return s3ValidateEncryption(s.storage, s.BucketName, encryption)
 }
 
 func (s RustFSStorage) ListObjects(prefix string, maxKeys int32) ([]string, error) {
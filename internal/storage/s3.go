@@ -2,8 +2,6 @@ package storage
 
 import (
 	"context"
-	"net/url"
-	"time"
 
 	c "github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/models"
@@ -24,6 +22,7 @@ type GenericS3Storage struct {
 	BucketName    string
 	storage       *minio.Client
 	signingClient *minio.Client
+	objectLock    bool
 }
 
 func NewGenericS3Storage(config *models.S3Configuration) IStorage {
@@ -69,6 +68,7 @@ func NewGenericS3Storage(config *models.S3Configuration) IStorage {
 		BucketName:    bucketName,
 		storage:       minioClient,
 		signingClient: signingClient,
+		objectLock:    s3ObjectLockEnabled(minioClient, bucketName),
 	}
 }
 
@@ -80,65 +80,49 @@ func (s *GenericS3Storage) PresignUpload(
 	objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
-	return presignS3Upload(s.storage, s.signingClient, s.BucketName, objectPath, size, metadata)
+	return presignS3Upload(s.storage, s.signingClient, s.BucketName, objectPath, size, metadata, encryption)
 }
 
 func (s *GenericS3Storage) SupportsMultipart() bool {
 	return true
 }
 
+func (s *GenericS3Storage) SupportsObjectLock() bool {
+	return s.objectLock
+}
+
+func (s *GenericS3Storage) SetObjectLock(path string, lock ObjectLock) error {
+	return s3SetObjectLock(s.storage, s.BucketName, path, lock)
+}
+
 func (s *GenericS3Storage) ListObjectParts(path, uploadID string) ([]PartInfo, error) {
 	return s3ListObjectParts(s.storage, s.BucketName, path, uploadID)
 }
 
-func (s *GenericS3Storage) CompleteMultipartUpload(path, uploadID string, parts []PartInfo, _ map[string]string) error {
-	return s3CompleteMultipartUpload(s.storage, s.BucketName, path, uploadID, parts)
+func (s *GenericS3Storage) CompleteMultipartUpload(
+	path, uploadID string, parts []PartInfo, _ map[string]string, encryption ObjectEncryption,
+) error {
+	return s3CompleteMultipartUpload(s.storage, s.BucketName, path, uploadID, parts, encryption)
 }
 
 func (s *GenericS3Storage) AbortMultipartUpload(path, uploadID string) error {
 	return s3AbortMultipartUpload(s.storage, s.BucketName, path, uploadID)
 }
 
-func (s *GenericS3Storage) PresignedGetObject(objectPath string, opts GetObjectOptions) (string, error) {
-	var reqParams url.Values
-	if opts.InlineContentType != "" {
-		reqParams = url.Values{
-			respContentDisposition: []string{"inline"},
-			respContentType:        []string{opts.InlineContentType},
-		}
-	} else if opts.DownloadFilename != "" {
-		reqParams = url.Values{
-			respContentDisposition: []string{attachmentDisposition(opts.DownloadFilename)},
-		}
-	}
-
-	presignedURL, err := s.signingClient.PresignedGetObject(
-		context.Background(),
-		s.BucketName,
-		objectPath,
-		c.UploadPolicyExpirationInMinutes*time.Minute,
-		reqParams,
-	)
-	if err != nil {
-		return "", err
-	}
-
-	return presignedURL.String(), nil
+func (s *GenericS3Storage) PresignedGetObject(objectPath string, opts GetObjectOptions) (PresignedDownload, error) {
+	return s3PresignedGetObject(s.signingClient, s.BucketName, objectPath, opts)
 }
 
-func (s *GenericS3Storage) StatObject(objectPath string) (map[string]string, error) {
-	file, err := s.storage.StatObject(
-		context.Background(),
-		s.BucketName,
-		objectPath,
-		minio.StatObjectOptions{},
-	)
-	if err != nil {
-		return nil, err
-	}
+func (s *GenericS3Storage) StatObject(objectPath string, encryption ObjectEncryption) (map[string]string, error) {
+	return s3StatObject(s.storage, s.BucketName, objectPath, encryption)
+}
 
-	return file.UserMetadata, err
+// ValidateEncryption accepts both SSE-KMS and SSE-C keys. Trash state never requires reading
+// objects back on generic providers, so the customer key is only needed on client requests.
+func (s *GenericS3Storage) ValidateEncryption(encryption ObjectEncryption) error {
+	return s3ValidateEncryption(s.storage, s.BucketName, encryption)
 }
 
 func (s *GenericS3Storage) ListObjects(prefix string, maxKeys int32) ([]string, error) {
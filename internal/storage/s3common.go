@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"net/url"
@@ -19,8 +20,18 @@ func presignS3Upload(
 	bucketName, objectPath string,
 	size int,
 	metadata map[string]string,
+	encryption ObjectEncryption,
 ) (PresignedUpload, error) {
 	ctx := context.Background()
+	sse, err := minioServerSide(encryption)
+	if err != nil {
+		return PresignedUpload{}, err
+	}
+	customerKey, err := minioCustomerKey(encryption)
+	if err != nil {
+		return PresignedUpload{}, err
+	}
+
 	userMetadata := map[string]string{
 		"Bucket-Id": metadata["bucket_id"],
 		"File-Id":   metadata["file_id"],
@@ -33,34 +44,32 @@ func presignS3Upload(
 		for key, value := range userMetadata {
 			metaHeaders.Set("X-Amz-Meta-"+key, value)
 		}
+		if sse != nil {
+			sse.Marshal(metaHeaders)
+		}
 
 		signHeaders := metaHeaders.Clone()
 		signHeaders.Set("Content-Length", strconv.FormatInt(int64(size), 10))
 
-		presignedURL, err := signingClient.PresignHeader(
+		presignedURL, presignErr := signingClient.PresignHeader(
 			ctx, http.MethodPut, bucketName, objectPath,
 			c.UploadPolicyExpirationInMinutes*time.Minute, nil, signHeaders,
 		)
-		if err != nil {
-			return PresignedUpload{}, err
-		}
-
-		clientHeaders := make(map[string]string, len(metaHeaders))
-		for key := range metaHeaders {
-			clientHeaders[key] = metaHeaders.Get(key)
+		if presignErr != nil {
+			return PresignedUpload{}, presignErr
 		}
 
 		return PresignedUpload{Response: models.FileUploadResponse{
 			Method: c.UploadMethodPut,
 			Parts: []models.FilePartURL{
-				{ID: 1, URL: presignedURL.String(), Size: int64(size), Headers: clientHeaders},
+				{ID: 1, URL: presignedURL.String(), Size: int64(size), Headers: flattenHeaders(metaHeaders)},
 			},
 		}}, nil
 	}
 
 	core := minio.Core{Client: storage}
 	uploadID, err := core.NewMultipartUpload(
-		ctx, bucketName, objectPath, minio.PutObjectOptions{UserMetadata: userMetadata},
+		ctx, bucketName, objectPath, minio.PutObjectOptions{UserMetadata: userMetadata, ServerSideEncryption: sse},
 	)
 	if err != nil {
 		return PresignedUpload{}, err
@@ -75,7 +84,12 @@ func presignS3Upload(
 			"uploadId":   []string{uploadID},
 			"partNumber": []string{strconv.Itoa(partNumber)},
 		}
-		extraHeaders := http.Header{}
+		// SSE-C parts repeat the customer key, SSE-KMS parts inherit the key of the upload.
+		partHeaders := http.Header{}
+		if customerKey != nil {
+			customerKey.Marshal(partHeaders)
+		}
+		extraHeaders := partHeaders.Clone()
 		extraHeaders.Set("Content-Length", strconv.FormatInt(expected, 10))
 
 		presignedURL, partErr := signingClient.PresignHeader(
@@ -88,7 +102,12 @@ func presignS3Upload(
 			}
 			return PresignedUpload{}, partErr
 		}
-		parts = append(parts, models.FilePartURL{ID: partNumber, URL: presignedURL.String(), Size: expected})
+		parts = append(parts, models.FilePartURL{
+			ID:      partNumber,
+			URL:     presignedURL.String(),
+			Size:    expected,
+			Headers: flattenHeaders(partHeaders),
+		})
 	}
 
 	return PresignedUpload{
@@ -127,20 +146,105 @@ func s3ListObjectParts(storage *minio.Client, bucketName, path, uploadID string)
 	return parts, nil
 }
 
-func s3CompleteMultipartUpload(storage *minio.Client, bucketName, path, uploadID string, parts []PartInfo) error {
+func s3CompleteMultipartUpload(
+	storage *minio.Client, bucketName, path, uploadID string, parts []PartInfo, encryption ObjectEncryption,
+) error {
 	core := minio.Core{Client: storage}
 
+	customerKey, err := minioCustomerKey(encryption)
+	if err != nil {
+		return err
+	}
+
 	completeParts := make([]minio.CompletePart, len(parts))
 	for i, part := range parts {
 		completeParts[i] = minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag}
 	}
 
-	_, err := core.CompleteMultipartUpload(
-		context.Background(), bucketName, path, uploadID, completeParts, minio.PutObjectOptions{},
+	_, err = core.CompleteMultipartUpload(
+		context.Background(), bucketName, path, uploadID, completeParts,
+		minio.PutObjectOptions{ServerSideEncryption: customerKey},
 	)
 	return err
 }
 
+func s3PresignedGetObject(
+	signingClient *minio.Client, bucketName, objectPath string, opts GetObjectOptions,
+) (PresignedDownload, error) {
+	var reqParams url.Values
+	if opts.InlineContentType != "" {
+		reqParams = url.Values{
+			respContentDisposition: []string{"inline"},
+			respContentType:        []string{opts.InlineContentType},
+		}
+	} else if opts.DownloadFilename != "" {
+		reqParams = url.Values{
+			respContentDisposition: []string{attachmentDisposition(opts.DownloadFilename)},
+		}
+	}
+
+	customerKey, err := minioCustomerKey(opts.Encryption)
+	if err != nil {
+		return PresignedDownload{}, err
+	}
+	headers := http.Header{}
+	if customerKey != nil {
+		customerKey.Marshal(headers)
+	}
+
+	presignedURL, err := signingClient.PresignHeader(
+		context.Background(), http.MethodGet, bucketName, objectPath,
+		c.UploadPolicyExpirationInMinutes*time.Minute, reqParams, headers,
+	)
+	if err != nil {
+		return PresignedDownload{}, err
+	}
+
+	return PresignedDownload{URL: presignedURL.String(), Headers: flattenHeaders(headers)}, nil
+}
+
+func s3StatObject(
+	storage *minio.Client, bucketName, objectPath string, encryption ObjectEncryption,
+) (map[string]string, error) {
+	customerKey, err := minioCustomerKey(encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := storage.StatObject(
+		context.Background(), bucketName, objectPath,
+		minio.StatObjectOptions{ServerSideEncryption: customerKey},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return file.UserMetadata, nil
+}
+
+// s3ValidateEncryption writes and removes an empty probe object with the encryption, so unknown
+// keys and missing key permissions surface when the bucket is configured rather than on upload.
+func s3ValidateEncryption(storage *minio.Client, bucketName string, encryption ObjectEncryption) error {
+	sse, err := minioServerSide(encryption)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	probePath := encryptionProbePath()
+	if _, err = storage.PutObject(
+		ctx, bucketName, probePath, bytes.NewReader(nil), 0,
+		minio.PutObjectOptions{ServerSideEncryption: sse},
+	); err != nil {
+		return err
+	}
+
+	if err = storage.RemoveObject(ctx, bucketName, probePath, minio.RemoveObjectOptions{}); err != nil {
+		zap.L().Warn("Failed to remove encryption probe object", zap.String("path", probePath), zap.Error(err))
+	}
+	return nil
+}
+
 func s3AbortMultipartUpload(storage *minio.Client, bucketName, path, uploadID string) error {
 	core := minio.Core{Client: storage}
 
@@ -150,3 +254,39 @@ func s3AbortMultipartUpload(storage *minio.Client, bucketName, path, uploadID st
 	}
 	return err
 }
+
+// s3ObjectLockEnabled reports whether Object Lock is enabled on the bucket. Buckets without
+// an Object Lock configuration return an error, which is treated as unsupported.
+func s3ObjectLockEnabled(storage *minio.Client, bucketName string) bool {
+	status, _, _, _, err := storage.GetObjectLockConfig(context.Background(), bucketName)
+	if err != nil {
+		zap.L().Debug("Object lock is not available on bucket",
+			zap.String("bucketName", bucketName), zap.Error(err))
+		return false
+	}
+	return status == "Enabled"
+}
+
+func s3SetObjectLock(storage *minio.Client, bucketName, path string, lock ObjectLock) error {
+	ctx := context.Background()
+
+	status := minio.LegalHoldDisabled
+	if lock.LegalHold {
+		status = minio.LegalHoldEnabled
+	}
+	if err := storage.PutObjectLegalHold(ctx, bucketName, path, minio.PutObjectLegalHoldOptions{
+		Status: &status,
+	}); err != nil {
+		return err
+	}
+
+	if lock.RetainUntil == nil {
+		return nil
+	}
+
+	mode := minio.Compliance
+	return storage.PutObjectRetention(ctx, bucketName, path, minio.PutObjectRetentionOptions{
+		Mode:            &mode,
+		RetainUntilDate: lock.RetainUntil,
+	})
+}
@@ -34,6 +34,21 @@ func (m *MockOpenIDCallbackFunc) OpenIDCallback(
 	return args.Get(0).(models.OIDCCallbackResult), args.Error(1) //nolint:errcheck // test mock type assertion
 }
 
+type MockSAMLCallbackFunc struct {
+	mock.Mock
+}
+
+func (m *MockSAMLCallbackFunc) SAMLCallback(
+	ctx context.Context,
+	logger *zap.Logger,
+	providerName,
+	samlResponse,
+	relayState string,
+) (models.OIDCCallbackResult, error) {
+	args := m.Called(ctx, logger, providerName, samlResponse, relayState)
+	return args.Get(0).(models.OIDCCallbackResult), args.Error(1) //nolint:errcheck // test mock type assertion
+}
+
 type MockCreateFunc[In any, Out any] struct {
 	mock.Mock
 }
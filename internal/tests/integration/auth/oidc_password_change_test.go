@@ -40,11 +40,11 @@ func TestOIDCPasswordChangeDenied(t *testing.T) {
 			)
 			require.Equal(t, models.OIDCProviderType, user.ProviderType)
 
-			status, codes := app.DoExpectError(t, http.MethodPatch,
-				"/api/v1/users/"+user.ID.String(), access.Value,
-				models.UserUpdateBody{
-					OldPassword: "irrelevant-old-password",
-					NewPassword: "new-secure-password",
+			status, codes := app.DoExpectError(t, http.MethodPost,
+				"/api/v1/account/password", access.Value,
+				models.PasswordChangeBody{
+					CurrentPassword: "irrelevant-old-password",
+					NewPassword:     "new-secure-password",
 				})
 
 			assert.Equal(t, http.StatusForbidden, status,
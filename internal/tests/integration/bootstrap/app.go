@@ -593,6 +593,16 @@ func (a *TestApp) AuthenticateShare(t *testing.T, shareID, password string) (int
 	)
 }
 
+func (a *TestApp) VerifyShareRecipient(t *testing.T, shareID, email, code string) (int, string) {
+	t.Helper()
+	return a.doGetCookie(t, http.MethodPost,
+		fmt.Sprintf("/api/v1/shares/%s/otp/verify", shareID),
+		"",
+		models.ShareOTPVerifyBody{Email: email, Code: code},
+		configuration.CookieShareToken,
+	)
+}
+
 func (a *TestApp) DoPublicShare(
 	t *testing.T,
 	method, path, shareCookie string,
@@ -726,6 +736,8 @@ func providerForDialect(t *testing.T, dialect string) DBProvider {
 		return &PostgresProvider{}
 	case configuration.ProviderSQLite:
 		return &SQLiteProvider{}
+	case configuration.ProviderMySQL:
+		return &MySQLProvider{}
 	default:
 		require.Failf(t, "unsupported dialect", "scenario requested dialect %q", dialect)
 		return nil
@@ -743,6 +755,10 @@ func injectDatabaseConfig(t *testing.T, cfg *models.Configuration, provider DBPr
 		if cfg.Database.SQLite == nil {
 			cfg.Database.SQLite = &models.SQLiteDatabaseConfig{Path: ":memory:"}
 		}
+	case configuration.ProviderMySQL:
+		my, ok := provider.(*MySQLProvider)
+		require.True(t, ok, "provider must be *MySQLProvider for mysql dialect")
+		cfg.Database.MySQL = my.ConfigFor(t)
 	default:
 		require.Failf(t, "unsupported dialect", "provider dialect %q", provider.Dialect())
 	}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
 	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -22,6 +24,11 @@ const (
 	postgresDBName       = "safebucket_test"
 	postgresUser         = "safebucket"
 	postgresPassword     = "safebucket"
+
+	defaultMySQLImage = "mysql:8.4"
+	mysqlDBName       = "safebucket_test"
+	mysqlUser         = "safebucket"
+	mysqlPassword     = "safebucket"
 )
 
 type PostgresProvider struct {
@@ -105,3 +112,80 @@ func (p *PostgresProvider) ConfigFor(t *testing.T) *models.PostgresDatabaseConfi
 		SSLMode:  "disable",
 	}
 }
+
+type MySQLProvider struct {
+	container *tcmysql.MySQLContainer
+}
+
+func (p *MySQLProvider) Connect(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	ctx := context.Background()
+
+	image := os.Getenv("MYSQL_IMAGE")
+	if image == "" {
+		image = defaultMySQLImage
+	}
+
+	container, err := tcmysql.Run(ctx, image,
+		tcmysql.WithDatabase(mysqlDBName),
+		tcmysql.WithUsername(mysqlUser),
+		tcmysql.WithPassword(mysqlPassword),
+	)
+	require.NoError(t, err, "start mysql container")
+	p.container = container
+
+	t.Cleanup(func() {
+		_ = testcontainers.TerminateContainer(container)
+	})
+
+	db, err := gorm.Open(mysql.Open(database.MySQLDSN(p.ConfigFor(t))), &gorm.Config{Logger: gormTestLogger()})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	return db
+}
+
+func (p *MySQLProvider) Setup(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := p.Connect(t)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	database.RunMigrations(sqlDB, database.DialectMySQL)
+	database.RegisterCallbacks(db)
+
+	return db
+}
+
+func (p *MySQLProvider) Dialect() string {
+	return database.DialectMySQL
+}
+
+func (p *MySQLProvider) Teardown() {}
+
+func (p *MySQLProvider) ConfigFor(t *testing.T) *models.MySQLDatabaseConfig {
+	t.Helper()
+	require.NotNil(t, p.container, "mysql container not started")
+
+	ctx := context.Background()
+	host, err := p.container.Host(ctx)
+	require.NoError(t, err, "mysql host")
+
+	port, err := p.container.MappedPort(ctx, "3306/tcp")
+	require.NoError(t, err, "mysql mapped port")
+
+	return &models.MySQLDatabaseConfig{
+		Host:     host,
+		Port:     int32(port.Num()),
+		User:     mysqlUser,
+		Password: mysqlPassword,
+		Name:     mysqlDBName,
+	}
+}
@@ -14,3 +14,14 @@ func WithLocalSharing(cfg models.Configuration, allowed bool, domains ...string)
 	cfg.Auth.Providers["local"] = provider
 	return cfg
 }
+
+func WithLocalShareIPRules(cfg models.Configuration, allowlist, denylist []string) models.Configuration {
+	if cfg.Auth.Providers == nil {
+		cfg.Auth.Providers = make(map[string]models.ProviderConfiguration)
+	}
+	provider := cfg.Auth.Providers["local"]
+	provider.SharingConfiguration.IPAllowlist = allowlist
+	provider.SharingConfiguration.IPDenylist = denylist
+	cfg.Auth.Providers["local"] = provider
+	return cfg
+}
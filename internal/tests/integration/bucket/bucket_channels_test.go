@@ -0,0 +1,129 @@
+//go:build integration
+
+package bucket_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+	"github.com/stretchr/testify/require"
+)
+
+type chatStandIn struct {
+	mu       sync.Mutex
+	messages []string
+	status   int
+}
+
+func newChatStandIn(t *testing.T) (*chatStandIn, *httptest.Server) {
+	t.Helper()
+
+	standIn := &chatStandIn{status: http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload struct {
+			Text string `json:"text"`
+		}
+		_ = json.Unmarshal(body, &payload)
+
+		standIn.mu.Lock()
+		defer standIn.mu.Unlock()
+		standIn.messages = append(standIn.messages, payload.Text)
+		w.WriteHeader(standIn.status)
+	}))
+	t.Cleanup(server.Close)
+
+	return standIn, server
+}
+
+func TestBucketChannels(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+			standIn, server := newChatStandIn(t)
+
+			owner := app.CreateUser(t, "owner@example.com")
+			viewer := app.CreateUser(t, "viewer@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			viewerToken := app.LoginAs(t, viewer.Email)
+			bucket := app.CreateBucket(t, ownerToken, "chat")
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: viewer.Email, Group: models.GroupViewer},
+			})
+
+			channelsPath := fmt.Sprintf("/api/v1/buckets/%s/channels", bucket.ID)
+			body := models.BucketChannelCreateBody{
+				Name:          "team-files",
+				Type:          models.ChannelTypeSlack,
+				WebhookURL:    server.URL + "/hooks/secret-token",
+				NotifyUploads: true,
+			}
+
+			status := app.DoStatus(t, http.MethodPost, channelsPath, viewerToken, body)
+			require.Equal(t, http.StatusForbidden, status)
+
+			var channel models.BucketChannel
+			status = app.Do(t, http.MethodPost, channelsPath, ownerToken, body, &channel)
+			require.Equal(t, http.StatusCreated, status)
+			require.True(t, channel.NotifyUploads)
+			require.False(t, channel.NotifyShares)
+
+			t.Run("list hides the webhook secret", func(t *testing.T) {
+				var page models.Page[models.BucketChannel]
+				status := app.Do(t, http.MethodGet, channelsPath, ownerToken, nil, &page)
+				require.Equal(t, http.StatusOK, status)
+				require.Len(t, page.Data, 1)
+				require.Equal(t, strings.TrimPrefix(server.URL, "http://"), page.Data[0].WebhookHost)
+				require.Empty(t, page.Data[0].WebhookURL)
+			})
+
+			channelPath := fmt.Sprintf("%s/%s", channelsPath, channel.ID)
+
+			t.Run("test message reaches the webhook", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost, channelPath+"/test", ownerToken, nil)
+				require.Equal(t, http.StatusNoContent, status)
+
+				standIn.mu.Lock()
+				defer standIn.mu.Unlock()
+				require.Len(t, standIn.messages, 1)
+				require.Contains(t, standIn.messages[0], `bucket "chat"`)
+			})
+
+			t.Run("failing webhook surfaces a delivery error", func(t *testing.T) {
+				standIn.mu.Lock()
+				standIn.status = http.StatusInternalServerError
+				standIn.mu.Unlock()
+
+				status, codes := app.DoExpectError(t, http.MethodPost, channelPath+"/test", ownerToken, nil)
+				require.Equal(t, http.StatusBadGateway, status)
+				require.Contains(t, codes, "CHANNEL_DELIVERY_FAILED")
+			})
+
+			t.Run("owner updates and deletes the channel", func(t *testing.T) {
+				shares := true
+				status := app.DoStatus(t, http.MethodPatch, channelPath, ownerToken,
+					models.BucketChannelUpdateBody{NotifyShares: &shares})
+				require.Equal(t, http.StatusNoContent, status)
+
+				var stored models.BucketChannel
+				require.NoError(t, app.DB().Where("id = ?", channel.ID).First(&stored).Error)
+				require.True(t, stored.NotifyShares)
+
+				status = app.DoStatus(t, http.MethodDelete, channelPath, ownerToken, nil)
+				require.Equal(t, http.StatusNoContent, status)
+
+				status, codes := app.DoExpectError(t, http.MethodDelete, channelPath, ownerToken, nil)
+				require.Equal(t, http.StatusNotFound, status)
+				require.Contains(t, codes, "CHANNEL_NOT_FOUND")
+			})
+		})
+	}
+}
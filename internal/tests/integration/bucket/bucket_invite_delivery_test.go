@@ -0,0 +1,74 @@
+//go:build integration
+
+package bucket_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketInvite_QueuedDelivery(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+
+			owner := app.CreateUser(t, "owner@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			adminToken := app.LoginAdmin(t)
+			bucket := app.CreateBucket(t, ownerToken, "deliveries")
+			invitee := "queued@example.com"
+
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: invitee, Group: models.GroupViewer},
+			})
+
+			var entry models.QueuedNotification
+			app.Eventually(t, func() bool {
+				return app.DB().
+					Where("recipient = ? AND template = ?", invitee, "user_invitation").
+					First(&entry).Error == nil && entry.Status == models.NotificationStatusSent
+			}, "invitation should be queued and delivered")
+			require.Empty(t, entry.Payload)
+			require.Equal(t, 1, entry.Attempts)
+
+			require.NoError(t, app.DB().Model(&entry).Updates(map[string]any{
+				"status":  models.NotificationStatusFailed,
+				"payload": "{}",
+			}).Error)
+
+			listPath := "/api/v1/admin/notifications?status=failed"
+			status := app.DoStatus(t, http.MethodGet, listPath, ownerToken, nil)
+			require.Equal(t, http.StatusForbidden, status)
+
+			var page models.Page[models.QueuedNotification]
+			status = app.Do(t, http.MethodGet, listPath, adminToken, nil, &page)
+			require.Equal(t, http.StatusOK, status)
+			require.Len(t, page.Data, 1)
+			require.Equal(t, entry.ID, page.Data[0].ID)
+
+			resendPath := fmt.Sprintf("/api/v1/admin/notifications/%s/resend", entry.ID)
+			status = app.DoStatus(t, http.MethodPost, resendPath, adminToken, nil)
+			require.Equal(t, http.StatusNoContent, status)
+
+			var requeued models.QueuedNotification
+			require.NoError(t, app.DB().Where("id = ?", entry.ID).First(&requeued).Error)
+			require.Equal(t, models.NotificationStatusPending, requeued.Status)
+			require.Equal(t, 0, requeued.Attempts)
+
+			status, codes := app.DoExpectError(t, http.MethodPost, resendPath, adminToken, nil)
+			require.Equal(t, http.StatusConflict, status)
+			require.Contains(t, codes, "NOTIFICATION_NOT_FAILED")
+
+			missingPath := fmt.Sprintf("/api/v1/admin/notifications/%s/resend", uuid.New())
+			status, codes = app.DoExpectError(t, http.MethodPost, missingPath, adminToken, nil)
+			require.Equal(t, http.StatusNotFound, status)
+			require.Contains(t, codes, "NOTIFICATION_NOT_FOUND")
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//go:build integration
+
+package bucket_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+	"github.com/stretchr/testify/require"
+)
+
+func findInvited(members []models.BucketMember, email string) *models.BucketMember {
+	for _, m := range members {
+		if m.Email == email && m.Status == "invited" {
+			return &m
+		}
+	}
+	return nil
+}
+
+func countInvitations(app *bootstrap.TestApp, t *testing.T, email string) int {
+	count := 0
+	for _, n := range app.ReadNotifications(t) {
+		if n.To == email && n.TemplateName == "user_invitation" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestBucketInvite_Lifecycle(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			cfg := bootstrap.LoadScenario(t, scenario)
+			cfg = bootstrap.WithLocalSharing(cfg, true)
+			app := bootstrap.BootTestApp(t, cfg)
+
+			owner := app.CreateUser(t, "owner@example.com")
+			viewer := app.CreateUser(t, "viewer@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			viewerToken := app.LoginAs(t, viewer.Email)
+			bucket := app.CreateBucket(t, ownerToken, "invites")
+			invitee := "newcomer@example.com"
+
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: viewer.Email, Group: models.GroupViewer},
+				{Email: invitee, Group: models.GroupContributor},
+			})
+
+			invited := findInvited(app.GetMembers(t, ownerToken, bucket.ID.String()), invitee)
+			require.NotNil(t, invited, "invitee should be listed as invited")
+			require.NotNil(t, invited.InviteID)
+			require.NotNil(t, invited.ExpiresAt)
+			require.WithinDuration(t, time.Now().Add(7*24*time.Hour), *invited.ExpiresAt, time.Hour)
+
+			invitePath := fmt.Sprintf("/api/v1/buckets/%s/members/invites/%s", bucket.ID, invited.InviteID)
+
+			t.Run("admin lists pending invites", func(t *testing.T) {
+				var page models.Page[models.AdminInviteListItem]
+				status := app.Do(t, http.MethodGet, "/api/v1/admin/invites", app.LoginAdmin(t), nil, &page)
+				require.Equal(t, http.StatusOK, status)
+				require.Len(t, page.Data, 1)
+				require.Equal(t, invitee, page.Data[0].Email)
+				require.Equal(t, bucket.Name, page.Data[0].BucketName)
+				require.Equal(t, owner.Email, page.Data[0].InvitedBy.Email)
+
+				status = app.DoStatus(t, http.MethodGet, "/api/v1/admin/invites", ownerToken, nil)
+				require.Equal(t, http.StatusForbidden, status)
+			})
+
+			t.Run("owner resends the invitation", func(t *testing.T) {
+				app.Eventually(t, func() bool { return countInvitations(app, t, invitee) == 1 },
+					"initial invitation should be sent")
+
+				status := app.DoStatus(t, http.MethodPost, invitePath+"/resend", viewerToken, nil)
+				require.Equal(t, http.StatusForbidden, status)
+
+				require.NoError(t, app.DB().Model(&models.Invite{}).
+					Where("id = ?", invited.InviteID).
+					Update("expires_at", time.Now().Add(time.Hour)).Error)
+
+				status = app.DoStatus(t, http.MethodPost, invitePath+"/resend", ownerToken, nil)
+				require.Equal(t, http.StatusNoContent, status)
+
+				app.Eventually(t, func() bool { return countInvitations(app, t, invitee) == 2 },
+					"resend should emit a second invitation")
+
+				refreshed := findInvited(app.GetMembers(t, ownerToken, bucket.ID.String()), invitee)
+				require.NotNil(t, refreshed)
+				require.True(t, refreshed.ExpiresAt.After(time.Now().Add(6*24*time.Hour)),
+					"resend should extend the invite expiry")
+			})
+
+			t.Run("expired invite cannot be accepted", func(t *testing.T) {
+				require.NoError(t, app.DB().Model(&models.Invite{}).
+					Where("id = ?", invited.InviteID).
+					Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+				status, codes := app.DoExpectError(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/invites/%s/challenges", invited.InviteID), "",
+					models.InviteChallengeCreateBody{Email: invitee})
+				require.Equal(t, http.StatusGone, status)
+				require.Contains(t, codes, "INVITE_EXPIRED")
+			})
+
+			t.Run("owner revokes the invitation", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodDelete, invitePath, viewerToken, nil)
+				require.Equal(t, http.StatusForbidden, status)
+
+				status = app.DoStatus(t, http.MethodDelete, invitePath, ownerToken, nil)
+				require.Equal(t, http.StatusNoContent, status)
+
+				require.Nil(t, findInvited(app.GetMembers(t, ownerToken, bucket.ID.String()), invitee))
+
+				status, codes := app.DoExpectError(t, http.MethodDelete, invitePath, ownerToken, nil)
+				require.Equal(t, http.StatusNotFound, status)
+				require.Contains(t, codes, "INVITE_NOT_FOUND")
+			})
+		})
+	}
+}
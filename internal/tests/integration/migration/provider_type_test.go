@@ -0,0 +1,38 @@
+//go:build integration
+
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestProviderTypesAreStorable guards the Postgres provider_type enum, which must list every
+// provider a user can sign in with.
+func TestProviderTypesAreStorable(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			dialect := bootstrap.LoadScenario(t, scenario).Database.Type
+			db := bootstrap.NewDBProvider(t, dialect).Setup(t)
+
+			for _, providerType := range []models.ProviderType{
+				models.LocalProviderType,
+				models.OIDCProviderType,
+				models.LDAPProviderType,
+				models.SAMLProviderType,
+			} {
+				user := models.User{
+					Email:        string(providerType) + "@example.com",
+					ProviderType: providerType,
+					ProviderKey:  "corp",
+					Role:         models.RoleUser,
+				}
+				require.NoError(t, db.Create(&user).Error, "create %s user", providerType)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickShareUploadApproval(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+
+			owner := app.CreateUser(t, "qsapproveowner@example.com")
+			contrib := app.CreateUser(t, "qsapprovecontrib@example.com")
+			viewer := app.CreateUser(t, "qsapproveviewer@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			contribToken := app.LoginAs(t, contrib.Email)
+			viewerToken := app.LoginAs(t, viewer.Email)
+
+			bucket := app.CreateBucket(t, ownerToken, "qs-approval")
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: contrib.Email, Group: models.GroupContributor},
+				{Email: viewer.Email, Group: models.GroupViewer},
+			})
+
+			share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+				Name:            "dropbox",
+				Type:            models.ShareTypeBucket,
+				AllowUpload:     true,
+				RequireApproval: true,
+			})
+			require.True(t, share.RequireApproval)
+
+			upload := func(t *testing.T, name string) string {
+				t.Helper()
+				var transfer models.FileUploadResponse
+				status := app.DoPublicShare(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/shares/%s/files", share.ID), "",
+					models.ShareUploadBody{Name: name, Size: 5}, &transfer)
+				require.Equal(t, http.StatusCreated, status)
+				app.PutPresigned(t, transfer, []byte("test!"))
+				require.Equal(t, http.StatusNoContent, app.DoPublicShare(t, http.MethodPatch,
+					fmt.Sprintf("/api/v1/shares/%s/files/%s", share.ID, transfer.ID), "", nil, nil))
+				return transfer.ID
+			}
+
+			bucketFiles := func(t *testing.T) []string {
+				t.Helper()
+				var b models.Bucket
+				require.Equal(t, http.StatusOK,
+					app.Do(t, http.MethodGet, fmt.Sprintf("/api/v1/buckets/%s", bucket.ID), viewerToken, nil, &b))
+				names := make([]string, 0, len(b.Files))
+				for _, f := range b.Files {
+					names = append(names, f.Name)
+				}
+				return names
+			}
+
+			pendingPath := fmt.Sprintf("/api/v1/buckets/%s/files/pending", bucket.ID)
+			filePath := func(id string) string {
+				return fmt.Sprintf("/api/v1/buckets/%s/files/%s", bucket.ID, id)
+			}
+
+			t.Run("approved upload becomes visible", func(t *testing.T) {
+				fileID := upload(t, "approve-me.txt")
+				assert.NotContains(t, bucketFiles(t), "approve-me.txt", "pending upload is hidden")
+
+				var pending models.Page[models.File]
+				require.Equal(t, http.StatusOK, app.Do(t, http.MethodGet, pendingPath, contribToken, nil, &pending))
+				require.Len(t, pending.Data, 1)
+				assert.Equal(t, models.FileStatusPending, pending.Data[0].Status)
+				require.NotNil(t, pending.Data[0].ShareID)
+				assert.Equal(t, share.ID, *pending.Data[0].ShareID)
+
+				assert.Equal(t, http.StatusForbidden, app.DoStatus(t, http.MethodGet, pendingPath, viewerToken, nil))
+				assert.Equal(t, http.StatusNotFound,
+					app.DoStatus(t, http.MethodGet, filePath(fileID)+"/url", viewerToken, nil),
+					"viewers cannot download pending uploads")
+				assert.Equal(t, http.StatusOK,
+					app.DoStatus(t, http.MethodGet, filePath(fileID)+"/url", contribToken, nil),
+					"reviewers can inspect pending uploads")
+
+				assert.Equal(t, http.StatusForbidden,
+					app.DoStatus(t, http.MethodPost, filePath(fileID)+"/approve", viewerToken, nil))
+				require.Equal(t, http.StatusNoContent,
+					app.DoStatus(t, http.MethodPost, filePath(fileID)+"/approve", contribToken, nil))
+				assert.Contains(t, bucketFiles(t), "approve-me.txt")
+
+				code, errs := app.DoExpectError(t, http.MethodPost, filePath(fileID)+"/approve", contribToken, nil)
+				assert.Equal(t, http.StatusConflict, code)
+				assert.Contains(t, errs, apierrors.CodeFileNotPending)
+			})
+
+			t.Run("rejected upload is purged", func(t *testing.T) {
+				fileID := upload(t, "reject-me.txt")
+
+				require.Equal(t, http.StatusNoContent,
+					app.DoStatus(t, http.MethodPost, filePath(fileID)+"/reject", ownerToken, nil))
+				assert.NotContains(t, bucketFiles(t), "reject-me.txt")
+
+				var pending models.Page[models.File]
+				require.Equal(t, http.StatusOK, app.Do(t, http.MethodGet, pendingPath, ownerToken, nil, &pending))
+				assert.Empty(t, pending.Data)
+
+				var count int64
+				app.DB().Unscoped().Model(&models.File{}).Where("id = ?", fileID).Count(&count)
+				assert.Zero(t, count, "rejected file row is removed")
+			})
+
+			t.Run("approval requires uploads", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID), ownerToken,
+					models.ShareCreateBody{
+						Name:            "invalid-approval",
+						Type:            models.ShareTypeBucket,
+						RequireApproval: true,
+					})
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+		})
+	}
+}
@@ -0,0 +1,159 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickShareDownloads(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+
+			owner := app.CreateUser(t, "qsdlowner@example.com")
+			contrib := app.CreateUser(t, "qsdlcontrib@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			contribToken := app.LoginAs(t, contrib.Email)
+
+			bucket := app.CreateBucket(t, ownerToken, "qs-downloads")
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: contrib.Email, Group: models.GroupContributor},
+			})
+
+			firstFile := app.UploadTestFile(t, ownerToken, bucket.ID.String(), "first.txt")
+			secondFile := app.UploadTestFile(t, ownerToken, bucket.ID.String(), "second.txt")
+
+			download := func(t *testing.T, shareID uuid.UUID, fileID string) (int, []string) {
+				t.Helper()
+				return app.DoExpectError(t, http.MethodGet,
+					fmt.Sprintf("/api/v1/shares/%s/files/%s/url", shareID, fileID), "", nil)
+			}
+
+			analyticsPath := func(shareID uuid.UUID) string {
+				return fmt.Sprintf("/api/v1/buckets/%s/shares/%s/analytics", bucket.ID, shareID)
+			}
+
+			t.Run("share download limit is enforced", func(t *testing.T) {
+				maxDownloads := 2
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:         "share-limit",
+					Type:         models.ShareTypeBucket,
+					MaxDownloads: &maxDownloads,
+				})
+
+				code, _ := download(t, share.ID, firstFile)
+				require.Equal(t, http.StatusOK, code)
+				code, _ = download(t, share.ID, secondFile)
+				require.Equal(t, http.StatusOK, code)
+
+				code, errs := download(t, share.ID, firstFile)
+				assert.Equal(t, http.StatusForbidden, code)
+				assert.Contains(t, errs, apierrors.CodeShareMaxDownloadsReached)
+
+				status := app.DoStatus(t, http.MethodPatch,
+					fmt.Sprintf("/api/v1/buckets/%s/shares/%s", bucket.ID, share.ID), ownerToken,
+					models.ShareUpdateBody{ResetCounters: true})
+				require.Equal(t, http.StatusNoContent, status)
+
+				code, _ = download(t, share.ID, firstFile)
+				assert.Equal(t, http.StatusOK, code, "reset counters allow new downloads")
+			})
+
+			t.Run("per-file download limit is enforced", func(t *testing.T) {
+				maxFileDownloads := 1
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:             "file-limit",
+					Type:             models.ShareTypeFiles,
+					FileIDs:          []uuid.UUID{uuid.MustParse(firstFile), uuid.MustParse(secondFile)},
+					MaxFileDownloads: &maxFileDownloads,
+				})
+
+				code, _ := download(t, share.ID, firstFile)
+				require.Equal(t, http.StatusOK, code)
+
+				code, errs := download(t, share.ID, firstFile)
+				assert.Equal(t, http.StatusForbidden, code)
+				assert.Contains(t, errs, apierrors.CodeShareFileMaxDownloadsReached)
+
+				code, _ = download(t, share.ID, secondFile)
+				assert.Equal(t, http.StatusOK, code, "other files keep their own budget")
+
+				var content models.PublicShareResponse
+				require.Equal(t, http.StatusOK, app.DoPublicShare(t, http.MethodGet,
+					fmt.Sprintf("/api/v1/shares/%s", share.ID), "", nil, &content))
+				assert.Empty(t, content.Files, "exhausted files are no longer listed")
+			})
+
+			t.Run("per-file limit requires a files share", func(t *testing.T) {
+				maxFileDownloads := 1
+				status := app.DoStatus(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID), ownerToken,
+					models.ShareCreateBody{
+						Name:             "invalid-limit",
+						Type:             models.ShareTypeBucket,
+						MaxFileDownloads: &maxFileDownloads,
+					})
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+
+			t.Run("owner reads analytics", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:    "analytics-share",
+					Type:    models.ShareTypeFiles,
+					FileIDs: []uuid.UUID{uuid.MustParse(firstFile)},
+				})
+
+				require.Equal(t, http.StatusOK, app.DoPublicShare(t, http.MethodGet,
+					fmt.Sprintf("/api/v1/shares/%s", share.ID), "", nil, nil))
+				for range 2 {
+					code, _ := download(t, share.ID, firstFile)
+					require.Equal(t, http.StatusOK, code)
+				}
+
+				var analytics models.ShareAnalyticsResponse
+				status := app.Do(t, http.MethodGet, analyticsPath(share.ID), ownerToken, nil, &analytics)
+				require.Equal(t, http.StatusOK, status)
+
+				assert.Equal(t, 1, analytics.Views)
+				assert.Equal(t, 2, analytics.Downloads)
+				require.Len(t, analytics.Files, 1)
+				assert.Equal(t, uuid.MustParse(firstFile), analytics.Files[0].FileID)
+				assert.Equal(t, "first.txt", analytics.Files[0].Name)
+				assert.Equal(t, 2, analytics.Files[0].Downloads)
+				assert.NotNil(t, analytics.Files[0].LastDownloadedAt)
+
+				require.Len(t, analytics.Accesses, 3)
+				for _, access := range analytics.Accesses {
+					assert.NotEmpty(t, access.IPAddress)
+					assert.NotEmpty(t, access.UserAgent)
+				}
+			})
+
+			t.Run("contributor cannot read analytics", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "private-analytics",
+					Type: models.ShareTypeBucket,
+				})
+
+				status := app.DoStatus(t, http.MethodGet, analyticsPath(share.ID), contribToken, nil)
+				assert.Equal(t, http.StatusForbidden, status)
+			})
+
+			t.Run("analytics of unknown share returns 404", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodGet, analyticsPath(uuid.New()), ownerToken, nil)
+				assert.Equal(t, http.StatusNotFound, status)
+			})
+		})
+	}
+}
@@ -0,0 +1,120 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test requests reach the server from the loopback interface.
+var loopback = []string{"127.0.0.0/8", "::1"}
+
+func TestQuickShareIPRules(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+
+			owner := app.CreateUser(t, "qsipowner@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			bucket := app.CreateBucket(t, ownerToken, "qs-ip-rules")
+
+			open := func(t *testing.T, share models.Share) (int, []string) {
+				t.Helper()
+				return app.DoExpectError(t, http.MethodGet, fmt.Sprintf("/api/v1/shares/%s", share.ID), "", nil)
+			}
+
+			t.Run("denylisted client is rejected", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:       "denied-share",
+					Type:       models.ShareTypeBucket,
+					IPDenylist: loopback,
+				})
+
+				code, errs := open(t, share)
+				assert.Equal(t, http.StatusForbidden, code)
+				assert.Contains(t, errs, apierrors.CodeShareAccessDenied)
+			})
+
+			t.Run("client outside the allowlist is rejected", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:        "partner-share",
+					Type:        models.ShareTypeBucket,
+					IPAllowlist: []string{"10.0.0.0/8"},
+				})
+
+				code, errs := open(t, share)
+				assert.Equal(t, http.StatusForbidden, code)
+				assert.Contains(t, errs, apierrors.CodeShareAccessDenied)
+
+				status := app.DoStatus(t, http.MethodPatch,
+					fmt.Sprintf("/api/v1/buckets/%s/shares/%s", bucket.ID, share.ID), ownerToken,
+					models.ShareUpdateBody{IPAllowlist: loopback})
+				require.Equal(t, http.StatusNoContent, status)
+
+				code, _ = open(t, share)
+				assert.Equal(t, http.StatusOK, code, "updated allowlist admits the client")
+
+				status = app.DoStatus(t, http.MethodPatch,
+					fmt.Sprintf("/api/v1/buckets/%s/shares/%s", bucket.ID, share.ID), ownerToken,
+					models.ShareUpdateBody{IPDenylist: loopback})
+				require.Equal(t, http.StatusNoContent, status)
+
+				code, _ = open(t, share)
+				assert.Equal(t, http.StatusForbidden, code, "denylist wins over allowlist")
+			})
+
+			t.Run("invalid network is rejected", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID), ownerToken,
+					models.ShareCreateBody{
+						Name:        "invalid-share",
+						Type:        models.ShareTypeBucket,
+						IPAllowlist: []string{"not-a-network"},
+					})
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+		})
+	}
+}
+
+func TestQuickShareProviderIPDefaults(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			cfg := bootstrap.LoadScenario(t, scenario)
+			cfg = bootstrap.WithLocalShareIPRules(cfg, nil, loopback)
+			app := bootstrap.BootTestApp(t, cfg)
+
+			owner := app.CreateUser(t, "qsipdefault@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			bucket := app.CreateBucket(t, ownerToken, "qs-ip-defaults")
+
+			share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+				Name: "default-rules",
+				Type: models.ShareTypeBucket,
+			})
+			assert.Equal(t, loopback, share.IPDenylist, "provider default is applied")
+
+			status := app.DoPublicShare(t, http.MethodGet, fmt.Sprintf("/api/v1/shares/%s", share.ID), "", nil, nil)
+			assert.Equal(t, http.StatusForbidden, status)
+
+			explicit := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+				Name:       "explicit-rules",
+				Type:       models.ShareTypeBucket,
+				IPDenylist: []string{"10.0.0.0/8"},
+			})
+			assert.Equal(t, []string{"10.0.0.0/8"}, explicit.IPDenylist, "explicit rules override the default")
+
+			status = app.DoPublicShare(t, http.MethodGet, fmt.Sprintf("/api/v1/shares/%s", explicit.ID), "", nil, nil)
+			assert.Equal(t, http.StatusOK, status)
+		})
+	}
+}
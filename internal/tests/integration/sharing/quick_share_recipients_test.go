@@ -0,0 +1,155 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickShareRecipients(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			app := bootstrap.BootScenario(t, scenario)
+
+			owner := app.CreateUser(t, "qsrecipowner@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			bucket := app.CreateBucket(t, ownerToken, "qs-recipients")
+			fileID := app.UploadTestFile(t, ownerToken, bucket.ID.String(), "restricted.txt")
+
+			share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+				Name:       "restricted-share",
+				Type:       models.ShareTypeFiles,
+				FileIDs:    []uuid.UUID{uuid.MustParse(fileID)},
+				Recipients: []string{"alice@example.com", "partner.org"},
+			})
+			require.True(t, share.RecipientRestricted)
+			require.Len(t, share.Recipients, 2)
+
+			sharePath := fmt.Sprintf("/api/v1/shares/%s", share.ID)
+			otpPath := sharePath + "/otp"
+
+			t.Run("content requires a verified recipient", func(t *testing.T) {
+				code, errs := app.DoExpectError(t, http.MethodGet, sharePath, "", nil)
+				assert.Equal(t, http.StatusUnauthorized, code)
+				assert.Contains(t, errs, apierrors.CodeShareRecipientRequired)
+			})
+
+			t.Run("password auth is rejected", func(t *testing.T) {
+				status, _ := app.AuthenticateShare(t, share.ID.String(), "whatever-password")
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+
+			t.Run("non-recipient gets no code", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost, otpPath, "",
+					models.ShareOTPRequestBody{Email: "mallory@example.com"})
+				assert.Equal(t, http.StatusCreated, status, "response must not reveal the recipient list")
+				assert.Empty(t, shareAccessCode(t, app, "mallory@example.com"))
+			})
+
+			t.Run("listed email verifies and downloads", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost, otpPath, "",
+					models.ShareOTPRequestBody{Email: "Alice@Example.com"})
+				require.Equal(t, http.StatusCreated, status)
+
+				var secret string
+				app.Eventually(t, func() bool {
+					secret = shareAccessCode(t, app, "alice@example.com")
+					return secret != ""
+				}, "share access code for alice@example.com")
+
+				status, _ = app.VerifyShareRecipient(t, share.ID.String(), "alice@example.com", "ZZZZZZ")
+				assert.Equal(t, http.StatusUnauthorized, status, "wrong code is rejected")
+
+				status, cookie := app.VerifyShareRecipient(t, share.ID.String(), "alice@example.com", secret)
+				require.Equal(t, http.StatusOK, status)
+				require.NotEmpty(t, cookie)
+
+				var content models.PublicShareResponse
+				require.Equal(t, http.StatusOK, app.DoPublicShare(t, http.MethodGet, sharePath, cookie, nil, &content))
+				require.Len(t, content.Files, 1)
+
+				var transfer models.FileDownloadResponse
+				status = app.DoPublicShare(t, http.MethodGet,
+					fmt.Sprintf("%s/files/%s/url", sharePath, fileID), cookie, nil, &transfer)
+				require.Equal(t, http.StatusOK, status)
+				assert.NotEmpty(t, transfer.URL)
+
+				status, _ = app.VerifyShareRecipient(t, share.ID.String(), "alice@example.com", secret)
+				assert.Equal(t, http.StatusBadRequest, status, "codes are single use")
+			})
+
+			t.Run("listed domain can request a code", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost, otpPath, "",
+					models.ShareOTPRequestBody{Email: "bob@partner.org"})
+				require.Equal(t, http.StatusCreated, status)
+
+				app.Eventually(t, func() bool {
+					return shareAccessCode(t, app, "bob@partner.org") != ""
+				}, "share access code for bob@partner.org")
+			})
+
+			t.Run("password token cannot open a restricted share", func(t *testing.T) {
+				open := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:     "password-share",
+					Type:     models.ShareTypeBucket,
+					Password: "share-password",
+				})
+				_, cookie := app.AuthenticateShare(t, open.ID.String(), "share-password")
+				require.NotEmpty(t, cookie)
+
+				status := app.DoPublicShare(t, http.MethodGet, sharePath, cookie, nil, nil)
+				assert.Equal(t, http.StatusUnauthorized, status)
+			})
+
+			t.Run("recipients cannot be combined with a password", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPost,
+					fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID), ownerToken,
+					models.ShareCreateBody{
+						Name:       "invalid-share",
+						Type:       models.ShareTypeBucket,
+						Password:   "share-password",
+						Recipients: []string{"alice@example.com"},
+					})
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+
+			t.Run("clearing recipients opens the share", func(t *testing.T) {
+				status := app.DoStatus(t, http.MethodPatch,
+					fmt.Sprintf("/api/v1/buckets/%s/shares/%s", bucket.ID, share.ID), ownerToken,
+					models.ShareUpdateBody{ClearRecipients: true})
+				require.Equal(t, http.StatusNoContent, status)
+
+				assert.Equal(t, http.StatusOK, app.DoPublicShare(t, http.MethodGet, sharePath, "", nil, nil))
+			})
+		})
+	}
+}
+
+func shareAccessCode(t *testing.T, app *bootstrap.TestApp, email string) string {
+	t.Helper()
+
+	var secret string
+	for _, n := range app.ReadNotifications(t) {
+		if n.To != email || n.TemplateName != "share_access" {
+			continue
+		}
+		var payload struct {
+			Secret string
+		}
+		if err := json.Unmarshal(n.Args, &payload); err == nil && payload.Secret != "" {
+			secret = payload.Secret
+		}
+	}
+	return secret
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickShareSend(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			cfg := bootstrap.LoadScenario(t, scenario)
+			cfg = bootstrap.WithLocalSharing(cfg, true, "example.com")
+			app := bootstrap.BootTestApp(t, cfg)
+
+			owner := app.CreateUser(t, "qssendowner@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			bucket := app.CreateBucket(t, ownerToken, "qs-send")
+
+			sendPath := func(share models.Share) string {
+				return fmt.Sprintf("/api/v1/buckets/%s/shares/%s/send", bucket.ID, share.ID)
+			}
+
+			t.Run("link and password are sent in separate messages", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:     "send-share",
+					Type:     models.ShareTypeBucket,
+					Password: "correct-horse",
+				})
+
+				var deliveries []models.ShareDelivery
+				status := app.Do(t, http.MethodPost, sendPath(share), ownerToken, models.ShareSendBody{
+					Emails:   []string{"Bob@Example.com", "bob@example.com", "carol@example.com"},
+					Message:  "Quarterly report",
+					Password: "correct-horse",
+				}, &deliveries)
+				require.Equal(t, http.StatusCreated, status)
+				require.Len(t, deliveries, 2, "duplicate recipients are sent once")
+				for _, d := range deliveries {
+					assert.True(t, d.PasswordSent)
+				}
+
+				app.Eventually(t, func() bool {
+					return len(shareNotifications(t, app, "share_sent")) == 2 &&
+						len(shareNotifications(t, app, "share_password")) == 2
+				}, "share link and password notifications")
+
+				for _, n := range shareNotifications(t, app, "share_sent") {
+					var payload struct {
+						ShareURL string
+						Message  string
+					}
+					require.NoError(t, json.Unmarshal(n.Args, &payload))
+					assert.Contains(t, payload.ShareURL, share.ID.String())
+					assert.Equal(t, "Quarterly report", payload.Message)
+					assert.NotContains(t, string(n.Args), "correct-horse", "password is not in the link email")
+				}
+
+				var shares models.Page[models.Share]
+				status = app.Do(t, http.MethodGet, fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID),
+					ownerToken, nil, &shares)
+				require.Equal(t, http.StatusOK, status)
+				for _, s := range shares.Data {
+					if s.ID == share.ID {
+						assert.Len(t, s.Deliveries, 2, "deliveries are recorded on the share")
+					}
+				}
+			})
+
+			t.Run("wrong password is rejected", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:     "wrong-password",
+					Type:     models.ShareTypeBucket,
+					Password: "correct-horse",
+				})
+
+				code, errs := app.DoExpectError(t, http.MethodPost, sendPath(share), ownerToken,
+					models.ShareSendBody{Emails: []string{"dave@example.com"}, Password: "battery-staple"})
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Contains(t, errs, apierrors.CodeSharePasswordInvalid)
+			})
+
+			t.Run("recipient outside the allowed domains is rejected", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "domain-share",
+					Type: models.ShareTypeBucket,
+				})
+
+				code, errs := app.DoExpectError(t, http.MethodPost, sendPath(share), ownerToken,
+					models.ShareSendBody{Emails: []string{"eve@example.com", "mallory@evil.test"}})
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Contains(t, errs, apierrors.CodeShareEmailDomainNotAllowed)
+			})
+		})
+	}
+}
+
+func shareNotifications(t *testing.T, app *bootstrap.TestApp, template string) []bootstrap.Notification {
+	t.Helper()
+
+	var out []bootstrap.Notification
+	for _, n := range app.ReadNotifications(t) {
+		if n.TemplateName == template {
+			out = append(out, n)
+		}
+	}
+	return out
+}
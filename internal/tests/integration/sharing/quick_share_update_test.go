@@ -0,0 +1,198 @@
+//go:build integration
+
+package sharing_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apierrors "github.com/safebucket/safebucket/internal/errors"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/tests/integration/bootstrap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickShareUpdate(t *testing.T) {
+	for _, scenario := range bootstrap.ActiveScenarios() {
+		t.Run(scenario, func(t *testing.T) {
+			cfg := bootstrap.LoadScenario(t, scenario)
+			cfg = bootstrap.WithLocalSharing(cfg, true)
+			app := bootstrap.BootTestApp(t, cfg)
+
+			owner := app.CreateUser(t, "qsupdowner@example.com")
+			contrib := app.CreateUser(t, "qsupdcontrib@example.com")
+			ownerToken := app.LoginAs(t, owner.Email)
+			contribToken := app.LoginAs(t, contrib.Email)
+
+			bucket := app.CreateBucket(t, ownerToken, "qs-update-bucket")
+			app.AddMembers(t, ownerToken, bucket.ID.String(), []models.BucketMemberBody{
+				{Email: contrib.Email, Group: models.GroupContributor},
+			})
+
+			firstFile := uuid.MustParse(app.UploadTestFile(t, ownerToken, bucket.ID.String(), "first.txt"))
+			secondFile := uuid.MustParse(app.UploadTestFile(t, ownerToken, bucket.ID.String(), "second.txt"))
+
+			getShare := func(t *testing.T, shareID uuid.UUID) models.Share {
+				t.Helper()
+				var page models.Page[models.Share]
+				status := app.Do(t, http.MethodGet,
+					fmt.Sprintf("/api/v1/buckets/%s/shares", bucket.ID), ownerToken, nil, &page)
+				require.Equal(t, http.StatusOK, status)
+				for _, share := range page.Data {
+					if share.ID == shareID {
+						return share
+					}
+				}
+				require.FailNow(t, "share not listed", shareID.String())
+				return models.Share{}
+			}
+
+			sharePath := func(shareID uuid.UUID) string {
+				return fmt.Sprintf("/api/v1/buckets/%s/shares/%s", bucket.ID, shareID)
+			}
+
+			t.Run("owner updates limits and resets counters", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "limits-share",
+					Type: models.ShareTypeBucket,
+				})
+				require.Equal(t, http.StatusOK, app.DoPublicShare(t, http.MethodGet,
+					fmt.Sprintf("/api/v1/shares/%s", share.ID), "", nil, nil))
+
+				name := "renamed-share"
+				expiresAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+				maxViews := 5
+				status := app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{
+						Name:          &name,
+						ExpiresAt:     &expiresAt,
+						MaxViews:      &maxViews,
+						ResetCounters: true,
+					})
+				require.Equal(t, http.StatusNoContent, status)
+
+				updated := getShare(t, share.ID)
+				assert.Equal(t, name, updated.Name)
+				require.NotNil(t, updated.ExpiresAt)
+				assert.WithinDuration(t, expiresAt, *updated.ExpiresAt, time.Second)
+				require.NotNil(t, updated.MaxViews)
+				assert.Equal(t, maxViews, *updated.MaxViews)
+				assert.Equal(t, 0, updated.CurrentViews, "counters reset")
+
+				status = app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{ClearExpiresAt: true, ClearMaxViews: true})
+				require.Equal(t, http.StatusNoContent, status)
+
+				cleared := getShare(t, share.ID)
+				assert.Nil(t, cleared.ExpiresAt)
+				assert.Nil(t, cleared.MaxViews)
+				assert.Equal(t, name, cleared.Name, "omitted fields are untouched")
+			})
+
+			t.Run("owner sets and clears password", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "password-share",
+					Type: models.ShareTypeBucket,
+				})
+
+				password := "new-share-secret"
+				status := app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{Password: &password})
+				require.Equal(t, http.StatusNoContent, status)
+				assert.True(t, getShare(t, share.ID).PasswordProtected)
+
+				authStatus, cookie := app.AuthenticateShare(t, share.ID.String(), password)
+				require.Equal(t, http.StatusOK, authStatus)
+				assert.NotEmpty(t, cookie)
+
+				status = app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{ClearPassword: true})
+				require.Equal(t, http.StatusNoContent, status)
+				assert.False(t, getShare(t, share.ID).PasswordProtected)
+			})
+
+			t.Run("value and clear flag are mutually exclusive", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "conflict-share",
+					Type: models.ShareTypeBucket,
+				})
+
+				maxViews := 3
+				status := app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{MaxViews: &maxViews, ClearMaxViews: true})
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+
+			t.Run("owner edits files of a files share", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name:    "files-share",
+					Type:    models.ShareTypeFiles,
+					FileIDs: []uuid.UUID{firstFile},
+				})
+
+				status := app.DoStatus(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{
+						AddFileIDs:    []uuid.UUID{secondFile},
+						RemoveFileIDs: []uuid.UUID{firstFile},
+					})
+				require.Equal(t, http.StatusNoContent, status)
+
+				updated := getShare(t, share.ID)
+				require.Len(t, updated.Files, 1)
+				assert.Equal(t, secondFile, updated.Files[0].FileID)
+
+				code, errs := app.DoExpectError(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{RemoveFileIDs: []uuid.UUID{secondFile}})
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Contains(t, errs, apierrors.CodeShareFilesEmpty)
+
+				code, errs = app.DoExpectError(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{RemoveFileIDs: []uuid.UUID{firstFile}})
+				assert.Equal(t, http.StatusNotFound, code)
+				assert.Contains(t, errs, apierrors.CodeShareFileNotInShare)
+
+				allowUpload := true
+				code, errs = app.DoExpectError(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{AllowUpload: &allowUpload})
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Contains(t, errs, apierrors.CodeShareUploadNotAllowed)
+			})
+
+			t.Run("files cannot be edited on a bucket share", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "bucket-share",
+					Type: models.ShareTypeBucket,
+				})
+
+				code, errs := app.DoExpectError(t, http.MethodPatch, sharePath(share.ID), ownerToken,
+					models.ShareUpdateBody{AddFileIDs: []uuid.UUID{firstFile}})
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Contains(t, errs, apierrors.CodeShareFilesNotEditable)
+			})
+
+			t.Run("contributor cannot update share", func(t *testing.T) {
+				share := app.CreateShare(t, ownerToken, bucket.ID.String(), models.ShareCreateBody{
+					Name: "protected-share",
+					Type: models.ShareTypeBucket,
+				})
+
+				name := "hijacked"
+				status := app.DoStatus(t, http.MethodPatch, sharePath(share.ID), contribToken,
+					models.ShareUpdateBody{Name: &name})
+				assert.Equal(t, http.StatusForbidden, status)
+			})
+
+			t.Run("update unknown share returns 404", func(t *testing.T) {
+				name := "ghost"
+				status := app.DoStatus(t, http.MethodPatch, sharePath(uuid.New()), ownerToken,
+					models.ShareUpdateBody{Name: &name})
+				assert.Equal(t, http.StatusNotFound, status)
+			})
+		})
+	}
+}
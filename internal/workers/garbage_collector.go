@@ -11,7 +11,9 @@ import (
 	"github.com/safebucket/safebucket/internal/cache"
 	"github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
 	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
 	"github.com/safebucket/safebucket/internal/storage"
 
 	"github.com/google/uuid"
@@ -31,16 +33,26 @@ type GarbageCollectorWorker struct {
 	ActivityLogger     activity.IActivityLogger
 	RunInterval        time.Duration
 	RefreshTokenExpiry int
+	// ExpiryWarningDays is how long before their expiration date bucket owners are warned about
+	// files; 0 disables the warnings.
+	ExpiryWarningDays int
 }
 
 func (w *GarbageCollectorWorker) Start(ctx context.Context) {
 	StartPeriodicWorker(ctx, "garbage_collector", w.RunInterval, []WorkerTask{
 		{Name: "stale_uploads", Fn: w.cleanupStaleUploads},
 		{Name: "expired_challenges", Fn: w.cleanupExpiredChallenges},
+		{Name: "expired_invites", Fn: w.cleanupExpiredInvites},
 		{Name: "expired_files", Fn: w.cleanupExpiredFiles},
+		{Name: "expiry_warnings", Fn: w.warnExpiringFiles},
+		{Name: "inactive_files", Fn: w.trashInactiveFiles},
 		{Name: "expired_shares", Fn: w.cleanupExpiredShares},
 		{Name: "max_views_shares", Fn: w.cleanupMaxViewsShares},
 		{Name: "expired_sessions", Fn: w.cleanupExpiredSessions},
+		{Name: "sent_outbox_events", Fn: w.cleanupSentOutboxEvents},
+		{Name: "sent_notifications", Fn: w.cleanupSentNotifications},
+		{Name: "expired_notifications", Fn: w.expireQueuedNotifications},
+		{Name: "old_share_accesses", Fn: w.cleanupOldShareAccesses},
 	})
 }
 
@@ -140,12 +152,82 @@ func (w *GarbageCollectorWorker) cleanupExpiredChallenges(_ context.Context) (in
 	return int(result.RowsAffected), nil
 }
 
-// cleanupExpiredFiles hard-deletes files that have passed their expiration date.
+// cleanupExpiredInvites hard-deletes invites that were not accepted before their expiration date.
+func (w *GarbageCollectorWorker) cleanupExpiredInvites(_ context.Context) (int, error) {
+	var invites []models.Invite
+
+	if err := w.DB.
+		Preload("Bucket").
+		Where("expires_at IS NOT NULL AND expires_at < ?", time.Now()).
+		Limit(GCBatchSize).
+		Find(&invites).Error; err != nil {
+		return 0, err
+	}
+
+	if len(invites) == 0 {
+		return 0, nil
+	}
+
+	inviteIDs := make([]uuid.UUID, len(invites))
+	for i, invite := range invites {
+		inviteIDs[i] = invite.ID
+	}
+
+	var rowsAffected int64
+
+	err := w.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("invite_id IN ?", inviteIDs).Delete(&models.Challenge{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.Invite{}, inviteIDs)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, invite := range invites {
+		action := models.Activity{
+			Message: activity.InviteExpired,
+			Object:  invite.Bucket.ToActivity(),
+			Filter: activity.NewLogFilter(models.ActivityFields{
+				Action:            rbac.ActionDelete.String(),
+				ObjectType:        rbac.ResourceBucket.String(),
+				BucketID:          invite.BucketID.String(),
+				InviteID:          invite.ID.String(),
+				BucketMemberEmail: invite.Email,
+			}),
+		}
+		if err := w.ActivityLogger.Send(action); err != nil {
+			zap.L().Error("Failed to log invite expiration activity", zap.Error(err))
+		}
+	}
+
+	if rowsAffected > 0 {
+		zap.L().Debug("Deleted expired invites", zap.Int64("count", rowsAffected))
+	}
+
+	return int(rowsAffected), nil
+}
+
+// cleanupExpiredFiles hard-deletes files that have passed their expiration date. Files under
+// legal hold or still within their bucket's retention period are kept until they are released.
 func (w *GarbageCollectorWorker) cleanupExpiredFiles(_ context.Context) (int, error) {
+	scope, err := retention.LoadScope(w.DB, nil)
+	if err != nil {
+		return 0, err
+	}
+
 	var files []models.File
 
-	if err := w.DB.Unscoped().
-		Where("expires_at IS NOT NULL AND expires_at < ?", time.Now()).
+	query := w.DB.Unscoped().Where("expires_at IS NOT NULL AND expires_at < ?", time.Now())
+	if err = scope.ExcludeProtectedFiles(query, retention.ActionPurge).
 		Limit(GCBatchSize).
 		Find(&files).Error; err != nil {
 		return 0, err
@@ -168,7 +250,7 @@ func (w *GarbageCollectorWorker) cleanupExpiredFiles(_ context.Context) (int, er
 
 	var rowsAffected int64
 
-	err := w.DB.Transaction(func(tx *gorm.DB) error {
+	err = w.DB.Transaction(func(tx *gorm.DB) error {
 		result := tx.Unscoped().Delete(&models.File{}, fileIDs)
 		if result.Error != nil {
 			return result.Error
@@ -318,3 +400,79 @@ func (w *GarbageCollectorWorker) cleanupExpiredSessions(_ context.Context) (int,
 
 	return cleaned, nil
 }
+
+// cleanupSentOutboxEvents hard-deletes outbox rows that were relayed more than a day ago.
+func (w *GarbageCollectorWorker) cleanupSentOutboxEvents(_ context.Context) (int, error) {
+	threshold := time.Now().Add(-OutboxSentRetention)
+
+	result := w.DB.
+		Where("status = ? AND sent_at < ?", models.OutboxStatusSent, threshold).
+		Delete(&models.OutboxEvent{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		zap.L().Debug("Deleted sent outbox events", zap.Int64("count", result.RowsAffected))
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// cleanupSentNotifications hard-deletes queued notifications delivered more than a week ago.
+func (w *GarbageCollectorWorker) cleanupSentNotifications(_ context.Context) (int, error) {
+	threshold := time.Now().Add(-NotificationSentRetention)
+
+	result := w.DB.
+		Where("status = ? AND sent_at < ?", models.NotificationStatusSent, threshold).
+		Delete(&models.QueuedNotification{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		zap.L().Debug("Deleted sent notifications", zap.Int64("count", result.RowsAffected))
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// expireQueuedNotifications fails notifications whose data expired, e.g. reset codes, and drops
+// their payload so no secret outlives its challenge.
+func (w *GarbageCollectorWorker) expireQueuedNotifications(_ context.Context) (int, error) {
+	result := w.DB.Model(&models.QueuedNotification{}).
+		Where("expires_at <= ? AND payload <> ?", time.Now(), "").
+		Updates(map[string]any{
+			"status":     models.NotificationStatusFailed,
+			"payload":    "",
+			"last_error": notifier.ErrNotificationExpired.Error(),
+		})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		zap.L().Debug("Expired queued notifications", zap.Int64("count", result.RowsAffected))
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// cleanupOldShareAccesses hard-deletes share accesses past the analytics retention period.
+func (w *GarbageCollectorWorker) cleanupOldShareAccesses(_ context.Context) (int, error) {
+	threshold := time.Now().Add(-configuration.ShareAccessRetention)
+
+	result := w.DB.Where("created_at < ?", threshold).Delete(&models.ShareAccess{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		zap.L().Debug("Deleted old share accesses", zap.Int64("count", result.RowsAffected))
+	}
+
+	return int(result.RowsAffected), nil
+}
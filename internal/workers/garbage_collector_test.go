@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/configuration"
 	"github.com/safebucket/safebucket/internal/database"
 	"github.com/safebucket/safebucket/internal/models"
 	"github.com/safebucket/safebucket/internal/storage"
@@ -21,11 +22,13 @@ type gcStubStorage struct {
 	abortedUploadIDs  []string
 }
 
-func (s *gcStubStorage) PresignedGetObject(string, storage.GetObjectOptions) (string, error) {
-	return "", nil
+func (s *gcStubStorage) PresignedGetObject(string, storage.GetObjectOptions) (storage.PresignedDownload, error) {
+	return storage.PresignedDownload{}, nil
 }
 
-func (s *gcStubStorage) PresignUpload(string, int, map[string]string) (storage.PresignedUpload, error) {
+func (s *gcStubStorage) PresignUpload(
+	string, int, map[string]string, storage.ObjectEncryption,
+) (storage.PresignedUpload, error) {
 	return storage.PresignedUpload{}, nil
 }
 
@@ -38,7 +41,9 @@ func (s *gcStubStorage) ListObjectParts(path, uploadID string) ([]storage.PartIn
 	return nil, nil
 }
 
-func (s *gcStubStorage) CompleteMultipartUpload(string, string, []storage.PartInfo, map[string]string) error {
+func (s *gcStubStorage) CompleteMultipartUpload(
+	string, string, []storage.PartInfo, map[string]string, storage.ObjectEncryption,
+) error {
 	return nil
 }
 
@@ -47,15 +52,45 @@ func (s *gcStubStorage) AbortMultipartUpload(_, uploadID string) error {
 	return nil
 }
 
-func (s *gcStubStorage) StatObject(string) (map[string]string, error) { return nil, nil }
-func (s *gcStubStorage) ListObjects(string, int32) ([]string, error)  { return nil, nil }
-func (s *gcStubStorage) RemoveObject(string) error                    { return nil }
-func (s *gcStubStorage) RemoveObjects([]string) error                 { return nil }
-func (s *gcStubStorage) EnsureTrashLifecyclePolicy(int) error         { return nil }
-func (s *gcStubStorage) MarkAsTrashed(string, any) error              { return nil }
-func (s *gcStubStorage) UnmarkAsTrashed(string, any) error            { return nil }
-func (s *gcStubStorage) IsTrashMarkerPath(string) (bool, string)      { return false, "" }
-func (s *gcStubStorage) GetBucketName() string                        { return "" }
+func (s *gcStubStorage) StatObject(string, storage.ObjectEncryption) (map[string]string, error) {
+	return nil, nil
+}
+
+func (s *gcStubStorage) ValidateEncryption(storage.ObjectEncryption) error { return nil }
+func (s *gcStubStorage) ListObjects(string, int32) ([]string, error)       { return nil, nil }
+func (s *gcStubStorage) RemoveObject(string) error                         { return nil }
+func (s *gcStubStorage) RemoveObjects([]string) error                      { return nil }
+func (s *gcStubStorage) EnsureTrashLifecyclePolicy(int) error              { return nil }
+func (s *gcStubStorage) MarkAsTrashed(string, any) error                   { return nil }
+func (s *gcStubStorage) UnmarkAsTrashed(string, any) error                 { return nil }
+func (s *gcStubStorage) IsTrashMarkerPath(string) (bool, string)           { return false, "" }
+func (s *gcStubStorage) SupportsObjectLock() bool                          { return false }
+func (s *gcStubStorage) SetObjectLock(string, storage.ObjectLock) error    { return nil }
+func (s *gcStubStorage) GetBucketName() string                             { return "" }
+
+type gcStubActivityLogger struct {
+	sent []models.Activity
+}
+
+func (l *gcStubActivityLogger) Search(
+	map[string][]string,
+	time.Time,
+	time.Time,
+	int,
+) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (l *gcStubActivityLogger) Send(message models.Activity) error {
+	l.sent = append(l.sent, message)
+	return nil
+}
+
+func (l *gcStubActivityLogger) CountByHour(map[string][]string, int) ([]models.TimeSeriesPoint, error) {
+	return nil, nil
+}
+
+func (l *gcStubActivityLogger) Close() error { return nil }
 
 func setupGCTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
@@ -218,3 +253,146 @@ func TestCleanupStaleUploads(t *testing.T) {
 		assert.Empty(t, store.abortedUploadIDs)
 	})
 }
+
+func createGCTestInvite(t *testing.T, db *gorm.DB, bucket models.Bucket, expiresAt *time.Time) models.Invite {
+	t.Helper()
+
+	invite := models.Invite{
+		Email:     "invitee-" + uuid.NewString() + "@example.com",
+		Group:     models.GroupViewer,
+		BucketID:  bucket.ID,
+		CreatedBy: bucket.CreatedBy,
+		ExpiresAt: expiresAt,
+	}
+	require.NoError(t, db.Create(&invite).Error)
+
+	return invite
+}
+
+func countInvites(t *testing.T, db *gorm.DB, inviteID uuid.UUID) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Model(&models.Invite{}).Where("id = ?", inviteID).Count(&count).Error)
+	return count
+}
+
+func TestCleanupExpiredInvites(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	t.Run("expired invite and its challenges are deleted", func(t *testing.T) {
+		db := setupGCTestDB(t)
+		bucket := gcTestBucket(t, db)
+		invite := createGCTestInvite(t, db, bucket, &past)
+
+		challenge := models.Challenge{
+			Type:         models.ChallengeTypeInvite,
+			InviteID:     &invite.ID,
+			HashedSecret: "hash",
+			ExpiresAt:    &future,
+			AttemptsLeft: 3,
+		}
+		require.NoError(t, db.Create(&challenge).Error)
+
+		logger := &gcStubActivityLogger{}
+		worker := &GarbageCollectorWorker{DB: db, ActivityLogger: logger}
+
+		count, err := worker.cleanupExpiredInvites(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, int64(0), countInvites(t, db, invite.ID))
+
+		var challenges int64
+		require.NoError(t, db.Unscoped().Model(&models.Challenge{}).
+			Where("invite_id = ?", invite.ID).Count(&challenges).Error)
+		assert.Equal(t, int64(0), challenges)
+
+		require.Len(t, logger.sent, 1)
+		assert.Equal(t, "INVITE_EXPIRED", logger.sent[0].Message)
+	})
+
+	t.Run("pending and legacy invites are left alone", func(t *testing.T) {
+		db := setupGCTestDB(t)
+		bucket := gcTestBucket(t, db)
+		pending := createGCTestInvite(t, db, bucket, &future)
+		legacy := createGCTestInvite(t, db, bucket, nil)
+
+		logger := &gcStubActivityLogger{}
+		worker := &GarbageCollectorWorker{DB: db, ActivityLogger: logger}
+
+		count, err := worker.cleanupExpiredInvites(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, int64(1), countInvites(t, db, pending.ID))
+		assert.Equal(t, int64(1), countInvites(t, db, legacy.ID))
+		assert.Empty(t, logger.sent)
+	})
+}
+
+func TestCleanupExpiredFiles(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("files under legal hold or retention are kept", func(t *testing.T) {
+		db := setupGCTestDB(t)
+		bucket := gcTestBucket(t, db)
+		held := createGCTestFile(t, db, bucket.ID, time.Now())
+		expired := createGCTestFile(t, db, bucket.ID, time.Now())
+		for _, file := range []models.File{held, expired} {
+			require.NoError(t, db.Model(&file).Updates(map[string]any{
+				"status":     models.FileStatusUploaded,
+				"expires_at": past,
+			}).Error)
+		}
+
+		hold := models.LegalHold{BucketID: bucket.ID, FileID: &held.ID, Reason: "audit", CreatedBy: bucket.CreatedBy}
+		require.NoError(t, db.Create(&hold).Error)
+
+		worker := &GarbageCollectorWorker{DB: db, Storage: &gcStubStorage{}, ActivityLogger: &gcStubActivityLogger{}}
+
+		count, err := worker.cleanupExpiredFiles(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, int64(1), countFiles(t, db, held.ID))
+		assert.Equal(t, int64(0), countFiles(t, db, expired.ID))
+
+		policy := models.RetentionPolicy{Name: "records", RetentionDays: 30, CreatedBy: bucket.CreatedBy}
+		require.NoError(t, db.Create(&policy).Error)
+		require.NoError(t, db.Model(&bucket).Update("retention_policy_id", policy.ID).Error)
+		require.NoError(t, db.Model(&hold).Update("released_at", time.Now()).Error)
+
+		count, err = worker.cleanupExpiredFiles(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, int64(1), countFiles(t, db, held.ID))
+	})
+}
+
+func TestCleanupOldShareAccesses(t *testing.T) {
+	db := setupGCTestDB(t)
+	bucket := gcTestBucket(t, db)
+	share := models.Share{
+		Name:      "analytics",
+		BucketID:  bucket.ID,
+		Type:      models.ShareTypeBucket,
+		CreatedBy: bucket.CreatedBy,
+	}
+	require.NoError(t, db.Create(&share).Error)
+
+	recent := models.ShareAccess{ShareID: share.ID, Type: models.ShareAccessTypeView}
+	old := models.ShareAccess{ShareID: share.ID, Type: models.ShareAccessTypeView}
+	require.NoError(t, db.Create(&recent).Error)
+	require.NoError(t, db.Create(&old).Error)
+	require.NoError(t, db.Model(&old).
+		UpdateColumn("created_at", time.Now().Add(-configuration.ShareAccessRetention-time.Hour)).Error)
+
+	worker := &GarbageCollectorWorker{DB: db}
+	count, err := worker.cleanupOldShareAccesses(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var remaining []models.ShareAccess
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, recent.ID, remaining[0].ID)
+}
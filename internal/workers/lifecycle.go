@@ -0,0 +1,208 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/lifecycle"
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/rbac"
+	"github.com/safebucket/safebucket/internal/retention"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// warnExpiringFiles emails the owners of each bucket the list of its files that expire within
+// ExpiryWarningDays. Files are flagged in the same transaction so each one is announced once.
+func (w *GarbageCollectorWorker) warnExpiringFiles(_ context.Context) (int, error) {
+	if w.ExpiryWarningDays <= 0 {
+		return 0, nil
+	}
+
+	scope, err := retention.LoadScope(w.DB, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	var files []models.File
+	query := w.DB.Where(
+		"status = ? AND expiry_warned_at IS NULL AND expires_at > ? AND expires_at <= ?",
+		models.FileStatusUploaded, now, now.AddDate(0, 0, w.ExpiryWarningDays),
+	)
+	if err = scope.ExcludeProtectedFiles(query, retention.ActionPurge).
+		Order("bucket_id, expires_at").
+		Limit(GCBatchSize).
+		Find(&files).Error; err != nil {
+		return 0, err
+	}
+
+	byBucket := map[uuid.UUID][]models.File{}
+	for _, file := range files {
+		byBucket[file.BucketID] = append(byBucket[file.BucketID], file)
+	}
+
+	warned := 0
+	var errs []error
+	for bucketID, bucketFiles := range byBucket {
+		if err = w.warnBucketOwners(bucketID, bucketFiles, now); err != nil {
+			zap.L().Error("Failed to warn bucket owners about expiring files",
+				zap.String("bucket_id", bucketID.String()), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		warned += len(bucketFiles)
+	}
+
+	if warned > 0 {
+		zap.L().Debug("Warned owners about expiring files", zap.Int("count", warned))
+	}
+
+	return warned, errors.Join(errs...)
+}
+
+func (w *GarbageCollectorWorker) warnBucketOwners(bucketID uuid.UUID, files []models.File, now time.Time) error {
+	var bucket models.Bucket
+	if err := w.DB.Where("id = ?", bucketID).First(&bucket).Error; err != nil {
+		return err
+	}
+
+	memberships, err := rbac.GetBucketMembers(w.DB, bucketID)
+	if err != nil {
+		return err
+	}
+
+	expiring := make([]events.ExpiringFile, len(files))
+	fileIDs := make([]uuid.UUID, len(files))
+	for i, file := range files {
+		expiring[i] = events.ExpiringFile{Name: file.Name, ExpiresAt: *file.ExpiresAt}
+		fileIDs[i] = file.ID
+	}
+
+	return w.DB.Transaction(func(tx *gorm.DB) error {
+		for _, membership := range memberships {
+			if membership.Group != models.GroupOwner {
+				continue
+			}
+			event := events.NewFilesExpiring(membership.User.Email, bucket.ID, bucket.Name, expiring)
+			if err := event.Enqueue(tx); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.File{}).
+			Where("id IN ?", fileIDs).
+			UpdateColumn("expiry_warned_at", now).Error
+	})
+}
+
+// trashInactiveFiles moves to the trash the files nobody downloaded within the inactivity period
+// of their bucket lifecycle rules. Trashed files then follow the regular trash retention.
+func (w *GarbageCollectorWorker) trashInactiveFiles(_ context.Context) (int, error) {
+	var bucketIDs []uuid.UUID
+	if err := w.DB.Model(&models.BucketLifecycleRule{}).
+		Where("inactive_days IS NOT NULL").
+		Distinct().
+		Pluck("bucket_id", &bucketIDs).Error; err != nil {
+		return 0, err
+	}
+
+	if len(bucketIDs) == 0 {
+		return 0, nil
+	}
+
+	scope, err := retention.LoadScope(w.DB, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	trashed := 0
+	var errs []error
+
+	for _, bucketID := range bucketIDs {
+		if trashed >= GCBatchSize {
+			break
+		}
+
+		rules, loadErr := lifecycle.Load(w.DB, bucketID)
+		if loadErr != nil {
+			errs = append(errs, loadErr)
+			continue
+		}
+
+		query, ok := rules.InactiveFiles(
+			w.DB.Where("bucket_id = ? AND status = ?", bucketID, models.FileStatusUploaded), now,
+		)
+		if !ok {
+			continue
+		}
+
+		var files []models.File
+		if err = scope.ExcludeProtectedFiles(query, retention.ActionTrash).
+			Limit(GCBatchSize - trashed).
+			Find(&files).Error; err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, file := range files {
+			if err = w.trashInactiveFile(file); err != nil {
+				zap.L().Error("Failed to trash inactive file",
+					zap.String("file_id", file.ID.String()), zap.Error(err))
+				errs = append(errs, err)
+				continue
+			}
+			trashed++
+		}
+	}
+
+	if trashed > 0 {
+		zap.L().Debug("Trashed inactive files", zap.Int("count", trashed))
+	}
+
+	return trashed, errors.Join(errs...)
+}
+
+func (w *GarbageCollectorWorker) trashInactiveFile(file models.File) error {
+	return w.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&file).
+			Where("status = ?", models.FileStatusUploaded).
+			Update("status", models.FileStatusDeleted)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Delete(&file).Error; err != nil {
+			return err
+		}
+
+		objectPath := path.Join("buckets", file.BucketID.String(), file.ID.String())
+		if err := w.Storage.MarkAsTrashed(objectPath, file); err != nil {
+			return err
+		}
+
+		action := models.Activity{
+			Message: activity.FileInactiveTrashed,
+			Object:  file.ToActivity(),
+			Filter: activity.NewLogFilter(models.ActivityFields{
+				Action:     rbac.ActionErase.String(),
+				BucketID:   file.BucketID.String(),
+				FileID:     file.ID.String(),
+				ObjectType: rbac.ResourceFile.String(),
+			}),
+		}
+		if err := w.ActivityLogger.Send(action); err != nil {
+			zap.L().Error("Failed to log inactive file activity", zap.Error(err))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package workers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/activity"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestTrashInactiveFiles(t *testing.T) {
+	db := setupGCTestDB(t)
+	bucket := gcTestBucket(t, db)
+
+	require.NoError(t, db.Create(&[]models.BucketLifecycleRule{
+		{BucketID: bucket.ID, InactiveDays: intPtr(30)},
+		{BucketID: bucket.ID, Extension: "log", InactiveDays: intPtr(7)},
+	}).Error)
+
+	stale := createGCTestFile(t, db, bucket.ID, time.Now().AddDate(0, 0, -40))
+	downloaded := createGCTestFile(t, db, bucket.ID, time.Now().AddDate(0, 0, -40))
+	recent := createGCTestFile(t, db, bucket.ID, time.Now().AddDate(0, 0, -10))
+	staleLog := createGCTestFile(t, db, bucket.ID, time.Now().AddDate(0, 0, -10))
+	require.NoError(t, db.Model(&models.File{}).
+		Where("bucket_id = ?", bucket.ID).
+		UpdateColumn("status", models.FileStatusUploaded).Error)
+	require.NoError(t, db.Model(&downloaded).UpdateColumn("last_downloaded_at", time.Now()).Error)
+	require.NoError(t, db.Model(&staleLog).UpdateColumn("extension", "LOG").Error)
+
+	logger := &gcStubActivityLogger{}
+	worker := &GarbageCollectorWorker{DB: db, Storage: &gcStubStorage{}, ActivityLogger: logger}
+
+	count, err := worker.trashInactiveFiles(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	for _, file := range []models.File{stale, staleLog} {
+		var trashed models.File
+		require.NoError(t, db.Unscoped().First(&trashed, "id = ?", file.ID).Error)
+		assert.True(t, trashed.DeletedAt.Valid)
+		assert.Equal(t, models.FileStatusDeleted, trashed.Status)
+	}
+	for _, file := range []models.File{downloaded, recent} {
+		assert.Equal(t, int64(1), countFiles(t, db, file.ID))
+	}
+
+	require.Len(t, logger.sent, 2)
+	assert.Equal(t, activity.FileInactiveTrashed, logger.sent[0].Message)
+}
+
+func TestWarnExpiringFiles(t *testing.T) {
+	db := setupGCTestDB(t)
+	bucket := gcTestBucket(t, db)
+
+	var owner models.User
+	require.NoError(t, db.First(&owner, "id = ?", bucket.CreatedBy).Error)
+	require.NoError(t, db.Create(&models.Membership{
+		UserID: owner.ID, BucketID: bucket.ID, Group: models.GroupOwner,
+	}).Error)
+
+	soon := createGCTestFile(t, db, bucket.ID, time.Now())
+	later := createGCTestFile(t, db, bucket.ID, time.Now())
+	require.NoError(t, db.Model(&models.File{}).
+		Where("bucket_id = ?", bucket.ID).
+		UpdateColumn("status", models.FileStatusUploaded).Error)
+	require.NoError(t, db.Model(&soon).UpdateColumn("expires_at", time.Now().AddDate(0, 0, 2)).Error)
+	require.NoError(t, db.Model(&later).UpdateColumn("expires_at", time.Now().AddDate(0, 0, 10)).Error)
+
+	worker := &GarbageCollectorWorker{DB: db, ExpiryWarningDays: 3}
+
+	count, err := worker.warnExpiringFiles(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var outbox []models.OutboxEvent
+	require.NoError(t, db.Where("event_type = ?", events.FilesExpiringName).Find(&outbox).Error)
+	require.Len(t, outbox, 1)
+
+	var payload events.FilesExpiringPayload
+	require.NoError(t, json.Unmarshal([]byte(outbox[0].Payload), &payload))
+	assert.Equal(t, owner.Email, payload.To)
+	require.Len(t, payload.Files, 1)
+	assert.Equal(t, soon.Name, payload.Files[0].Name)
+
+	count, err = worker.warnExpiringFiles(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "files are only announced once")
+}
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/cache"
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/notifier"
+)
+
+const NotificationDigestInterval = 5 * time.Minute
+
+// NotificationDigestWorker periodically sends the hourly and daily digests collected for
+// members who opted out of immediate notifications.
+type NotificationDigestWorker struct {
+	Cache       cache.ICache
+	Notifier    notifier.INotifier
+	RunInterval time.Duration
+}
+
+func (w *NotificationDigestWorker) Start(ctx context.Context) {
+	StartPeriodicWorker(ctx, "notification_digest", w.RunInterval, []WorkerTask{
+		{Name: "due_digests", Fn: w.flushDue},
+	})
+}
+
+func (w *NotificationDigestWorker) flushDue(_ context.Context) (int, error) {
+	return events.FlushNotificationDigests(w.Cache, w.Notifier, time.Now()), nil
+}
@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationRetryInterval  = 30 * time.Second
+	NotificationRetryBatchSize = 50
+	NotificationSentRetention  = 7 * 24 * time.Hour
+)
+
+// NotificationRetryWorker redelivers queued notifications whose previous attempt failed
+// once their backoff has elapsed.
+type NotificationRetryWorker struct {
+	DB          *gorm.DB
+	Queue       *notifier.QueuedNotifier
+	RunInterval time.Duration
+}
+
+func (w *NotificationRetryWorker) Start(ctx context.Context) {
+	StartPeriodicWorker(ctx, "notification_retry", w.RunInterval, []WorkerTask{
+		{Name: "due_notifications", Fn: w.retryDue},
+	})
+}
+
+func (w *NotificationRetryWorker) retryDue(ctx context.Context) (int, error) {
+	var entries []models.QueuedNotification
+	if err := w.DB.
+		Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(NotificationRetryBatchSize).
+		Find(&entries).Error; err != nil {
+		return 0, err
+	}
+
+	retried := 0
+	for i := range entries {
+		if ctx.Err() != nil {
+			return retried, nil
+		}
+
+		claimed, err := w.Queue.Claim(&entries[i])
+		if err != nil {
+			return retried, err
+		}
+		if !claimed {
+			continue
+		}
+
+		w.Queue.Deliver(&entries[i])
+		retried++
+	}
+
+	if retried > 0 {
+		zap.L().Debug("Retried queued notifications", zap.Int("count", retried))
+	}
+
+	return retried, nil
+}
@@ -0,0 +1,304 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/notifier"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testQueueKey = "0123456789abcdef0123456789abcdef"
+
+type retryStubNotifier struct {
+	sent []map[string]any
+	err  error
+}
+
+func (n *retryStubNotifier) NotifyFromTemplate(_ string, _ string, _ string, data any) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, data.(map[string]any))
+	return nil
+}
+
+func retryTestUser(t *testing.T, db *gorm.DB, email string, bounced bool) {
+	t.Helper()
+
+	user := models.User{
+		Email:        email,
+		ProviderType: models.LocalProviderType,
+		ProviderKey:  string(models.LocalProviderType),
+		Role:         models.RoleUser,
+		EmailBounced: bounced,
+	}
+	require.NoError(t, db.Create(&user).Error)
+}
+
+func loadQueuedNotification(t *testing.T, db *gorm.DB) models.QueuedNotification {
+	t.Helper()
+
+	var entry models.QueuedNotification
+	require.NoError(t, db.First(&entry).Error)
+	return entry
+}
+
+func isBounced(t *testing.T, db *gorm.DB, email string) bool {
+	t.Helper()
+
+	var user models.User
+	require.NoError(t, db.Where("email = ?", email).First(&user).Error)
+	return user.EmailBounced
+}
+
+func TestQueuedNotifier_SendsImmediately(t *testing.T) {
+	db := setupGCTestDB(t)
+	retryTestUser(t, db, "alice@example.com", true)
+
+	stub := &retryStubNotifier{}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+
+	payload := map[string]string{"ChallengeURL": "http://localhost/reset"}
+	require.NoError(t, queue.NotifyFromTemplate("alice@example.com", "Reset", "password_reset", payload))
+
+	require.Len(t, stub.sent, 1)
+	assert.Equal(t, "http://localhost/reset", stub.sent[0]["ChallengeURL"])
+
+	entry := loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusSent, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Empty(t, entry.Payload, "template data must not be kept once delivered")
+	assert.NotNil(t, entry.SentAt)
+	assert.False(t, isBounced(t, db, "alice@example.com"), "a delivery clears the bounced flag")
+}
+
+func TestNotificationRetry_BacksOffThenSucceeds(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	stub := &retryStubNotifier{err: errors.New("connection refused")}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+	worker := &NotificationRetryWorker{DB: db, Queue: queue, RunInterval: time.Second}
+
+	require.NoError(t, queue.NotifyFromTemplate("bob@example.com", "Invite", "user_invitation", map[string]string{}))
+
+	entry := loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusPending, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	require.NotNil(t, entry.LastError)
+	assert.Equal(t, "connection refused", *entry.LastError)
+	assert.True(t, entry.NextAttemptAt.After(time.Now()), "the next attempt waits for the backoff")
+
+	count, err := worker.retryDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "entries are not retried before their backoff elapses")
+
+	require.NoError(t, db.Model(&entry).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	stub.err = nil
+
+	count, err = worker.retryDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, stub.sent, 1)
+
+	entry = loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusSent, entry.Status)
+	assert.Equal(t, 2, entry.Attempts)
+	assert.Nil(t, entry.LastError)
+}
+
+func TestNotificationRetry_FailsAfterMaxAttempts(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	stub := &retryStubNotifier{err: errors.New("connection refused")}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+
+	require.NoError(t, queue.NotifyFromTemplate("carol@example.com", "Invite", "user_invitation", map[string]string{}))
+	require.NoError(t, db.Model(&models.QueuedNotification{}).
+		Where("1 = 1").
+		Update("attempts", notifier.NotificationMaxAttempts-1).Error)
+
+	entry := loadQueuedNotification(t, db)
+	queue.Deliver(&entry)
+
+	entry = loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusFailed, entry.Status)
+	assert.Equal(t, notifier.NotificationMaxAttempts, entry.Attempts)
+	assert.NotEmpty(t, entry.Payload, "failed entries keep their data so an admin can resend them")
+}
+
+func TestNotificationRetry_BounceFlagsUser(t *testing.T) {
+	db := setupGCTestDB(t)
+	retryTestUser(t, db, "dave@example.com", false)
+
+	stub := &retryStubNotifier{
+		err: fmt.Errorf("%w: 550 mailbox unavailable", notifier.ErrRecipientRejected),
+	}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+
+	require.NoError(t, queue.NotifyFromTemplate("dave@example.com", "Invite", "user_invitation", map[string]string{}))
+
+	entry := loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusFailed, entry.Status, "bounces are not retried")
+	assert.Equal(t, 1, entry.Attempts)
+	assert.True(t, isBounced(t, db, "dave@example.com"))
+}
+
+type expiringPayload struct {
+	Secret    string
+	ExpiresAt time.Time
+}
+
+func (p expiringPayload) NotificationExpiresAt() time.Time { return p.ExpiresAt }
+
+func TestQueuedNotifier_EncryptsPayload(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	stub := &retryStubNotifier{err: errors.New("connection refused")}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+
+	payload := expiringPayload{Secret: "483920", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, queue.NotifyFromTemplate("frank@example.com", "Reset", "password_reset", payload))
+
+	entry := loadQueuedNotification(t, db)
+	assert.NotEmpty(t, entry.Payload)
+	assert.NotContains(t, entry.Payload, "483920", "secrets must not be stored in plain text")
+	require.NotNil(t, entry.ExpiresAt)
+	assert.WithinDuration(t, payload.ExpiresAt, *entry.ExpiresAt, time.Second)
+
+	stub.err = nil
+	queue.Deliver(&entry)
+	require.Len(t, stub.sent, 1)
+	assert.Equal(t, "483920", stub.sent[0]["Secret"])
+}
+
+func TestQueuedNotifier_ClaimIsExclusive(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	stub := &retryStubNotifier{err: errors.New("connection refused")}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+	require.NoError(t, queue.NotifyFromTemplate("gina@example.com", "Invite", "user_invitation", map[string]string{}))
+
+	entry := loadQueuedNotification(t, db)
+	first, second := entry, entry
+
+	claimed, err := queue.Claim(&first)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = queue.Claim(&second)
+	require.NoError(t, err)
+	assert.False(t, claimed, "an entry already claimed by another worker must be skipped")
+}
+
+func TestNotificationRetry_SkipsFreshEntries(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	// NotifyFromTemplate inserts entries claimed for their first attempt.
+	queue := notifier.NewQueuedNotifier(db, &retryStubNotifier{}, testQueueKey)
+	worker := &NotificationRetryWorker{DB: db, Queue: queue, RunInterval: time.Second}
+
+	require.NoError(t, db.Create(&models.QueuedNotification{
+		Recipient:     "hana@example.com",
+		Subject:       "Invite",
+		Template:      "user_invitation",
+		Status:        models.NotificationStatusPending,
+		NextAttemptAt: time.Now().Add(notifier.NotificationClaimTimeout),
+	}).Error)
+
+	count, err := worker.retryDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count, "entries being sent by their first attempt are not picked up")
+}
+
+func TestNotificationRetry_DropsExpiredEntries(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	stub := &retryStubNotifier{err: errors.New("connection refused")}
+	queue := notifier.NewQueuedNotifier(db, stub, testQueueKey)
+	worker := &NotificationRetryWorker{DB: db, Queue: queue, RunInterval: time.Second}
+
+	payload := expiringPayload{Secret: "483920", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, queue.NotifyFromTemplate("ivan@example.com", "Reset", "password_reset", payload))
+	require.NoError(t, db.Model(&models.QueuedNotification{}).Where("1 = 1").Updates(map[string]any{
+		"next_attempt_at": time.Now().Add(-time.Second),
+		"expires_at":      time.Now().Add(-time.Second),
+	}).Error)
+	stub.err = nil
+
+	count, err := worker.retryDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Empty(t, stub.sent, "expired codes are not delivered")
+
+	entry := loadQueuedNotification(t, db)
+	assert.Equal(t, models.NotificationStatusFailed, entry.Status)
+	assert.Empty(t, entry.Payload)
+}
+
+func TestExpireQueuedNotifications(t *testing.T) {
+	db := setupGCTestDB(t)
+	worker := &GarbageCollectorWorker{DB: db}
+
+	expired := time.Now().Add(-time.Minute)
+	valid := time.Now().Add(time.Hour)
+	for _, expiresAt := range []time.Time{expired, valid} {
+		require.NoError(t, db.Create(&models.QueuedNotification{
+			Recipient:     "jane@example.com",
+			Subject:       "Reset",
+			Template:      "password_reset",
+			Payload:       "sealed",
+			Status:        models.NotificationStatusFailed,
+			NextAttemptAt: time.Now(),
+			ExpiresAt:     &expiresAt,
+		}).Error)
+	}
+
+	count, err := worker.expireQueuedNotifications(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var remaining int64
+	require.NoError(t, db.Model(&models.QueuedNotification{}).Where("payload <> ?", "").Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+}
+
+func TestNotificationRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, notifier.RetryDelay(1))
+	assert.Equal(t, 2*time.Minute, notifier.RetryDelay(2))
+	assert.Equal(t, 16*time.Minute, notifier.RetryDelay(5))
+	assert.Equal(t, time.Hour, notifier.RetryDelay(20))
+}
+
+func TestCleanupSentNotifications(t *testing.T) {
+	db := setupGCTestDB(t)
+	worker := &GarbageCollectorWorker{DB: db}
+
+	old := time.Now().Add(-2 * NotificationSentRetention)
+	recent := time.Now()
+	for _, sentAt := range []time.Time{old, recent} {
+		require.NoError(t, db.Create(&models.QueuedNotification{
+			Recipient:     "erin@example.com",
+			Subject:       "Invite",
+			Template:      "user_invitation",
+			Status:        models.NotificationStatusSent,
+			NextAttemptAt: sentAt,
+			SentAt:        &sentAt,
+		}).Error)
+	}
+
+	count, err := worker.cleanupSentNotifications(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var remaining int64
+	require.NoError(t, db.Model(&models.QueuedNotification{}).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+}
@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/messaging"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	OutboxBatchSize     = 100
+	OutboxRelayInterval = time.Second
+	OutboxSentRetention = 24 * time.Hour
+)
+
+// OutboxRelayWorker publishes pending outbox rows through the event router and marks
+// them as sent. Rows are only marked after a successful publish, so delivery is
+// at-least-once.
+type OutboxRelayWorker struct {
+	DB          *gorm.DB
+	Publisher   messaging.IPublisher
+	RunInterval time.Duration
+}
+
+func (w *OutboxRelayWorker) Start(ctx context.Context) {
+	zap.L().Info("Starting worker",
+		zap.String("worker", "outbox_relay"),
+		zap.Duration("interval", w.RunInterval))
+
+	ticker := time.NewTicker(w.RunInterval)
+	defer ticker.Stop()
+
+	for {
+		w.relayPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Worker shutting down", zap.String("worker", "outbox_relay"))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayPending drains the outbox batch by batch until it is empty or a publish fails.
+func (w *OutboxRelayWorker) relayPending(ctx context.Context) int {
+	total := 0
+
+	for ctx.Err() == nil {
+		published, done := w.relayBatch()
+		total += published
+		if done {
+			break
+		}
+	}
+
+	if total > 0 {
+		zap.L().Debug("Relayed outbox events", zap.Int("count", total))
+	}
+
+	return total
+}
+
+func (w *OutboxRelayWorker) relayBatch() (int, bool) {
+	var entries []models.OutboxEvent
+	if err := w.DB.
+		Where("status = ?", models.OutboxStatusPending).
+		Order("created_at ASC").
+		Limit(OutboxBatchSize).
+		Find(&entries).Error; err != nil {
+		zap.L().Error("Failed to fetch pending outbox events", zap.Error(err))
+		return 0, true
+	}
+
+	published := 0
+	for _, entry := range entries {
+		if err := w.Publisher.Publish(events.NewOutboxMessage(entry)); err != nil {
+			zap.L().Error("Failed to publish outbox event",
+				zap.String("id", entry.ID.String()),
+				zap.String("event_type", entry.EventType),
+				zap.Error(err))
+
+			lastError := err.Error()
+			w.DB.Model(&entry).Updates(map[string]interface{}{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": lastError,
+			})
+			return published, true
+		}
+
+		now := time.Now()
+		if err := w.DB.Model(&entry).Updates(map[string]interface{}{
+			"status":  models.OutboxStatusSent,
+			"sent_at": now,
+		}).Error; err != nil {
+			zap.L().Error("Failed to mark outbox event as sent",
+				zap.String("id", entry.ID.String()),
+				zap.Error(err))
+			return published, true
+		}
+
+		published++
+	}
+
+	return published, len(entries) < OutboxBatchSize
+}
@@ -0,0 +1,104 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/safebucket/safebucket/internal/events"
+	"github.com/safebucket/safebucket/internal/models"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outboxStubPublisher struct {
+	published []*message.Message
+	err       error
+}
+
+func (p *outboxStubPublisher) Publish(msgs ...*message.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+func (p *outboxStubPublisher) Close() error { return nil }
+
+func TestOutboxRelay_PublishesAndMarksSent(t *testing.T) {
+	db := setupGCTestDB(t)
+	bucketID, userID := uuid.New(), uuid.New()
+
+	event := events.NewBucketPurge(nil, bucketID, userID)
+	require.NoError(t, event.Enqueue(db))
+
+	publisher := &outboxStubPublisher{}
+	worker := &OutboxRelayWorker{DB: db, Publisher: publisher, RunInterval: time.Second}
+
+	assert.Equal(t, 1, worker.relayPending(context.Background()))
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, events.BucketPurgeName, publisher.published[0].Metadata.Get("type"))
+	assert.Contains(t, string(publisher.published[0].Payload), bucketID.String())
+
+	var entry models.OutboxEvent
+	require.NoError(t, db.First(&entry).Error)
+	assert.Equal(t, models.OutboxStatusSent, entry.Status)
+	assert.NotNil(t, entry.SentAt)
+	assert.Equal(t, publisher.published[0].UUID, entry.MessageID)
+
+	assert.Equal(t, 0, worker.relayPending(context.Background()), "sent rows must not be relayed again")
+}
+
+func TestOutboxRelay_KeepsPendingOnPublishFailure(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	event := events.NewFolderPurge(nil, uuid.New(), uuid.New(), uuid.New())
+	require.NoError(t, event.Enqueue(db))
+
+	publisher := &outboxStubPublisher{err: errors.New("broker down")}
+	worker := &OutboxRelayWorker{DB: db, Publisher: publisher, RunInterval: time.Second}
+
+	assert.Equal(t, 0, worker.relayPending(context.Background()))
+
+	var entry models.OutboxEvent
+	require.NoError(t, db.First(&entry).Error)
+	assert.Equal(t, models.OutboxStatusPending, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	require.NotNil(t, entry.LastError)
+	assert.Equal(t, "broker down", *entry.LastError)
+}
+
+func TestCleanupSentOutboxEvents(t *testing.T) {
+	db := setupGCTestDB(t)
+
+	old := time.Now().Add(-2 * OutboxSentRetention)
+	recent := time.Now()
+	require.NoError(t, db.Create(&models.OutboxEvent{
+		MessageID: "old", EventType: events.BucketPurgeName, Payload: "{}",
+		Status: models.OutboxStatusSent, SentAt: &old,
+	}).Error)
+	require.NoError(t, db.Create(&models.OutboxEvent{
+		MessageID: "recent", EventType: events.BucketPurgeName, Payload: "{}",
+		Status: models.OutboxStatusSent, SentAt: &recent,
+	}).Error)
+	require.NoError(t, db.Create(&models.OutboxEvent{
+		MessageID: "pending", EventType: events.BucketPurgeName, Payload: "{}",
+		Status: models.OutboxStatusPending,
+	}).Error)
+
+	worker := &GarbageCollectorWorker{DB: db}
+	count, err := worker.cleanupSentOutboxEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var remaining []models.OutboxEvent
+	require.NoError(t, db.Order("message_id").Find(&remaining).Error)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, "pending", remaining[0].MessageID)
+	assert.Equal(t, "recent", remaining[1].MessageID)
+}
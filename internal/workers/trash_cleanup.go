@@ -8,6 +8,7 @@ import (
 	"github.com/safebucket/safebucket/internal/events"
 	"github.com/safebucket/safebucket/internal/messaging"
 	"github.com/safebucket/safebucket/internal/models"
+	"github.com/safebucket/safebucket/internal/retention"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
@@ -58,9 +59,15 @@ func (w *TrashCleanupWorker) cleanupExpiredFiles(ctx context.Context) (int, erro
 	default:
 	}
 
+	scope, err := retention.LoadScope(w.DB, nil)
+	if err != nil {
+		return 0, err
+	}
+
 	var files []models.File
-	result := w.DB.Unscoped().
-		Where("deleted_at IS NOT NULL AND deleted_at < ? AND folder_id IS NULL", expirationTime).
+	query := w.DB.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND folder_id IS NULL", expirationTime)
+	result := scope.ExcludeProtectedFiles(query, retention.ActionPurge).
 		Limit(FileBatchSize).
 		Find(&files)
 
@@ -95,16 +102,9 @@ func (w *TrashCleanupWorker) cleanupExpiredFolders(ctx context.Context) (int, er
 	expirationTime := time.Now().AddDate(0, 0, -w.TrashRetentionDays)
 	totalQueued := 0
 
-	// Query root-level trashed folders (no parent)
-	// FolderPurge events will handle children recursively
-	var folders []models.Folder
-	result := w.DB.Unscoped().
-		Where("deleted_at IS NOT NULL AND deleted_at < ? AND folder_id IS NULL", expirationTime).
-		Limit(FolderBatchSize).
-		Find(&folders)
-
-	if result.Error != nil {
-		return 0, result.Error
+	folders, err := w.expiredRootFolders(expirationTime)
+	if err != nil {
+		return 0, err
 	}
 
 	if len(folders) == 0 {
@@ -130,13 +130,59 @@ func (w *TrashCleanupWorker) cleanupExpiredFolders(ctx context.Context) (int, er
 		totalQueued++
 	}
 
-	if err := w.cleanupOrphanedFolders(ctx, expirationTime); err != nil {
+	if err = w.cleanupOrphanedFolders(ctx, expirationTime); err != nil {
 		return totalQueued, err
 	}
 
 	return totalQueued, nil
 }
 
+// expiredRootFolders returns up to FolderBatchSize root-level trashed folders whose trash has
+// expired. FolderPurge events handle children recursively. Folders protected by a legal hold
+// or a retention period are skipped, paging past them so they never block the batch.
+func (w *TrashCleanupWorker) expiredRootFolders(expirationTime time.Time) ([]models.Folder, error) {
+	scope, err := retention.LoadScope(w.DB, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []models.Folder
+	lastID := uuid.Nil
+	for len(expired) < FolderBatchSize {
+		var folders []models.Folder
+		if err := w.DB.Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND folder_id IS NULL", expirationTime).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(FolderBatchSize).
+			Find(&folders).Error; err != nil {
+			return nil, err
+		}
+
+		protected, err := scope.ProtectedFolders(w.DB, folders, retention.ActionPurge)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, folder := range folders {
+			if protected[folder.ID] {
+				zap.L().Debug("Skipping protected expired folder", zap.String("folder_id", folder.ID.String()))
+				continue
+			}
+			if len(expired) < FolderBatchSize {
+				expired = append(expired, folder)
+			}
+		}
+
+		if len(folders) < FolderBatchSize {
+			break
+		}
+		lastID = folders[len(folders)-1].ID
+	}
+
+	return expired, nil
+}
+
 func (w *TrashCleanupWorker) cleanupOrphanedFolders(ctx context.Context, expirationTime time.Time) error {
 	var folders []models.Folder
 	// Find trashed folders with a folder_id that no longer exists
@@ -50,7 +50,10 @@ export function ActivityItem({ item }: ActivityItemProps) {
       </ItemMedia>
       <ItemContent>
         <ItemTitle>
-          {getUserDisplayName(item.user, t("activity.share_link"))}
+          {getUserDisplayName(
+            item.user,
+            item.recipient_email ?? t("activity.share_link"),
+          )}
           <div
             className={cn(
               "flex h-6 w-6 items-center justify-center rounded-full",
@@ -2,16 +2,26 @@ import {
   ArchiveRestore,
   Clock,
   Eye,
+  FileCheck,
   FileDiff,
   FileDown,
   FileMinus,
   FileUp,
+  FileX,
   FolderMinus,
   FolderPen,
   FolderPlus,
+  KeyRound,
   Link2,
   Link2Off,
+  Lock,
+  LockOpen,
+  Mail,
+  MessageSquare,
+  MessageSquareOff,
   Share2,
+  ShieldCheck,
+  ShieldX,
   Smartphone,
   SmartphoneCharging,
   SmartphoneNfc,
@@ -65,6 +75,12 @@ export const messageMap = {
     iconColor: "text-orange-500",
     iconBg: "bg-orange-100",
   },
+  FILE_INACTIVE_TRASHED: {
+    messageKey: "activity.messages.file_inactive_trashed",
+    icon: Clock,
+    iconColor: "text-orange-500",
+    iconBg: "bg-orange-100",
+  },
   FILE_TRASHED: {
     messageKey: "activity.messages.file_trashed",
     icon: Trash2,
@@ -77,6 +93,18 @@ export const messageMap = {
     iconColor: "text-blue-500",
     iconBg: "bg-blue-100",
   },
+  FILE_APPROVED: {
+    messageKey: "activity.messages.file_approved",
+    icon: FileCheck,
+    iconColor: "text-green-500",
+    iconBg: "bg-green-100",
+  },
+  FILE_REJECTED: {
+    messageKey: "activity.messages.file_rejected",
+    icon: FileX,
+    iconColor: "text-red-500",
+    iconBg: "bg-red-100",
+  },
   FOLDER_CREATED: {
     messageKey: "activity.messages.folder_created",
     icon: FolderPlus,
@@ -155,6 +183,12 @@ export const messageMap = {
     iconColor: "text-green-500",
     iconBg: "bg-green-100",
   },
+  SHARE_UPDATED: {
+    messageKey: "activity.messages.share_updated",
+    icon: Link2,
+    iconColor: "text-amber-500",
+    iconBg: "bg-amber-100",
+  },
   SHARE_DELETED: {
     messageKey: "activity.messages.share_deleted",
     icon: Link2Off,
@@ -185,4 +219,82 @@ export const messageMap = {
     iconColor: "text-teal-500",
     iconBg: "bg-teal-100",
   },
+  SHARE_ACCESS_DENIED: {
+    messageKey: "activity.messages.share_access_denied",
+    icon: ShieldX,
+    iconColor: "text-red-500",
+    iconBg: "bg-red-100",
+  },
+  SHARE_SENT: {
+    messageKey: "activity.messages.share_sent",
+    icon: Mail,
+    iconColor: "text-blue-500",
+    iconBg: "bg-blue-100",
+  },
+  CHANNEL_CREATED: {
+    messageKey: "activity.messages.channel_created",
+    icon: MessageSquare,
+    iconColor: "text-indigo-500",
+    iconBg: "bg-indigo-100",
+  },
+  CHANNEL_DELETED: {
+    messageKey: "activity.messages.channel_deleted",
+    icon: MessageSquareOff,
+    iconColor: "text-red-500",
+    iconBg: "bg-red-100",
+  },
+  RETENTION_POLICY_CREATED: {
+    messageKey: "activity.messages.retention_policy_created",
+    icon: ShieldCheck,
+    iconColor: "text-green-500",
+    iconBg: "bg-green-100",
+  },
+  RETENTION_POLICY_UPDATED: {
+    messageKey: "activity.messages.retention_policy_updated",
+    icon: ShieldCheck,
+    iconColor: "text-blue-500",
+    iconBg: "bg-blue-100",
+  },
+  RETENTION_POLICY_DELETED: {
+    messageKey: "activity.messages.retention_policy_deleted",
+    icon: ShieldCheck,
+    iconColor: "text-red-500",
+    iconBg: "bg-red-100",
+  },
+  BUCKET_RETENTION_UPDATED: {
+    messageKey: "activity.messages.bucket_retention_updated",
+    icon: ShieldCheck,
+    iconColor: "text-blue-500",
+    iconBg: "bg-blue-100",
+  },
+  LEGAL_HOLD_PLACED: {
+    messageKey: "activity.messages.legal_hold_placed",
+    icon: Lock,
+    iconColor: "text-orange-500",
+    iconBg: "bg-orange-100",
+  },
+  LEGAL_HOLD_RELEASED: {
+    messageKey: "activity.messages.legal_hold_released",
+    icon: LockOpen,
+    iconColor: "text-green-500",
+    iconBg: "bg-green-100",
+  },
+  BUCKET_LIFECYCLE_UPDATED: {
+    messageKey: "activity.messages.bucket_lifecycle_updated",
+    icon: Clock,
+    iconColor: "text-blue-500",
+    iconBg: "bg-blue-100",
+  },
+  USER_KEY_UPDATED: {
+    messageKey: "activity.messages.user_key_updated",
+    icon: KeyRound,
+    iconColor: "text-purple-500",
+    iconBg: "bg-purple-100",
+  },
+  BUCKET_KEYS_SHARED: {
+    messageKey: "activity.messages.bucket_keys_shared",
+    icon: KeyRound,
+    iconColor: "text-green-500",
+    iconBg: "bg-green-100",
+  },
 } satisfies Record<ActivityMessage, object>;
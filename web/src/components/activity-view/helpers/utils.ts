@@ -37,13 +37,18 @@ export const formatMessage = (
   return t(mapping.messageKey)
     .replace(
       "%%USERNAME%%",
-      getUserDisplayName(log.user, t("activity.share_link")),
+      getUserDisplayName(
+        log.user,
+        log.recipient_email ?? t("activity.share_link"),
+      ),
     )
     .replace("%%BUCKET_NAME%%", log.bucket?.name || "")
     .replace("%%FILE_NAME%%", log.file?.name || "")
     .replace("%%FOLDER_NAME%%", log.folder?.name || "")
     .replace("%%BUCKET_MEMBER_EMAIL%%", log.bucket_member_email || "")
-    .replace("%%SHARE_NAME%%", log.share?.name || "");
+    .replace("%%SHARE_NAME%%", log.share?.name || "")
+    .replace("%%CLIENT_IP%%", log.client_ip || "")
+    .replace("%%RECIPIENT_EMAIL%%", log.recipient_email || "");
 };
 
 export const timeAgo = (
@@ -110,7 +110,9 @@ export const createColumns = (t: TFunction): Array<ColumnDef<IActivity>> => [
     header: t("admin.activity.columns.user"),
     cell: ({ row }) => {
       const user = row.original.user;
-      return user?.email ?? t("activity.share_link");
+      return (
+        user?.email ?? row.original.recipient_email ?? t("activity.share_link")
+      );
     },
   },
   {
@@ -31,6 +31,16 @@ import { TextValue } from "./components/TextValue";
 import { Button } from "@/components/ui/button";
 import { Badge } from "@/components/ui/badge";
 import { Skeleton } from "@/components/ui/skeleton";
+import type { IAdminRateLimitSettings } from "@/types/app_settings";
+
+const RATE_LIMIT_CLASSES: Array<keyof IAdminRateLimitSettings> = [
+  "auth",
+  "upload_init",
+  "download_url",
+  "share_public",
+  "admin",
+  "per_share",
+];
 
 export function AdminSettingsDetails() {
   const { t } = useTranslation();
@@ -97,6 +107,11 @@ export function AdminSettingsDetails() {
                 >
                   <TextValue value={settings.app.trash_retention_days} />
                 </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.invite_expiry_days")}
+                >
+                  <TextValue value={settings.app.invite_expiry_days} />
+                </SettingRow>
                 <SettingRow
                   label={t("admin.settings.fields.allow_redirect_download")}
                 >
@@ -137,6 +152,21 @@ export function AdminSettingsDetails() {
                 >
                   <CoverageValue status={settings.workers.garbage_collector} />
                 </SettingRow>
+                <SettingRow label={t("admin.settings.fields.outbox_relay")}>
+                  <CoverageValue status={settings.workers.outbox_relay} />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.notification_digest")}
+                >
+                  <CoverageValue
+                    status={settings.workers.notification_digest}
+                  />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.notification_retry")}
+                >
+                  <CoverageValue status={settings.workers.notification_retry} />
+                </SettingRow>
               </SettingsSection>
 
               <SettingsSection
@@ -172,6 +202,11 @@ export function AdminSettingsDetails() {
                     <TextValue value={settings.database.path} />
                   </SettingRow>
                 )}
+                {settings.database.replicas && (
+                  <SettingRow label={t("admin.settings.fields.replicas")}>
+                    <ListValue values={settings.database.replicas} />
+                  </SettingRow>
+                )}
               </SettingsSection>
 
               <SettingsSection
@@ -283,6 +318,11 @@ export function AdminSettingsDetails() {
                     <TextValue value={settings.events.subscription_suffix} />
                   </SettingRow>
                 )}
+                {settings.events.hosts && (
+                  <SettingRow label={t("admin.settings.fields.hosts")}>
+                    <ListValue values={settings.events.hosts} />
+                  </SettingRow>
+                )}
               </SettingsSection>
 
               <SettingsSection
@@ -477,6 +517,26 @@ export function AdminSettingsDetails() {
                     }
                   />
                 </SettingRow>
+                {RATE_LIMIT_CLASSES.map((rateClass) => {
+                  const budget = settings.security.rate_limits[rateClass];
+                  return (
+                    <SettingRow
+                      key={rateClass}
+                      label={t(
+                        `admin.settings.rate_limit_classes.${rateClass}`,
+                      )}
+                    >
+                      {budget.requests_per_minute > 0 ? (
+                        <RateValue
+                          value={budget.requests_per_minute}
+                          burst={budget.burst}
+                        />
+                      ) : (
+                        <span>{t("admin.settings.values.general_budget")}</span>
+                      )}
+                    </SettingRow>
+                  );
+                })}
                 <SettingRow
                   label={t("admin.settings.fields.access_token_expiry")}
                 >
@@ -505,6 +565,37 @@ export function AdminSettingsDetails() {
                 >
                   <BoolValue value={settings.security.cookie_secure_force} />
                 </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.password_min_length")}
+                >
+                  <TextValue value={settings.security.password_min_length} />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.password_character_classes")}
+                >
+                  <ListValue
+                    values={settings.security.password_character_classes}
+                  />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.password_disallow_email")}
+                >
+                  <BoolValue
+                    value={settings.security.password_disallow_email}
+                  />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.password_history_size")}
+                >
+                  <TextValue value={settings.security.password_history_size} />
+                </SettingRow>
+                <SettingRow
+                  label={t("admin.settings.fields.breached_password_check")}
+                >
+                  <BoolValue
+                    value={settings.security.breached_password_check}
+                  />
+                </SettingRow>
               </SettingsSection>
             </div>
           </div>
@@ -134,6 +134,17 @@ export function AdminSettingsOverview() {
               <SettingRow label={t("admin.settings.fields.garbage_collector")}>
                 <CoverageValue status={settings.workers.garbage_collector} />
               </SettingRow>
+              <SettingRow label={t("admin.settings.fields.outbox_relay")}>
+                <CoverageValue status={settings.workers.outbox_relay} />
+              </SettingRow>
+              <SettingRow
+                label={t("admin.settings.fields.notification_digest")}
+              >
+                <CoverageValue status={settings.workers.notification_digest} />
+              </SettingRow>
+              <SettingRow label={t("admin.settings.fields.notification_retry")}>
+                <CoverageValue status={settings.workers.notification_retry} />
+              </SettingRow>
             </SettingsSection>
 
             <SettingsSection
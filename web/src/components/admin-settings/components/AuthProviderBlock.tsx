@@ -29,6 +29,12 @@ export function AuthProviderBlock({
       <SettingRow label={t("admin.settings.fields.sharing_allowed")}>
         <BoolValue value={provider.sharing_allowed} />
       </SettingRow>
+      <SettingRow label={t("admin.settings.fields.share_ip_allowlist")}>
+        <ListValue values={provider.share_ip_allowlist} />
+      </SettingRow>
+      <SettingRow label={t("admin.settings.fields.share_ip_denylist")}>
+        <ListValue values={provider.share_ip_denylist} />
+      </SettingRow>
       {provider.issuer && (
         <SettingRow label={t("admin.settings.fields.issuer")}>
           <CopyableValue value={provider.issuer} />
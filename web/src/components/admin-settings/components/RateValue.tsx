@@ -1,10 +1,23 @@
 import { useTranslation } from "react-i18next";
 import { NotSet } from "./NotSet";
 
-export function RateValue({ value }: { value?: number | null }) {
+export function RateValue({
+  value,
+  burst,
+}: {
+  value?: number | null;
+  burst?: number;
+}) {
   const { t } = useTranslation();
   if (value === undefined || value === null) {
     return <NotSet />;
   }
+  if (burst) {
+    return (
+      <span>
+        {t("admin.settings.values.per_minute_burst", { value, burst })}
+      </span>
+    );
+  }
   return <span>{t("admin.settings.values.per_minute", { value })}</span>;
 }
@@ -4,6 +4,8 @@ import { useTranslation } from "react-i18next";
 import { useAdminUsersData } from "./hooks/useAdminUsersData";
 import { createColumns } from "./components/columns";
 import { AdminUsersTable } from "./components/AdminUsersTable";
+import { AdminInvitesCard } from "./components/AdminInvitesCard";
+import { AdminNotificationsCard } from "./components/AdminNotificationsCard";
 import type { FC } from "react";
 import type { FieldValues } from "react-hook-form";
 import type { IUser } from "@/components/auth-view/types/session";
@@ -33,6 +35,7 @@ export const AdminUsersView: FC = () => {
     isLoading,
     createUserMutation,
     deleteUserMutation,
+    unlockUserMutation,
     userToDelete,
     setUserToDelete,
   } = useAdminUsersData();
@@ -51,6 +54,10 @@ export const AdminUsersView: FC = () => {
     deleteUserDialog.trigger();
   };
 
+  const handleUnlock = (user: IUser) => {
+    unlockUserMutation.mutate(user.id);
+  };
+
   const handleConfirmDelete = () => {
     if (userToDelete) {
       deleteUserMutation.mutate(userToDelete.id);
@@ -85,11 +92,20 @@ export const AdminUsersView: FC = () => {
             columns={columns}
             data={users}
             onDeleteUser={handleDeleteClick}
+            onUnlockUser={handleUnlock}
             currentUserId={session?.userId ?? ""}
           />
         </CardContent>
       </Card>
 
+      <div className="mt-6">
+        <AdminInvitesCard />
+      </div>
+
+      <div className="mt-6">
+        <AdminNotificationsCard />
+      </div>
+
       <FormDialog
         {...createUserDialog.props}
         maxWidth="650px"
@@ -0,0 +1,109 @@
+import { useQuery } from "@tanstack/react-query";
+import { useTranslation } from "react-i18next";
+import { X } from "lucide-react";
+import type { FC } from "react";
+
+import {
+  adminInvitesQueryOptions,
+  useRevokeAdminInviteMutation,
+} from "@/queries/admin";
+import { formatDate } from "@/lib/utils";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+
+export const AdminInvitesCard: FC = () => {
+  const { t } = useTranslation();
+  const { data: invites = [] } = useQuery(adminInvitesQueryOptions());
+  const revokeInvite = useRevokeAdminInviteMutation();
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>{t("admin.invites.title")}</CardTitle>
+        <CardDescription>{t("admin.invites.description")}</CardDescription>
+      </CardHeader>
+      <CardContent>
+        <div className="rounded-md border">
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t("admin.invites.columns.email")}</TableHead>
+                <TableHead>{t("admin.invites.columns.bucket")}</TableHead>
+                <TableHead>{t("admin.invites.columns.group")}</TableHead>
+                <TableHead>{t("admin.invites.columns.invited_by")}</TableHead>
+                <TableHead>{t("admin.invites.columns.expires_at")}</TableHead>
+                <TableHead className="w-[50px]" />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {invites.length ? (
+                invites.map((invite) => {
+                  const isExpired =
+                    !!invite.expires_at &&
+                    new Date(invite.expires_at) < new Date();
+
+                  return (
+                    <TableRow key={invite.id}>
+                      <TableCell className="font-medium">
+                        {invite.email}
+                      </TableCell>
+                      <TableCell>{invite.bucket_name}</TableCell>
+                      <TableCell className="capitalize">
+                        {invite.group}
+                      </TableCell>
+                      <TableCell>{invite.invited_by.email}</TableCell>
+                      <TableCell>
+                        {isExpired ? (
+                          <Badge variant="destructive">
+                            {t("admin.invites.expired")}
+                          </Badge>
+                        ) : invite.expires_at ? (
+                          formatDate(invite.expires_at)
+                        ) : (
+                          t("admin.invites.never")
+                        )}
+                      </TableCell>
+                      <TableCell>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          className="text-red-600"
+                          title={t("admin.invites.revoke")}
+                          disabled={revokeInvite.isPending}
+                          onClick={() => revokeInvite.mutate(invite)}
+                        >
+                          <X className="h-4 w-4" />
+                        </Button>
+                      </TableCell>
+                    </TableRow>
+                  );
+                })
+              ) : (
+                <TableRow>
+                  <TableCell colSpan={6} className="h-24 text-center">
+                    {t("admin.invites.empty")}
+                  </TableCell>
+                </TableRow>
+              )}
+            </TableBody>
+          </Table>
+        </div>
+      </CardContent>
+    </Card>
+  );
+};
@@ -0,0 +1,108 @@
+import { useQuery } from "@tanstack/react-query";
+import { useTranslation } from "react-i18next";
+import { RotateCw } from "lucide-react";
+import type { FC } from "react";
+
+import {
+  adminFailedNotificationsQueryOptions,
+  useResendNotificationMutation,
+} from "@/queries/admin";
+import { formatDate } from "@/lib/utils";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+
+export const AdminNotificationsCard: FC = () => {
+  const { t } = useTranslation();
+  const { data: notifications = [] } = useQuery(
+    adminFailedNotificationsQueryOptions(),
+  );
+  const resendNotification = useResendNotificationMutation();
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>{t("admin.notifications.title")}</CardTitle>
+        <CardDescription>
+          {t("admin.notifications.description")}
+        </CardDescription>
+      </CardHeader>
+      <CardContent>
+        <div className="rounded-md border">
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>
+                  {t("admin.notifications.columns.recipient")}
+                </TableHead>
+                <TableHead>
+                  {t("admin.notifications.columns.subject")}
+                </TableHead>
+                <TableHead>
+                  {t("admin.notifications.columns.attempts")}
+                </TableHead>
+                <TableHead>{t("admin.notifications.columns.error")}</TableHead>
+                <TableHead>
+                  {t("admin.notifications.columns.created_at")}
+                </TableHead>
+                <TableHead className="w-[50px]" />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {notifications.length ? (
+                notifications.map((notification) => (
+                  <TableRow key={notification.id}>
+                    <TableCell className="font-medium">
+                      {notification.recipient}
+                    </TableCell>
+                    <TableCell>{notification.subject}</TableCell>
+                    <TableCell>{notification.attempts}</TableCell>
+                    <TableCell
+                      className="text-muted-foreground max-w-[280px] truncate text-xs"
+                      title={notification.last_error}
+                    >
+                      {notification.last_error}
+                    </TableCell>
+                    <TableCell>{formatDate(notification.created_at)}</TableCell>
+                    <TableCell>
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        title={t("admin.notifications.resend")}
+                        disabled={resendNotification.isPending}
+                        onClick={() =>
+                          resendNotification.mutate(notification.id)
+                        }
+                      >
+                        <RotateCw className="h-4 w-4" />
+                      </Button>
+                    </TableCell>
+                  </TableRow>
+                ))
+              ) : (
+                <TableRow>
+                  <TableCell colSpan={6} className="h-24 text-center">
+                    {t("admin.notifications.empty")}
+                  </TableCell>
+                </TableRow>
+              )}
+            </TableBody>
+          </Table>
+        </div>
+      </CardContent>
+    </Card>
+  );
+};
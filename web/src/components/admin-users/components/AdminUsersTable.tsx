@@ -24,6 +24,7 @@ interface AdminUsersTableProps {
   columns: Array<ColumnDef<IUser>>;
   data: Array<IUser>;
   onDeleteUser: (user: IUser) => void;
+  onUnlockUser: (user: IUser) => void;
   currentUserId: string;
 }
 
@@ -31,6 +32,7 @@ export function AdminUsersTable({
   columns,
   data,
   onDeleteUser,
+  onUnlockUser,
   currentUserId,
 }: AdminUsersTableProps) {
   const { t } = useTranslation();
@@ -82,6 +84,7 @@ export function AdminUsersTable({
                   <UserRowActions
                     user={row.original}
                     onDelete={onDeleteUser}
+                    onUnlock={onUnlockUser}
                     isCurrentUser={row.original.id === currentUserId}
                   />
                 </TableCell>
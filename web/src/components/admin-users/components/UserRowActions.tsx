@@ -1,4 +1,5 @@
-import { Ellipsis, Trash2 } from "lucide-react";
+import { Ellipsis, LockOpen, Trash2 } from "lucide-react";
+import { useTranslation } from "react-i18next";
 import type { FC } from "react";
 import type { IUser } from "@/components/auth-view/types/session";
 import { Button } from "@/components/ui/button";
@@ -12,14 +13,18 @@ import {
 interface UserRowActionsProps {
   user: IUser;
   onDelete: (user: IUser) => void;
+  onUnlock: (user: IUser) => void;
   isCurrentUser: boolean;
 }
 
 export const UserRowActions: FC<UserRowActionsProps> = ({
   user,
   onDelete,
+  onUnlock,
   isCurrentUser,
 }) => {
+  const { t } = useTranslation();
+
   return (
     <div onClick={(e) => e.stopPropagation()}>
       <DropdownMenu>
@@ -33,13 +38,19 @@ export const UserRowActions: FC<UserRowActionsProps> = ({
           </Button>
         </DropdownMenuTrigger>
         <DropdownMenuContent align="end">
+          {user.locked_until && (
+            <DropdownMenuItem onClick={() => onUnlock(user)}>
+              <LockOpen className="mr-2 h-4 w-4" />
+              {t("admin.users.unlock")}
+            </DropdownMenuItem>
+          )}
           <DropdownMenuItem
             onClick={() => onDelete(user)}
             disabled={isCurrentUser}
             className="text-destructive focus:text-destructive"
           >
             <Trash2 className="mr-2 h-4 w-4" />
-            Delete
+            {t("admin.users.delete")}
           </DropdownMenuItem>
         </DropdownMenuContent>
       </DropdownMenu>
@@ -8,6 +8,24 @@ export const createColumns = (t: TFunction): Array<ColumnDef<IUser>> => [
   {
     accessorKey: "email",
     header: t("admin.users.columns.email"),
+    cell: ({ row }) => (
+      <div className="flex items-center gap-2">
+        {row.original.email}
+        {row.original.email_bounced && (
+          <Badge variant="destructive">{t("admin.users.email_bounced")}</Badge>
+        )}
+        {row.original.locked_until && (
+          <Badge
+            variant="destructive"
+            title={t("admin.users.locked_until", {
+              date: new Date(row.original.locked_until).toLocaleString(),
+            })}
+          >
+            {t("admin.users.locked")}
+          </Badge>
+        )}
+      </div>
+    ),
   },
   {
     accessorKey: "first_name",
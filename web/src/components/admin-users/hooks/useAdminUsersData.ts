@@ -4,6 +4,7 @@ import type { IUser } from "@/components/auth-view/types/session";
 import {
   useCreateUserMutation,
   useDeleteUserMutation,
+  useUnlockUserMutation,
   usersQueryOptions,
 } from "@/queries/admin";
 
@@ -13,12 +14,14 @@ export const useAdminUsersData = () => {
   const { data: users, isLoading } = useQuery(usersQueryOptions());
   const createUserMutation = useCreateUserMutation();
   const deleteUserMutation = useDeleteUserMutation();
+  const unlockUserMutation = useUnlockUserMutation();
 
   return {
     users: users ?? [],
     isLoading,
     createUserMutation,
     deleteUserMutation,
+    unlockUserMutation,
     userToDelete,
     setUserToDelete,
   };
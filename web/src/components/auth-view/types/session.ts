@@ -14,6 +14,9 @@ export interface IUser {
   role: "admin" | "user" | "guest";
   mfa_enabled: boolean;
   mfa_enabled_at?: string;
+  email_bounced?: boolean;
+  locked_until?: string;
+  password_change_allowed?: boolean;
   created_at: string;
   updated_at: string;
 }
@@ -58,6 +61,10 @@ export interface ISessionResponse {
   id: string;
   is_current: boolean;
   created_at: string;
+  last_seen_at?: string;
+  ip?: string;
+  user_agent?: string;
+  auth_provider?: string;
 }
 
 export interface ISessionListResponse {
@@ -51,6 +51,8 @@ export const BucketMembers: FC<IBucketMembersProps> = ({ bucket }) => {
     addMember,
     updateMemberRole,
     handleUpdateMembers,
+    resendInvite,
+    revokeInvite,
   } = useBucketMembersData(bucket);
 
   if (isLoading) {
@@ -138,6 +140,8 @@ export const BucketMembers: FC<IBucketMembersProps> = ({ bucket }) => {
                       member={member}
                       isCurrentUser={member.email === currentUserEmail}
                       updateMemberRole={updateMemberRole}
+                      onResendInvite={resendInvite}
+                      onRevokeInvite={revokeInvite}
                     />
                   ))}
                 </div>
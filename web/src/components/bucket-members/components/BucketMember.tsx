@@ -1,3 +1,5 @@
+import { useTranslation } from "react-i18next";
+import { RotateCw, X } from "lucide-react";
 import type { FC } from "react";
 
 import type { IMemberState } from "@/components/bucket-members/hooks/useBucketMembersData";
@@ -19,63 +21,113 @@ import {
   ItemTitle,
 } from "@/components/ui/item.tsx";
 import { bucketGroups } from "@/types/bucket.ts";
+import { Button } from "@/components/ui/button";
+import { formatDate } from "@/lib/utils";
 
 interface IBucketMemberProps {
   member: IMemberState;
   isCurrentUser: boolean;
   updateMemberRole: (email: string, newRole: string) => void;
+  onResendInvite: (inviteId: string) => void;
+  onRevokeInvite: (inviteId: string) => void;
 }
 
 export const BucketMember: FC<IBucketMemberProps> = ({
   member,
   isCurrentUser,
   updateMemberRole,
-}) => (
-  <Item key={member.email} variant="outline">
-    <ItemMedia>
-      <Avatar className="size-10">
-        <AvatarImage src="/avatars/01.png" />
-        <AvatarFallback>{member.email.charAt(0).toUpperCase()}</AvatarFallback>
-      </Avatar>
-    </ItemMedia>
-    <ItemContent>
-      <ItemTitle>
-        {member.first_name && member.last_name
-          ? `${member.first_name} ${member.last_name}${isCurrentUser ? " (you)" : ""}`
-          : member.email}
-      </ItemTitle>
-      <ItemDescription>{member.email}</ItemDescription>
-      <div className="">
-        {member.isNew && (
-          <div className="text-xs text-green-500">New member</div>
+  onResendInvite,
+  onRevokeInvite,
+}) => {
+  const { t } = useTranslation();
+  const inviteId = member.invite_id;
+  const isExpired =
+    !!member.expires_at && new Date(member.expires_at) < new Date();
+
+  return (
+    <Item key={member.email} variant="outline">
+      <ItemMedia>
+        <Avatar className="size-10">
+          <AvatarImage src="/avatars/01.png" />
+          <AvatarFallback>
+            {member.email.charAt(0).toUpperCase()}
+          </AvatarFallback>
+        </Avatar>
+      </ItemMedia>
+      <ItemContent>
+        <ItemTitle>
+          {member.first_name && member.last_name
+            ? `${member.first_name} ${member.last_name}${isCurrentUser ? " (you)" : ""}`
+            : member.email}
+        </ItemTitle>
+        <ItemDescription>{member.email}</ItemDescription>
+        <div className="">
+          {member.isNew && (
+            <div className="text-xs text-green-500">New member</div>
+          )}
+          {inviteId &&
+            (isExpired ? (
+              <div className="text-xs text-red-500">
+                {t("bucket.settings.members.invite_expired")}
+              </div>
+            ) : (
+              member.expires_at && (
+                <div className="text-muted-foreground text-xs">
+                  {t("bucket.settings.members.invite_expires", {
+                    date: formatDate(member.expires_at),
+                  })}
+                </div>
+              )
+            ))}
+        </div>
+      </ItemContent>
+      <ItemActions>
+        {inviteId && (
+          <>
+            <Button
+              variant="ghost"
+              size="icon"
+              title={t("bucket.settings.members.resend_invite")}
+              onClick={() => onResendInvite(inviteId)}
+            >
+              <RotateCw />
+            </Button>
+            <Button
+              variant="ghost"
+              size="icon"
+              className="text-red-600"
+              title={t("bucket.settings.members.revoke_invite")}
+              onClick={() => onRevokeInvite(inviteId)}
+            >
+              <X />
+            </Button>
+          </>
         )}
-      </div>
-    </ItemContent>
-    <ItemActions>
-      <Select
-        value={member.group}
-        onValueChange={(value) => updateMemberRole(member.email, value)}
-        disabled={isCurrentUser}
-      >
-        <SelectTrigger className="w-32">
-          <SelectValue />
-        </SelectTrigger>
-        <SelectContent>
-          {bucketGroups.map((group) => (
-            <SelectItem key={group.id} value={group.id}>
-              {group.name}
-            </SelectItem>
-          ))}
-          {!isCurrentUser && (
-            <>
-              <SelectSeparator />
-              <SelectItem value="remove" className="text-red-600">
-                Remove
+        <Select
+          value={member.group}
+          onValueChange={(value) => updateMemberRole(member.email, value)}
+          disabled={isCurrentUser}
+        >
+          <SelectTrigger className="w-32">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {bucketGroups.map((group) => (
+              <SelectItem key={group.id} value={group.id}>
+                {group.name}
               </SelectItem>
-            </>
-          )}
-        </SelectContent>
-      </Select>
-    </ItemActions>
-  </Item>
-);
+            ))}
+            {!isCurrentUser && (
+              <>
+                <SelectSeparator />
+                <SelectItem value="remove" className="text-red-600">
+                  Remove
+                </SelectItem>
+              </>
+            )}
+          </SelectContent>
+        </Select>
+      </ItemActions>
+    </Item>
+  );
+};
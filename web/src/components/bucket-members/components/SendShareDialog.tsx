@@ -0,0 +1,175 @@
+import { useEffect, useState } from "react";
+import { useTranslation } from "react-i18next";
+import type { FC } from "react";
+
+import type { IShare } from "@/types/share.ts";
+import { useSendShareMutation } from "@/queries/bucket.ts";
+import { FormErrorAlert } from "@/components/common/FormErrorAlert";
+import { Button } from "@/components/ui/button.tsx";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog.tsx";
+import { resolveErrorMessage } from "@/components/ui/hooks/use-toast.ts";
+import { Input } from "@/components/ui/input.tsx";
+import { Label } from "@/components/ui/label.tsx";
+
+interface ISendShareDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  share: IShare;
+  bucketId: string;
+}
+
+function parseEmails(value: string): Array<string> {
+  return value
+    .split(/[\s,;]+/)
+    .map((entry) => entry.trim())
+    .filter(Boolean);
+}
+
+export const SendShareDialog: FC<ISendShareDialogProps> = ({
+  open,
+  onOpenChange,
+  share,
+  bucketId,
+}) => {
+  const { t } = useTranslation();
+  const sendShare = useSendShareMutation(bucketId);
+
+  const [emails, setEmails] = useState("");
+  const [message, setMessage] = useState("");
+  const [password, setPassword] = useState("");
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!open) {
+      setEmails("");
+      setMessage("");
+      setPassword("");
+      setError(null);
+    }
+  }, [open]);
+
+  const recipients = parseEmails(emails);
+
+  const handleSend = async () => {
+    if (recipients.length === 0) return;
+
+    setError(null);
+    try {
+      await sendShare.mutateAsync({
+        shareId: share.id,
+        body: {
+          emails: recipients,
+          message: message || undefined,
+          password: password || undefined,
+        },
+      });
+      onOpenChange(false);
+    } catch (err) {
+      setError(resolveErrorMessage(err as Error));
+    }
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="sm:max-w-md">
+        <DialogHeader>
+          <DialogTitle>{t("bucket.settings.shares.send_title")}</DialogTitle>
+          <DialogDescription>
+            {t("bucket.settings.shares.send_description", {
+              name: share.name,
+            })}
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="space-y-4">
+          <FormErrorAlert error={error} />
+
+          <div className="space-y-2">
+            <Label htmlFor="send-share-emails">
+              {t("bucket.settings.shares.send_recipients")}
+            </Label>
+            <Input
+              id="send-share-emails"
+              type="text"
+              value={emails}
+              onChange={(e) => setEmails(e.target.value)}
+              placeholder={t(
+                "bucket.settings.shares.send_recipients_placeholder",
+              )}
+              disabled={sendShare.isPending}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label htmlFor="send-share-message">
+              {t("bucket.settings.shares.send_message")}
+            </Label>
+            <Input
+              id="send-share-message"
+              type="text"
+              value={message}
+              maxLength={1000}
+              onChange={(e) => setMessage(e.target.value)}
+              disabled={sendShare.isPending}
+            />
+          </div>
+
+          {share.password_protected && (
+            <div className="space-y-2">
+              <Label htmlFor="send-share-password">
+                {t("bucket.settings.shares.send_password")}
+              </Label>
+              <Input
+                id="send-share-password"
+                type="password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                disabled={sendShare.isPending}
+              />
+              <p className="text-muted-foreground text-xs">
+                {t("bucket.settings.shares.send_password_description")}
+              </p>
+            </div>
+          )}
+
+          {share.deliveries && share.deliveries.length > 0 && (
+            <div className="space-y-1">
+              <Label>{t("bucket.settings.shares.send_history")}</Label>
+              <ul className="text-muted-foreground max-h-32 space-y-1 overflow-y-auto text-xs">
+                {share.deliveries.map((delivery) => (
+                  <li key={delivery.id} className="flex justify-between gap-2">
+                    <span className="truncate">{delivery.email}</span>
+                    <span>
+                      {new Date(delivery.created_at).toLocaleString()}
+                    </span>
+                  </li>
+                ))}
+              </ul>
+            </div>
+          )}
+        </div>
+
+        <DialogFooter className="sm:justify-between">
+          <Button variant="outline" onClick={() => onOpenChange(false)}>
+            {t("common.cancel")}
+          </Button>
+          <Button
+            onClick={handleSend}
+            disabled={recipients.length === 0 || sendShare.isPending}
+          >
+            {sendShare.isPending
+              ? t("common.loading")
+              : t("bucket.settings.shares.send")}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+};
@@ -0,0 +1,169 @@
+import { useQuery } from "@tanstack/react-query";
+import { useTranslation } from "react-i18next";
+import type { FC } from "react";
+
+import type { IShare } from "@/types/share.ts";
+import { shareAnalyticsQueryOptions } from "@/queries/bucket.ts";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog.tsx";
+import { Skeleton } from "@/components/ui/skeleton.tsx";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table.tsx";
+
+interface IShareAnalyticsDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  share: IShare;
+  bucketId: string;
+}
+
+export const ShareAnalyticsDialog: FC<IShareAnalyticsDialogProps> = ({
+  open,
+  onOpenChange,
+  share,
+  bucketId,
+}) => {
+  const { t } = useTranslation();
+  const { data, isLoading } = useQuery({
+    ...shareAnalyticsQueryOptions(bucketId, share.id),
+    enabled: open,
+  });
+
+  const fileNames = new Map(
+    data?.files.map((file) => [file.file_id, file.name]) ?? [],
+  );
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="sm:max-w-3xl">
+        <DialogHeader>
+          <DialogTitle>
+            {t("bucket.settings.shares.analytics_title")}
+          </DialogTitle>
+          <DialogDescription>
+            {t("bucket.settings.shares.analytics_description", {
+              name: share.name,
+            })}
+          </DialogDescription>
+        </DialogHeader>
+
+        {isLoading || !data ? (
+          <div className="space-y-2">
+            <Skeleton className="h-4 w-48" />
+            <Skeleton className="h-24 w-full" />
+          </div>
+        ) : (
+          <div className="max-h-[60vh] space-y-6 overflow-y-auto">
+            <div className="text-muted-foreground flex gap-4 text-sm">
+              <span>
+                {t("bucket.settings.shares.analytics_views", {
+                  count: data.views,
+                })}
+              </span>
+              <span>
+                {t("bucket.settings.shares.analytics_downloads", {
+                  count: data.downloads,
+                })}
+              </span>
+            </div>
+
+            {data.files.length > 0 && (
+              <Table>
+                <TableHeader>
+                  <TableRow>
+                    <TableHead>{t("bucket.settings.shares.file")}</TableHead>
+                    <TableHead>
+                      {t("bucket.settings.shares.downloads_label")}
+                    </TableHead>
+                    <TableHead>
+                      {t("bucket.settings.shares.last_download")}
+                    </TableHead>
+                  </TableRow>
+                </TableHeader>
+                <TableBody>
+                  {data.files.map((file) => (
+                    <TableRow key={file.file_id}>
+                      <TableCell className="max-w-60 truncate">
+                        {file.name || file.file_id}
+                      </TableCell>
+                      <TableCell>
+                        {file.max_downloads
+                          ? `${file.downloads} / ${file.max_downloads}`
+                          : file.downloads}
+                      </TableCell>
+                      <TableCell>
+                        {file.last_downloaded_at
+                          ? new Date(file.last_downloaded_at).toLocaleString()
+                          : "-"}
+                      </TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            )}
+
+            {data.accesses.length === 0 ? (
+              <p className="text-muted-foreground text-sm">
+                {t("bucket.settings.shares.no_accesses")}
+              </p>
+            ) : (
+              <Table>
+                <TableHeader>
+                  <TableRow>
+                    <TableHead>
+                      {t("bucket.settings.shares.access_date")}
+                    </TableHead>
+                    <TableHead>
+                      {t("bucket.settings.shares.access_type")}
+                    </TableHead>
+                    <TableHead>{t("bucket.settings.shares.file")}</TableHead>
+                    <TableHead>{t("bucket.settings.shares.client")}</TableHead>
+                  </TableRow>
+                </TableHeader>
+                <TableBody>
+                  {data.accesses.map((access) => (
+                    <TableRow key={access.id}>
+                      <TableCell>
+                        {new Date(access.created_at).toLocaleString()}
+                      </TableCell>
+                      <TableCell>
+                        {t(`bucket.settings.shares.access_${access.type}`)}
+                      </TableCell>
+                      <TableCell className="max-w-40 truncate">
+                        {access.file_id
+                          ? (fileNames.get(access.file_id) ?? access.file_id)
+                          : "-"}
+                      </TableCell>
+                      <TableCell className="max-w-60">
+                        <div className="truncate font-mono text-xs">
+                          {access.recipient_email || access.ip_address}
+                        </div>
+                        <div
+                          className="text-muted-foreground truncate text-xs"
+                          title={access.user_agent}
+                        >
+                          {access.user_agent}
+                        </div>
+                      </TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            )}
+          </div>
+        )}
+      </DialogContent>
+    </Dialog>
+  );
+};
@@ -1,4 +1,16 @@
-import { Copy, Ellipsis, Lock, QrCode, Trash2, Upload } from "lucide-react";
+import {
+  BarChart3,
+  Copy,
+  Ellipsis,
+  Lock,
+  Mail,
+  Network,
+  QrCode,
+  ShieldCheck,
+  Trash2,
+  Upload,
+  Users,
+} from "lucide-react";
 import { useState } from "react";
 import { useTranslation } from "react-i18next";
 import type { FC } from "react";
@@ -6,6 +18,8 @@ import type { FC } from "react";
 import type { IShare } from "@/types/share.ts";
 import { useDeleteShareMutation } from "@/queries/bucket.ts";
 import { QrCodeDialog } from "@/components/common/components/QrCodeDialog.tsx";
+import { SendShareDialog } from "@/components/bucket-members/components/SendShareDialog.tsx";
+import { ShareAnalyticsDialog } from "@/components/bucket-members/components/ShareAnalyticsDialog.tsx";
 import { Badge } from "@/components/ui/badge.tsx";
 import { Button } from "@/components/ui/button.tsx";
 import {
@@ -33,6 +47,8 @@ interface IShareLinkItemProps {
 export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
   const { t } = useTranslation();
   const [qrOpen, setQrOpen] = useState(false);
+  const [analyticsOpen, setAnalyticsOpen] = useState(false);
+  const [sendOpen, setSendOpen] = useState(false);
   const deleteShare = useDeleteShareMutation(bucketId);
 
   const shareUrl = `${window.location.origin}/shares/${share.id}`;
@@ -46,6 +62,15 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
         current: share.current_views,
       });
 
+  const downloadsText = share.max_downloads
+    ? t("bucket.settings.shares.downloads", {
+        current: share.current_downloads,
+        max: share.max_downloads,
+      })
+    : t("bucket.settings.shares.downloads_unlimited", {
+        current: share.current_downloads,
+      });
+
   let expiryText: string;
   if (!share.expires_at) {
     expiryText = t("bucket.settings.shares.no_expiry");
@@ -59,6 +84,10 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
           });
   }
 
+  const networkRestricted = Boolean(
+    share.ip_allowlist?.length || share.ip_denylist?.length,
+  );
+
   const createdText = new Date(share.created_at).toLocaleDateString();
 
   const handleCopyLink = () => {
@@ -98,10 +127,18 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
                 <Copy className="size-4" />
                 {t("bucket.settings.shares.copy_link")}
               </DropdownMenuItem>
+              <DropdownMenuItem onClick={() => setSendOpen(true)}>
+                <Mail className="size-4" />
+                {t("bucket.settings.shares.send_by_email")}
+              </DropdownMenuItem>
               <DropdownMenuItem onClick={() => setQrOpen(true)}>
                 <QrCode className="size-4" />
                 {t("bucket.settings.shares.show_qr")}
               </DropdownMenuItem>
+              <DropdownMenuItem onClick={() => setAnalyticsOpen(true)}>
+                <BarChart3 className="size-4" />
+                {t("bucket.settings.shares.show_analytics")}
+              </DropdownMenuItem>
               <DropdownMenuItem
                 onClick={() => deleteShare.mutate(share.id)}
                 disabled={deleteShare.isPending}
@@ -118,6 +155,8 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
         <div className="text-muted-foreground flex flex-wrap items-center gap-x-3 gap-y-1 text-xs">
           <span>{viewsText}</span>
           <span>·</span>
+          <span>{downloadsText}</span>
+          <span>·</span>
           <span>{expiryText}</span>
           <span>·</span>
           <span>{createdText}</span>
@@ -127,12 +166,32 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
               {t("bucket.settings.shares.password_protected")}
             </Badge>
           )}
+          {share.recipient_restricted && (
+            <Badge variant="secondary">
+              <Users className="size-3" />
+              {t("bucket.settings.shares.recipients_only", {
+                count: share.recipients?.length ?? 0,
+              })}
+            </Badge>
+          )}
+          {networkRestricted && (
+            <Badge variant="secondary">
+              <Network className="size-3" />
+              {t("bucket.settings.shares.network_restricted")}
+            </Badge>
+          )}
           {share.allow_upload && (
             <Badge variant="secondary">
               <Upload className="size-3" />
               {t("bucket.settings.shares.uploads_allowed")}
             </Badge>
           )}
+          {share.require_approval && (
+            <Badge variant="secondary">
+              <ShieldCheck className="size-3" />
+              {t("bucket.settings.shares.approval_required")}
+            </Badge>
+          )}
         </div>
       </ItemFooter>
       <QrCodeDialog
@@ -142,6 +201,18 @@ export const ShareLinkItem: FC<IShareLinkItemProps> = ({ share, bucketId }) => {
         title={t("bucket.settings.shares.qr_title")}
         description={t("bucket.settings.shares.qr_description")}
       />
+      <SendShareDialog
+        open={sendOpen}
+        onOpenChange={setSendOpen}
+        share={share}
+        bucketId={bucketId}
+      />
+      <ShareAnalyticsDialog
+        open={analyticsOpen}
+        onOpenChange={setAnalyticsOpen}
+        share={share}
+        bucketId={bucketId}
+      />
     </Item>
   );
 };
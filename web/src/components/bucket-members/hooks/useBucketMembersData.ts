@@ -3,7 +3,11 @@ import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { useTranslation } from "react-i18next";
 import type { IBucket } from "@/types/bucket.ts";
 import { EMAIL_REGEX } from "@/types/bucket.ts";
-import { bucketMembersQueryOptions } from "@/queries/bucket";
+import {
+  bucketMembersQueryOptions,
+  useResendInviteMutation,
+  useRevokeInviteMutation,
+} from "@/queries/bucket";
 import { useCurrentUser } from "@/queries/user";
 import { api_updateMembers } from "@/components/bucket-members/helpers/api";
 import {
@@ -18,6 +22,8 @@ export interface IMemberState {
   first_name?: string;
   last_name?: string;
   status: "active" | "invited";
+  invite_id?: string;
+  expires_at?: string;
   isNew?: boolean;
 }
 
@@ -30,6 +36,8 @@ export const useBucketMembersData = (bucket: IBucket) => {
 
   const queryClient = useQueryClient();
   const { data: user } = useCurrentUser();
+  const resendInviteMutation = useResendInviteMutation(bucket.id);
+  const revokeInviteMutation = useRevokeInviteMutation(bucket.id);
 
   const [membersState, setMembersState] = useState<Array<IMemberState>>([]);
   const [newMemberEmail, setNewMemberEmail] = useState("");
@@ -48,6 +56,8 @@ export const useBucketMembersData = (bucket: IBucket) => {
           first_name: member.first_name,
           last_name: member.last_name,
           status: member.status,
+          invite_id: member.invite_id,
+          expires_at: member.expires_at,
           isNew: false,
         })),
       );
@@ -123,6 +133,12 @@ export const useBucketMembersData = (bucket: IBucket) => {
       .finally(() => setIsSubmitting(false));
   };
 
+  const resendInvite = (inviteId: string) =>
+    resendInviteMutation.mutate(inviteId);
+
+  const revokeInvite = (inviteId: string) =>
+    revokeInviteMutation.mutate(inviteId);
+
   return {
     isLoading,
     membersState,
@@ -137,5 +153,7 @@ export const useBucketMembersData = (bucket: IBucket) => {
     addMember,
     updateMemberRole,
     handleUpdateMembers,
+    resendInvite,
+    revokeInvite,
   };
 };
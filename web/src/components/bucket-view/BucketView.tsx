@@ -5,6 +5,7 @@ import { BucketActivityView } from "@/components/bucket-view/components/BucketAc
 import { BucketGridView } from "@/components/bucket-view/components/BucketGridView";
 import { BucketHeader } from "@/components/bucket-view/components/BucketHeader";
 import { BucketListView } from "@/components/bucket-view/components/BucketListView";
+import { BucketPendingView } from "@/components/bucket-view/components/BucketPendingView";
 import { BucketSettings } from "@/components/bucket-view/components/BucketSettings";
 import { BucketTrashView } from "@/components/bucket-view/components/BucketTrashView";
 import { BucketViewMode } from "@/components/bucket-view/helpers/types";
@@ -56,6 +57,7 @@ export const BucketView: FC<IBucketViewProps> = ({
         onPermanentDelete={purgeItem}
       />
     ),
+    [BucketViewMode.Pending]: <BucketPendingView bucket={bucket} />,
     [BucketViewMode.Settings]: <BucketSettings bucket={bucket} />,
   };
 
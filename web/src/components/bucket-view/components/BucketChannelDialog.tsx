@@ -0,0 +1,187 @@
+import { useEffect, useState } from "react";
+import { useTranslation } from "react-i18next";
+import type { FC } from "react";
+
+import type { ChannelType } from "@/types/channel.ts";
+import { CHANNEL_EVENTS, CHANNEL_TYPES } from "@/types/channel.ts";
+import { useCreateChannelMutation } from "@/queries/bucket.ts";
+import { FormErrorAlert } from "@/components/common/FormErrorAlert";
+import { Button } from "@/components/ui/button.tsx";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog.tsx";
+import { resolveErrorMessage } from "@/components/ui/hooks/use-toast.ts";
+import { Input } from "@/components/ui/input.tsx";
+import { Label } from "@/components/ui/label.tsx";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { Switch } from "@/components/ui/switch";
+
+interface IBucketChannelDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  bucketId: string;
+}
+
+const DEFAULT_EVENTS = {
+  notify_uploads: true,
+  notify_downloads: false,
+  notify_shares: true,
+};
+
+export const BucketChannelDialog: FC<IBucketChannelDialogProps> = ({
+  open,
+  onOpenChange,
+  bucketId,
+}) => {
+  const { t } = useTranslation();
+  const createChannel = useCreateChannelMutation(bucketId);
+
+  const [name, setName] = useState("");
+  const [type, setType] = useState<ChannelType>("slack");
+  const [webhookUrl, setWebhookUrl] = useState("");
+  const [events, setEvents] = useState(DEFAULT_EVENTS);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!open) {
+      setName("");
+      setType("slack");
+      setWebhookUrl("");
+      setEvents(DEFAULT_EVENTS);
+      setError(null);
+    }
+  }, [open]);
+
+  const isValid = name.trim() !== "" && webhookUrl.trim() !== "";
+
+  const handleCreate = async () => {
+    if (!isValid) return;
+
+    setError(null);
+    try {
+      await createChannel.mutateAsync({
+        name: name.trim(),
+        type,
+        webhook_url: webhookUrl.trim(),
+        ...events,
+      });
+      onOpenChange(false);
+    } catch (err) {
+      setError(resolveErrorMessage(err as Error));
+    }
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="sm:max-w-md">
+        <DialogHeader>
+          <DialogTitle>{t("bucket.settings.channels.add_title")}</DialogTitle>
+          <DialogDescription>
+            {t("bucket.settings.channels.add_description")}
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="space-y-4">
+          <FormErrorAlert error={error} />
+
+          <div className="space-y-2">
+            <Label htmlFor="channel-name">
+              {t("bucket.settings.channels.name")}
+            </Label>
+            <Input
+              id="channel-name"
+              value={name}
+              maxLength={100}
+              onChange={(e) => setName(e.target.value)}
+              placeholder={t("bucket.settings.channels.name_placeholder")}
+              disabled={createChannel.isPending}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label htmlFor="channel-type">
+              {t("bucket.settings.channels.type")}
+            </Label>
+            <Select
+              value={type}
+              onValueChange={(val: ChannelType) => setType(val)}
+              disabled={createChannel.isPending}
+            >
+              <SelectTrigger id="channel-type" className="w-full">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {CHANNEL_TYPES.map((channelType) => (
+                  <SelectItem key={channelType} value={channelType}>
+                    {t(`bucket.settings.channels.type_${channelType}`)}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+
+          <div className="space-y-2">
+            <Label htmlFor="channel-webhook-url">
+              {t("bucket.settings.channels.webhook_url")}
+            </Label>
+            <Input
+              id="channel-webhook-url"
+              type="url"
+              value={webhookUrl}
+              maxLength={2048}
+              onChange={(e) => setWebhookUrl(e.target.value)}
+              placeholder="https://hooks.slack.com/services/..."
+              disabled={createChannel.isPending}
+            />
+            <p className="text-muted-foreground text-xs">
+              {t("bucket.settings.channels.webhook_url_description")}
+            </p>
+          </div>
+
+          <div className="space-y-3">
+            {CHANNEL_EVENTS.map(({ key, label }) => (
+              <div key={key} className="flex items-center justify-between">
+                <Label htmlFor={`channel-${key}`} className="font-normal">
+                  {t(`bucket.settings.channels.${label}`)}
+                </Label>
+                <Switch
+                  id={`channel-${key}`}
+                  checked={events[key]}
+                  onCheckedChange={(val: boolean) =>
+                    setEvents((prev) => ({ ...prev, [key]: val }))
+                  }
+                  disabled={createChannel.isPending}
+                />
+              </div>
+            ))}
+          </div>
+        </div>
+
+        <DialogFooter className="sm:justify-between">
+          <Button variant="outline" onClick={() => onOpenChange(false)}>
+            {t("common.cancel")}
+          </Button>
+          <Button
+            onClick={handleCreate}
+            disabled={!isValid || createChannel.isPending}
+          >
+            {createChannel.isPending
+              ? t("common.loading")
+              : t("bucket.settings.channels.add")}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+};
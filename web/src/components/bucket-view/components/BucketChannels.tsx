@@ -0,0 +1,145 @@
+import { useState } from "react";
+import { useQuery } from "@tanstack/react-query";
+import { MessageSquare, Plus, Send, Trash2 } from "lucide-react";
+import { useTranslation } from "react-i18next";
+import type { FC } from "react";
+
+import type { IBucket } from "@/types/bucket.ts";
+import { CHANNEL_EVENTS } from "@/types/channel.ts";
+import {
+  bucketChannelsQueryOptions,
+  useDeleteChannelMutation,
+  useTestChannelMutation,
+  useUpdateChannelMutation,
+} from "@/queries/bucket.ts";
+import { BucketChannelDialog } from "@/components/bucket-view/components/BucketChannelDialog";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Switch } from "@/components/ui/switch";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+
+interface IBucketChannelsProps {
+  bucket: IBucket;
+}
+
+export const BucketChannels: FC<IBucketChannelsProps> = ({ bucket }) => {
+  const { t } = useTranslation();
+  const [dialogOpen, setDialogOpen] = useState(false);
+
+  const { data: channels = [] } = useQuery(
+    bucketChannelsQueryOptions(bucket.id),
+  );
+  const updateChannel = useUpdateChannelMutation(bucket.id);
+  const deleteChannel = useDeleteChannelMutation(bucket.id);
+  const testChannel = useTestChannelMutation(bucket.id);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center justify-between gap-2">
+          <span className="flex items-center gap-2">
+            <MessageSquare className="h-5 w-5" />
+            {t("bucket.settings.channels.title")}
+          </span>
+          <Button size="sm" onClick={() => setDialogOpen(true)}>
+            <Plus className="size-4" />
+            {t("bucket.settings.channels.add")}
+          </Button>
+        </CardTitle>
+        <CardDescription>
+          {t("bucket.settings.channels.description")}
+        </CardDescription>
+      </CardHeader>
+      <CardContent>
+        {channels.length === 0 ? (
+          <p className="text-muted-foreground py-4 text-center text-sm">
+            {t("bucket.settings.channels.no_channels")}
+          </p>
+        ) : (
+          <div className="rounded-md border">
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>{t("bucket.settings.channels.name")}</TableHead>
+                  {CHANNEL_EVENTS.map(({ key, label }) => (
+                    <TableHead key={key} className="text-center">
+                      {t(`bucket.settings.channels.${label}`)}
+                    </TableHead>
+                  ))}
+                  <TableHead />
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {channels.map((channel) => (
+                  <TableRow key={channel.id}>
+                    <TableCell>
+                      <div className="font-medium">{channel.name}</div>
+                      <div className="text-muted-foreground text-xs">
+                        {t(`bucket.settings.channels.type_${channel.type}`)}
+                        {" · "}
+                        {channel.webhook_host}
+                      </div>
+                    </TableCell>
+                    {CHANNEL_EVENTS.map(({ key }) => (
+                      <TableCell key={key} className="text-center">
+                        <Switch
+                          checked={channel[key]}
+                          onCheckedChange={(val: boolean) =>
+                            updateChannel.mutate({
+                              channelId: channel.id,
+                              body: { [key]: val },
+                            })
+                          }
+                          disabled={updateChannel.isPending}
+                        />
+                      </TableCell>
+                    ))}
+                    <TableCell className="text-right">
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        title={t("bucket.settings.channels.test")}
+                        onClick={() => testChannel.mutate(channel.id)}
+                        disabled={testChannel.isPending}
+                      >
+                        <Send className="size-4" />
+                      </Button>
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        title={t("bucket.settings.channels.delete")}
+                        onClick={() => deleteChannel.mutate(channel.id)}
+                        disabled={deleteChannel.isPending}
+                      >
+                        <Trash2 className="size-4" />
+                      </Button>
+                    </TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          </div>
+        )}
+      </CardContent>
+
+      <BucketChannelDialog
+        open={dialogOpen}
+        onOpenChange={setDialogOpen}
+        bucketId={bucket.id}
+      />
+    </Card>
+  );
+};
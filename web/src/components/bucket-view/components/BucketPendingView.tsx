@@ -0,0 +1,204 @@
+import { useTranslation } from "react-i18next";
+import { useQuery } from "@tanstack/react-query";
+import { Check, Download, ShieldCheck, X } from "lucide-react";
+import { useState } from "react";
+import type { FC } from "react";
+
+import type { IBucket } from "@/types/bucket.ts";
+import type { IFile } from "@/types/file.ts";
+import {
+  api_downloadFile,
+  downloadFromStorage,
+} from "@/components/file-actions/helpers/api";
+import { FileIconView } from "@/components/bucket-view/components/FileIconView";
+import {
+  bucketPendingFilesQueryOptions,
+  useApproveFileMutation,
+  useRejectFileMutation,
+} from "@/queries/bucket";
+import { formatDate, formatFileSize } from "@/lib/utils";
+import { Button } from "@/components/ui/button";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from "@/components/ui/alert-dialog";
+
+interface IBucketPendingViewProps {
+  bucket: IBucket;
+}
+
+const buildFolderPath = (
+  folderId: string | undefined,
+  folders: IBucket["folders"],
+): string => {
+  const path: Array<string> = [];
+  let currentId = folderId;
+
+  while (currentId) {
+    const folder = folders.find((f) => f.id === currentId);
+    if (!folder) break;
+    path.unshift(folder.name);
+    currentId = folder.folder_id;
+  }
+
+  return "/" + path.join("/");
+};
+
+export const BucketPendingView: FC<IBucketPendingViewProps> = ({
+  bucket,
+}) => {
+  const { t } = useTranslation();
+  const { data: files = [] } = useQuery(
+    bucketPendingFilesQueryOptions(bucket.id),
+  );
+  const approveMutation = useApproveFileMutation(bucket.id);
+  const rejectMutation = useRejectFileMutation(bucket.id);
+  const [rejecting, setRejecting] = useState<IFile | null>(null);
+
+  const handleDownload = async (file: IFile) => {
+    const response = await api_downloadFile(bucket.id, file.id);
+    downloadFromStorage(response.url, file.name, response.headers);
+  };
+
+  const handleConfirmReject = () => {
+    if (rejecting) {
+      rejectMutation.mutate(rejecting.id);
+      setRejecting(null);
+    }
+  };
+
+  if (files.length === 0) {
+    return (
+      <div className="flex flex-col items-center justify-center h-[400px] text-muted-foreground">
+        <ShieldCheck className="h-16 w-16 mb-4 opacity-20" />
+        <p className="text-lg font-medium">{t("bucket.pending_view.empty")}</p>
+        <p className="text-sm mt-2">
+          {t("bucket.pending_view.empty_description")}
+        </p>
+      </div>
+    );
+  }
+
+  return (
+    <>
+      <div className="space-y-4">
+        <div className="bg-muted/50 p-4 rounded-lg border">
+          <p className="text-sm text-muted-foreground">
+            {t("bucket.pending_view.notice")}
+          </p>
+        </div>
+        <div className="rounded-md border">
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t("bucket.pending_view.name")}</TableHead>
+                <TableHead>{t("bucket.pending_view.location")}</TableHead>
+                <TableHead>{t("bucket.pending_view.size")}</TableHead>
+                <TableHead>{t("bucket.pending_view.uploaded_at")}</TableHead>
+                <TableHead className="text-right">
+                  {t("bucket.pending_view.actions")}
+                </TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {files.map((file) => (
+                <TableRow key={file.id}>
+                  <TableCell>
+                    <div className="flex items-center gap-2 overflow-hidden max-w-87.5">
+                      <FileIconView
+                        className="text-primary h-5 w-5 shrink-0"
+                        isFolder={false}
+                        extension={file.extension}
+                      />
+                      <p className="truncate">{file.name}</p>
+                    </div>
+                  </TableCell>
+                  <TableCell className="text-sm text-muted-foreground">
+                    {buildFolderPath(file.folder_id, bucket.folders)}
+                  </TableCell>
+                  <TableCell>{formatFileSize(file.size)}</TableCell>
+                  <TableCell>{formatDate(file.created_at)}</TableCell>
+                  <TableCell>
+                    <div className="flex justify-end gap-1">
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        title={t("bucket.pending_view.download")}
+                        onClick={() => handleDownload(file)}
+                      >
+                        <Download className="h-4 w-4" />
+                      </Button>
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        className="text-green-600"
+                        title={t("bucket.pending_view.approve")}
+                        disabled={approveMutation.isPending}
+                        onClick={() => approveMutation.mutate(file.id)}
+                      >
+                        <Check className="h-4 w-4" />
+                      </Button>
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        className="text-red-600"
+                        title={t("bucket.pending_view.reject")}
+                        disabled={rejectMutation.isPending}
+                        onClick={() => setRejecting(file)}
+                      >
+                        <X className="h-4 w-4" />
+                      </Button>
+                    </div>
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </div>
+      </div>
+
+      <AlertDialog
+        open={rejecting !== null}
+        onOpenChange={(open) => !open && setRejecting(null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>
+              {t("bucket.pending_view.confirm_reject_title")}
+            </AlertDialogTitle>
+            <AlertDialogDescription>
+              {t("bucket.pending_view.confirm_reject_description", {
+                fileName: rejecting?.name,
+              })}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>
+              {t("bucket.pending_view.cancel")}
+            </AlertDialogCancel>
+            <AlertDialogAction
+              onClick={handleConfirmReject}
+              className="bg-red-600 hover:bg-red-700 focus:ring-red-600"
+            >
+              {t("bucket.pending_view.reject")}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </>
+  );
+};
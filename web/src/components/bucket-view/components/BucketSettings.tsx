@@ -1,15 +1,19 @@
+import { BucketChannels } from "./BucketChannels";
 import { BucketDeletion } from "./BucketDeletion";
 import { BucketInformation } from "./BucketInformation";
 import type { FC } from "react";
 
 import type { IBucket } from "@/types/bucket.ts";
 import { BucketMembers } from "@/components/bucket-members/BucketMembers.tsx";
+import { useBucketPermissions } from "@/hooks/usePermissions";
 
 interface IBucketSettingsProps {
   bucket: IBucket;
 }
 
 export const BucketSettings: FC<IBucketSettingsProps> = ({ bucket }) => {
+  const { isOwner } = useBucketPermissions(bucket.id);
+
   return (
     <div className="mx-auto">
       <div className="grid grid-cols-1 gap-6 lg:grid-cols-5">
@@ -18,8 +22,9 @@ export const BucketSettings: FC<IBucketSettingsProps> = ({ bucket }) => {
           <BucketDeletion bucket={bucket} />
         </div>
 
-        <div className="lg:col-span-3">
+        <div className="space-y-6 lg:col-span-3">
           <BucketMembers bucket={bucket} />
+          {isOwner && <BucketChannels bucket={bucket} />}
         </div>
       </div>
     </div>
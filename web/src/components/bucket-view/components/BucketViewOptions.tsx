@@ -4,6 +4,7 @@ import {
   LayoutGrid,
   LayoutList,
   Settings,
+  ShieldCheck,
   Trash2,
 } from "lucide-react";
 import { t } from "i18next";
@@ -50,6 +51,11 @@ const options = [
     value: <Trash2 />,
     tooltip: t("bucket.header.trash"),
   },
+  {
+    key: BucketViewMode.Pending,
+    value: <ShieldCheck />,
+    tooltip: t("bucket.header.pending"),
+  },
   {
     key: BucketViewMode.Settings,
     value: <Settings />,
@@ -65,13 +71,15 @@ export const BucketViewOptions: FC<IBucketViewOptionsProps> = ({
   variant = "buttons",
 }) => {
   const { view, setView, bucketId } = useBucketViewContext();
-  const { isOwner } = useBucketPermissions(bucketId);
+  const { isOwner, isContributor } = useBucketPermissions(bucketId);
 
   const filteredOptions = useMemo(() => {
     return options.filter(
-      (opt) => !(opt.key === BucketViewMode.Settings && !isOwner),
+      (opt) =>
+        !(opt.key === BucketViewMode.Settings && !isOwner) &&
+        !(opt.key === BucketViewMode.Pending && !isContributor),
     );
-  }, [isOwner]);
+  }, [isOwner, isContributor]);
 
   const activeOption = filteredOptions.find((opt) => opt.key === view);
 
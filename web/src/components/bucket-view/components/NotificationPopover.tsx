@@ -4,7 +4,10 @@ import { useTranslation } from "react-i18next";
 import { useQuery } from "@tanstack/react-query";
 import type { FC } from "react";
 
-import type { INotificationPreferences } from "@/components/bucket-view/helpers/types.ts";
+import type {
+  INotificationPreferences,
+  NotificationDelivery,
+} from "@/components/bucket-view/helpers/types.ts";
 import { Button } from "@/components/ui/button";
 import { Label } from "@/components/ui/label";
 import {
@@ -12,6 +15,14 @@ import {
   PopoverContent,
   PopoverTrigger,
 } from "@/components/ui/popover";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { Separator } from "@/components/ui/separator";
 import { Switch } from "@/components/ui/switch";
 import {
   Tooltip,
@@ -25,6 +36,31 @@ import {
   useUpdateNotificationPreferencesMutation,
 } from "@/queries/bucket";
 
+type NotificationToggle = Exclude<
+  keyof INotificationPreferences,
+  "notification_delivery"
+>;
+
+interface INotificationToggle {
+  key: NotificationToggle;
+  label: string;
+}
+
+const NOTIFICATION_TOGGLES: Array<INotificationToggle> = [
+  { key: "upload_notifications", label: "uploads" },
+  { key: "download_notifications", label: "downloads" },
+  { key: "delete_notifications", label: "deletions" },
+  { key: "restore_notifications", label: "restores" },
+  { key: "share_notifications", label: "shares" },
+  { key: "member_notifications", label: "members" },
+];
+
+const NOTIFICATION_DELIVERIES: Array<NotificationDelivery> = [
+  "immediate",
+  "hourly",
+  "daily",
+];
+
 interface NotificationPopoverProps {
   bucketId: string;
 }
@@ -48,13 +84,18 @@ export const NotificationPopover: FC<NotificationPopoverProps> = ({
 
   if (!membership) return null;
 
-  const handleNotificationToggle = ({
-    upload_notifications,
-    download_notifications,
-  }: INotificationPreferences) => {
+  const handleNotificationChange = (
+    changes: Partial<INotificationPreferences>,
+  ) => {
     mutation.mutate({
-      upload_notifications,
-      download_notifications,
+      upload_notifications: membership.upload_notifications,
+      download_notifications: membership.download_notifications,
+      delete_notifications: membership.delete_notifications,
+      restore_notifications: membership.restore_notifications,
+      share_notifications: membership.share_notifications,
+      member_notifications: membership.member_notifications,
+      notification_delivery: membership.notification_delivery,
+      ...changes,
     });
   };
 
@@ -85,44 +126,48 @@ export const NotificationPopover: FC<NotificationPopoverProps> = ({
             </p>
           </div>
           <div className="space-y-3">
-            <div className="flex items-center justify-between">
-              <Label
-                htmlFor="upload-notifications"
-                className="text-sm font-normal"
-              >
-                {t("bucket.notifications.uploads")}
-              </Label>
-              <Switch
-                id="upload-notifications"
-                checked={membership.upload_notifications}
-                onCheckedChange={(val: boolean) =>
-                  handleNotificationToggle({
-                    upload_notifications: val,
-                    download_notifications: membership.download_notifications,
-                  })
-                }
-                disabled={mutation.isPending}
-              />
-            </div>
-            <div className="flex items-center justify-between">
-              <Label
-                htmlFor="download-notifications"
-                className="text-sm font-normal"
-              >
-                {t("bucket.notifications.downloads")}
-              </Label>
-              <Switch
-                id="download-notifications"
-                checked={membership.download_notifications}
-                onCheckedChange={(val: boolean) =>
-                  handleNotificationToggle({
-                    upload_notifications: membership.upload_notifications,
-                    download_notifications: val,
-                  })
-                }
-                disabled={mutation.isPending}
-              />
-            </div>
+            {NOTIFICATION_TOGGLES.map(({ key, label }) => (
+              <div key={key} className="flex items-center justify-between">
+                <Label htmlFor={key} className="text-sm font-normal">
+                  {t(`bucket.notifications.${label}`)}
+                </Label>
+                <Switch
+                  id={key}
+                  checked={membership[key]}
+                  onCheckedChange={(val: boolean) =>
+                    handleNotificationChange({ [key]: val })
+                  }
+                  disabled={mutation.isPending}
+                />
+              </div>
+            ))}
+          </div>
+          <Separator />
+          <div className="space-y-2">
+            <Label htmlFor="notification-delivery" className="text-sm">
+              {t("bucket.notifications.delivery")}
+            </Label>
+            <Select
+              value={membership.notification_delivery}
+              onValueChange={(val: NotificationDelivery) =>
+                handleNotificationChange({ notification_delivery: val })
+              }
+              disabled={mutation.isPending}
+            >
+              <SelectTrigger id="notification-delivery" className="w-full">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {NOTIFICATION_DELIVERIES.map((delivery) => (
+                  <SelectItem key={delivery} value={delivery}>
+                    {t(`bucket.notifications.delivery_${delivery}`)}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <p className="text-muted-foreground text-xs">
+              {t("bucket.notifications.delivery_description")}
+            </p>
           </div>
         </div>
       </PopoverContent>
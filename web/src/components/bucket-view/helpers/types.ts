@@ -19,16 +19,25 @@ export enum BucketViewMode {
   Grid = "grid",
   Activity = "activity",
   Trash = "trash",
+  Pending = "pending",
   Settings = "settings",
 }
 
 export type IDownloadFileResponse = {
   url: string;
+  headers?: Record<string, string>;
 };
 
+export type NotificationDelivery = "immediate" | "hourly" | "daily";
+
 export interface INotificationPreferences {
   upload_notifications: boolean;
   download_notifications: boolean;
+  delete_notifications: boolean;
+  restore_notifications: boolean;
+  share_notifications: boolean;
+  member_notifications: boolean;
+  notification_delivery: NotificationDelivery;
 }
 
 export type IBucketMember = INotificationPreferences & {
@@ -38,4 +47,6 @@ export type IBucketMember = INotificationPreferences & {
   last_name?: string;
   group: string;
   status: "active" | "invited";
+  invite_id?: string;
+  expires_at?: string;
 };
@@ -1,9 +1,11 @@
 import { useTranslation } from "react-i18next";
 import { useQuery } from "@tanstack/react-query";
 import { Download, LoaderCircle } from "lucide-react";
+import { useEffect } from "react";
 import type { FC } from "react";
 
 import type { IFile } from "@/types/file.ts";
+import { fetchFromStorage } from "@/components/file-actions/helpers/api";
 import { getPreviewKind } from "@/components/file-actions/helpers/preview-kind";
 import {
   Dialog,
@@ -21,7 +23,7 @@ interface IFilePreviewDialogProps {
   open: boolean;
   onOpenChange: (isOpen: boolean) => void;
   file: IFile;
-  fetchUrl: () => Promise<{ url: string }>;
+  fetchUrl: () => Promise<{ url: string; headers?: Record<string, string> }>;
   onDownload: () => void;
 }
 
@@ -40,12 +42,26 @@ export const FilePreviewDialog: FC<IFilePreviewDialogProps> = ({
 
   const { data, isLoading, isError } = useQuery({
     queryKey: [file.id, "preview"],
-    queryFn: fetchUrl,
+    queryFn: async () => {
+      const { url, headers } = await fetchUrl();
+      if (!headers || Object.keys(headers).length === 0) {
+        return { url, isObjectUrl: false };
+      }
+      // Media elements cannot send the headers of customer-key encrypted files.
+      const blob = await fetchFromStorage(url, headers);
+      return { url: URL.createObjectURL(blob), isObjectUrl: true };
+    },
     enabled: open && canPreview,
     staleTime: 0,
     gcTime: 0,
   });
 
+  useEffect(() => {
+    if (!data?.isObjectUrl) return;
+    const objectUrl = data.url;
+    return () => URL.revokeObjectURL(objectUrl);
+  }, [data]);
+
   const url = data?.url;
 
   return (
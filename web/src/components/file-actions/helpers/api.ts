@@ -14,7 +14,13 @@ export const api_downloadFile = (
     params: { context },
   });
 
-export const downloadFromStorage = (url: string, filename: string) => {
+// Files of buckets encrypted with a customer key can only be read with the headers returned
+// alongside the presigned URL.
+export const downloadFromStorage = (
+  url: string,
+  filename: string,
+  headers: Record<string, string> = {},
+) => {
   const xhr = new XMLHttpRequest();
 
   xhr.onreadystatechange = () => {
@@ -24,6 +30,9 @@ export const downloadFromStorage = (url: string, filename: string) => {
   };
   xhr.responseType = "blob";
   xhr.open("GET", url, true);
+  Object.entries(headers).forEach(([key, value]) => {
+    xhr.setRequestHeader(key, value);
+  });
   xhr.send(null);
 
   toast({
@@ -32,3 +41,14 @@ export const downloadFromStorage = (url: string, filename: string) => {
     description: i18n.t("toast.download_started", { filename }),
   });
 };
+
+export const fetchFromStorage = async (
+  url: string,
+  headers: Record<string, string>,
+) => {
+  const response = await fetch(url, { headers });
+  if (!response.ok) {
+    throw new Error(`Storage responded with ${response.status}`);
+  }
+  return response.blob();
+};
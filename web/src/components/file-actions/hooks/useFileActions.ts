@@ -43,7 +43,7 @@ export const useFileActions = (): IFileActions => {
 
   const downloadFile = (fileId: string, filename: string) => {
     api_downloadFile(bucketId, fileId).then((res) =>
-      downloadFromStorage(res.url, filename),
+      downloadFromStorage(res.url, filename, res.headers),
     );
   };
 
@@ -19,7 +19,7 @@ import {
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { authProvidersQueryOptions } from "@/queries/auth_providers.ts";
-import { ProviderType } from "@/types/auth_providers.ts";
+import { ProviderType, isRedirectProvider } from "@/types/auth_providers.ts";
 import { useLogin } from "@/hooks/useAuth";
 import { checkEmailDomain } from "@/components/reset-password/helpers/utils.ts";
 import { AuthProvidersButtons } from "@/components/auth-providers-buttons/AuthProvidersButtons.tsx";
@@ -42,6 +42,7 @@ export const InviteFormSmartEnrollment: FC<ISmartInviteEnrollmentProps> = ({
 
   const providersQuery = useSuspenseQuery(authProvidersQueryOptions());
   const providers = providersQuery.data;
+  const redirectProviders = providers.filter((p) => isRedirectProvider(p.type));
 
   const {
     register,
@@ -97,14 +98,11 @@ export const InviteFormSmartEnrollment: FC<ISmartInviteEnrollmentProps> = ({
         </CardDescription>
       </CardHeader>
       <CardContent className="space-y-2">
-        <AuthProvidersButtons
-          providers={providers.filter((p) => p.type === ProviderType.OIDC)}
-        />
+        <AuthProvidersButtons providers={redirectProviders} />
 
         {providers.find((p) => p.type === ProviderType.LOCAL) && (
           <>
-            {providers.filter((p) => p.type === ProviderType.OIDC).length >
-              0 && (
+            {redirectProviders.length > 0 && (
               <div className="relative">
                 <div className="absolute inset-0 flex items-center">
                   <span className="w-full border-t" />
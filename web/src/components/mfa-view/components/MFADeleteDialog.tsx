@@ -16,7 +16,7 @@ import { useRemoveMFADeviceMutation } from "@/queries/mfa";
 import { FormErrorAlert } from "@/components/common/FormErrorAlert";
 import { MFAVerifyInput } from "@/components/mfa-view/components/MFAVerifyInput";
 import { MFA_CODE_LENGTH } from "@/components/mfa-view/helpers/constants";
-import { ProviderType } from "@/types/auth_providers.ts";
+import { isRedirectProvider } from "@/types/auth_providers.ts";
 
 interface MFADeleteDialogProps {
   deviceId: string | null;
@@ -31,7 +31,7 @@ export function MFADeleteDialog({
 }: MFADeleteDialogProps) {
   const { t } = useTranslation();
   const removeMutation = useRemoveMFADeviceMutation();
-  const isOIDC = providerType === ProviderType.OIDC;
+  const isRedirectFlow = isRedirectProvider(providerType);
 
   const [password, setPassword] = useState("");
   const [code, setCode] = useState("");
@@ -45,7 +45,9 @@ export function MFADeleteDialog({
     }
   }, [deviceId]);
 
-  const canConfirm = isOIDC ? code.length === MFA_CODE_LENGTH : !!password;
+  const canConfirm = isRedirectFlow
+    ? code.length === MFA_CODE_LENGTH
+    : !!password;
 
   const handleConfirmDelete = async () => {
     if (!deviceId || !canConfirm) return;
@@ -53,7 +55,7 @@ export function MFADeleteDialog({
     setError(null);
     try {
       await removeMutation.mutateAsync(
-        isOIDC ? { deviceId, code } : { deviceId, password },
+        isRedirectFlow ? { deviceId, code } : { deviceId, password },
       );
       onClose();
     } catch (err) {
@@ -74,7 +76,7 @@ export function MFADeleteDialog({
         <DialogHeader>
           <DialogTitle>{t("auth.mfa.delete_device_title")}</DialogTitle>
           <DialogDescription>
-            {isOIDC
+            {isRedirectFlow
               ? t("auth.mfa.delete_code_instruction")
               : t("auth.mfa.delete_device_description")}
           </DialogDescription>
@@ -83,7 +85,7 @@ export function MFADeleteDialog({
         <div className="space-y-4">
           <FormErrorAlert error={error} />
 
-          {isOIDC ? (
+          {isRedirectFlow ? (
             <div className="space-y-2">
               <Label>{t("auth.mfa.delete_code_label")}</Label>
               <MFAVerifyInput
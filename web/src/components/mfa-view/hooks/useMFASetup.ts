@@ -12,7 +12,7 @@ import {
   useAddMFADeviceMutation,
   useVerifyMFADeviceMutation,
 } from "@/queries/mfa";
-import { ProviderType } from "@/types/auth_providers.ts";
+import { isRedirectProvider } from "@/types/auth_providers.ts";
 
 export interface UseMFASetupOptions {
   isRestricted?: boolean;
@@ -54,9 +54,9 @@ export function useMFASetup(
   const addDeviceMutation = useAddMFADeviceMutation();
   const verifyDeviceMutation = useVerifyMFADeviceMutation();
 
-  const isOIDC = providerType === ProviderType.OIDC;
-  const needsPassword = !isRestricted && !isOIDC;
-  const needsStepUpCode = !isRestricted && isOIDC && hasExistingDevices;
+  const isRedirectFlow = isRedirectProvider(providerType);
+  const needsPassword = !isRestricted && !isRedirectFlow;
+  const needsStepUpCode = !isRestricted && isRedirectFlow && hasExistingDevices;
 
   const [step, setStep] = useState<SetupStep>("name");
   const [deviceName, setDeviceName] = useState(MFA_DEFAULT_DEVICE_NAME);
@@ -48,9 +48,18 @@ export interface IQuickShareForm {
   expiresAt: Date | undefined;
   limitViews: boolean;
   maxViews: number | "";
+  limitDownloads: boolean;
+  maxDownloads: number | "";
+  maxFileDownloads: number | "";
   passwordProtected: boolean;
   password: string;
+  restrictRecipients: boolean;
+  recipients: string;
+  restrictNetworks: boolean;
+  ipAllowlist: string;
+  ipDenylist: string;
   allowUploads: boolean;
+  requireApproval: boolean;
   maxUploadSize: number | "";
   maxUploads: number | "";
 }
@@ -76,14 +85,30 @@ function getDefaultValues(
     expiresAt: undefined,
     limitViews: false,
     maxViews: 1,
+    limitDownloads: false,
+    maxDownloads: 1,
+    maxFileDownloads: "",
     passwordProtected: false,
     password: "",
+    restrictRecipients: false,
+    recipients: "",
+    restrictNetworks: false,
+    ipAllowlist: "",
+    ipDenylist: "",
     allowUploads: false,
+    requireApproval: false,
     maxUploadSize: 100,
     maxUploads: 1,
   };
 }
 
+function parseList(value: string): Array<string> {
+  return value
+    .split(/[\s,;]+/)
+    .map((entry) => entry.trim())
+    .filter(Boolean);
+}
+
 export const QuickShareDialog: FC<IQuickShareDialogProps> = ({
   open,
   onOpenChange,
@@ -105,7 +130,10 @@ export const QuickShareDialog: FC<IQuickShareDialogProps> = ({
   const selectedFolderId = watch("selectedFolderId");
   const hasExpiry = watch("hasExpiry");
   const limitViews = watch("limitViews");
+  const limitDownloads = watch("limitDownloads");
   const passwordProtected = watch("passwordProtected");
+  const restrictRecipients = watch("restrictRecipients");
+  const restrictNetworks = watch("restrictNetworks");
   const allowUploads = watch("allowUploads");
 
   const [step, setStep] = useState<Step>(1);
@@ -126,6 +154,7 @@ export const QuickShareDialog: FC<IQuickShareDialogProps> = ({
   const handleScopeChange = (newScope: ShareScope) => {
     setValue("scope", newScope);
     setValue("allowUploads", false);
+    setValue("requireApproval", false);
     if (newScope === "files") {
       setValue(
         "selectedFileIds",
@@ -177,11 +206,31 @@ export const QuickShareDialog: FC<IQuickShareDialogProps> = ({
         values.limitViews && values.maxViews
           ? Number(values.maxViews)
           : undefined,
+      max_downloads:
+        values.limitDownloads && values.maxDownloads
+          ? Number(values.maxDownloads)
+          : undefined,
+      max_file_downloads:
+        values.limitDownloads &&
+        values.scope === "files" &&
+        values.maxFileDownloads
+          ? Number(values.maxFileDownloads)
+          : undefined,
       password:
         values.passwordProtected && values.password
           ? values.password
           : undefined,
+      recipients: values.restrictRecipients
+        ? parseList(values.recipients)
+        : undefined,
+      ip_allowlist: values.restrictNetworks
+        ? parseList(values.ipAllowlist)
+        : undefined,
+      ip_denylist: values.restrictNetworks
+        ? parseList(values.ipDenylist)
+        : undefined,
       allow_upload: values.allowUploads,
+      require_approval: values.allowUploads && values.requireApproval,
       max_uploads:
         values.allowUploads && values.maxUploads
           ? Number(values.maxUploads)
@@ -242,7 +291,10 @@ export const QuickShareDialog: FC<IQuickShareDialogProps> = ({
               control={control}
               hasExpiry={hasExpiry}
               limitViews={limitViews}
+              limitDownloads={limitDownloads}
               passwordProtected={passwordProtected}
+              restrictRecipients={restrictRecipients}
+              restrictNetworks={restrictNetworks}
               allowUploads={allowUploads}
             />
           )}
@@ -1,6 +1,16 @@
 import { useTranslation } from "react-i18next";
 
-import { Calendar, Eye, EyeOff, Lock, Upload } from "lucide-react";
+import {
+  Calendar,
+  Download,
+  Eye,
+  EyeOff,
+  Lock,
+  Network,
+  ShieldCheck,
+  Upload,
+  Users,
+} from "lucide-react";
 import { useState } from "react";
 import { Controller } from "react-hook-form";
 import type { Control } from "react-hook-form";
@@ -20,7 +30,10 @@ interface IQuickShareOptionsStepProps {
   control: Control<IQuickShareForm>;
   hasExpiry: boolean;
   limitViews: boolean;
+  limitDownloads: boolean;
   passwordProtected: boolean;
+  restrictRecipients: boolean;
+  restrictNetworks: boolean;
   allowUploads: boolean;
 }
 
@@ -29,7 +42,10 @@ export const QuickShareOptionsStep: FC<IQuickShareOptionsStepProps> = ({
   control,
   hasExpiry,
   limitViews,
+  limitDownloads,
   passwordProtected,
+  restrictRecipients,
+  restrictNetworks,
   allowUploads,
 }) => {
   const { t } = useTranslation();
@@ -136,6 +152,78 @@ export const QuickShareOptionsStep: FC<IQuickShareOptionsStepProps> = ({
 
       <Separator />
 
+      <div className="space-y-4">
+        <div className="flex items-center justify-between">
+          <div className="space-y-1">
+            <div className="flex items-center gap-2">
+              <Download className="text-muted-foreground h-4 w-4" />
+              <Label>{t("quick_share.limit_downloads")}</Label>
+            </div>
+            <p className="text-muted-foreground text-xs">
+              {t("quick_share.limit_downloads_description")}
+            </p>
+          </div>
+          <Controller
+            name="limitDownloads"
+            control={control}
+            render={({ field: { onChange, value } }) => (
+              <Switch checked={value} onCheckedChange={onChange} />
+            )}
+          />
+        </div>
+
+        {limitDownloads && (
+          <>
+            <div className="space-y-2">
+              <Label>{t("quick_share.max_downloads")}</Label>
+              <Controller
+                name="maxDownloads"
+                control={control}
+                render={({ field: { onChange, value } }) => (
+                  <Input
+                    type="number"
+                    min={1}
+                    value={value}
+                    onChange={(e) =>
+                      onChange(
+                        e.target.value === "" ? "" : Number(e.target.value),
+                      )
+                    }
+                  />
+                )}
+              />
+            </div>
+
+            {scope === "files" && (
+              <div className="space-y-2">
+                <Label>{t("quick_share.max_file_downloads")}</Label>
+                <p className="text-muted-foreground text-xs">
+                  {t("quick_share.max_file_downloads_description")}
+                </p>
+                <Controller
+                  name="maxFileDownloads"
+                  control={control}
+                  render={({ field: { onChange, value } }) => (
+                    <Input
+                      type="number"
+                      min={1}
+                      value={value}
+                      onChange={(e) =>
+                        onChange(
+                          e.target.value === "" ? "" : Number(e.target.value),
+                        )
+                      }
+                    />
+                  )}
+                />
+              </div>
+            )}
+          </>
+        )}
+      </div>
+
+      <Separator />
+
       <div className="space-y-4">
         <div className="flex items-center justify-between">
           <div className="space-y-1">
@@ -151,7 +239,11 @@ export const QuickShareOptionsStep: FC<IQuickShareOptionsStepProps> = ({
             name="passwordProtected"
             control={control}
             render={({ field: { onChange, value } }) => (
-              <Switch checked={value} onCheckedChange={onChange} />
+              <Switch
+                checked={value}
+                onCheckedChange={onChange}
+                disabled={restrictRecipients}
+              />
             )}
           />
         </div>
@@ -193,6 +285,111 @@ export const QuickShareOptionsStep: FC<IQuickShareOptionsStepProps> = ({
         )}
       </div>
 
+      <Separator />
+
+      <div className="space-y-4">
+        <div className="flex items-center justify-between">
+          <div className="space-y-1">
+            <div className="flex items-center gap-2">
+              <Users className="text-muted-foreground h-4 w-4" />
+              <Label>{t("quick_share.restrict_recipients")}</Label>
+            </div>
+            <p className="text-muted-foreground text-xs">
+              {t("quick_share.restrict_recipients_description")}
+            </p>
+          </div>
+          <Controller
+            name="restrictRecipients"
+            control={control}
+            render={({ field: { onChange, value } }) => (
+              <Switch
+                checked={value}
+                onCheckedChange={onChange}
+                disabled={passwordProtected}
+              />
+            )}
+          />
+        </div>
+
+        {restrictRecipients && (
+          <div className="space-y-2">
+            <Label>{t("quick_share.recipients")}</Label>
+            <Controller
+              name="recipients"
+              control={control}
+              rules={{ required: true }}
+              render={({ field: { onChange, value } }) => (
+                <Input
+                  type="text"
+                  value={value}
+                  onChange={onChange}
+                  placeholder={t("quick_share.recipients_placeholder")}
+                />
+              )}
+            />
+          </div>
+        )}
+      </div>
+
+      <Separator />
+
+      <div className="space-y-4">
+        <div className="flex items-center justify-between">
+          <div className="space-y-1">
+            <div className="flex items-center gap-2">
+              <Network className="text-muted-foreground h-4 w-4" />
+              <Label>{t("quick_share.restrict_networks")}</Label>
+            </div>
+            <p className="text-muted-foreground text-xs">
+              {t("quick_share.restrict_networks_description")}
+            </p>
+          </div>
+          <Controller
+            name="restrictNetworks"
+            control={control}
+            render={({ field: { onChange, value } }) => (
+              <Switch checked={value} onCheckedChange={onChange} />
+            )}
+          />
+        </div>
+
+        {restrictNetworks && (
+          <>
+            <div className="space-y-2">
+              <Label>{t("quick_share.ip_allowlist")}</Label>
+              <Controller
+                name="ipAllowlist"
+                control={control}
+                render={({ field: { onChange, value } }) => (
+                  <Input
+                    type="text"
+                    value={value}
+                    onChange={onChange}
+                    placeholder={t("quick_share.ip_list_placeholder")}
+                  />
+                )}
+              />
+            </div>
+
+            <div className="space-y-2">
+              <Label>{t("quick_share.ip_denylist")}</Label>
+              <Controller
+                name="ipDenylist"
+                control={control}
+                render={({ field: { onChange, value } }) => (
+                  <Input
+                    type="text"
+                    value={value}
+                    onChange={onChange}
+                    placeholder={t("quick_share.ip_list_placeholder")}
+                  />
+                )}
+              />
+            </div>
+          </>
+        )}
+      </div>
+
       {scope !== "files" && (
         <>
           <Separator />
@@ -261,6 +458,25 @@ export const QuickShareOptionsStep: FC<IQuickShareOptionsStepProps> = ({
                     )}
                   />
                 </div>
+
+                <div className="flex items-center justify-between">
+                  <div className="space-y-1">
+                    <div className="flex items-center gap-2">
+                      <ShieldCheck className="text-muted-foreground h-4 w-4" />
+                      <Label>{t("quick_share.require_approval")}</Label>
+                    </div>
+                    <p className="text-muted-foreground text-xs">
+                      {t("quick_share.require_approval_description")}
+                    </p>
+                  </div>
+                  <Controller
+                    name="requireApproval"
+                    control={control}
+                    render={({ field: { onChange, value } }) => (
+                      <Switch checked={value} onCheckedChange={onChange} />
+                    )}
+                  />
+                </div>
               </>
             )}
           </div>
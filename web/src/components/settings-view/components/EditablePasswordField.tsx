@@ -6,13 +6,15 @@ import { Button } from "@/components/ui/button.tsx";
 
 interface EditablePasswordFieldProps {
   label: string;
-  onSave: (oldPassword: string, newPassword: string) => void;
+  onSave: (oldPassword: string, newPassword: string, code: string) => void;
+  requireCode?: boolean;
   isLoading?: boolean;
 }
 
 export function EditablePasswordField({
   label,
   onSave,
+  requireCode = false,
   isLoading = false,
 }: EditablePasswordFieldProps) {
   const { t } = useTranslation();
@@ -20,6 +22,7 @@ export function EditablePasswordField({
   const [oldPassword, setOldPassword] = useState("");
   const [newPassword, setNewPassword] = useState("");
   const [confirmPassword, setConfirmPassword] = useState("");
+  const [code, setCode] = useState("");
   const [error, setError] = useState("");
 
   const handleSave = () => {
@@ -40,7 +43,12 @@ export function EditablePasswordField({
       return;
     }
 
-    onSave(oldPassword, newPassword);
+    if (requireCode && !/^\d{6}$/.test(code)) {
+      setError(t("settings.profile.mfa_code_required"));
+      return;
+    }
+
+    onSave(oldPassword, newPassword, code);
     handleCancel();
   };
 
@@ -48,6 +56,7 @@ export function EditablePasswordField({
     setOldPassword("");
     setNewPassword("");
     setConfirmPassword("");
+    setCode("");
     setError("");
     setIsEditing(false);
   };
@@ -84,13 +93,30 @@ export function EditablePasswordField({
                 className="text-sm"
                 disabled={isLoading}
               />
+              {requireCode && (
+                <Input
+                  type="text"
+                  inputMode="numeric"
+                  autoComplete="one-time-code"
+                  maxLength={6}
+                  value={code}
+                  onChange={(e) => setCode(e.target.value)}
+                  placeholder={t("settings.profile.mfa_code_placeholder")}
+                  className="text-sm"
+                  disabled={isLoading}
+                />
+              )}
             </div>
             <div className="flex gap-2 pt-1">
               <Button
                 size="sm"
                 onClick={handleSave}
                 disabled={
-                  isLoading || !oldPassword || !newPassword || !confirmPassword
+                  isLoading ||
+                  !oldPassword ||
+                  !newPassword ||
+                  !confirmPassword ||
+                  (requireCode && !code)
                 }
               >
                 <Check className="h-3 w-3" />
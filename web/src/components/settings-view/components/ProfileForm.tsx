@@ -2,7 +2,10 @@ import { useTranslation } from "react-i18next";
 import { Info } from "lucide-react";
 import type { IUser } from "@/components/auth-view/types/session.ts";
 import { ProviderType } from "@/types/auth_providers.ts";
-import { useUpdateUserMutation } from "@/queries/user.ts";
+import {
+  useChangePasswordMutation,
+  useUpdateUserMutation,
+} from "@/queries/user.ts";
 import {
   Card,
   CardContent,
@@ -20,6 +23,7 @@ interface ProfileFormProps {
 export function ProfileForm({ user }: ProfileFormProps) {
   const { t } = useTranslation();
   const updateUserMutation = useUpdateUserMutation(user.id);
+  const changePasswordMutation = useChangePasswordMutation(user.id);
 
   const isLocalProvider = user.provider_type === ProviderType.LOCAL;
   const supportsMFA =
@@ -66,13 +70,15 @@ export function ProfileForm({ user }: ProfileFormProps) {
           {isLocalProvider && (
             <EditablePasswordField
               label={t("settings.profile.password")}
-              onSave={(oldPassword, newPassword) => {
-                updateUserMutation.mutate({
-                  old_password: oldPassword,
+              requireCode={user.mfa_enabled}
+              onSave={(currentPassword, newPassword, code) => {
+                changePasswordMutation.mutate({
+                  current_password: currentPassword,
                   new_password: newPassword,
+                  code: code || undefined,
                 });
               }}
-              isLoading={updateUserMutation.isPending}
+              isLoading={changePasswordMutation.isPending}
             />
           )}
         </CardContent>
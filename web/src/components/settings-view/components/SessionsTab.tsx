@@ -22,6 +22,15 @@ interface SessionsTabProps {
   userId: string;
 }
 
+const formatSessionDate = (value: string) =>
+  new Date(value).toLocaleDateString(undefined, {
+    year: "numeric",
+    month: "short",
+    day: "numeric",
+    hour: "2-digit",
+    minute: "2-digit",
+  });
+
 export function SessionsTab({ userId }: SessionsTabProps) {
   const { t } = useTranslation();
   const { data, isLoading } = useSessionsQuery(userId);
@@ -74,10 +83,14 @@ export function SessionsTab({ userId }: SessionsTabProps) {
                   className="flex items-center justify-between rounded-md border p-3"
                 >
                   <div className="flex items-center gap-3">
-                    <div>
+                    <div className="min-w-0">
                       <div className="flex items-center gap-2">
-                        <span className="text-sm font-medium font-mono">
-                          {session.id.slice(0, 8)}
+                        <span
+                          className="truncate text-sm font-medium"
+                          title={session.user_agent}
+                        >
+                          {session.user_agent ||
+                            t("settings.sessions.unknown_device")}
                         </span>
                         {session.is_current && (
                           <Badge variant="secondary">
@@ -85,19 +98,28 @@ export function SessionsTab({ userId }: SessionsTabProps) {
                           </Badge>
                         )}
                       </div>
+                      {(session.ip || session.auth_provider) && (
+                        <p className="text-xs text-muted-foreground">
+                          {[
+                            session.ip &&
+                              t("settings.sessions.ip", { ip: session.ip }),
+                            session.auth_provider &&
+                              t("settings.sessions.provider", {
+                                provider: session.auth_provider,
+                              }),
+                          ]
+                            .filter(Boolean)
+                            .join(" · ")}
+                        </p>
+                      )}
                       <p className="text-xs text-muted-foreground">
                         {t("settings.sessions.created_at", {
-                          date: new Date(session.created_at).toLocaleDateString(
-                            undefined,
-                            {
-                              year: "numeric",
-                              month: "short",
-                              day: "numeric",
-                              hour: "2-digit",
-                              minute: "2-digit",
-                            },
-                          ),
+                          date: formatSessionDate(session.created_at),
                         })}
+                        {session.last_seen_at &&
+                          ` · ${t("settings.sessions.last_seen", {
+                            date: formatSessionDate(session.last_seen_at),
+                          })}`}
                       </p>
                     </div>
                   </div>
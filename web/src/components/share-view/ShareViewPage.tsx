@@ -6,15 +6,19 @@ import type { FC } from "react";
 import type { IPublicShareResponse } from "@/types/share";
 import { ShareLanding } from "@/components/share-view/components/ShareLanding.tsx";
 import { SharePasswordForm } from "@/components/share-view/components/SharePasswordForm.tsx";
+import { ShareRecipientForm } from "@/components/share-view/components/ShareRecipientForm.tsx";
 import { ShareContentView } from "@/components/share-view/components/ShareContentView.tsx";
 import {
   shareContentQueryOptions,
   useShareAuthMutation,
+  useShareOTPRequestMutation,
+  useShareOTPVerifyMutation,
 } from "@/queries/share";
 
 type PageState =
   | { step: "idle" }
   | { step: "password" }
+  | { step: "recipient" }
   | { step: "content"; shareContent: IPublicShareResponse };
 
 interface IShareConsumerPageProps {
@@ -29,6 +33,8 @@ export const ShareViewPage: FC<IShareConsumerPageProps> = ({ uuid }) => {
   const [error, setError] = useState<string | null>(null);
 
   const authMutation = useShareAuthMutation();
+  const otpRequestMutation = useShareOTPRequestMutation(uuid);
+  const otpVerifyMutation = useShareOTPVerifyMutation(uuid);
 
   const fetchShareContent = async () => {
     const data = await queryClient.fetchQuery(shareContentQueryOptions(uuid));
@@ -45,6 +51,8 @@ export const ShareViewPage: FC<IShareConsumerPageProps> = ({ uuid }) => {
       const code = err instanceof Error ? err.message : "INTERNAL_SERVER_ERROR";
       if (code === "SHARE_TOKEN_REQUIRED") {
         setState({ step: "password" });
+      } else if (code === "SHARE_RECIPIENT_REQUIRED") {
+        setState({ step: "recipient" });
       } else {
         setError(t(`errors.${code}`));
       }
@@ -68,6 +76,38 @@ export const ShareViewPage: FC<IShareConsumerPageProps> = ({ uuid }) => {
     }
   };
 
+  const handleRequestCode = async (email: string) => {
+    setIsLoading(true);
+    setError(null);
+
+    try {
+      await otpRequestMutation.mutateAsync(email);
+      return true;
+    } catch (err) {
+      const code = err instanceof Error ? err.message : "INTERNAL_SERVER_ERROR";
+      setError(t(`errors.${code}`));
+      return false;
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
+  const handleVerifyCode = async (email: string, code: string) => {
+    setIsLoading(true);
+    setError(null);
+
+    try {
+      await otpVerifyMutation.mutateAsync({ email, code });
+      await fetchShareContent();
+    } catch (err) {
+      const errorCode =
+        err instanceof Error ? err.message : "INTERNAL_SERVER_ERROR";
+      setError(t(`errors.${errorCode}`));
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
   const handleBack = () => {
     setState({ step: "idle" });
     setError(null);
@@ -91,6 +131,16 @@ export const ShareViewPage: FC<IShareConsumerPageProps> = ({ uuid }) => {
           error={error}
         />
       );
+    case "recipient":
+      return (
+        <ShareRecipientForm
+          onRequestCode={handleRequestCode}
+          onVerifyCode={handleVerifyCode}
+          onBack={handleBack}
+          isLoading={isLoading}
+          error={error}
+        />
+      );
     case "content":
       return (
         <ShareContentView shareId={uuid} shareContent={state.shareContent} />
@@ -1,6 +1,13 @@
 import { useRef } from "react";
 import { useTranslation } from "react-i18next";
-import { Calendar, Eye, LayoutGrid, LayoutList, Upload } from "lucide-react";
+import {
+  Calendar,
+  Eye,
+  LayoutGrid,
+  LayoutList,
+  ShieldCheck,
+  Upload,
+} from "lucide-react";
 import type { FC } from "react";
 
 import type { IPublicShareResponse } from "@/types/share.ts";
@@ -123,6 +130,12 @@ export const ShareHeader: FC<IShareHeaderProps> = ({
                 {t("share_consumer.uploads_allowed")}
               </Badge>
             )}
+            {shareContent.require_approval && (
+              <Badge variant="secondary" className="gap-1">
+                <ShieldCheck className="h-3 w-3" />
+                {t("share_consumer.uploads_reviewed")}
+              </Badge>
+            )}
           </div>
         </div>
       </div>
@@ -0,0 +1,128 @@
+import { useState } from "react";
+import { ArrowLeft, Loader2, Mail } from "lucide-react";
+import { useTranslation } from "react-i18next";
+import type { FC } from "react";
+
+import { Button } from "@/components/ui/button.tsx";
+import { Card } from "@/components/ui/card.tsx";
+import { Input } from "@/components/ui/input.tsx";
+import {
+  InputOTP,
+  InputOTPGroup,
+  InputOTPSlot,
+} from "@/components/ui/input-otp.tsx";
+import { Label } from "@/components/ui/label.tsx";
+import { FormErrorAlert } from "@/components/common/FormErrorAlert.tsx";
+
+const CODE_LENGTH = 6;
+
+interface IShareRecipientFormProps {
+  onRequestCode: (email: string) => Promise<boolean>;
+  onVerifyCode: (email: string, code: string) => void;
+  onBack: () => void;
+  isLoading: boolean;
+  error: string | null;
+}
+
+export const ShareRecipientForm: FC<IShareRecipientFormProps> = ({
+  onRequestCode,
+  onVerifyCode,
+  onBack,
+  isLoading,
+  error,
+}) => {
+  const { t } = useTranslation();
+  const [email, setEmail] = useState("");
+  const [code, setCode] = useState("");
+  const [codeSent, setCodeSent] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!codeSent) {
+      if (email && (await onRequestCode(email))) {
+        setCodeSent(true);
+      }
+      return;
+    }
+    if (code.length === CODE_LENGTH) {
+      onVerifyCode(email, code);
+    }
+  };
+
+  return (
+    <div className="flex min-h-svh items-center justify-center p-6">
+      <Card className="flex w-full max-w-md flex-col gap-6 p-8">
+        <div className="flex flex-col items-center gap-4">
+          <div className="bg-primary/10 flex h-16 w-16 items-center justify-center rounded-full">
+            <Mail className="text-primary h-8 w-8" />
+          </div>
+          <div className="space-y-2 text-center">
+            <h1 className="text-xl font-semibold">
+              {t("share_consumer.recipient_required")}
+            </h1>
+            <p className="text-muted-foreground text-sm">
+              {codeSent
+                ? t("share_consumer.code_sent", { email })
+                : t("share_consumer.recipient_description")}
+            </p>
+          </div>
+        </div>
+
+        <form onSubmit={handleSubmit} className="flex flex-col gap-4">
+          {codeSent ? (
+            <div className="flex justify-center">
+              <InputOTP
+                maxLength={CODE_LENGTH}
+                value={code}
+                onChange={(value) => setCode(value.toUpperCase())}
+              >
+                <InputOTPGroup>
+                  {Array.from({ length: CODE_LENGTH }, (_, index) => (
+                    <InputOTPSlot key={index} index={index} />
+                  ))}
+                </InputOTPGroup>
+              </InputOTP>
+            </div>
+          ) : (
+            <div className="space-y-2">
+              <Label htmlFor="share-recipient-email">{t("auth.email")}</Label>
+              <Input
+                id="share-recipient-email"
+                type="email"
+                placeholder={t("share_consumer.email_placeholder")}
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+              />
+            </div>
+          )}
+
+          <FormErrorAlert error={error} />
+
+          <div className="flex gap-2">
+            <Button
+              type="button"
+              variant="outline"
+              onClick={onBack}
+              className="gap-2"
+            >
+              <ArrowLeft className="h-4 w-4" />
+              {t("share_consumer.back")}
+            </Button>
+            <Button
+              type="submit"
+              disabled={
+                isLoading || (codeSent ? code.length !== CODE_LENGTH : !email)
+              }
+              className="flex-1 gap-2"
+            >
+              {isLoading && <Loader2 className="h-4 w-4 animate-spin" />}
+              {codeSent
+                ? t("share_consumer.verify_code")
+                : t("share_consumer.send_code")}
+            </Button>
+          </div>
+        </form>
+      </Card>
+    </div>
+  );
+};
@@ -10,10 +10,13 @@ import type {
   AdminStatsResponse,
   CreateUserPayload,
   IAdminBucket,
+  IAdminInvite,
+  IQueuedNotification,
 } from "@/types/admin.ts";
 import type { IAdminSettingsResponse } from "@/types/app_settings";
 import { api } from "@/lib/api";
 import { successToast } from "@/components/ui/hooks/use-toast";
+import i18n from "@/lib/i18n";
 
 const ACTIVITY_PAGE_SIZE = 50;
 
@@ -56,6 +59,18 @@ export const useDeleteUserMutation = () => {
   });
 };
 
+export const useUnlockUserMutation = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (userId: string) => api.post(`/users/${userId}/unlock`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["admin", "users"] });
+      successToast(i18n.t("admin.users.unlocked"));
+    },
+  });
+};
+
 export const adminStatsQueryOptions = (days: number = 90) =>
   queryOptions({
     queryKey: ["admin", "stats", days],
@@ -97,6 +112,51 @@ export const adminBucketsQueryOptions = () =>
     staleTime: 60 * 1000,
   });
 
+export const adminInvitesQueryOptions = () =>
+  queryOptions({
+    queryKey: ["admin", "invites"],
+    queryFn: () => api.get<{ data: Array<IAdminInvite> }>("/admin/invites"),
+    select: (data) => data.data,
+    staleTime: 60 * 1000,
+  });
+
+export const useRevokeAdminInviteMutation = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (invite: IAdminInvite) =>
+      api.delete(`/buckets/${invite.bucket_id}/members/invites/${invite.id}`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["admin", "invites"] });
+      successToast(i18n.t("admin.invites.revoked"));
+    },
+  });
+};
+
+export const adminFailedNotificationsQueryOptions = () =>
+  queryOptions({
+    queryKey: ["admin", "notifications", "failed"],
+    queryFn: () =>
+      api.get<{ data: Array<IQueuedNotification> }>(
+        "/admin/notifications?status=failed",
+      ),
+    select: (data) => data.data,
+    staleTime: 60 * 1000,
+  });
+
+export const useResendNotificationMutation = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (notificationId: string) =>
+      api.post(`/admin/notifications/${notificationId}/resend`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["admin", "notifications"] });
+      successToast(i18n.t("admin.notifications.resent"));
+    },
+  });
+};
+
 export const useDeleteAdminBucketMutation = () => {
   const queryClient = useQueryClient();
 
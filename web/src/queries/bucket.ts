@@ -11,7 +11,19 @@ import type {
   INotificationPreferences,
 } from "@/components/bucket-view/helpers/types.ts";
 import type { IBucket } from "@/types/bucket.ts";
-import type { IShare, IShareCreateBody } from "@/types/share.ts";
+import type {
+  IBucketChannel,
+  IBucketChannelCreateBody,
+  IBucketChannelUpdateBody,
+} from "@/types/channel.ts";
+import type { IFile } from "@/types/file.ts";
+import type {
+  IShare,
+  IShareAnalytics,
+  IShareCreateBody,
+  IShareDelivery,
+  IShareSendBody,
+} from "@/types/share.ts";
 import { api } from "@/lib/api";
 import { successToast } from "@/components/ui/hooks/use-toast";
 import i18n from "@/lib/i18n";
@@ -91,6 +103,36 @@ export const useUpdateNotificationPreferencesMutation = (bucketId: string) => {
   });
 };
 
+export const useResendInviteMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (inviteId: string) =>
+      api.post(`/buckets/${bucketId}/members/invites/${inviteId}/resend`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "members"],
+      });
+      successToast(i18n.t("bucket.settings.members.invite_resent"));
+    },
+  });
+};
+
+export const useRevokeInviteMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (inviteId: string) =>
+      api.delete(`/buckets/${bucketId}/members/invites/${inviteId}`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "members"],
+      });
+      successToast(i18n.t("bucket.settings.members.invite_revoked"));
+    },
+  });
+};
+
 export const bucketSharesQueryOptions = (bucketId: string) =>
   queryOptions({
     queryKey: ["buckets", bucketId, "shares"],
@@ -99,6 +141,15 @@ export const bucketSharesQueryOptions = (bucketId: string) =>
     select: (response) => response.data,
   });
 
+export const shareAnalyticsQueryOptions = (bucketId: string, shareId: string) =>
+  queryOptions({
+    queryKey: ["buckets", bucketId, "shares", shareId, "analytics"],
+    queryFn: () =>
+      api.get<IShareAnalytics>(
+        `/buckets/${bucketId}/shares/${shareId}/analytics`,
+      ),
+  });
+
 export const useCreateShareMutation = (bucketId: string) => {
   const queryClient = useQueryClient();
 
@@ -113,6 +164,30 @@ export const useCreateShareMutation = (bucketId: string) => {
   });
 };
 
+export const useSendShareMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      shareId,
+      body,
+    }: {
+      shareId: string;
+      body: IShareSendBody;
+    }) =>
+      api.post<Array<IShareDelivery>>(
+        `/buckets/${bucketId}/shares/${shareId}/send`,
+        body,
+      ),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "shares"],
+      });
+      successToast(i18n.t("bucket.settings.shares.sent"));
+    },
+  });
+};
+
 export const useDeleteShareMutation = (bucketId: string) => {
   const queryClient = useQueryClient();
 
@@ -128,6 +203,74 @@ export const useDeleteShareMutation = (bucketId: string) => {
   });
 };
 
+export const bucketChannelsQueryOptions = (bucketId: string) =>
+  queryOptions({
+    queryKey: ["buckets", bucketId, "channels"],
+    queryFn: () =>
+      api.get<{ data: Array<IBucketChannel> }>(
+        `/buckets/${bucketId}/channels`,
+      ),
+    select: (response) => response.data,
+  });
+
+export const useCreateChannelMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (body: IBucketChannelCreateBody) =>
+      api.post<IBucketChannel>(`/buckets/${bucketId}/channels`, body),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "channels"],
+      });
+      successToast(i18n.t("bucket.settings.channels.created"));
+    },
+  });
+};
+
+export const useUpdateChannelMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      channelId,
+      body,
+    }: {
+      channelId: string;
+      body: IBucketChannelUpdateBody;
+    }) => api.patch(`/buckets/${bucketId}/channels/${channelId}`, body),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "channels"],
+      });
+    },
+  });
+};
+
+export const useDeleteChannelMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (channelId: string) =>
+      api.delete(`/buckets/${bucketId}/channels/${channelId}`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["buckets", bucketId, "channels"],
+      });
+      successToast(i18n.t("bucket.settings.channels.deleted"));
+    },
+  });
+};
+
+export const useTestChannelMutation = (bucketId: string) =>
+  useMutation({
+    mutationFn: (channelId: string) =>
+      api.post(`/buckets/${bucketId}/channels/${channelId}/test`),
+    onSuccess: () => {
+      successToast(i18n.t("bucket.settings.channels.test_sent"));
+    },
+  });
+
 export const bucketTrashedFilesQueryOptions = (bucketId: string) =>
   queryOptions({
     queryKey: ["buckets", bucketId, "trash"],
@@ -142,3 +285,38 @@ export const bucketTrashedFilesQueryOptions = (bucketId: string) =>
     },
     enabled: !!bucketId,
   });
+
+export const bucketPendingFilesQueryOptions = (bucketId: string) =>
+  queryOptions({
+    queryKey: ["buckets", bucketId, "pending"],
+    queryFn: () =>
+      api.get<{ data: Array<IFile> }>(`/buckets/${bucketId}/files/pending`),
+    select: (response) => response.data,
+    enabled: !!bucketId,
+  });
+
+export const useApproveFileMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (fileId: string) =>
+      api.post(`/buckets/${bucketId}/files/${fileId}/approve`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["buckets", bucketId] });
+      successToast(i18n.t("bucket.pending_view.approved"));
+    },
+  });
+};
+
+export const useRejectFileMutation = (bucketId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (fileId: string) =>
+      api.post(`/buckets/${bucketId}/files/${fileId}/reject`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["buckets", bucketId] });
+      successToast(i18n.t("bucket.pending_view.rejected"));
+    },
+  });
+};
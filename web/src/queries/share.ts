@@ -58,3 +58,25 @@ export const useShareConfirmUploadMutation = (shareId: string) =>
         method: "PATCH",
       }),
   });
+
+export const useShareOTPRequestMutation = (shareId: string) =>
+  useMutation({
+    meta: { skipGlobalErrorToast: true },
+    mutationFn: (email: string) =>
+      shareFetch<null>(`/${shareId}/otp`, {
+        method: "POST",
+        body: { email },
+        retryOnRateLimit: false,
+      }),
+  });
+
+export const useShareOTPVerifyMutation = (shareId: string) =>
+  useMutation({
+    meta: { skipGlobalErrorToast: true },
+    mutationFn: ({ email, code }: { email: string; code: string }) =>
+      shareFetch<null>(`/${shareId}/otp/verify`, {
+        method: "POST",
+        body: { email, code },
+        retryOnRateLimit: false,
+      }),
+  });
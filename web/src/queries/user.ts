@@ -11,8 +11,12 @@ import { useSession } from "@/hooks/useAuth";
 interface UpdateUserPayload {
   first_name?: string;
   last_name?: string;
-  old_password?: string;
-  new_password?: string;
+}
+
+interface ChangePasswordPayload {
+  current_password: string;
+  new_password: string;
+  code?: string;
 }
 
 export interface UserStats {
@@ -25,7 +29,7 @@ export const useCurrentUser = () => {
 
   return useQuery({
     queryKey: ["users", session?.userId],
-    queryFn: () => fetchApi<IUser>(`/users/${session?.userId}`),
+    queryFn: () => fetchApi<IUser>("/account"),
     enabled: !!session?.userId,
     staleTime: 5 * 60 * 1000,
   });
@@ -44,6 +48,21 @@ export const useUpdateUserMutation = (userId: string) => {
   });
 };
 
+export const useChangePasswordMutation = (userId: string) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (data: ChangePasswordPayload) =>
+      api.post("/account/password", data),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: ["users", userId, "sessions"],
+      });
+      successToast(i18n.t("settings.profile.password_changed"));
+    },
+  });
+};
+
 export const useUserStatsQuery = (userId: string) => {
   return useQuery({
     queryKey: ["users", userId, "stats"],
@@ -31,6 +31,8 @@ export interface IActivity {
   timestamp: string;
   message: ActivityMessage;
   bucket_member_email?: string;
+  recipient_email?: string;
+  client_ip?: string;
   mfa_device?: IMFADevice;
 }
 
@@ -42,8 +44,11 @@ export enum ActivityMessage {
   FILE_UPDATED = "FILE_UPDATED",
   FILE_DELETED = "FILE_DELETED",
   FILE_EXPIRED = "FILE_EXPIRED",
+  FILE_INACTIVE_TRASHED = "FILE_INACTIVE_TRASHED",
   FILE_TRASHED = "FILE_TRASHED",
   FILE_RESTORED = "FILE_RESTORED",
+  FILE_APPROVED = "FILE_APPROVED",
+  FILE_REJECTED = "FILE_REJECTED",
   FOLDER_CREATED = "FOLDER_CREATED",
   FOLDER_UPDATED = "FOLDER_UPDATED",
   FOLDER_TRASHED = "FOLDER_TRASHED",
@@ -57,11 +62,25 @@ export enum ActivityMessage {
   MFA_DEVICE_UPDATED = "MFA_DEVICE_UPDATED",
   MFA_DEVICE_REMOVED = "MFA_DEVICE_REMOVED",
   SHARE_CREATED = "SHARE_CREATED",
+  SHARE_UPDATED = "SHARE_UPDATED",
   SHARE_DELETED = "SHARE_DELETED",
   SHARE_EXPIRED = "SHARE_EXPIRED",
   SHARE_MAX_VIEWS_REACHED = "SHARE_MAX_VIEWS_REACHED",
   SHARE_FILE_DOWNLOADED = "SHARE_FILE_DOWNLOADED",
   SHARE_FILE_UPLOADED = "SHARE_FILE_UPLOADED",
+  SHARE_ACCESS_DENIED = "SHARE_ACCESS_DENIED",
+  SHARE_SENT = "SHARE_SENT",
+  CHANNEL_CREATED = "CHANNEL_CREATED",
+  CHANNEL_DELETED = "CHANNEL_DELETED",
+  RETENTION_POLICY_CREATED = "RETENTION_POLICY_CREATED",
+  RETENTION_POLICY_UPDATED = "RETENTION_POLICY_UPDATED",
+  RETENTION_POLICY_DELETED = "RETENTION_POLICY_DELETED",
+  BUCKET_RETENTION_UPDATED = "BUCKET_RETENTION_UPDATED",
+  LEGAL_HOLD_PLACED = "LEGAL_HOLD_PLACED",
+  LEGAL_HOLD_RELEASED = "LEGAL_HOLD_RELEASED",
+  BUCKET_LIFECYCLE_UPDATED = "BUCKET_LIFECYCLE_UPDATED",
+  USER_KEY_UPDATED = "USER_KEY_UPDATED",
+  BUCKET_KEYS_SHARED = "BUCKET_KEYS_SHARED",
 }
 
 export interface IActivityPage {
@@ -34,3 +34,35 @@ export interface IAdminBucket {
   file_count: number;
   size: number;
 }
+
+export interface IAdminInvite {
+  id: string;
+  email: string;
+  group: string;
+  bucket_id: string;
+  bucket_name: string;
+  invited_by: {
+    id: string;
+    first_name: string;
+    last_name: string;
+    email: string;
+  };
+  created_at: string;
+  expires_at: string | null;
+}
+
+export type NotificationStatus = "pending" | "sent" | "failed";
+
+export interface IQueuedNotification {
+  id: string;
+  recipient: string;
+  subject: string;
+  template: string;
+  status: NotificationStatus;
+  attempts: number;
+  next_attempt_at: string;
+  last_error?: string;
+  created_at: string;
+  updated_at: string;
+  sent_at?: string;
+}
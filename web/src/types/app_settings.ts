@@ -7,6 +7,7 @@ export interface IAdminAppSettings {
   static_files_enabled: boolean;
   max_upload_size: number;
   trash_retention_days: number;
+  invite_expiry_days: number;
   allow_redirect_download: boolean;
   tls_enabled: boolean;
 }
@@ -20,6 +21,9 @@ export interface IAdminWorkerSettings {
   bucket_events: CoverageStatus;
   trash_cleanup: CoverageStatus;
   garbage_collector: CoverageStatus;
+  outbox_relay: CoverageStatus;
+  notification_digest: CoverageStatus;
+  notification_retry: CoverageStatus;
 }
 
 export interface IAdminDatabaseSettings {
@@ -29,6 +33,7 @@ export interface IAdminDatabaseSettings {
   name?: string;
   sslmode?: string;
   path?: string;
+  replicas?: Array<string>;
 }
 
 export interface IAdminCacheSettings {
@@ -56,6 +61,7 @@ export interface IAdminEventsSettings {
   port?: string;
   project_id?: string;
   subscription_suffix?: string;
+  hosts?: Array<string>;
 }
 
 export interface IAdminNotifierSettings {
@@ -81,6 +87,8 @@ export interface IAdminAuthProviderSettings {
   domains?: Array<string>;
   mfa_required: boolean;
   sharing_allowed: boolean;
+  share_ip_allowlist?: Array<string>;
+  share_ip_denylist?: Array<string>;
   issuer?: string;
   url?: string;
   base_dn?: string;
@@ -115,15 +123,35 @@ export interface IAdminObservabilitySettings {
   tracing: IAdminTracingSettings;
 }
 
+export interface IAdminRateLimitBudget {
+  requests_per_minute: number;
+  burst: number;
+}
+
+export interface IAdminRateLimitSettings {
+  auth: IAdminRateLimitBudget;
+  upload_init: IAdminRateLimitBudget;
+  download_url: IAdminRateLimitBudget;
+  share_public: IAdminRateLimitBudget;
+  admin: IAdminRateLimitBudget;
+  per_share: IAdminRateLimitBudget;
+}
+
 export interface IAdminSecuritySettings {
   authenticated_requests_per_minute: number;
   unauthenticated_requests_per_minute: number;
+  rate_limits: IAdminRateLimitSettings;
   access_token_expiry: number;
   refresh_token_expiry: number;
   mfa_token_expiry: number;
   trusted_proxies?: Array<string>;
   allowed_origins?: Array<string>;
   cookie_secure_force: boolean;
+  password_min_length: number;
+  password_character_classes?: Array<string>;
+  password_disallow_email: boolean;
+  password_history_size: number;
+  breached_password_check: boolean;
 }
 
 export interface IAdminSettingsResponse {
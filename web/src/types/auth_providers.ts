@@ -2,8 +2,14 @@ export enum ProviderType {
   LOCAL = "local",
   OIDC = "oidc",
   LDAP = "ldap",
+  SAML = "saml",
 }
 
+// Redirect-flow users sign in at their identity provider, so Safebucket never
+// sees their password and step-up checks fall back to TOTP codes.
+export const isRedirectProvider = (type?: string): boolean =>
+  type === ProviderType.OIDC || type === ProviderType.SAML;
+
 export interface IProvider {
   id: string;
   name: string;
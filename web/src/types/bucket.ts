@@ -6,6 +6,10 @@ export interface IBucket {
   name: string;
   files: Array<IFile>;
   folders: Array<IFolder>;
+  encrypted: boolean;
+  key_version: number;
+  sse_mode?: "kms" | "customer";
+  sse_key_id?: string;
   created_by: string;
   created_at: string;
   updated_at: string;
@@ -0,0 +1,45 @@
+export type ChannelType = "slack" | "teams" | "mattermost";
+
+export interface IBucketChannel {
+  id: string;
+  bucket_id: string;
+  type: ChannelType;
+  name: string;
+  webhook_host: string;
+  notify_uploads: boolean;
+  notify_downloads: boolean;
+  notify_shares: boolean;
+  created_by?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface IBucketChannelCreateBody {
+  name: string;
+  type: ChannelType;
+  webhook_url: string;
+  notify_uploads: boolean;
+  notify_downloads: boolean;
+  notify_shares: boolean;
+}
+
+export type IBucketChannelUpdateBody = Partial<
+  Omit<IBucketChannelCreateBody, "type">
+>;
+
+export type ChannelEventKey =
+  | "notify_uploads"
+  | "notify_downloads"
+  | "notify_shares";
+
+export const CHANNEL_TYPES: Array<ChannelType> = [
+  "slack",
+  "teams",
+  "mattermost",
+];
+
+export const CHANNEL_EVENTS: Array<{ key: ChannelEventKey; label: string }> = [
+  { key: "notify_uploads", label: "uploads" },
+  { key: "notify_downloads", label: "downloads" },
+  { key: "notify_shares", label: "shares" },
+];
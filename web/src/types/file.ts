@@ -4,6 +4,14 @@ export enum FileStatus {
   deleting = "deleting",
   deleted = "deleted",
   restoring = "restoring",
+  pending = "pending",
+}
+
+export interface IFileEncryption {
+  cipher: string;
+  chunk_size: number;
+  key_version: number;
+  nonce: string;
 }
 
 export interface IFile {
@@ -17,5 +25,7 @@ export interface IFile {
   deleted_at: string | null;
   deleted_by?: string;
   original_path?: string;
+  share_id?: string;
   expires_at: string | null;
+  encryption?: IFileEncryption;
 }
@@ -14,10 +14,18 @@ export interface IShare {
   current_views: number;
   password_protected: boolean;
   allow_upload: boolean;
+  require_approval: boolean;
   max_uploads: number | null;
   current_uploads: number;
   max_upload_size: number | null;
+  max_downloads: number | null;
+  current_downloads: number;
   files: Array<IShareFile> | null;
+  ip_allowlist?: Array<string>;
+  ip_denylist?: Array<string>;
+  recipient_restricted: boolean;
+  recipients?: Array<IShareRecipient>;
+  deliveries?: Array<IShareDelivery>;
   created_by: string;
   created_at: string;
 }
@@ -26,6 +34,29 @@ export interface IShareFile {
   id: string;
   share_id: string;
   file_id: string;
+  max_downloads: number | null;
+  current_downloads: number;
+}
+
+export interface IShareRecipient {
+  id: string;
+  share_id: string;
+  value: string;
+}
+
+export interface IShareDelivery {
+  id: string;
+  share_id: string;
+  email: string;
+  sent_by?: string;
+  password_sent: boolean;
+  created_at: string;
+}
+
+export interface IShareSendBody {
+  emails: Array<string>;
+  message?: string;
+  password?: string;
 }
 
 export interface IShareCreateBody {
@@ -36,9 +67,44 @@ export interface IShareCreateBody {
   expires_at?: string;
   max_views?: number;
   password?: string;
+  recipients?: Array<string>;
   allow_upload: boolean;
+  require_approval?: boolean;
   max_uploads?: number;
   max_upload_size?: number;
+  max_downloads?: number;
+  max_file_downloads?: number;
+  ip_allowlist?: Array<string>;
+  ip_denylist?: Array<string>;
+}
+
+export type ShareAccessType = "view" | "download";
+
+export interface IShareAccess {
+  id: string;
+  share_id: string;
+  file_id?: string;
+  type: ShareAccessType;
+  ip_address: string;
+  user_agent: string;
+  recipient_email?: string;
+  created_at: string;
+}
+
+export interface IShareFileAnalytics {
+  file_id: string;
+  name: string;
+  downloads: number;
+  max_downloads?: number;
+  last_downloaded_at?: string;
+}
+
+export interface IShareAnalytics {
+  share_id: string;
+  views: number;
+  downloads: number;
+  files: Array<IShareFileAnalytics>;
+  accesses: Array<IShareAccess>;
 }
 
 export interface IPublicShareResponse {
@@ -46,12 +112,14 @@ export interface IPublicShareResponse {
   name: string;
   type: ShareScope;
   allow_upload: boolean;
+  require_approval: boolean;
   max_upload_size: number | null;
   max_uploads: number | null;
   current_uploads: number;
   expires_at: string | null;
   max_views: number | null;
   current_views: number;
+  max_downloads: number | null;
   files: Array<IFile>;
   folders: Array<IFolder>;
 }